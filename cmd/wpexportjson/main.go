@@ -1,35 +1,91 @@
 package main
 
 import (
-	"archive/zip"
+	"context"
+	"encoding/json"
 	"fmt"
-	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/spf13/cobra"
 	"github.com/tradik/wpexporter/internal/api"
+	"github.com/tradik/wpexporter/internal/archive"
 	"github.com/tradik/wpexporter/internal/bruteforce"
 	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/internal/deploy"
 	"github.com/tradik/wpexporter/internal/export"
+	"github.com/tradik/wpexporter/internal/importer"
+	"github.com/tradik/wpexporter/internal/log"
+	"github.com/tradik/wpexporter/internal/server"
+	"github.com/tradik/wpexporter/internal/state"
 	"github.com/tradik/wpexporter/pkg/models"
 )
 
 var (
-	cfgFile       string
-	url           string
-	output        string
-	format        string
-	bruteForce    bool
-	maxID         int
-	downloadMedia bool
-	concurrent    int
-	verbose       bool
-	createZip     bool
-	noFiles       bool
+	cfgFile              string
+	url                  string
+	output               string
+	format               string
+	bruteForce           bool
+	maxID                int
+	downloadMedia        bool
+	concurrent           int
+	retries              int
+	rateLimit            float64
+	verbose              bool
+	createZip            bool
+	noFiles              bool
+	incremental          bool
+	stateFile            string
+	resume               bool
+	scanCheckpointPath   string
+	serveAddr            string
+	watch                bool
+	serveOnly            bool
+	watchInterval        time.Duration
+	writeSitemap         bool
+	writeLLMsTxt         bool
+	writeStats           bool
+	renderTOC            bool
+	markdownFlavor       string
+	frontMatterFormat    string
+	highlight            string
+	writeFeeds           bool
+	archiveFormat        string
+	signKey              string
+	force                bool
+	prune                bool
+	includeComments      bool
+	includeRevisions     bool
+	includeCustomContent bool
+	scanStrategy         string
+	sparseMinHitRate     float64
+	writeScanStats       bool
+
+	after     string
+	before    string
+	sinceLast bool
+
+	importFrom  string
+	importInput string
+
+	deployOutput string
+	deployDryRun bool
+
+	logFormat string
+	logLevel  string
 )
 
+// newLogger builds a logger rendering per the --log-format/--log-level global flags.
+func newLogger() *log.Logger {
+	return log.New(log.Format(logFormat), log.ParseLevel(logLevel))
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "wpexportjson",
@@ -74,12 +130,72 @@ and multiple export formats.`,
 	RunE: runExport,
 }
 
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Export and preview the result with live reload",
+	Long: `Export WordPress content, then serve the result over HTTP, rendering
+Markdown files to HTML on the fly and pushing a browser reload whenever the
+export changes. With --watch, the export is periodically re-run while serving.
+With --serve-only, the export step is skipped and an existing output directory
+is served as-is.
+
+Examples:
+  # Export then preview at http://localhost:8080
+  wpexportjson serve --url https://example.com -f markdown
+
+  # Re-export every 30s and push a reload to the browser when it changes
+  wpexportjson serve --url https://example.com -f markdown --watch
+
+  # Just preview a directory from a previous export, without re-crawling
+  wpexportjson serve --output ./my-export --serve-only`,
+	RunE: runServe,
+}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Convert another platform's export archive into this tool's export formats",
+	Long: `Parse a WordPress WXR/RSS2 export or a Mastodon outbox.json archive into the
+same data this tool's own "export" command gathers, then write it out with any
+supported Format writer (json, markdown, atom, rss, sitemap, ...), turning this tool
+into a general content-archive converter.
+
+Examples:
+  # Convert a WordPress WXR dump to Markdown
+  wpexportjson import --from wxr --input site.wordpress.2024-01-01.xml -f markdown
+
+  # Convert a Mastodon archive's outbox.json to JSON
+  wpexportjson import --from mastodon --input outbox.json`,
+	RunE: runImport,
+}
+
+// deployCmd represents the deploy command
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Push an export's output directory to a remote target",
+	Long: `Push an already-exported output directory to the target configured under the
+"deploy" block of the config file: a git repository, an S3 (or S3-compatible) bucket, or
+an rsync destination. Only the git and S3 targets skip files unchanged since the previous
+deploy; rsync relies on rsync's own --delete mirroring instead.
+
+Examples:
+  # Push ./my-export to the git repo/branch configured under deploy.git
+  wpexportjson deploy --output ./my-export
+
+  # See what a deploy would do without pushing anything
+  wpexportjson deploy --output ./my-export --dry-run`,
+	RunE: runDeploy,
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.wpexportjson/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log output format (text|json)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "minimum log level (debug|info|warn|error)")
 
 	// Export command flags
 	exportCmd.Flags().StringVarP(&url, "url", "u", "", "WordPress site URL (required)")
@@ -89,15 +205,76 @@ func init() {
 	exportCmd.Flags().IntVar(&maxID, "max-id", 10000, "maximum ID for brute force")
 	exportCmd.Flags().BoolVar(&downloadMedia, "download-media", true, "download images and videos")
 	exportCmd.Flags().IntVarP(&concurrent, "concurrent", "c", 5, "concurrent downloads")
+	exportCmd.Flags().IntVar(&retries, "retry", 3, "retry attempts for a failed media download before falling back to a placeholder")
+	exportCmd.Flags().Float64Var(&rateLimit, "rate-limit", 0, "starting requests/second for media downloads, backing off on 429/5xx (0: use the limiter's default)")
 	exportCmd.Flags().BoolVar(&createZip, "zip", false, "create ZIP archive of export")
 	exportCmd.Flags().BoolVar(&noFiles, "no-files", false, "remove export files after creating ZIP (requires --zip)")
+	exportCmd.Flags().BoolVar(&incremental, "incremental", false, "only report posts/pages/media changed since the previous run, via a persisted state file")
+	exportCmd.Flags().StringVar(&stateFile, "state-file", "", "path to the incremental export state file (default: <output>/.wpexport-state.json)")
+	exportCmd.Flags().BoolVar(&resume, "resume", false, "resume a brute force scan from its last checkpoint instead of starting over")
+	exportCmd.Flags().StringVar(&scanCheckpointPath, "scan-checkpoint-path", "", "path to the brute force scan's resume checkpoint (default: <output>/.wpexport-scan-checkpoint.json)")
+	exportCmd.Flags().BoolVar(&writeSitemap, "write-sitemap", false, "write a sitemap.xml covering posts, pages, categories, and tags alongside the export (default true for -f markdown)")
+	exportCmd.Flags().BoolVar(&writeLLMsTxt, "write-llms-txt", false, "write an llms.txt index of titles, URLs, and excerpts alongside the export")
+	exportCmd.Flags().BoolVar(&writeStats, "write-stats", false, "write a stats.json of HTML tags/classes/IDs found in the export, for downstream CSS purging")
+	exportCmd.Flags().BoolVar(&renderTOC, "render-toc", false, "inject a Table of Contents section at the top of each -f markdown post/page body")
+	exportCmd.Flags().StringVar(&markdownFlavor, "markdown-flavor", "plain", "markdown layout to emit for -f markdown (plain|hugo|jekyll|bundle)")
+	exportCmd.Flags().StringVar(&frontMatterFormat, "front-matter-format", "yaml", "front matter format for --markdown-flavor hugo (yaml|toml|json)")
+	exportCmd.Flags().StringVar(&highlight, "highlight", "none", "pre-render <pre><code> blocks to syntax-highlighted HTML instead of a plain fence (none|chroma|html)")
+	exportCmd.Flags().BoolVar(&writeFeeds, "feeds", false, "write feed.atom and feed.rss alongside the export (default true for -f markdown; pass --feeds=false to opt out)")
+	exportCmd.Flags().BoolVar(&writeSitemap, "sitemap", false, "alias for --write-sitemap")
+	exportCmd.Flags().StringVar(&after, "after", "", "only include posts/pages/media published on or after this date (YYYY-MM-DD)")
+	exportCmd.Flags().StringVar(&before, "before", "", "only include posts/pages/media published before this date (YYYY-MM-DD)")
+	exportCmd.Flags().BoolVar(&sinceLast, "since-last", false, "fetch only content modified since the previous export's state file, merging into its existing export.json")
+	exportCmd.Flags().StringVar(&archiveFormat, "archive-format", "zip", "archive format for --zip (zip|tar.gz)")
+	exportCmd.Flags().StringVar(&signKey, "sign-key", "", "path to a PEM-encoded ed25519 private key to sign the archive's MANIFEST.json")
+	exportCmd.Flags().BoolVar(&force, "force", false, "rewrite every markdown file even if its content is unchanged since the previous export (see .wpexporter-manifest.json)")
+	exportCmd.Flags().BoolVar(&prune, "prune", false, "remove previously-exported markdown files for posts/pages no longer present in this export")
+	exportCmd.Flags().BoolVar(&includeComments, "include-comments", false, "fetch every comment on the site into the export")
+	exportCmd.Flags().BoolVar(&includeRevisions, "include-revisions", false, "fetch each post/page's revision history into the export (one extra request per post/page)")
+	exportCmd.Flags().BoolVar(&includeCustomContent, "include-custom-content", false, "discover non-core post types/taxonomies and fetch their items into the export")
+	exportCmd.Flags().StringVar(&scanStrategy, "scan-strategy", "linear", "brute force ID-space strategy (linear|exponential|sparse)")
+	exportCmd.Flags().Float64Var(&sparseMinHitRate, "sparse-min-hit-rate", 0, "rolling hit rate below which --scan-strategy sparse starts skipping ahead (0: use the default)")
+	exportCmd.Flags().BoolVar(&writeScanStats, "write-scan-stats", false, "write wpexporter_scan_stats.json with brute force scan probe counts, latency percentiles, and discovered ID gaps")
 
 	// Mark required flags
 	if err := exportCmd.MarkFlagRequired("url"); err != nil {
 		panic(fmt.Sprintf("Failed to mark url flag as required: %v", err))
 	}
 
+	// Serve command flags
+	serveCmd.Flags().StringVarP(&url, "url", "u", "", "WordPress site URL (required unless --serve-only)")
+	serveCmd.Flags().StringVarP(&output, "output", "o", "", "output directory or file (default: export/{domain-name}.{date}{time})")
+	serveCmd.Flags().StringVarP(&format, "format", "f", "markdown", "export format (json|markdown)")
+	serveCmd.Flags().StringVar(&serveAddr, "serve-addr", ":8080", "address the preview server listens on")
+	serveCmd.Flags().BoolVar(&watch, "watch", false, "periodically re-export and reload the browser when the export changes")
+	serveCmd.Flags().DurationVar(&watchInterval, "watch-interval", 30*time.Second, "how often to re-export in --watch mode")
+	serveCmd.Flags().BoolVar(&serveOnly, "serve-only", false, "skip exporting and just serve an existing output directory")
+
+	// Import command flags
+	importCmd.Flags().StringVar(&importFrom, "from", "", "source archive format: wxr|mastodon (required)")
+	importCmd.Flags().StringVarP(&importInput, "input", "i", "", "path to the source archive file (required)")
+	importCmd.Flags().StringVarP(&output, "output", "o", "", "output directory or file (default: export/{domain-name}.{date}{time})")
+	importCmd.Flags().StringVarP(&format, "format", "f", "json", "export format (json|markdown)")
+
+	if err := importCmd.MarkFlagRequired("from"); err != nil {
+		panic(fmt.Sprintf("Failed to mark from flag as required: %v", err))
+	}
+	if err := importCmd.MarkFlagRequired("input"); err != nil {
+		panic(fmt.Sprintf("Failed to mark input flag as required: %v", err))
+	}
+
+	// Deploy command flags
+	deployCmd.Flags().StringVarP(&deployOutput, "output", "o", "", "export output directory to push (required)")
+	deployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "log what would be pushed without making any network calls")
+
+	if err := deployCmd.MarkFlagRequired("output"); err != nil {
+		panic(fmt.Sprintf("Failed to mark output flag as required: %v", err))
+	}
+
 	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(deployCmd)
 }
 
 func initConfig() {
@@ -129,7 +306,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 
 	// Load configuration file if specified or found
 	if cfgFile != "" || configFileExists() {
-		loadedCfg, err := config.LoadConfig(cfgFile)
+		loadedCfg, err := config.LoadConfig(cfgFile, os.DirFS("/"), os.Getenv)
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
@@ -158,6 +335,12 @@ func runExport(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("concurrent") {
 		cfg.Concurrent = concurrent
 	}
+	if cmd.Flags().Changed("retry") {
+		cfg.Retries = retries
+	}
+	if cmd.Flags().Changed("rate-limit") {
+		cfg.RateLimit = rateLimit
+	}
 	if cmd.Flags().Changed("verbose") {
 		cfg.Verbose = verbose
 	}
@@ -167,12 +350,115 @@ func runExport(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("no-files") {
 		cfg.NoFiles = noFiles
 	}
+	if cmd.Flags().Changed("incremental") {
+		cfg.Incremental = incremental
+	}
+	if cmd.Flags().Changed("state-file") {
+		cfg.StateFile = stateFile
+	}
+	if cmd.Flags().Changed("resume") {
+		cfg.Resume = resume
+	}
+	if cmd.Flags().Changed("scan-checkpoint-path") {
+		cfg.ScanCheckpointPath = scanCheckpointPath
+	}
+	if cmd.Flags().Changed("write-sitemap") || cmd.Flags().Changed("sitemap") {
+		cfg.WriteSitemap = writeSitemap
+	}
+	if cmd.Flags().Changed("write-llms-txt") {
+		cfg.WriteLLMsTxt = writeLLMsTxt
+	}
+	if cmd.Flags().Changed("write-stats") {
+		cfg.WriteStats = writeStats
+	}
+	if cmd.Flags().Changed("render-toc") {
+		cfg.RenderTOC = renderTOC
+	}
+	if cmd.Flags().Changed("markdown-flavor") {
+		cfg.MarkdownFlavor = markdownFlavor
+	}
+	if cmd.Flags().Changed("front-matter-format") {
+		cfg.FrontMatterFormat = frontMatterFormat
+	}
+	if cmd.Flags().Changed("highlight") {
+		cfg.Highlight = highlight
+	}
+	if cmd.Flags().Changed("feeds") {
+		cfg.WriteFeeds = writeFeeds
+	}
+	if cmd.Flags().Changed("after") {
+		cfg.After = after
+	}
+	if cmd.Flags().Changed("before") {
+		cfg.Before = before
+	}
+	if cmd.Flags().Changed("since-last") {
+		cfg.SinceLast = sinceLast
+	}
+	if cmd.Flags().Changed("archive-format") {
+		cfg.ArchiveFormat = archiveFormat
+	}
+	if cmd.Flags().Changed("sign-key") {
+		cfg.SignKey = signKey
+	}
+	if cmd.Flags().Changed("force") {
+		cfg.Force = force
+	}
+	if cmd.Flags().Changed("prune") {
+		cfg.Prune = prune
+	}
+	if cmd.Flags().Changed("include-comments") {
+		cfg.IncludeComments = includeComments
+	}
+	if cmd.Flags().Changed("include-revisions") {
+		cfg.IncludeRevisions = includeRevisions
+	}
+	if cmd.Flags().Changed("include-custom-content") {
+		cfg.IncludeCustomContent = includeCustomContent
+	}
+	if cmd.Flags().Changed("scan-strategy") {
+		cfg.ScanStrategy = scanStrategy
+	}
+	if cmd.Flags().Changed("sparse-min-hit-rate") {
+		cfg.SparseMinHitRate = sparseMinHitRate
+	}
+	if cmd.Flags().Changed("write-scan-stats") {
+		cfg.WriteScanStats = writeScanStats
+	}
 
 	// Validate --no-files requires --zip
 	if cfg.NoFiles && !cfg.CreateZip {
 		return fmt.Errorf("--no-files requires --zip flag")
 	}
 
+	// A markdown export defaults to also writing feed.atom/feed.rss/sitemap.xml (see
+	// Exporter.writeExtras) so a mirrored or archived site keeps valid syndication
+	// endpoints even after the WordPress backend it was exported from is gone - unless the
+	// user explicitly passed --feeds/--write-sitemap (or --sitemap) themselves, or a loaded
+	// config file explicitly set write_feeds/write_sitemap (see Config.WasSet) - a config
+	// file that simply doesn't mention them still gets the default, same as no config file.
+	if cfg.Format == "markdown" {
+		if !cmd.Flags().Changed("feeds") && !cfg.WasSet("write_feeds") {
+			cfg.WriteFeeds = true
+		}
+		if !cmd.Flags().Changed("write-sitemap") && !cmd.Flags().Changed("sitemap") && !cfg.WasSet("write_sitemap") {
+			cfg.WriteSitemap = true
+		}
+	}
+
+	// --prune removes any post/page file not seen during this run, which is only safe
+	// when the export actually saw every current post/page. --since-last on a non-JSON
+	// format (see mergeSinceLastExport) exports just the delta fetch, so --prune would
+	// delete every unmodified file's markdown as if WordPress had deleted it. --after/
+	// --before narrow the fetch to a date range the same way, so they're just as unsafe
+	// to combine with --prune regardless of format.
+	if cfg.Prune && cfg.SinceLast && cfg.Format != "json" {
+		return fmt.Errorf("--prune requires the full post set and can't be combined with --since-last for format %q (only --format json merges the delta back in)", cfg.Format)
+	}
+	if cfg.Prune && (cfg.After != "" || cfg.Before != "") {
+		return fmt.Errorf("--prune requires the full post set and can't be combined with --after/--before, which restrict the export to a date range")
+	}
+
 	// Generate default output path if not specified
 	if err := cfg.GenerateDefaultOutput(); err != nil {
 		return fmt.Errorf("failed to generate default output path: %w", err)
@@ -183,6 +469,230 @@ func runExport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	return runExportWithConfig(cfg)
+}
+
+// runImport parses a source archive via internal/importer and writes it back out with
+// export.Exporter, reusing the same Format writers the export command uses.
+func runImport(cmd *cobra.Command, args []string) error {
+	adapter, ok := importer.Adapters[importFrom]
+	if !ok {
+		return fmt.Errorf("unknown --from %q: must be one of wxr, mastodon", importFrom)
+	}
+
+	data, err := adapter.Import(importInput)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", importInput, err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.URL = data.Site.URL
+	if cfg.URL == "" {
+		cfg.URL = "imported-archive"
+	}
+	cfg.DownloadMedia = false
+	if cmd.Flags().Changed("output") {
+		cfg.Output = output
+	}
+	if cmd.Flags().Changed("format") {
+		cfg.Format = format
+	}
+
+	if err := cfg.GenerateDefaultOutput(); err != nil {
+		return fmt.Errorf("failed to generate default output path: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	exporter := export.NewExporter(cfg)
+	if err := exporter.Export(data); err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fmt.Printf("Imported %d posts, %d pages, %d media from %s\n", len(data.Posts), len(data.Pages), len(data.Media), importInput)
+	return nil
+}
+
+// runDeploy pushes deployOutput to the target configured under the config file's "deploy"
+// block.
+func runDeploy(cmd *cobra.Command, args []string) error {
+	cfg := config.DefaultConfig()
+	if cfgFile != "" || configFileExists() {
+		loadedCfg, err := config.LoadConfig(cfgFile, os.DirFS("/"), os.Getenv)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = loadedCfg
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if cfg.Deploy.Type == "" {
+		return fmt.Errorf("no deploy target configured: set deploy.type ('git', 's3', or 'rsync') in the config file")
+	}
+
+	if _, err := os.Stat(deployOutput); err != nil {
+		return fmt.Errorf("output directory %s: %w", deployOutput, err)
+	}
+
+	target, err := deploy.New(cfg.Deploy)
+	if err != nil {
+		return err
+	}
+
+	stats := deployStats(deployOutput)
+	if err := target.Push(context.Background(), deployOutput, deploy.TargetOptions{Stats: stats, DryRun: deployDryRun}); err != nil {
+		return fmt.Errorf("deploy failed: %w", err)
+	}
+
+	fmt.Printf("Deployed %s to %s target\n", deployOutput, cfg.Deploy.Type)
+	return nil
+}
+
+// postsDirs and pagesDirs are the markdown post/page directories used across the markdown
+// formats' flavors: plain and bundle write "posts"/"pages", hugo writes "content/posts"/
+// "content/pages", and jekyll writes "_posts" (its pages are plain "<slug>.md" files at the
+// output root, indistinguishable from other root-level files, so they're left uncounted).
+var postsDirs = []string{"posts", filepath.Join("content", "posts"), "_posts"}
+var pagesDirs = []string{"pages", filepath.Join("content", "pages")}
+
+// coreRestBases lists the rest_base values wpexportjson already fetches through a
+// dedicated endpoint (posts, pages, media) or doesn't export content for (users), so
+// discoverCustomContent skips them even though WordPress lists them alongside any
+// site-registered custom post type/taxonomy.
+var coreRestBases = map[string]bool{
+	"posts":      true,
+	"pages":      true,
+	"media":      true,
+	"users":      true,
+	"categories": true,
+	"tags":       true,
+	"comments":   true,
+}
+
+// discoverCustomContent hits /wp-json/wp/v2/types and /wp-json/wp/v2/taxonomies to find
+// every non-core post type and taxonomy the site has registered (e.g. a "product" post
+// type backing a WooCommerce store), then fetches each one's items by its rest_base into
+// a models.ExportData.CustomContent bucket, since a custom type's schema isn't known ahead
+// of time and may not resemble models.WordPressPost at all.
+func discoverCustomContent(ctx context.Context, apiClient *api.Client) (map[string][]json.RawMessage, map[string]int, error) {
+	types, err := apiClient.GetPostTypesContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get post types: %w", err)
+	}
+
+	taxonomies, err := apiClient.GetTaxonomiesContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get taxonomies: %w", err)
+	}
+
+	restBases := make(map[string]bool)
+	for _, t := range types {
+		if t.RestBase != "" && !coreRestBases[t.RestBase] {
+			restBases[t.RestBase] = true
+		}
+	}
+	for _, tax := range taxonomies {
+		if tax.RestBase != "" && !coreRestBases[tax.RestBase] {
+			restBases[tax.RestBase] = true
+		}
+	}
+
+	content := make(map[string][]json.RawMessage)
+	counts := make(map[string]int)
+	for restBase := range restBases {
+		items, err := apiClient.GetCustomContentContext(ctx, restBase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get custom content for %s: %w", restBase, err)
+		}
+		content[restBase] = items
+		counts[restBase] = len(items)
+	}
+
+	return content, counts, nil
+}
+
+// fetchRevisions fetches each post/page's revision history concurrently, via the same
+// bounded-worker-pool pattern as media.Downloader.DownloadMedia, rather than one request
+// at a time - --include-revisions costs one extra request per post/page, which would
+// otherwise serialize to hours on a large site. A post/page with no revisions is omitted
+// from the result.
+func fetchRevisions(apiClient *api.Client, items []models.WordPressPost, concurrent int) (map[int][]models.WordPressRevision, error) {
+	limit := concurrent
+	if limit <= 0 {
+		limit = 1
+	}
+
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(limit)
+
+	var mu sync.Mutex
+	revisions := make(map[int][]models.WordPressRevision)
+
+	for _, item := range items {
+		group.Go(func() error {
+			postRevisions, err := apiClient.GetPostRevisionsContext(ctx, item.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get revisions for post %d: %w", item.ID, err)
+			}
+			if len(postRevisions) == 0 {
+				return nil
+			}
+			mu.Lock()
+			revisions[item.ID] = postRevisions
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// deployStats builds a best-effort models.ExportStats for outputDir's commit message
+// template, since a standalone deploy (unlike export/serve) has no in-memory ExportData,
+// and no record of the MarkdownFlavor it was produced with, to draw real counts from: it
+// sums markdown files across postsDirs/pagesDirs (only the flavor actually used will exist,
+// so the rest contribute zero) and every file under "media", leaving every count
+// zero for a Format that doesn't use that layout (e.g. "json").
+func deployStats(outputDir string) models.ExportStats {
+	stats := models.ExportStats{
+		TotalMedia: countFiles(filepath.Join(outputDir, "media"), ""),
+	}
+	for _, dir := range postsDirs {
+		stats.TotalPosts += countFiles(filepath.Join(outputDir, dir), ".md")
+	}
+	for _, dir := range pagesDirs {
+		stats.TotalPages += countFiles(filepath.Join(outputDir, dir), ".md")
+	}
+	return stats
+}
+
+// countFiles returns how many regular files with the given extension (or any extension,
+// when ext is empty) exist under dir, or 0 if dir doesn't exist.
+func countFiles(dir, ext string) int {
+	count := 0
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info == nil || info.IsDir() {
+			return nil
+		}
+		if ext == "" || filepath.Ext(path) == ext {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// runExportWithConfig performs one full export against an already-validated cfg. It's
+// shared by the export command and the serve command's --watch re-export loop.
+func runExportWithConfig(cfg *config.Config) error {
+	logger := newLogger()
+
 	// Create API client
 	apiClient, err := api.NewClient(cfg)
 	if err != nil {
@@ -195,77 +705,171 @@ func runExport(cmd *cobra.Command, args []string) error {
 	// Create brute force scanner
 	scanner := bruteforce.NewScanner(cfg, apiClient)
 
-	fmt.Printf("Starting WordPress export from: %s\n", cfg.URL)
-	fmt.Printf("Output: %s (format: %s)\n", cfg.Output, cfg.Format)
+	logger.Info("starting WordPress export", log.F("url", cfg.URL), log.F("output", cfg.Output), log.F("format", cfg.Format))
 
 	if cfg.BruteForce {
-		fmt.Printf("Brute force enabled (max ID: %d)\n", cfg.MaxID)
+		logger.Info("brute force enabled", log.F("max_id", cfg.MaxID))
 	}
 
 	if cfg.DownloadMedia {
-		fmt.Printf("Media download enabled (concurrent: %d)\n", cfg.Concurrent)
+		logger.Info("media download enabled", log.F("concurrent", cfg.Concurrent))
 	}
 
 	startTime := time.Now()
 
-	// Get site information
-	fmt.Println("\nFetching site information...")
+	endSite := logger.Phase("fetch_site_info")
 	siteInfo, err := apiClient.GetSiteInfo()
 	if err != nil {
 		return fmt.Errorf("failed to get site info: %w", err)
 	}
+	endSite()
 
-	// Get all content via API
-	fmt.Println("Fetching posts...")
-	posts, err := apiClient.GetPosts()
-	if err != nil {
-		return fmt.Errorf("failed to get posts: %w", err)
-	}
-	fmt.Printf("Found %d posts\n", len(posts))
+	var posts, pages []models.WordPressPost
+	var media []models.WordPressMedia
 
-	fmt.Println("Fetching pages...")
-	pages, err := apiClient.GetPages()
-	if err != nil {
-		return fmt.Errorf("failed to get pages: %w", err)
-	}
-	fmt.Printf("Found %d pages\n", len(pages))
+	switch {
+	case cfg.SinceLast:
+		endFetch := logger.Phase("fetch_since_last")
+		posts, pages, media, err = fetchSinceLast(context.Background(), apiClient, cfg, logger)
+		if err != nil {
+			return err
+		}
+		endFetch()
+		logger.Info("found posts", log.F("count", len(posts)))
+		logger.Info("found pages", log.F("count", len(pages)))
+		logger.Info("found media", log.F("count", len(media)))
 
-	fmt.Println("Fetching media...")
-	media, err := apiClient.GetMedia()
-	if err != nil {
-		return fmt.Errorf("failed to get media: %w", err)
+		if posts, pages, media, err = mergeSinceLastExport(cfg, posts, pages, media); err != nil {
+			return fmt.Errorf("failed to merge since-last export: %w", err)
+		}
+	case cfg.After != "" || cfg.Before != "":
+		afterRFC, beforeRFC, err := dateRangeRFC3339(cfg.After, cfg.Before)
+		if err != nil {
+			return err
+		}
+
+		endFetch := logger.Phase("fetch_date_range")
+		posts, err = apiClient.GetPostsInRangeContext(context.Background(), afterRFC, beforeRFC)
+		if err != nil {
+			return fmt.Errorf("failed to get posts: %w", err)
+		}
+		pages, err = apiClient.GetPagesInRangeContext(context.Background(), afterRFC, beforeRFC)
+		if err != nil {
+			return fmt.Errorf("failed to get pages: %w", err)
+		}
+		media, err = apiClient.GetMediaInRangeContext(context.Background(), afterRFC, beforeRFC)
+		if err != nil {
+			return fmt.Errorf("failed to get media: %w", err)
+		}
+		endFetch()
+		logger.Info("found posts", log.F("count", len(posts)))
+		logger.Info("found pages", log.F("count", len(pages)))
+		logger.Info("found media", log.F("count", len(media)))
+	default:
+		endPosts := logger.Phase("fetch_posts")
+		posts, err = apiClient.GetPostsWithProgress(func(n int) {
+			logger.Debug("fetching posts", log.F("count", n))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get posts: %w", err)
+		}
+		endPosts()
+		logger.Info("found posts", log.F("count", len(posts)))
+
+		endPages := logger.Phase("fetch_pages")
+		pages, err = apiClient.GetPagesWithProgress(func(n int) {
+			logger.Debug("fetching pages", log.F("count", n))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get pages: %w", err)
+		}
+		endPages()
+		logger.Info("found pages", log.F("count", len(pages)))
+
+		endMedia := logger.Phase("fetch_media")
+		media, err = apiClient.GetMediaWithProgress(func(n int) {
+			logger.Debug("fetching media", log.F("count", n))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get media: %w", err)
+		}
+		endMedia()
+		logger.Info("found media", log.F("count", len(media)))
 	}
-	fmt.Printf("Found %d media items\n", len(media))
 
-	fmt.Println("Fetching categories...")
 	categories, err := apiClient.GetCategories()
 	if err != nil {
 		return fmt.Errorf("failed to get categories: %w", err)
 	}
-	fmt.Printf("Found %d categories\n", len(categories))
+	logger.Info("found categories", log.F("count", len(categories)))
 
-	fmt.Println("Fetching tags...")
 	tags, err := apiClient.GetTags()
 	if err != nil {
 		return fmt.Errorf("failed to get tags: %w", err)
 	}
-	fmt.Printf("Found %d tags\n", len(tags))
+	logger.Info("found tags", log.F("count", len(tags)))
 
-	fmt.Println("Fetching users...")
 	users, err := apiClient.GetUsers()
 	if err != nil {
 		return fmt.Errorf("failed to get users: %w", err)
 	}
-	fmt.Printf("Found %d users\n", len(users))
+	logger.Info("found users", log.F("count", len(users)))
+
+	var comments []models.WordPressComment
+	if cfg.IncludeComments {
+		comments, err = apiClient.GetComments()
+		if err != nil {
+			return fmt.Errorf("failed to get comments: %w", err)
+		}
+		logger.Info("found comments", log.F("count", len(comments)))
+	}
+
+	var customContent map[string][]json.RawMessage
+	var customContentCounts map[string]int
+	if cfg.IncludeCustomContent {
+		endDiscover := logger.Phase("discover_custom_content")
+		customContent, customContentCounts, err = discoverCustomContent(context.Background(), apiClient)
+		if err != nil {
+			return fmt.Errorf("failed to discover custom content: %w", err)
+		}
+		endDiscover()
+		logger.Info("discovered custom content", log.F("types", len(customContentCounts)))
+	}
 
 	// Perform brute force scanning if enabled
 	var bruteForceFound int
 	if cfg.BruteForce {
-		fmt.Println("\nPerforming brute force content discovery...")
-		scanResult, err := scanner.ScanForContent(posts, pages, media)
+		endScan := logger.Phase("brute_force_scan")
+
+		var scanResult *bruteforce.ScanResult
+		if cfg.Resume {
+			scanCtx, cancelScan := context.WithCancel(context.Background())
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, os.Interrupt)
+			go func() {
+				<-sigCh
+				fmt.Println("\nInterrupted - saving scan checkpoint...")
+				cancelScan()
+			}()
+
+			scanResult, err = scanner.Resume(scanCtx, posts, pages, media)
+			signal.Stop(sigCh)
+			cancelScan()
+		} else {
+			scanResult, err = scanner.ScanForContent(posts, pages, media)
+		}
 		if err != nil {
 			return fmt.Errorf("brute force scan failed: %w", err)
 		}
+		endScan()
+
+		if cfg.WriteScanStats {
+			reportPath := filepath.Join(cfg.Output, "wpexporter_scan_stats.json")
+			if err := scanner.WriteReport(reportPath); err != nil {
+				return fmt.Errorf("failed to write scan stats report: %w", err)
+			}
+			logger.Info("wrote scan stats report", log.F("path", reportPath))
+		}
 
 		// Merge brute force results
 		posts = append(posts, scanResult.Posts...)
@@ -274,158 +878,472 @@ func runExport(cmd *cobra.Command, args []string) error {
 		bruteForceFound = scanResult.Found
 	}
 
+	// Reconcile against the previous run's state for an incremental export
+	if cfg.Incremental {
+		manifest, err := reconcileIncrementalState(cfg, posts, pages, media)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile incremental state: %w", err)
+		}
+		logger.Info("incremental reconciliation complete",
+			log.F("added", len(manifest.Posts.Added)+len(manifest.Pages.Added)+len(manifest.Media.Added)),
+			log.F("updated", len(manifest.Posts.Updated)+len(manifest.Pages.Updated)+len(manifest.Media.Updated)),
+			log.F("deleted", len(manifest.Posts.Deleted)+len(manifest.Pages.Deleted)+len(manifest.Media.Deleted)))
+	}
+
+	var revisions map[int][]models.WordPressRevision
+	if cfg.IncludeRevisions {
+		var err error
+		revisions, err = fetchRevisions(apiClient, append(append([]models.WordPressPost{}, posts...), pages...), cfg.Concurrent)
+		if err != nil {
+			return fmt.Errorf("failed to get revisions: %w", err)
+		}
+		logger.Info("found revisions", log.F("posts_with_revisions", len(revisions)))
+	}
+
+	totalRevisions := 0
+	for _, r := range revisions {
+		totalRevisions += len(r)
+	}
+
 	// Create export data
 	exportData := &models.ExportData{
-		Site:       *siteInfo,
-		Posts:      posts,
-		Pages:      pages,
-		Media:      media,
-		Categories: categories,
-		Tags:       tags,
-		Users:      users,
+		Site:          *siteInfo,
+		Posts:         posts,
+		Pages:         pages,
+		Media:         media,
+		Categories:    categories,
+		Tags:          tags,
+		Users:         users,
+		Comments:      comments,
+		Revisions:     revisions,
+		CustomContent: customContent,
 		Stats: models.ExportStats{
-			TotalPosts:      len(posts),
-			TotalPages:      len(pages),
-			TotalMedia:      len(media),
-			TotalCategories: len(categories),
-			TotalTags:       len(tags),
-			TotalUsers:      len(users),
-			BruteForceFound: bruteForceFound,
+			TotalPosts:          len(posts),
+			TotalPages:          len(pages),
+			TotalMedia:          len(media),
+			TotalCategories:     len(categories),
+			TotalTags:           len(tags),
+			TotalUsers:          len(users),
+			BruteForceFound:     bruteForceFound,
+			TotalComments:       len(comments),
+			TotalRevisions:      totalRevisions,
+			CustomContentCounts: customContentCounts,
 		},
 	}
 
-	// Export data
-	fmt.Println("\nExporting data...")
+	endExport := logger.Phase("export")
 	if err := exporter.Export(exportData); err != nil {
 		return fmt.Errorf("export failed: %w", err)
 	}
+	endExport()
 
-	// Create ZIP archive if requested
+	if cfg.SinceLast {
+		if err := persistSinceLastState(cfg, posts, pages, media); err != nil {
+			return fmt.Errorf("failed to persist since-last state: %w", err)
+		}
+	}
+
+	// Create archive if requested
 	var zipPath string
 	if cfg.CreateZip {
-		fmt.Println("Creating ZIP archive...")
-		zipPath = cfg.Output + ".zip"
-		if err := createZipArchive(cfg.Output, zipPath); err != nil {
-			return fmt.Errorf("failed to create ZIP archive: %w", err)
+		var err error
+		zipPath, err = createArchive(cfg, cfg.Output, cfg.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create archive: %w", err)
 		}
-		fmt.Printf("ZIP archive created: %s\n", zipPath)
+		logger.Info("archive created", log.F("path", zipPath))
 
 		// Remove files if --no-files is set
 		if cfg.NoFiles {
-			fmt.Println("Removing export files...")
 			if err := os.RemoveAll(cfg.Output); err != nil {
 				return fmt.Errorf("failed to remove export files: %w", err)
 			}
-			fmt.Println("Export files removed")
+			logger.Info("export files removed")
 		}
 	}
 
-	// Print summary
 	duration := time.Since(startTime)
-	fmt.Printf("\n=== Export Summary ===\n")
-	fmt.Printf("Site: %s\n", siteInfo.Name)
-	fmt.Printf("Posts: %d\n", len(posts))
-	fmt.Printf("Pages: %d\n", len(pages))
-	fmt.Printf("Media: %d\n", len(media))
-	fmt.Printf("Categories: %d\n", len(categories))
-	fmt.Printf("Tags: %d\n", len(tags))
-	fmt.Printf("Users: %d\n", len(users))
+	summaryFields := []log.Field{
+		log.F("site", siteInfo.Name),
+		log.F("posts", len(posts)),
+		log.F("pages", len(pages)),
+		log.F("media", len(media)),
+		log.F("categories", len(categories)),
+		log.F("tags", len(tags)),
+		log.F("users", len(users)),
+		log.F("duration_ms", duration.Milliseconds()),
+		log.F("output", cfg.Output),
+	}
+	if cfg.BruteForce {
+		summaryFields = append(summaryFields, log.F("brute_force_found", bruteForceFound))
+	}
+	if cfg.DownloadMedia {
+		summaryFields = append(summaryFields, log.F("media_downloaded", exportData.Stats.MediaDownloaded))
+	}
+	if cfg.CreateZip {
+		summaryFields = append(summaryFields, log.F("zip", zipPath))
+	}
+	logger.Summary(summaryFields...)
 
-	if cfg.BruteForce && bruteForceFound > 0 {
-		fmt.Printf("Brute force found: %d\n", bruteForceFound)
+	return nil
+}
+
+// runServe builds a Config the same way runExport does, optionally exports once (skipped
+// with --serve-only), then serves Config.Output until interrupted, re-exporting on
+// watchInterval when --watch is set.
+func runServe(cmd *cobra.Command, args []string) error {
+	cfg := config.DefaultConfig()
+
+	if cfgFile != "" || configFileExists() {
+		loadedCfg, err := config.LoadConfig(cfgFile, os.DirFS("/"), os.Getenv)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = loadedCfg
 	}
 
-	if cfg.DownloadMedia {
-		fmt.Printf("Media downloaded: %d\n", exportData.Stats.MediaDownloaded)
+	if cmd.Flags().Changed("url") {
+		cfg.URL = url
+	}
+	if cmd.Flags().Changed("output") {
+		cfg.Output = output
+	}
+	if cmd.Flags().Changed("format") {
+		cfg.Format = format
+	}
+	if cmd.Flags().Changed("serve-addr") {
+		cfg.ServeAddr = serveAddr
+	}
+	if cmd.Flags().Changed("watch") {
+		cfg.Watch = watch
+	}
+	if cmd.Flags().Changed("serve-only") {
+		cfg.ServeOnly = serveOnly
+	}
+	cfg.Serve = true
+	// --watch re-exports repeatedly, so it needs a state file mtime to watch; turning on
+	// Incremental costs nothing extra here since the export itself is unfiltered either way.
+	if cfg.Watch {
+		cfg.Incremental = true
 	}
 
-	fmt.Printf("Duration: %v\n", duration)
+	if err := cfg.GenerateDefaultOutput(); err != nil {
+		return fmt.Errorf("failed to generate default output path: %w", err)
+	}
+	if cfg.ServeAddr == "" {
+		cfg.ServeAddr = ":8080"
+	}
 
-	if cfg.CreateZip {
-		fmt.Printf("ZIP: %s\n", zipPath)
-		if !cfg.NoFiles {
-			fmt.Printf("Output: %s\n", cfg.Output)
+	if !cfg.ServeOnly {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+		if err := runExportWithConfig(cfg); err != nil {
+			return fmt.Errorf("export failed: %w", err)
 		}
-	} else {
-		fmt.Printf("Output: %s\n", cfg.Output)
 	}
 
-	return nil
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		fmt.Println("\nShutting down preview server...")
+		cancel()
+	}()
+
+	previewServer := server.New(cfg.ServeAddr, cfg.Output)
+
+	if cfg.Watch {
+		go server.WatchStateFile(ctx, stateFilePath(cfg), watchInterval, func() {
+			fmt.Println("Re-exporting...")
+			if err := runExportWithConfig(cfg); err != nil {
+				fmt.Printf("re-export failed: %v\n", err)
+				return
+			}
+			previewServer.Reload()
+		})
+	}
+
+	fmt.Printf("Serving %s at http://localhost%s\n", cfg.Output, cfg.ServeAddr)
+	return previewServer.ListenAndServe(ctx)
+}
+
+// incrementalManifest records what changed since the previous run, per content type, and
+// is written alongside the export as manifest.json when --incremental is set.
+type incrementalManifest struct {
+	Posts state.Manifest `json:"posts"`
+	Pages state.Manifest `json:"pages"`
+	Media state.Manifest `json:"media"`
 }
 
-// createZipArchive creates a ZIP archive of the specified directory
-func createZipArchive(sourceDir, targetZip string) error {
-	zipFile, err := os.Create(targetZip)
+// stateFilePath returns cfg.StateFile, or the default "<Output>/.wpexport-state.json"
+// when it's unset.
+func stateFilePath(cfg *config.Config) string {
+	if cfg.StateFile != "" {
+		return cfg.StateFile
+	}
+	return filepath.Join(cfg.Output, ".wpexport-state.json")
+}
+
+// reconcileIncrementalState loads cfg's state file, diffs posts/pages/media against the
+// hashes recorded on the previous run, writes the resulting manifest as manifest.json in the
+// state file's directory (cfg.Output unless StateFile points elsewhere), and
+// persists the updated state for the next run.
+func reconcileIncrementalState(cfg *config.Config, posts, pages []models.WordPressPost, media []models.WordPressMedia) (incrementalManifest, error) {
+	statePath := stateFilePath(cfg)
+
+	s, err := state.Load(statePath)
 	if err != nil {
-		return fmt.Errorf("failed to create zip file: %w", err)
+		return incrementalManifest{}, err
 	}
-	defer func() {
-		_ = zipFile.Close()
-	}()
 
-	zipWriter := zip.NewWriter(zipFile)
-	defer func() {
-		_ = zipWriter.Close()
-	}()
+	postHashes := make(map[int]string, len(posts))
+	for _, p := range posts {
+		postHashes[p.ID] = state.ContentHash(p.Title.Rendered, p.Content.Rendered, p.ModifiedGMT.Format(time.RFC3339))
+	}
+	pageHashes := make(map[int]string, len(pages))
+	for _, p := range pages {
+		pageHashes[p.ID] = state.ContentHash(p.Title.Rendered, p.Content.Rendered, p.ModifiedGMT.Format(time.RFC3339))
+	}
+	mediaHashes := make(map[int]string, len(media))
+	for _, m := range media {
+		mediaHashes[m.ID] = state.ContentHash(m.Title.Rendered, m.SourceURL, m.ModifiedGMT.Format(time.RFC3339))
+	}
+
+	manifest := incrementalManifest{
+		Posts: s.Reconcile("posts", postHashes),
+		Pages: s.Reconcile("pages", pageHashes),
+		Media: s.Reconcile("media", mediaHashes),
+	}
+
+	s.LastExportedAt = time.Now()
+	if err := s.Save(); err != nil {
+		return manifest, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, fmt.Errorf("failed to marshal incremental manifest: %w", err)
+	}
+	manifestPath := filepath.Join(filepath.Dir(statePath), "manifest.json")
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return manifest, fmt.Errorf("failed to write incremental manifest: %w", err)
+	}
+
+	return manifest, nil
+}
 
-	// Walk through the source directory
-	err = filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+// dateRangeRFC3339 converts --after/--before ("YYYY-MM-DD", already validated by
+// Config.Validate) into the RFC3339 timestamps api.Client's range fetchers expect,
+// leaving either side empty when the corresponding date is unset.
+func dateRangeRFC3339(after, before string) (string, string, error) {
+	var afterRFC, beforeRFC string
+	if after != "" {
+		t, err := time.Parse("2006-01-02", after)
 		if err != nil {
-			return err
+			return "", "", fmt.Errorf("invalid --after date: %w", err)
 		}
-
-		// Get relative path
-		relPath, err := filepath.Rel(sourceDir, path)
+		afterRFC = t.UTC().Format(time.RFC3339)
+	}
+	if before != "" {
+		t, err := time.Parse("2006-01-02", before)
 		if err != nil {
-			return err
+			return "", "", fmt.Errorf("invalid --before date: %w", err)
 		}
+		beforeRFC = t.UTC().Format(time.RFC3339)
+	}
+	return afterRFC, beforeRFC, nil
+}
 
-		// Skip the root directory itself
-		if relPath == "." {
-			return nil
-		}
+// fetchSinceLast retrieves posts/pages/media modified after each endpoint's high-water
+// mark recorded in cfg's state file by a previous --since-last run. A missing or
+// not-yet-recorded cursor fetches everything, the same as a first run.
+func fetchSinceLast(ctx context.Context, apiClient *api.Client, cfg *config.Config, logger *log.Logger) ([]models.WordPressPost, []models.WordPressPost, []models.WordPressMedia, error) {
+	s, err := state.Load(stateFilePath(cfg))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load state: %w", err)
+	}
 
-		// Create zip header
-		header, err := zip.FileInfoHeader(info)
-		if err != nil {
-			return err
+	logger.Info("since-last export",
+		log.F("posts_after", s.Endpoints["posts"].ModifiedAfter),
+		log.F("pages_after", s.Endpoints["pages"].ModifiedAfter),
+		log.F("media_after", s.Endpoints["media"].ModifiedAfter))
+
+	posts, err := apiClient.GetPostsModifiedAfterContext(ctx, s.Endpoints["posts"].ModifiedAfter)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+	pages, err := apiClient.GetPagesModifiedAfterContext(ctx, s.Endpoints["pages"].ModifiedAfter)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get pages: %w", err)
+	}
+	media, err := apiClient.GetMediaModifiedAfterContext(ctx, s.Endpoints["media"].ModifiedAfter)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get media: %w", err)
+	}
+	return posts, pages, media, nil
+}
+
+// mergeSinceLastExport overlays a --since-last delta fetch onto any existing
+// "<Output>/export.json" (or the file Output names directly), replacing entries that
+// share an ID and appending the rest, so a delta fetch doesn't drop content that wasn't
+// modified and therefore wasn't re-fetched. Merging only applies to Format "json"; other
+// formats are split across many files that can't cheaply be patched in place, so they
+// just export whatever the delta fetch returned. Note this can't detect deletions the
+// way --incremental's Reconcile does, since a modified_after-filtered fetch never sees
+// the full current ID set to diff against.
+func mergeSinceLastExport(cfg *config.Config, posts, pages []models.WordPressPost, media []models.WordPressMedia) ([]models.WordPressPost, []models.WordPressPost, []models.WordPressMedia, error) {
+	if cfg.Format != "json" {
+		return posts, pages, media, nil
+	}
+
+	existingPath := cfg.Output
+	if filepath.Ext(existingPath) != ".json" {
+		existingPath = filepath.Join(cfg.Output, "export.json")
+	}
+
+	data, err := os.ReadFile(existingPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return posts, pages, media, nil
 		}
+		return nil, nil, nil, fmt.Errorf("failed to read existing export %s: %w", existingPath, err)
+	}
+
+	var previous models.ExportData
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse existing export %s: %w", existingPath, err)
+	}
 
-		// Use relative path in zip
-		header.Name = relPath
+	return mergePostsByID(previous.Posts, posts), mergePostsByID(previous.Pages, pages), mergeMediaByID(previous.Media, media), nil
+}
 
-		// Set compression method for files
-		if !info.IsDir() {
-			header.Method = zip.Deflate
-		} else {
-			header.Name += "/"
+// mergePostsByID overlays delta onto base: a post sharing an ID with one in delta is
+// replaced, and posts only in delta are appended, preserving base's order otherwise.
+func mergePostsByID(base, delta []models.WordPressPost) []models.WordPressPost {
+	byID := make(map[int]models.WordPressPost, len(delta))
+	for _, p := range delta {
+		byID[p.ID] = p
+	}
+
+	merged := make([]models.WordPressPost, 0, len(base)+len(delta))
+	seen := make(map[int]bool, len(base))
+	for _, p := range base {
+		if updated, ok := byID[p.ID]; ok {
+			p = updated
 		}
+		merged = append(merged, p)
+		seen[p.ID] = true
+	}
+	for _, p := range delta {
+		if !seen[p.ID] {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
 
-		// Create writer for this file
-		writer, err := zipWriter.CreateHeader(header)
-		if err != nil {
-			return err
+// mergeMediaByID mirrors mergePostsByID for media items.
+func mergeMediaByID(base, delta []models.WordPressMedia) []models.WordPressMedia {
+	byID := make(map[int]models.WordPressMedia, len(delta))
+	for _, m := range delta {
+		byID[m.ID] = m
+	}
+
+	merged := make([]models.WordPressMedia, 0, len(base)+len(delta))
+	seen := make(map[int]bool, len(base))
+	for _, m := range base {
+		if updated, ok := byID[m.ID]; ok {
+			m = updated
+		}
+		merged = append(merged, m)
+		seen[m.ID] = true
+	}
+	for _, m := range delta {
+		if !seen[m.ID] {
+			merged = append(merged, m)
 		}
+	}
+	return merged
+}
 
-		// If it's a directory, we're done
-		if info.IsDir() {
-			return nil
+// persistSinceLastState records each endpoint's maximum modified_gmt seen in this run's
+// fetch as its high-water mark for the next --since-last run, and bumps LastExportedAt.
+func persistSinceLastState(cfg *config.Config, posts, pages []models.WordPressPost, media []models.WordPressMedia) error {
+	s, err := state.Load(stateFilePath(cfg))
+	if err != nil {
+		return err
+	}
+
+	if max := maxPostModifiedGMT(posts); !max.IsZero() {
+		s.SetModifiedAfter("posts", max.UTC().Format(time.RFC3339))
+	}
+	if max := maxPostModifiedGMT(pages); !max.IsZero() {
+		s.SetModifiedAfter("pages", max.UTC().Format(time.RFC3339))
+	}
+	if max := maxMediaModifiedGMT(media); !max.IsZero() {
+		s.SetModifiedAfter("media", max.UTC().Format(time.RFC3339))
+	}
+
+	s.LastExportedAt = time.Now()
+	return s.Save()
+}
+
+// maxPostModifiedGMT returns the latest ModifiedGMT across posts, or the zero time if
+// posts is empty.
+func maxPostModifiedGMT(posts []models.WordPressPost) time.Time {
+	var max time.Time
+	for _, p := range posts {
+		if p.ModifiedGMT.After(max) {
+			max = p.ModifiedGMT.Time
 		}
+	}
+	return max
+}
 
-		// Open and copy file contents
-		file, err := os.Open(path)
-		if err != nil {
-			return err
+// maxMediaModifiedGMT returns the latest ModifiedGMT across media items, or the zero
+// time if media is empty.
+func maxMediaModifiedGMT(media []models.WordPressMedia) time.Time {
+	var max time.Time
+	for _, m := range media {
+		if m.ModifiedGMT.After(max) {
+			max = m.ModifiedGMT.Time
 		}
-		defer func() {
-			_ = file.Close()
-		}()
+	}
+	return max
+}
 
-		_, err = io.Copy(writer, file)
-		return err
-	})
+// createArchive packages sourceDir (without its ".zip"/".tar.gz" extension, in
+// targetBase) via cfg.ArchiveFormat, writing a SHA-256 MANIFEST.json and an options.json
+// snapshot of cfg into sourceDir first so both end up inside the archive alongside the
+// exported content. When cfg.SignKey is set, it also signs MANIFEST.json and writes a
+// detached MANIFEST.json.sig.
+func createArchive(cfg *config.Config, sourceDir, targetBase string) (string, error) {
+	archiveFormat := cfg.ArchiveFormat
+	if archiveFormat == "" {
+		archiveFormat = "zip"
+	}
+	archiver, ok := archive.Archivers[archiveFormat]
+	if !ok {
+		return "", fmt.Errorf("unknown archive format %q: must be one of zip, tar.gz", archiveFormat)
+	}
+
+	if _, err := archive.WriteManifest(sourceDir); err != nil {
+		return "", fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+	if err := archive.WriteOptions(sourceDir, cfg); err != nil {
+		return "", fmt.Errorf("failed to write archive options: %w", err)
+	}
+	if cfg.SignKey != "" {
+		if _, err := archive.SignManifest(sourceDir, cfg.SignKey); err != nil {
+			return "", fmt.Errorf("failed to sign archive manifest: %w", err)
+		}
+	}
 
-	return err
+	return archiver.Archive(sourceDir, targetBase)
 }
 
 func main() {
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/internal/xmlrpc"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+var inputFile string
+
+// importCmd replays a previously-exported JSON dump against a (typically different)
+// WordPress site via XML-RPC, for round-trip migration between installs.
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a previously-exported JSON dump via XML-RPC",
+	Long: `Replay a JSON dump produced by "export --format json" against a WordPress site
+using the XML-RPC API. Media is uploaded first so posts can reference it, then
+categories and tags, then pages (parents before children), then posts.`,
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&url, "url", "u", "", "WordPress site URL (required)")
+	importCmd.Flags().StringVar(&username, "username", "", "WordPress username (required)")
+	importCmd.Flags().StringVar(&password, "password", "", "WordPress password (required)")
+	importCmd.Flags().StringVarP(&inputFile, "input", "i", "", "path to a JSON export produced by this tool (required)")
+
+	importCmd.MarkFlagRequired("url")
+	importCmd.MarkFlagRequired("username")
+	importCmd.MarkFlagRequired("input")
+
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	cfg := config.DefaultConfig()
+	cfg.URL = url
+
+	raw, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var data models.ExportData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("failed to parse export data: %w", err)
+	}
+
+	client, err := xmlrpc.NewClient(cfg, username, password)
+	if err != nil {
+		return fmt.Errorf("failed to create XML-RPC client: %w", err)
+	}
+
+	ctx := context.Background()
+
+	fmt.Printf("Importing into: %s\n", cfg.URL)
+
+	mediaIDs, err := importMedia(ctx, client, data.Media)
+	if err != nil {
+		return fmt.Errorf("failed to import media: %w", err)
+	}
+	fmt.Printf("Uploaded %d media items\n", len(mediaIDs))
+
+	categoryIDs, err := importTerms(ctx, client, "category", data.Categories)
+	if err != nil {
+		return fmt.Errorf("failed to import categories: %w", err)
+	}
+	fmt.Printf("Created %d categories\n", len(categoryIDs))
+
+	tagIDs, err := importTags(ctx, client, data.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to import tags: %w", err)
+	}
+	fmt.Printf("Created %d tags\n", len(tagIDs))
+
+	pageIDs, err := importPages(ctx, client, data.Pages, mediaIDs, categoryIDs, tagIDs)
+	if err != nil {
+		return fmt.Errorf("failed to import pages: %w", err)
+	}
+	fmt.Printf("Created %d pages\n", len(pageIDs))
+
+	postIDs, err := importPosts(ctx, client, data.Posts, mediaIDs, categoryIDs, tagIDs)
+	if err != nil {
+		return fmt.Errorf("failed to import posts: %w", err)
+	}
+	fmt.Printf("Created %d posts\n", len(postIDs))
+
+	return nil
+}
+
+// downloadMediaBytes fetches a media item's source file so it can be re-uploaded to the
+// destination site.
+func downloadMediaBytes(sourceURL string) ([]byte, error) {
+	resp, err := http.Get(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, sourceURL)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// importMedia uploads every media item and returns a map from its original ID to the ID
+// assigned by the destination site.
+func importMedia(ctx context.Context, client *xmlrpc.Client, items []models.WordPressMedia) (map[int]int, error) {
+	ids := make(map[int]int, len(items))
+
+	for _, item := range items {
+		data, err := downloadMediaBytes(item.SourceURL)
+		if err != nil {
+			return ids, fmt.Errorf("failed to fetch media %d (%s): %w", item.ID, item.SourceURL, err)
+		}
+
+		uploaded, err := client.UploadFile(ctx, item.MediaDetails.File, item.MimeType, data)
+		if err != nil {
+			return ids, fmt.Errorf("failed to upload media %d: %w", item.ID, err)
+		}
+
+		ids[item.ID] = uploaded.ID
+	}
+
+	return ids, nil
+}
+
+// importTerms creates categories and returns a map from original ID to new ID.
+func importTerms(ctx context.Context, client *xmlrpc.Client, taxonomy string, items []models.WordPressCategory) (map[int]int, error) {
+	ids := make(map[int]int, len(items))
+
+	for _, item := range items {
+		newID, err := client.NewTerm(ctx, xmlrpc.Term{
+			Name:        item.Name,
+			Slug:        item.Slug,
+			Taxonomy:    taxonomy,
+			Parent:      ids[item.Parent],
+			Description: item.Description,
+		})
+		if err != nil {
+			return ids, fmt.Errorf("failed to create category %q: %w", item.Name, err)
+		}
+
+		ids[item.ID] = newID
+	}
+
+	return ids, nil
+}
+
+// importTags creates tags and returns a map from original ID to new ID.
+func importTags(ctx context.Context, client *xmlrpc.Client, items []models.WordPressTag) (map[int]int, error) {
+	ids := make(map[int]int, len(items))
+
+	for _, item := range items {
+		newID, err := client.NewTerm(ctx, xmlrpc.Term{
+			Name:        item.Name,
+			Slug:        item.Slug,
+			Taxonomy:    "post_tag",
+			Description: item.Description,
+		})
+		if err != nil {
+			return ids, fmt.Errorf("failed to create tag %q: %w", item.Name, err)
+		}
+
+		ids[item.ID] = newID
+	}
+
+	return ids, nil
+}
+
+// importPages creates pages in parent-before-child order, remapping media/term/parent IDs
+// to the ones assigned by the destination site, and returns a map from original ID to new
+// ID so later pages can resolve their parent.
+func importPages(ctx context.Context, client *xmlrpc.Client, items []models.WordPressPost, mediaIDs, categoryIDs, tagIDs map[int]int) (map[int]int, error) {
+	ids := make(map[int]int, len(items))
+	remaining := append([]models.WordPressPost(nil), items...)
+
+	for len(remaining) > 0 {
+		progressed := false
+		var next []models.WordPressPost
+
+		for _, page := range remaining {
+			if page.Parent != 0 {
+				if _, ok := ids[page.Parent]; !ok {
+					next = append(next, page)
+					continue
+				}
+			}
+
+			newID, err := createContentItem(ctx, client, page, mediaIDs, categoryIDs, tagIDs, ids[page.Parent])
+			if err != nil {
+				return ids, fmt.Errorf("failed to create page %q: %w", page.Slug, err)
+			}
+
+			ids[page.ID] = newID
+			progressed = true
+		}
+
+		if !progressed {
+			return ids, fmt.Errorf("could not resolve parent for %d page(s); the export may reference a parent outside this dump", len(next))
+		}
+
+		remaining = next
+	}
+
+	return ids, nil
+}
+
+// importPosts creates posts, remapping media/category/tag IDs to the ones assigned by the
+// destination site. Posts have no parent relation to preserve.
+func importPosts(ctx context.Context, client *xmlrpc.Client, items []models.WordPressPost, mediaIDs, categoryIDs, tagIDs map[int]int) (map[int]int, error) {
+	ids := make(map[int]int, len(items))
+
+	for _, post := range items {
+		newID, err := createContentItem(ctx, client, post, mediaIDs, categoryIDs, tagIDs, 0)
+		if err != nil {
+			return ids, fmt.Errorf("failed to create post %q: %w", post.Slug, err)
+		}
+
+		ids[post.ID] = newID
+	}
+
+	return ids, nil
+}
+
+// createContentItem remaps a post/page's media and term references to their
+// destination-site IDs and creates it via wp.newPost.
+func createContentItem(ctx context.Context, client *xmlrpc.Client, item models.WordPressPost, mediaIDs, categoryIDs, tagIDs map[int]int, newParent int) (int, error) {
+	item.FeaturedMedia = mediaIDs[item.FeaturedMedia]
+	item.Parent = newParent
+
+	categories := make([]int, 0, len(item.Categories))
+	for _, id := range item.Categories {
+		categories = append(categories, categoryIDs[id])
+	}
+	item.Categories = categories
+
+	tags := make([]int, 0, len(item.Tags))
+	for _, id := range item.Tags {
+		tags = append(tags, tagIDs[id])
+	}
+	item.Tags = tags
+
+	return client.NewPost(ctx, item)
+}
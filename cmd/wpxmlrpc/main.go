@@ -2,13 +2,18 @@ package main
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tradik/wpexporter/internal/cache"
 	"github.com/tradik/wpexporter/internal/config"
 	"github.com/tradik/wpexporter/internal/export"
+	"github.com/tradik/wpexporter/internal/log"
+	"github.com/tradik/wpexporter/internal/plugin"
 	"github.com/tradik/wpexporter/internal/xmlrpc"
 	"github.com/tradik/wpexporter/pkg/models"
 )
@@ -21,8 +26,39 @@ var (
 	output   string
 	format   string
 	verbose  bool
+
+	writeFeeds   bool
+	writeSitemap bool
+
+	logFormat string
+	logLevel  string
+
+	cacheDir        string
+	cacheTTLSeconds int
 )
 
+// commandeer owns the dependencies runExport needs that would otherwise be read from the
+// real disk/environment/clock: the filesystem and environment LoadConfig reads from, and
+// the clock it times the export against. This keeps runExport's effects injectable, so
+// tests can exercise it without touching the real environment or wall clock.
+type commandeer struct {
+	fs     fs.FS
+	getenv func(string) string
+	now    func() time.Time
+	logger *log.Logger
+}
+
+// newCommandeer builds a commandeer wired to the real filesystem, environment, and clock,
+// with a logger rendering per the --log-format/--log-level global flags.
+func newCommandeer() *commandeer {
+	return &commandeer{
+		fs:     os.DirFS("/"),
+		getenv: os.Getenv,
+		now:    time.Now,
+		logger: log.New(log.Format(logFormat), log.ParseLevel(logLevel)),
+	}
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "wpxmlrpc",
@@ -38,7 +74,46 @@ var exportCmd = &cobra.Command{
 	Short: "Export WordPress content via XML-RPC",
 	Long: `Export all content from a WordPress site using XML-RPC API.
 Requires WordPress username and password for authentication.`,
-	RunE: runExport,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return newCommandeer().runExport(cmd, args)
+	},
+}
+
+// pluginCmd is the parent for subcommands that manage external export format plugins
+// (see internal/plugin), discovered from ./plugins, $HOME/.wpxmlrpc/plugins, and
+// /etc/wpxmlrpc/plugins.
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage export format plugins",
+}
+
+// pluginListCmd lists every plugin discovered on the plugin search path.
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed export format plugins",
+	RunE:  runPluginList,
+}
+
+// pluginInstallCmd downloads and installs a plugin from a tar.gz URL.
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <url>",
+	Short: "Install an export format plugin from a tar.gz URL",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPluginInstall,
+}
+
+// cacheCmd is the parent for subcommands that manage the on-disk content cache (see
+// internal/cache) used to revalidate previously-downloaded media between runs.
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the on-disk content cache",
+}
+
+// cacheGCCmd evicts expired entries from a cache directory.
+var cacheGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Evict expired entries from the content cache",
+	RunE:  runCacheGC,
 }
 
 func init() {
@@ -47,6 +122,8 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.wpxmlrpc/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log output format (text|json)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "minimum log level (debug|info|warn|error)")
 
 	// Export command flags
 	exportCmd.Flags().StringVarP(&url, "url", "u", "", "WordPress site URL (required)")
@@ -54,45 +131,67 @@ func init() {
 	exportCmd.Flags().StringVar(&password, "password", "", "WordPress password (required)")
 	exportCmd.Flags().StringVarP(&output, "output", "o", "", "output directory or file (default: export/{domain-name}.{date}{time})")
 	exportCmd.Flags().StringVarP(&format, "format", "f", "json", "export format (json|markdown)")
+	exportCmd.Flags().BoolVar(&writeFeeds, "feeds", false, "write feed.atom and feed.rss alongside the export")
+	exportCmd.Flags().BoolVar(&writeSitemap, "sitemap", false, "write a sitemap.xml covering posts, pages, categories, and tags alongside the export")
 
 	// Mark required flags
 	exportCmd.MarkFlagRequired("url")
 	exportCmd.MarkFlagRequired("username")
 
 	rootCmd.AddCommand(exportCmd)
+
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInstallCmd)
+	rootCmd.AddCommand(pluginCmd)
+
+	cacheGCCmd.Flags().StringVar(&cacheDir, "dir", "", "cache root directory (required)")
+	cacheGCCmd.Flags().IntVar(&cacheTTLSeconds, "ttl-seconds", 0, "default entry TTL in seconds used for sub-caches with no configured override (0 = never expire)")
+	cacheGCCmd.MarkFlagRequired("dir")
+	cacheCmd.AddCommand(cacheGCCmd)
+	rootCmd.AddCommand(cacheCmd)
 }
 
 func initConfig() {
 	// Configuration will be loaded in runExport
 }
 
-// configFileExists checks if a configuration file exists in standard locations
-func configFileExists() bool {
+// configFileExists checks whether a configuration file exists in one of c's standard
+// search locations, reading through c.fs/c.getenv rather than the real disk/environment.
+func (c *commandeer) configFileExists() bool {
 	configPaths := []string{
 		"./config.yaml",
 		"./config.yml",
-		filepath.Join(os.Getenv("HOME"), ".wpxmlrpc", "config.yaml"),
-		filepath.Join(os.Getenv("HOME"), ".wpxmlrpc", "config.yml"),
+		filepath.Join(c.getenv("HOME"), ".wpxmlrpc", "config.yaml"),
+		filepath.Join(c.getenv("HOME"), ".wpxmlrpc", "config.yml"),
 		"/etc/wpxmlrpc/config.yaml",
 		"/etc/wpxmlrpc/config.yml",
 	}
 
+	cwd, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+
 	for _, path := range configPaths {
-		if _, err := os.Stat(path); err == nil {
+		abs := path
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(cwd, abs)
+		}
+		if _, err := fs.Stat(c.fs, strings.TrimPrefix(filepath.ToSlash(abs), "/")); err == nil {
 			return true
 		}
 	}
 	return false
 }
 
-func runExport(cmd *cobra.Command, args []string) error {
+func (c *commandeer) runExport(cmd *cobra.Command, args []string) error {
 	// Start with default configuration
 	cfg := config.DefaultConfig()
 	cfg.Output = "./xmlrpc-export" // Different default for XML-RPC
 
 	// Load configuration file if specified or found
-	if cfgFile != "" || configFileExists() {
-		loadedCfg, err := config.LoadConfig(cfgFile)
+	if cfgFile != "" || c.configFileExists() {
+		loadedCfg, err := config.LoadConfig(cfgFile, c.fs, c.getenv)
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
@@ -112,6 +211,12 @@ func runExport(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("verbose") {
 		cfg.Verbose = verbose
 	}
+	if cmd.Flags().Changed("feeds") {
+		cfg.WriteFeeds = writeFeeds
+	}
+	if cmd.Flags().Changed("sitemap") {
+		cfg.WriteSitemap = writeSitemap
+	}
 
 	// Generate default output path if not specified
 	if err := cfg.GenerateDefaultOutput(); err != nil {
@@ -132,68 +237,70 @@ func runExport(cmd *cobra.Command, args []string) error {
 	// Create exporter
 	exporter := export.NewExporter(cfg)
 
-	fmt.Printf("Starting WordPress XML-RPC export from: %s\n", cfg.URL)
-	fmt.Printf("Output: %s (format: %s)\n", cfg.Output, cfg.Format)
-	fmt.Printf("Username: %s\n", username)
+	c.logger.Info("starting WordPress XML-RPC export", log.F("url", cfg.URL), log.F("output", cfg.Output), log.F("format", cfg.Format), log.F("username", username))
 
-	startTime := time.Now()
+	startTime := c.now()
 
-	// Test connection
-	fmt.Println("\nTesting XML-RPC connection...")
+	endConnect := c.logger.Phase("test_connection")
 	if err := xmlrpcClient.TestConnection(); err != nil {
 		return fmt.Errorf("XML-RPC connection failed: %w", err)
 	}
-	fmt.Println("✓ XML-RPC connection successful")
+	endConnect()
 
-	// Get site information
-	fmt.Println("\nFetching site information...")
+	endSite := c.logger.Phase("fetch_site_info")
 	siteInfo, err := xmlrpcClient.GetSiteInfo()
 	if err != nil {
 		return fmt.Errorf("failed to get site info: %w", err)
 	}
+	endSite()
 
-	// Get all content via XML-RPC
-	fmt.Println("Fetching posts...")
-	posts, err := xmlrpcClient.GetPosts()
+	endPosts := c.logger.Phase("fetch_posts")
+	posts, err := xmlrpcClient.GetPostsWithProgress(func(n int) {
+		c.logger.Debug("fetching posts", log.F("count", n))
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get posts: %w", err)
 	}
-	fmt.Printf("Found %d posts\n", len(posts))
+	endPosts()
+	c.logger.Info("found posts", log.F("count", len(posts)))
 
-	fmt.Println("Fetching pages...")
-	pages, err := xmlrpcClient.GetPages()
+	endPages := c.logger.Phase("fetch_pages")
+	pages, err := xmlrpcClient.GetPagesWithProgress(func(n int) {
+		c.logger.Debug("fetching pages", log.F("count", n))
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get pages: %w", err)
 	}
-	fmt.Printf("Found %d pages\n", len(pages))
+	endPages()
+	c.logger.Info("found pages", log.F("count", len(pages)))
 
-	fmt.Println("Fetching media...")
-	media, err := xmlrpcClient.GetMedia()
+	endMedia := c.logger.Phase("fetch_media")
+	media, err := xmlrpcClient.GetMediaWithProgress(func(n int) {
+		c.logger.Debug("fetching media", log.F("count", n))
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get media: %w", err)
 	}
-	fmt.Printf("Found %d media items\n", len(media))
+	endMedia()
+	c.logger.Info("found media", log.F("count", len(media)))
 
-	fmt.Println("Fetching categories...")
 	categories, err := xmlrpcClient.GetCategories()
 	if err != nil {
 		return fmt.Errorf("failed to get categories: %w", err)
 	}
-	fmt.Printf("Found %d categories\n", len(categories))
+	c.logger.Info("found categories", log.F("count", len(categories)))
 
-	fmt.Println("Fetching tags...")
 	tags, err := xmlrpcClient.GetTags()
 	if err != nil {
 		return fmt.Errorf("failed to get tags: %w", err)
 	}
-	fmt.Printf("Found %d tags\n", len(tags))
+	c.logger.Info("found tags", log.F("count", len(tags)))
 
-	fmt.Println("Fetching users...")
 	users, err := xmlrpcClient.GetUsers()
 	if err != nil {
 		return fmt.Errorf("failed to get users: %w", err)
 	}
-	fmt.Printf("Found %d users\n", len(users))
+	c.logger.Info("found users", log.F("count", len(users)))
 
 	// Create export data
 	exportData := &models.ExportData{
@@ -214,25 +321,79 @@ func runExport(cmd *cobra.Command, args []string) error {
 		},
 	}
 
-	// Export data
-	fmt.Println("\nExporting data...")
+	endExport := c.logger.Phase("export")
 	if err := exporter.Export(exportData); err != nil {
 		return fmt.Errorf("export failed: %w", err)
 	}
+	endExport()
+
+	duration := c.now().Sub(startTime)
+	c.logger.Summary(
+		log.F("site", siteInfo.Name),
+		log.F("posts", len(posts)),
+		log.F("pages", len(pages)),
+		log.F("media", len(media)),
+		log.F("categories", len(categories)),
+		log.F("tags", len(tags)),
+		log.F("users", len(users)),
+		log.F("duration_ms", duration.Milliseconds()),
+		log.F("output", cfg.Output),
+	)
 
-	// Print summary
-	duration := time.Since(startTime)
-	fmt.Printf("\n=== XML-RPC Export Summary ===\n")
-	fmt.Printf("Site: %s\n", siteInfo.Name)
-	fmt.Printf("Posts: %d\n", len(posts))
-	fmt.Printf("Pages: %d\n", len(pages))
-	fmt.Printf("Media: %d\n", len(media))
-	fmt.Printf("Categories: %d\n", len(categories))
-	fmt.Printf("Tags: %d\n", len(tags))
-	fmt.Printf("Users: %d\n", len(users))
-	fmt.Printf("Duration: %v\n", duration)
-	fmt.Printf("Output: %s\n", cfg.Output)
+	return nil
+}
+
+// runPluginList prints every export format plugin discovered on the default plugin
+// search path.
+func runPluginList(cmd *cobra.Command, args []string) error {
+	plugins, err := plugin.FindPlugins(plugin.DefaultPluginDirs())
+	if err != nil {
+		return fmt.Errorf("failed to search for plugins: %w", err)
+	}
+
+	if len(plugins) == 0 {
+		fmt.Println("No plugins installed.")
+		return nil
+	}
+
+	for _, p := range plugins {
+		fmt.Printf("%s\t(format: %s)\t%s\n", p.Manifest.Name, p.Manifest.Format, p.Manifest.Description)
+	}
+	return nil
+}
+
+// runPluginInstall downloads and installs a plugin from a tar.gz URL into
+// $HOME/.wpxmlrpc/plugins.
+func runPluginInstall(cmd *cobra.Command, args []string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	pluginsDir := filepath.Join(home, ".wpxmlrpc", "plugins")
+	if err := os.MkdirAll(pluginsDir, 0750); err != nil {
+		return fmt.Errorf("failed to create plugins directory: %w", err)
+	}
+
+	manifest, err := plugin.Install(args[0], pluginsDir)
+	if err != nil {
+		return fmt.Errorf("failed to install plugin: %w", err)
+	}
+
+	fmt.Printf("Installed plugin %q (format: %s) to %s\n", manifest.Name, manifest.Format, filepath.Join(pluginsDir, manifest.Name))
+	return nil
+}
+
+// runCacheGC evicts every entry under cacheDir older than cacheTTLSeconds.
+func runCacheGC(cmd *cobra.Command, args []string) error {
+	c := cache.New(cacheDir, time.Duration(cacheTTLSeconds)*time.Second)
+
+	evicted, err := c.GC(nil)
+	if err != nil {
+		return fmt.Errorf("failed to garbage collect cache: %w", err)
+	}
 
+	fmt.Printf("Evicted %d expired cache entries from %s\n", evicted, cacheDir)
 	return nil
 }
 
@@ -0,0 +1,89 @@
+// Package activitypub renders WordPress content as ActivityStreams 2.0 objects and
+// WebFinger documents, letting a site's content be federated read-only into the
+// Fediverse without running a live ActivityPub server.
+package activitypub
+
+// ContextURL is the ActivityStreams 2.0 JSON-LD context shared by every object this
+// package produces.
+const ContextURL = "https://www.w3.org/ns/activitystreams"
+
+// Actor represents an ActivityStreams Person actor for a WordPress author.
+type Actor struct {
+	Context           []string  `json:"@context"`
+	ID                string    `json:"id"`
+	Type              string    `json:"type"`
+	PreferredUsername string    `json:"preferredUsername"`
+	Name              string    `json:"name"`
+	Summary           string    `json:"summary,omitempty"`
+	Inbox             string    `json:"inbox"`
+	Outbox            string    `json:"outbox"`
+	PublicKey         PublicKey `json:"publicKey"`
+}
+
+// PublicKey is the HTTP-signature public key block embedded in an Actor document.
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// Object is a Note or Article ActivityStreams object representing a WordPress post.
+type Object struct {
+	Context      []string     `json:"@context"`
+	ID           string       `json:"id"`
+	Type         string       `json:"type"`
+	AttributedTo string       `json:"attributedTo"`
+	Content      string       `json:"content"`
+	Published    string       `json:"published"`
+	URL          string       `json:"url,omitempty"`
+	Tag          []Tag        `json:"tag,omitempty"`
+	Attachment   []Attachment `json:"attachment,omitempty"`
+}
+
+// Tag is an ActivityStreams tag entry, used here to carry hashtags derived from WP tags.
+type Tag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Href string `json:"href,omitempty"`
+}
+
+// Attachment is an ActivityStreams attachment entry, used here for featured media.
+type Attachment struct {
+	Type      string `json:"type"`
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url"`
+}
+
+// OrderedCollection is the top-level, paginated ActivityStreams collection for an
+// actor's outbox.
+type OrderedCollection struct {
+	Context    []string `json:"@context"`
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	TotalItems int      `json:"totalItems"`
+	First      string   `json:"first,omitempty"`
+}
+
+// OrderedCollectionPage is a single page of an OrderedCollection.
+type OrderedCollectionPage struct {
+	Context      []string      `json:"@context"`
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	PartOf       string        `json:"partOf"`
+	Next         string        `json:"next,omitempty"`
+	OrderedItems []interface{} `json:"orderedItems"`
+}
+
+// WebFinger is a WebFinger JRD document mapping an acct: resource to its actor URL, as
+// served from /.well-known/webfinger?resource=acct:<user>@<host>.
+type WebFinger struct {
+	Subject string          `json:"subject"`
+	Links   []WebFingerLink `json:"links"`
+}
+
+// WebFingerLink is a single rel/href entry in a WebFinger JRD document.
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href"`
+}
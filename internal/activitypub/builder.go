@@ -0,0 +1,74 @@
+package activitypub
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// BuildActor renders a WordPressUser as an ActivityStreams Person actor, addressed at
+// <baseURL>/actors/<slug>, with publicKeyPEM embedded for HTTP-signature verification.
+func BuildActor(user models.WordPressUser, baseURL, publicKeyPEM string) Actor {
+	actorURL := fmt.Sprintf("%s/actors/%s", strings.TrimSuffix(baseURL, "/"), user.Slug)
+
+	return Actor{
+		Context:           []string{ContextURL},
+		ID:                actorURL,
+		Type:              "Person",
+		PreferredUsername: user.Slug,
+		Name:              user.Name,
+		Summary:           user.Description,
+		Inbox:             actorURL + "/inbox",
+		Outbox:            actorURL + "/outbox",
+		PublicKey: PublicKey{
+			ID:           actorURL + "#main-key",
+			Owner:        actorURL,
+			PublicKeyPem: publicKeyPEM,
+		},
+	}
+}
+
+// BuildObject renders a WordPressPost as an ActivityStreams object of the given type
+// (typically "Note" for posts, "Article" for pages), attributed to actorURL, with
+// hashtag entries derived from tags and an attachment for featuredMedia when present.
+func BuildObject(post models.WordPressPost, objectType, actorURL, baseURL string, tags []models.WordPressTag, featuredMedia *models.WordPressMedia) Object {
+	obj := Object{
+		Context:      []string{ContextURL},
+		ID:           fmt.Sprintf("%s/notes/%d", strings.TrimSuffix(baseURL, "/"), post.ID),
+		Type:         objectType,
+		AttributedTo: actorURL,
+		Content:      post.Content.Rendered,
+		Published:    post.Date.Format("2006-01-02T15:04:05Z07:00"),
+		URL:          post.Link,
+	}
+
+	for _, tag := range tags {
+		obj.Tag = append(obj.Tag, Tag{
+			Type: "Hashtag",
+			Name: "#" + strings.ReplaceAll(tag.Slug, "-", ""),
+			Href: tag.Link,
+		})
+	}
+
+	if featuredMedia != nil {
+		obj.Attachment = append(obj.Attachment, Attachment{
+			Type:      "Image",
+			MediaType: featuredMedia.MimeType,
+			URL:       featuredMedia.SourceURL,
+		})
+	}
+
+	return obj
+}
+
+// BuildWebFinger renders the WebFinger JRD document for the acct:<slug>@<host> resource,
+// pointing it at the actor's ActivityPub document.
+func BuildWebFinger(slug, host, actorURL string) WebFinger {
+	return WebFinger{
+		Subject: fmt.Sprintf("acct:%s@%s", slug, host),
+		Links: []WebFingerLink{
+			{Rel: "self", Type: "application/activity+json", Href: actorURL},
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package activitypub
+
+import (
+	"testing"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestBuildActor(t *testing.T) {
+	user := models.WordPressUser{ID: 1, Slug: "jdoe", Name: "Jane Doe", Description: "Writer"}
+
+	actor := BuildActor(user, "https://example.com", "PUBLIC-KEY-PEM")
+
+	if actor.ID != "https://example.com/actors/jdoe" {
+		t.Errorf("BuildActor() ID = %q, want %q", actor.ID, "https://example.com/actors/jdoe")
+	}
+
+	if actor.Type != "Person" {
+		t.Errorf("BuildActor() Type = %q, want %q", actor.Type, "Person")
+	}
+
+	if actor.PublicKey.PublicKeyPem != "PUBLIC-KEY-PEM" {
+		t.Errorf("BuildActor() PublicKeyPem = %q, want %q", actor.PublicKey.PublicKeyPem, "PUBLIC-KEY-PEM")
+	}
+
+	if actor.Inbox != actor.ID+"/inbox" || actor.Outbox != actor.ID+"/outbox" {
+		t.Errorf("BuildActor() inbox/outbox = %q/%q, want derived from actor ID", actor.Inbox, actor.Outbox)
+	}
+}
+
+func TestBuildObject(t *testing.T) {
+	post := models.WordPressPost{
+		ID:            42,
+		Content:       models.RenderedContent{Rendered: "<p>Hello, Fediverse</p>"},
+		Link:          "https://example.com/hello-fediverse",
+		FeaturedMedia: 7,
+	}
+	tags := []models.WordPressTag{{Slug: "wordpress", Link: "https://example.com/tag/wordpress"}}
+	media := &models.WordPressMedia{MimeType: "image/png", SourceURL: "https://example.com/image.png"}
+
+	obj := BuildObject(post, "Note", "https://example.com/actors/jdoe", "https://example.com", tags, media)
+
+	if obj.ID != "https://example.com/notes/42" {
+		t.Errorf("BuildObject() ID = %q, want %q", obj.ID, "https://example.com/notes/42")
+	}
+
+	if obj.AttributedTo != "https://example.com/actors/jdoe" {
+		t.Errorf("BuildObject() AttributedTo = %q, want %q", obj.AttributedTo, "https://example.com/actors/jdoe")
+	}
+
+	if len(obj.Tag) != 1 || obj.Tag[0].Name != "#wordpress" {
+		t.Errorf("BuildObject() Tag = %+v, want a single #wordpress hashtag", obj.Tag)
+	}
+
+	if len(obj.Attachment) != 1 || obj.Attachment[0].URL != media.SourceURL {
+		t.Errorf("BuildObject() Attachment = %+v, want the featured media attached", obj.Attachment)
+	}
+}
+
+func TestBuildObjectWithoutFeaturedMedia(t *testing.T) {
+	post := models.WordPressPost{ID: 1}
+
+	obj := BuildObject(post, "Note", "https://example.com/actors/jdoe", "https://example.com", nil, nil)
+
+	if len(obj.Attachment) != 0 {
+		t.Errorf("BuildObject() Attachment = %+v, want none", obj.Attachment)
+	}
+}
+
+func TestBuildWebFinger(t *testing.T) {
+	wf := BuildWebFinger("jdoe", "example.com", "https://example.com/actors/jdoe")
+
+	if wf.Subject != "acct:jdoe@example.com" {
+		t.Errorf("BuildWebFinger() Subject = %q, want %q", wf.Subject, "acct:jdoe@example.com")
+	}
+
+	if len(wf.Links) != 1 || wf.Links[0].Href != "https://example.com/actors/jdoe" {
+		t.Errorf("BuildWebFinger() Links = %+v, want a single self link to the actor", wf.Links)
+	}
+}
+
+func TestGenerateKeyPair(t *testing.T) {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair() error = %v", err)
+	}
+
+	if keyPair.PrivateKeyPEM == "" || keyPair.PublicKeyPEM == "" {
+		t.Error("GenerateKeyPair() should populate both PEM blocks")
+	}
+}
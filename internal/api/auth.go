@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// Authenticator attaches credentials to an outgoing request so the API client can read
+// protected WordPress content (drafts, private posts, user email addresses, settings).
+type Authenticator interface {
+	// Apply decorates req with whatever headers/auth the scheme requires.
+	Apply(req *resty.Request)
+}
+
+// RequestDecorator is an Authenticator whose credential can expire mid-export and be
+// recovered from, rather than one that's fixed for the client's lifetime (see
+// AppPasswordAuth, OAuth2Auth). NonceAuth is the only implementation: a wp-admin nonce
+// expires with the PHP session that issued it, so a request coming back 401/403 needs a
+// refresh-and-retry rather than just surfacing the error.
+type RequestDecorator interface {
+	Authenticator
+	// Refresh re-fetches whatever credential expired, returning an error if
+	// reauthentication isn't possible (e.g. no refresh endpoint configured).
+	Refresh(ctx context.Context, client *resty.Client) error
+}
+
+// AppPasswordAuth authenticates using WordPress Application Passwords over HTTP Basic.
+type AppPasswordAuth struct {
+	Username string
+	Password string
+}
+
+// Apply sets HTTP Basic auth using the WordPress username and application password.
+func (a *AppPasswordAuth) Apply(req *resty.Request) {
+	req.SetBasicAuth(a.Username, a.Password)
+}
+
+// OAuth2Auth authenticates by attaching a bearer token, either supplied directly or
+// obtained via the OAuth2 client-credentials grant against TokenEndpoint.
+type OAuth2Auth struct {
+	Token string
+
+	// ClientID/ClientSecret drive the client-credentials grant when Token isn't
+	// supplied directly; see FetchToken.
+	ClientID     string
+	ClientSecret string
+	// TokenEndpoint is the OAuth2 token endpoint to exchange ClientID/ClientSecret
+	// at. Empty falls back to "<siteOrigin>/oauth/token", the path exposed by
+	// WP OAuth Server-style plugins.
+	TokenEndpoint string
+}
+
+// Apply sets the Authorization: Bearer header.
+func (a *OAuth2Auth) Apply(req *resty.Request) {
+	req.SetAuthToken(a.Token)
+}
+
+// clientCredentialsResponse is the payload returned by an OAuth2 token endpoint.
+type clientCredentialsResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+// FetchToken exchanges ClientID/ClientSecret for an access token via the OAuth2
+// client-credentials grant (RFC 6749 section 4.4), populating Token on success.
+func (a *OAuth2Auth) FetchToken(ctx context.Context, client *resty.Client, siteOrigin string) error {
+	endpoint := a.TokenEndpoint
+	if endpoint == "" {
+		endpoint = siteOrigin + "/oauth/token"
+	}
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetFormData(map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     a.ClientID,
+			"client_secret": a.ClientSecret,
+		}).
+		Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to obtain OAuth2 token: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("OAuth2 token endpoint returned status %d", resp.StatusCode())
+	}
+
+	var tokenResp clientCredentialsResponse
+	if err := json.Unmarshal(resp.Body(), &tokenResp); err != nil {
+		return fmt.Errorf("failed to parse OAuth2 token response: %w", err)
+	}
+
+	a.Token = tokenResp.AccessToken
+	return nil
+}
+
+// JWTAuth authenticates against the JWT Authentication for WP-API plugin, exchanging
+// a username/password for a bearer token via /wp-json/jwt-auth/v1/token on first use.
+type JWTAuth struct {
+	Username string
+	Password string
+
+	token string
+}
+
+// jwtTokenResponse is the payload returned by /wp-json/jwt-auth/v1/token.
+type jwtTokenResponse struct {
+	Token string `json:"token"`
+}
+
+// FetchToken exchanges the configured username/password for a JWT bearer token.
+func (a *JWTAuth) FetchToken(ctx context.Context, client *resty.Client, siteOrigin string) error {
+	resp, err := client.R().
+		SetContext(ctx).
+		SetBody(map[string]string{"username": a.Username, "password": a.Password}).
+		Post(siteOrigin + "/wp-json/jwt-auth/v1/token")
+	if err != nil {
+		return fmt.Errorf("failed to obtain JWT token: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("JWT token endpoint returned status %d", resp.StatusCode())
+	}
+
+	var tokenResp jwtTokenResponse
+	if err := json.Unmarshal(resp.Body(), &tokenResp); err != nil {
+		return fmt.Errorf("failed to parse JWT token response: %w", err)
+	}
+
+	a.token = tokenResp.Token
+	return nil
+}
+
+// Apply sets the Authorization: Bearer header using the previously fetched token.
+func (a *JWTAuth) Apply(req *resty.Request) {
+	if a.token != "" {
+		req.SetAuthToken(a.token)
+	}
+}
+
+// newAuthenticator builds an Authenticator from the resolved configuration, or nil when
+// AuthType is unset (anonymous access).
+func newAuthenticator(cfg *config.Config) (Authenticator, error) {
+	switch cfg.AuthType {
+	case "":
+		return nil, nil
+	case "app_password":
+		if cfg.Username == "" || cfg.AppPassword == "" {
+			return nil, fmt.Errorf("auth_type=app_password requires username and app_password")
+		}
+		return &AppPasswordAuth{Username: cfg.Username, Password: cfg.AppPassword}, nil
+	case "oauth2":
+		if cfg.Token != "" {
+			return &OAuth2Auth{Token: cfg.Token}, nil
+		}
+		if cfg.OAuthClientID == "" || cfg.OAuthClientSecret == "" {
+			return nil, fmt.Errorf("auth_type=oauth2 requires a token, or oauth_client_id and oauth_client_secret for the client-credentials grant")
+		}
+		return &OAuth2Auth{ClientID: cfg.OAuthClientID, ClientSecret: cfg.OAuthClientSecret}, nil
+	case "jwt":
+		if cfg.JWTToken != "" {
+			return &JWTAuth{Username: cfg.Username, Password: cfg.AppPassword, token: cfg.JWTToken}, nil
+		}
+		if cfg.Username == "" || cfg.AppPassword == "" {
+			return nil, fmt.Errorf("auth_type=jwt requires username and app_password (used as the account password), or a pre-fetched jwt_token")
+		}
+		return &JWTAuth{Username: cfg.Username, Password: cfg.AppPassword}, nil
+	case "oauth1":
+		if cfg.OAuth1ConsumerKey == "" || cfg.OAuth1ConsumerSecret == "" {
+			return nil, fmt.Errorf("auth_type=oauth1 requires oauth1_consumer_key and oauth1_consumer_secret")
+		}
+		if cfg.OAuth1Token == "" || cfg.OAuth1TokenSecret == "" {
+			return nil, fmt.Errorf("auth_type=oauth1 requires oauth1_token and oauth1_token_secret from a completed authorization flow")
+		}
+		return &OAuth1Auth{
+			ConsumerKey:    cfg.OAuth1ConsumerKey,
+			ConsumerSecret: cfg.OAuth1ConsumerSecret,
+			Token:          cfg.OAuth1Token,
+			TokenSecret:    cfg.OAuth1TokenSecret,
+		}, nil
+	case "nonce":
+		if cfg.Nonce == "" && len(cfg.NonceCookies) == 0 {
+			return nil, fmt.Errorf("auth_type=nonce requires nonce and/or nonce_cookies")
+		}
+		return &NonceAuth{AuthConfig: AuthConfig{
+			Username:        cfg.Username,
+			AppPassword:     cfg.AppPassword,
+			Cookies:         cookiesFromMap(cfg.NonceCookies),
+			Nonce:           cfg.Nonce,
+			NonceRefreshURL: cfg.NonceRefreshURL,
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported auth_type: %s", cfg.AuthType)
+	}
+}
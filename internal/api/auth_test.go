@@ -0,0 +1,164 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestNewClientAppPasswordAuth(t *testing.T) {
+	var gotAuthHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:         server.URL,
+		Timeout:     5,
+		Retries:     1,
+		UserAgent:   "test-agent",
+		AuthType:    "app_password",
+		Username:    "admin",
+		AppPassword: "xxxx yyyy zzzz",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetPostByID(1); err != nil {
+		t.Fatalf("GetPostByID() error = %v", err)
+	}
+
+	if gotAuthHeader == "" || gotAuthHeader[:6] != "Basic " {
+		t.Errorf("expected a Basic Authorization header, got %q", gotAuthHeader)
+	}
+}
+
+func TestNewClientInvalidAuthType(t *testing.T) {
+	cfg := &config.Config{
+		URL:       "https://example.com",
+		Timeout:   5,
+		Retries:   1,
+		UserAgent: "test-agent",
+		AuthType:  "bogus",
+	}
+
+	if _, err := NewClient(cfg); err == nil {
+		t.Error("NewClient() with an unsupported auth_type should return an error")
+	}
+}
+
+func TestNewClientAppPasswordRequiresCredentials(t *testing.T) {
+	cfg := &config.Config{
+		URL:       "https://example.com",
+		Timeout:   5,
+		Retries:   1,
+		UserAgent: "test-agent",
+		AuthType:  "app_password",
+	}
+
+	if _, err := NewClient(cfg); err == nil {
+		t.Error("NewClient() with auth_type=app_password and no credentials should return an error")
+	}
+}
+
+func TestNewClientOAuth2ClientCredentialsFetchesToken(t *testing.T) {
+	var gotAuthHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			_ = r.ParseForm()
+			if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "my-client" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"access_token":"issued-token"}`))
+			return
+		}
+
+		gotAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:               server.URL,
+		Timeout:           5,
+		Retries:           1,
+		UserAgent:         "test-agent",
+		AuthType:          "oauth2",
+		OAuthClientID:     "my-client",
+		OAuthClientSecret: "my-secret",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetPostByID(1); err != nil {
+		t.Fatalf("GetPostByID() error = %v", err)
+	}
+
+	if gotAuthHeader != "Bearer issued-token" {
+		t.Errorf("Authorization header = %q, want %q", gotAuthHeader, "Bearer issued-token")
+	}
+}
+
+func TestNewClientOAuth2RequiresTokenOrClientCredentials(t *testing.T) {
+	cfg := &config.Config{
+		URL:       "https://example.com",
+		Timeout:   5,
+		Retries:   1,
+		UserAgent: "test-agent",
+		AuthType:  "oauth2",
+	}
+
+	if _, err := NewClient(cfg); err == nil {
+		t.Error("NewClient() with auth_type=oauth2 and no token or client credentials should return an error")
+	}
+}
+
+func TestGetPostByIDReturnsErrorOn401(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":"rest_forbidden"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:         server.URL,
+		Timeout:     5,
+		Retries:     1,
+		UserAgent:   "test-agent",
+		AuthType:    "app_password",
+		Username:    "admin",
+		AppPassword: "wrong-password",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.GetPostByID(1)
+	if err == nil {
+		t.Fatal("GetPostByID() error = nil, want an error for a 401 response")
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GetPostByID() error = %v, want a *StatusError with StatusCode 401", err)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestGetPostsByIDsContextRequestsInclude(t *testing.T) {
+	var gotInclude, gotPerPage string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotInclude = r.URL.Query().Get("include")
+		gotPerPage = r.URL.Query().Get("per_page")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"id":1},{"id":3}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	posts, err := client.GetPostsByIDsContext(context.Background(), []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("GetPostsByIDsContext() error = %v", err)
+	}
+
+	if gotInclude != "1,2,3" {
+		t.Errorf("include query = %q, want %q", gotInclude, "1,2,3")
+	}
+	if gotPerPage != "3" {
+		t.Errorf("per_page query = %q, want %q", gotPerPage, "3")
+	}
+	if len(posts) != 2 || posts[0].ID != 1 || posts[1].ID != 3 {
+		t.Errorf("GetPostsByIDsContext() = %+v, want two posts with ids 1,3 (2 is a gap)", posts)
+	}
+}
+
+func TestGetPostsByIDsContextEmptyIDsMakesNoRequest(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	posts, err := client.GetPostsByIDsContext(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetPostsByIDsContext() error = %v", err)
+	}
+	if posts != nil {
+		t.Errorf("GetPostsByIDsContext(nil) = %+v, want nil", posts)
+	}
+	if called {
+		t.Error("GetPostsByIDsContext(nil) made an HTTP request, want none")
+	}
+}
+
+func TestGetMediaByIDsContextNonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetMediaByIDsContext(context.Background(), []int{1, 2}); err == nil {
+		t.Fatal("GetMediaByIDsContext() error = nil, want a StatusError for the 403 response")
+	}
+}
@@ -0,0 +1,386 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// isNilContent reports whether content - the interface{} a getter or opts.Fetch returned -
+// is untyped nil or a typed nil pointer (e.g. the (*models.WordPressPost)(nil) a 404 getter
+// returns, which a plain `content != nil` misses once it's boxed into an interface{}).
+func isNilContent(content interface{}) bool {
+	if content == nil {
+		return true
+	}
+	v := reflect.ValueOf(content)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// BruteForceOptions configures BruteForceContentWithOptions's worker pool, rate limiting,
+// and early-stop heuristic. A zero value falls back to the equivalent Client.config
+// fields (Concurrency/Concurrent, StopAfterConsecutiveMisses, RateLimit/MaxQPS) and
+// sensible defaults for RPS/Burst.
+type BruteForceOptions struct {
+	Workers int
+	// MinID starts enumeration here instead of 1, for scanning a specific sub-range of IDs.
+	MinID int
+	RPS   float64
+	// Ceiling caps how far Limiter's AIMD recovery may raise RPS back up to after
+	// throttling. Defaults to RPS (no recovery past the starting rate) when <= 0.
+	Ceiling                    float64
+	Burst                      int
+	StopAfterConsecutiveMisses int
+	// Limiter, when set, is shared across this call's worker pool instead of building a
+	// new one from RPS/Ceiling/Burst. Callers that run several BruteForceContentWithOptions
+	// scans concurrently (e.g. posts, pages, and media at once) pass the same Limiter to
+	// all of them so the combined request rate against the site stays bounded.
+	Limiter *RateLimiter
+	// SkipIDs, when set, marks IDs already known to exist (e.g. from a prior export) so the
+	// scan reports them on progress without spending a rate-limited request confirming them.
+	SkipIDs map[int]bool
+	// Fetch, when set, overrides the built-in posts/pages/media contentType switch below,
+	// so callers can brute force scan any other endpoint (custom post types, users,
+	// comments, ...) through the same worker pool, rate limiter, and stats reporting.
+	Fetch func(ctx context.Context, id int) (interface{}, error)
+	// IDs, when set, overrides the built-in MinID..maxID linear producer with a
+	// caller-supplied sequence - e.g. internal/bruteforce's adaptive Strategy
+	// implementations, which probe a narrowed or sparse subset of the ID space instead of
+	// every ID from MinID to maxID in order. MinID is ignored when IDs is set.
+	IDs <-chan int
+}
+
+// BruteForceStats is a point-in-time snapshot of a brute force scan's progress, sent
+// incrementally on the stats channel so callers can render a live progress bar.
+type BruteForceStats struct {
+	Attempted int
+	Found     int
+	Throttled int
+	QPS       float64
+	Elapsed   time.Duration
+}
+
+const (
+	defaultBruteForceRPS        = 50.0
+	minThrottledRPS             = 0.5
+	recoverAfterConsecutiveHits = 20
+	recoverFactor               = 1.5
+)
+
+// BruteForceContent attempts to discover content by ID enumeration
+func (c *Client) BruteForceContent(contentType string, maxID int, found chan<- interface{}, progress chan<- int) {
+	c.BruteForceContentContext(context.Background(), contentType, maxID, found, progress)
+}
+
+// BruteForceContentContext attempts to discover content by ID enumeration, fanning the
+// probes out across a bounded, rate-limited worker pool. The scan stops early once ctx is
+// cancelled/expires, or once cfg.StopAfterConsecutiveMisses consecutive probes come back
+// empty (when configured).
+func (c *Client) BruteForceContentContext(ctx context.Context, contentType string, maxID int, found chan<- interface{}, progress chan<- int) {
+	c.BruteForceContentWithOptions(ctx, contentType, maxID, BruteForceOptions{}, found, progress, nil, nil)
+}
+
+// BruteForceContentWithOptions is the full-featured brute force scan: a bounded worker
+// pool shares a rate.Limiter, adaptively halving its RPS (with jittered exponential
+// backoff) on HTTP 429/5xx and gradually restoring it after a run of healthy responses.
+// errs and stats are optional (nil is accepted) non-blocking channels for live callers;
+// found and progress are closed when the scan finishes, matching the legacy API.
+func (c *Client) BruteForceContentWithOptions(ctx context.Context, contentType string, maxID int, opts BruteForceOptions, found chan<- interface{}, progress chan<- int, errs chan<- error, stats chan<- BruteForceStats) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = c.config.Concurrency
+	}
+	if workers <= 0 {
+		workers = c.config.Concurrent
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	stopAfterMisses := opts.StopAfterConsecutiveMisses
+	if stopAfterMisses <= 0 {
+		stopAfterMisses = c.config.StopAfterConsecutiveMisses
+	}
+
+	limiter := opts.Limiter
+	if limiter == nil {
+		rps := opts.RPS
+		if rps <= 0 {
+			rps = c.config.RateLimit
+		}
+		ceiling := opts.Ceiling
+		if ceiling <= 0 {
+			ceiling = c.config.MaxQPS
+		}
+		burst := opts.Burst
+		if burst <= 0 {
+			burst = workers
+		}
+		limiter = NewRateLimiter(rps, ceiling, burst)
+	}
+
+	scanCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var consecutiveMisses int32
+	var attempted, foundCount, throttledCount int64
+	startTime := time.Now()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	statsDone := make(chan struct{})
+	if stats != nil {
+		go func() {
+			defer close(statsDone)
+			ticker := time.NewTicker(250 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-scanCtx.Done():
+					return
+				case <-ticker.C:
+					sendStats(stats, &attempted, &foundCount, &throttledCount, startTime, limiter)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				if scanCtx.Err() != nil {
+					return
+				}
+
+				if opts.SkipIDs != nil && opts.SkipIDs[id] {
+					select {
+					case progress <- id:
+					default:
+					}
+					continue
+				}
+
+				if err := limiter.Wait(scanCtx); err != nil {
+					return
+				}
+
+				atomic.AddInt64(&attempted, 1)
+
+				var content interface{}
+				var err error
+
+				switch {
+				case opts.Fetch != nil:
+					content, err = opts.Fetch(scanCtx, id)
+				case contentType == "posts":
+					content, err = c.GetPostByIDContext(scanCtx, id)
+				case contentType == "pages":
+					content, err = c.GetPageByIDContext(scanCtx, id)
+				case contentType == "media":
+					content, err = c.GetMediaByIDContext(scanCtx, id)
+				default:
+					content = nil
+				}
+
+				var statusErr *StatusError
+				switch {
+				case err == nil && !isNilContent(content):
+					found <- content
+					atomic.AddInt64(&foundCount, 1)
+					atomic.StoreInt32(&consecutiveMisses, 0)
+					limiter.Recover()
+				case errors.As(err, &statusErr) && statusErr.Throttled():
+					atomic.AddInt64(&throttledCount, 1)
+					sendErr(errs, err)
+					var retryAfter time.Duration
+					if c.config.RespectRetryAfter {
+						retryAfter = statusErr.RetryAfter
+					}
+					backoff := limiter.Throttle(retryAfter)
+					select {
+					case <-scanCtx.Done():
+					case <-time.After(backoff):
+					}
+				default:
+					if err != nil {
+						sendErr(errs, err)
+					} else {
+						limiter.Recover()
+					}
+					if stopAfterMisses > 0 {
+						if atomic.AddInt32(&consecutiveMisses, 1) >= int32(stopAfterMisses) {
+							cancel()
+						}
+					}
+				}
+
+				select {
+				case progress <- id:
+				default:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+
+		if opts.IDs != nil {
+			for id := range opts.IDs {
+				select {
+				case <-scanCtx.Done():
+					return
+				case jobs <- id:
+				}
+			}
+			return
+		}
+
+		minID := opts.MinID
+		if minID <= 0 {
+			minID = 1
+		}
+		for id := minID; id <= maxID; id++ {
+			select {
+			case <-scanCtx.Done():
+				return
+			case jobs <- id:
+			}
+		}
+	}()
+
+	wg.Wait()
+	cancel()
+	if stats != nil {
+		<-statsDone
+		sendStats(stats, &attempted, &foundCount, &throttledCount, startTime, limiter)
+	}
+	close(found)
+	close(progress)
+}
+
+func sendErr(errs chan<- error, err error) {
+	if errs == nil {
+		return
+	}
+	select {
+	case errs <- err:
+	default:
+	}
+}
+
+func sendStats(stats chan<- BruteForceStats, attempted, found, throttled *int64, start time.Time, limiter *RateLimiter) {
+	select {
+	case stats <- BruteForceStats{
+		Attempted: int(atomic.LoadInt64(attempted)),
+		Found:     int(atomic.LoadInt64(found)),
+		Throttled: int(atomic.LoadInt64(throttled)),
+		QPS:       limiter.QPS(),
+		Elapsed:   time.Since(start),
+	}:
+	default:
+	}
+}
+
+// RateLimiter is an adaptive, AIMD-style rate limiter shared by a brute force worker
+// pool: Throttle halves its effective RPS (with a jittered exponential, or
+// server-dictated, backoff) on a 429/5xx response, and Recover gradually restores it,
+// up to ceiling, after a run of healthy ones.
+type RateLimiter struct {
+	limiter *rate.Limiter
+
+	mu            sync.Mutex
+	ceiling       float64
+	current       float64
+	successStreak int
+	throttleCount int
+}
+
+// NewRateLimiter returns a RateLimiter starting at rps (defaulting to
+// defaultBruteForceRPS when <= 0), recovering no higher than ceiling (defaulting to rps
+// itself, i.e. no recovery past the starting rate, when <= 0).
+func NewRateLimiter(rps, ceiling float64, burst int) *RateLimiter {
+	if rps <= 0 {
+		rps = defaultBruteForceRPS
+	}
+	if ceiling <= 0 {
+		ceiling = rps
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(rps), burst),
+		ceiling: ceiling,
+		current: rps,
+	}
+}
+
+// Wait blocks until the limiter admits one more request, or ctx is done.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	return r.limiter.Wait(ctx)
+}
+
+// QPS returns the limiter's current effective rate, for callers (e.g. a progress bar)
+// that want to surface it live.
+func (r *RateLimiter) QPS() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// Throttle halves the effective RPS (down to minThrottledRPS) and returns how long the
+// caller should wait before its next attempt: retryAfter verbatim when positive, or a
+// jittered, exponentially increasing backoff otherwise.
+func (r *RateLimiter) Throttle(retryAfter time.Duration) time.Duration {
+	r.mu.Lock()
+	r.successStreak = 0
+	r.current = math.Max(r.current/2, minThrottledRPS)
+	r.limiter.SetLimit(rate.Limit(r.current))
+	r.throttleCount++
+	attempt := r.throttleCount
+	r.mu.Unlock()
+
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	capped := math.Min(float64(time.Second)*math.Pow(2, float64(attempt-1)), float64(30*time.Second))
+	base := time.Duration(capped)
+	jitter := time.Duration(rand.Int63n(int64(base/2) + 1))
+	return base/2 + jitter
+}
+
+// Recover nudges the effective RPS back toward ceiling after a run of healthy responses.
+func (r *RateLimiter) Recover() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current >= r.ceiling {
+		return
+	}
+
+	r.successStreak++
+	if r.successStreak >= recoverAfterConsecutiveHits {
+		r.current = math.Min(r.current*recoverFactor, r.ceiling)
+		r.limiter.SetLimit(rate.Limit(r.current))
+		r.successStreak = 0
+		r.throttleCount = 0
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestBruteForceContentWithOptionsThrottlesOn429(t *testing.T) {
+	var calls int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&calls, 1)
+		// The first two probes are throttled; everything after comes back a plain miss.
+		if n <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{URL: server.URL, Timeout: 5, Retries: 0, UserAgent: "test-agent"}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	found := make(chan interface{}, 10)
+	progress := make(chan int, 10)
+	errs := make(chan error, 10)
+	stats := make(chan BruteForceStats, 10)
+
+	opts := BruteForceOptions{Workers: 1, RPS: 1000, Burst: 1, StopAfterConsecutiveMisses: 5}
+
+	done := make(chan struct{})
+	go func() {
+		client.BruteForceContentWithOptions(context.Background(), "posts", 10, opts, found, progress, errs, stats)
+		close(done)
+	}()
+
+	var sawThrottleErr bool
+	timeout := time.After(5 * time.Second)
+	for finished := false; !finished; {
+		select {
+		case _, ok := <-found:
+			if !ok {
+				found = nil
+			}
+		case _, ok := <-progress:
+			if !ok {
+				progress = nil
+			}
+		case err, ok := <-errs:
+			if ok && err != nil {
+				sawThrottleErr = true
+			}
+		case <-stats:
+		case <-done:
+			finished = true
+		case <-timeout:
+			t.Fatal("BruteForceContentWithOptions() timed out")
+		}
+	}
+
+	if !sawThrottleErr {
+		t.Error("expected at least one throttling error on the errs channel")
+	}
+}
+
+func TestBruteForceContentWithOptionsStopsAfterConsecutiveMisses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{URL: server.URL, Timeout: 5, Retries: 0, UserAgent: "test-agent"}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	found := make(chan interface{}, 10)
+	progress := make(chan int, 1000)
+
+	opts := BruteForceOptions{Workers: 1, RPS: 1000, Burst: 5, StopAfterConsecutiveMisses: 3}
+	client.BruteForceContentWithOptions(context.Background(), "posts", 1000, opts, found, progress, nil, nil)
+
+	if len(progress) >= 1000 {
+		t.Errorf("expected the scan to stop well before scanning all 1000 ids, scanned %d", len(progress))
+	}
+}
+
+func TestBruteForceContentWithOptionsFindsContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/wp/v2/posts/5" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":5}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{URL: server.URL, Timeout: 5, Retries: 0, UserAgent: "test-agent"}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	found := make(chan interface{}, 10)
+	progress := make(chan int, 10)
+
+	opts := BruteForceOptions{Workers: 2, RPS: 1000, Burst: 2}
+	client.BruteForceContentWithOptions(context.Background(), "posts", 10, opts, found, progress, nil, nil)
+
+	var count int
+	for range found {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("found %d items, want 1", count)
+	}
+}
+
+func TestBruteForceContentWithOptionsSkipsAndRespectsMinID(t *testing.T) {
+	var calls int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{URL: server.URL, Timeout: 5, Retries: 0, UserAgent: "test-agent"}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	found := make(chan interface{}, 10)
+	progress := make(chan int, 10)
+
+	opts := BruteForceOptions{
+		Workers: 1,
+		RPS:     1000,
+		Burst:   1,
+		MinID:   5,
+		SkipIDs: map[int]bool{6: true, 7: true},
+	}
+	client.BruteForceContentWithOptions(context.Background(), "posts", 10, opts, found, progress, nil, nil)
+
+	var scanned []int
+	for id := range progress {
+		scanned = append(scanned, id)
+	}
+	if len(scanned) != 6 {
+		t.Errorf("scanned %d ids, want 6 (5 through 10)", len(scanned))
+	}
+
+	// Only ids 5, 8, 9, 10 should have actually hit the server; 6 and 7 were skipped.
+	if got := atomic.LoadInt64(&calls); got != 4 {
+		t.Errorf("made %d requests, want 4 (ids skipped via SkipIDs shouldn't be fetched)", got)
+	}
+}
+
+func TestRateLimiterThrottleHonorsRetryAfter(t *testing.T) {
+	r := NewRateLimiter(10, 10, 1)
+
+	if got := r.Throttle(3 * time.Second); got != 3*time.Second {
+		t.Errorf("Throttle(3s) = %v, want the retryAfter value returned verbatim", got)
+	}
+	if got := r.QPS(); got != 5 {
+		t.Errorf("QPS() after one Throttle = %v, want 5 (halved from 10)", got)
+	}
+}
+
+func TestRateLimiterThrottleFallsBackToExponentialBackoffWithoutRetryAfter(t *testing.T) {
+	r := NewRateLimiter(10, 10, 1)
+
+	if got := r.Throttle(0); got <= 0 || got > 30*time.Second {
+		t.Errorf("Throttle(0) = %v, want a positive, capped jittered backoff", got)
+	}
+}
+
+func TestRateLimiterRecoverClimbsBackTowardCeiling(t *testing.T) {
+	r := NewRateLimiter(10, 40, 1)
+	r.Throttle(0)
+	if got := r.QPS(); got != 5 {
+		t.Fatalf("QPS() after Throttle = %v, want 5", got)
+	}
+
+	for i := 0; i < recoverAfterConsecutiveHits; i++ {
+		r.Recover()
+	}
+	if got := r.QPS(); got != 7.5 {
+		t.Errorf("QPS() after recovering = %v, want 7.5 (5 * recoverFactor)", got)
+	}
+}
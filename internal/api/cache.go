@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// HTTPCache is a pluggable store for per-endpoint conditional-GET metadata. Client uses
+// it to send If-None-Match / If-Modified-Since on subsequent requests and to replay the
+// cached body when the server answers 304 Not Modified.
+type HTTPCache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is everything the client needs to issue a conditional GET for a given key
+// and to replay the cached response if the server says nothing changed.
+type CacheEntry struct {
+	ETag          string `json:"etag,omitempty"`
+	LastModified  string `json:"last_modified,omitempty"`
+	ModifiedAfter string `json:"modified_after,omitempty"`
+	Body          []byte `json:"body,omitempty"`
+}
+
+// JSONFileCache is an HTTPCache backed by a single JSON file on disk, keyed by request
+// URL. It's the default store used when a cache path is configured but no other
+// HTTPCache implementation is supplied via Client.SetCache.
+type JSONFileCache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+// NewJSONFileCache loads an existing cache file at path, or starts with an empty cache
+// if none exists yet.
+func NewJSONFileCache(path string) (*JSONFileCache, error) {
+	cache := &JSONFileCache{path: path, entries: make(map[string]CacheEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &cache.entries); err != nil {
+			return nil, fmt.Errorf("failed to parse cache file: %w", err)
+		}
+	}
+
+	return cache, nil
+}
+
+// Get returns the cached entry for key, if any.
+func (c *JSONFileCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+// Set stores entry under key and persists the cache to disk.
+func (c *JSONFileCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+	c.save()
+}
+
+// save persists the current entries to disk. A failure here isn't fatal to the caller:
+// the in-memory cache remains usable for the rest of the run, just not across runs.
+func (c *JSONFileCache) save() {
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path, data, 0600)
+}
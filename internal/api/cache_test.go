@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// memoryCache is a minimal in-process HTTPCache used to test Client's conditional-GET
+// wiring without touching disk.
+type memoryCache struct {
+	entries map[string]CacheEntry
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: make(map[string]CacheEntry)}
+}
+
+func (m *memoryCache) Get(key string) (CacheEntry, bool) {
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+func (m *memoryCache) Set(key string, entry CacheEntry) {
+	m.entries[key] = entry
+}
+
+func TestGetPostsSecondRequestReplaysCacheOn304(t *testing.T) {
+	posts := []models.WordPressPost{{ID: 1, Slug: "hello-world"}}
+	postsJSON, _ := json.Marshal(posts)
+
+	var requestCount int
+	var gotIfNoneMatch string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+
+		if inm := r.Header.Get("If-None-Match"); inm != "" {
+			gotIfNoneMatch = inm
+			if inm == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(postsJSON)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{URL: server.URL, Timeout: 10, Retries: 1, UserAgent: "test-agent"}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client.SetCache(newMemoryCache())
+
+	first, err := client.GetPosts()
+	if err != nil {
+		t.Fatalf("first GetPosts() error = %v", err)
+	}
+	if len(first) != 1 || first[0].ID != 1 {
+		t.Fatalf("first GetPosts() = %+v, want a single post with ID 1", first)
+	}
+
+	requestsBeforeSecondCall := requestCount
+
+	second, err := client.GetPosts()
+	if err != nil {
+		t.Fatalf("second GetPosts() error = %v", err)
+	}
+
+	if requestCount != requestsBeforeSecondCall+1 {
+		t.Errorf("second GetPosts() made %d requests, want exactly 1 (the conditional page-1 GET, no further pages)", requestCount-requestsBeforeSecondCall)
+	}
+
+	if gotIfNoneMatch != `"v1"` {
+		t.Errorf("second GetPosts() sent If-None-Match = %q, want %q", gotIfNoneMatch, `"v1"`)
+	}
+
+	if len(second) != 1 || second[0].ID != 1 || second[0].Slug != "hello-world" {
+		t.Errorf("second GetPosts() = %+v, want the cached slice from the first call", second)
+	}
+}
+
+func TestJSONFileCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	cache, err := NewJSONFileCache(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileCache() error = %v", err)
+	}
+
+	cache.Set("https://example.com/posts", CacheEntry{ETag: `"abc"`, Body: []byte(`[]`)})
+
+	reloaded, err := NewJSONFileCache(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileCache() reload error = %v", err)
+	}
+
+	entry, ok := reloaded.Get("https://example.com/posts")
+	if !ok {
+		t.Fatal("expected reloaded cache to contain the persisted entry")
+	}
+
+	if entry.ETag != `"abc"` {
+		t.Errorf("reloaded entry ETag = %q, want %q", entry.ETag, `"abc"`)
+	}
+}
+
+func TestJSONFileCacheMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	cache, err := NewJSONFileCache(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileCache() error = %v", err)
+	}
+
+	if _, ok := cache.Get("anything"); ok {
+		t.Error("expected a fresh cache to have no entries")
+	}
+}
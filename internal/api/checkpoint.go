@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// EndpointCheckpoint records how far a prior run got through one paginated endpoint, so a
+// re-run can skip pages it already fetched and use If-None-Match for the page it left off on.
+type EndpointCheckpoint struct {
+	LastPage int    `json:"last_page"`
+	LastID   int    `json:"last_id"`
+	ETag     string `json:"etag"`
+}
+
+// Checkpoint is a small sidecar JSON file tracking per-endpoint export progress, letting
+// Iterate* resume after a network failure instead of re-fetching completed pages.
+type Checkpoint struct {
+	Endpoints map[string]EndpointCheckpoint `json:"endpoints"`
+
+	path string
+}
+
+// LoadCheckpoint reads a Checkpoint from path, returning an empty, unsaved Checkpoint if
+// the file doesn't exist yet.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	cp := &Checkpoint{Endpoints: map[string]EndpointCheckpoint{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cp, nil
+		}
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	cp.path = path
+
+	return cp, nil
+}
+
+// Get returns the checkpoint recorded for endpoint, and whether one exists.
+func (cp *Checkpoint) Get(endpoint string) (EndpointCheckpoint, bool) {
+	ec, ok := cp.Endpoints[endpoint]
+	return ec, ok
+}
+
+// Set records progress for endpoint and persists the checkpoint to its backing file.
+func (cp *Checkpoint) Set(endpoint string, ec EndpointCheckpoint) error {
+	cp.Endpoints[endpoint] = ec
+	return cp.save()
+}
+
+// save writes the checkpoint to its backing path.
+func (cp *Checkpoint) save() error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	if err := os.WriteFile(cp.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", cp.path, err)
+	}
+	return nil
+}
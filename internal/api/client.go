@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -8,8 +9,8 @@ import (
 	"time"
 
 	"github.com/go-resty/resty/v2"
-	"github.com/tradik/wpexportjson/internal/config"
-	"github.com/tradik/wpexportjson/pkg/models"
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
 )
 
 // Client represents a WordPress REST API client
@@ -17,6 +18,15 @@ type Client struct {
 	config     *config.Config
 	httpClient *resty.Client
 	baseURL    string
+	auth       Authenticator
+	cache      HTTPCache
+}
+
+// SetCache installs an HTTPCache used to issue conditional GETs (If-None-Match /
+// If-Modified-Since) on list endpoints and replay their cached body on a 304 Not
+// Modified response. Passing nil disables caching.
+func (c *Client) SetCache(cache HTTPCache) {
+	c.cache = cache
 }
 
 // NewClient creates a new WordPress API client
@@ -27,35 +37,84 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		return nil, fmt.Errorf("invalid URL: %w", err)
 	}
 
+	origin := strings.TrimSuffix(parsedURL.String(), "/")
+	if cfg.ResolveRedirects {
+		canonical, err := config.ResolveBaseURL(cfg, cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve canonical URL: %w", err)
+		}
+		origin = canonical
+	}
+
 	// Construct base API URL
-	baseURL := strings.TrimSuffix(parsedURL.String(), "/") + "/wp-json/wp/v2"
+	baseURL := origin + "/wp-json/wp/v2"
 
 	// Create HTTP client
 	httpClient := resty.New()
 	httpClient.SetTimeout(time.Duration(cfg.Timeout) * time.Second)
-	httpClient.SetRetryCount(cfg.Retries)
 	httpClient.SetHeader("User-Agent", cfg.UserAgent)
 	httpClient.SetHeader("Accept", "application/json")
+	configureRetry(httpClient, cfg.Retries)
+
+	auth, err := newAuthenticator(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication: %w", err)
+	}
+
+	if jwtAuth, ok := auth.(*JWTAuth); ok && jwtAuth.token == "" {
+		if err := jwtAuth.FetchToken(context.Background(), httpClient, origin); err != nil {
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if oauth2Auth, ok := auth.(*OAuth2Auth); ok && oauth2Auth.Token == "" {
+		if err := oauth2Auth.FetchToken(context.Background(), httpClient, origin); err != nil {
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if oauth1Auth, ok := auth.(*OAuth1Auth); ok {
+		httpClient.OnBeforeRequest(oauth1Auth.sign)
+	}
+
+	if decorator, ok := auth.(RequestDecorator); ok {
+		wireNonceRetry(httpClient, decorator)
+	}
 
 	return &Client{
 		config:     cfg,
 		httpClient: httpClient,
 		baseURL:    baseURL,
+		auth:       auth,
 	}, nil
 }
 
+// newRequest builds a resty request bound to ctx with the configured authenticator applied.
+func (c *Client) newRequest(ctx context.Context) *resty.Request {
+	req := c.httpClient.R().SetContext(ctx)
+	if c.auth != nil {
+		c.auth.Apply(req)
+	}
+	return req
+}
+
 // GetSiteInfo retrieves WordPress site information
 func (c *Client) GetSiteInfo() (*models.SiteInfo, error) {
+	return c.GetSiteInfoContext(context.Background())
+}
+
+// GetSiteInfoContext retrieves WordPress site information, honoring ctx cancellation/deadlines
+func (c *Client) GetSiteInfoContext(ctx context.Context) (*models.SiteInfo, error) {
 	settingsURL := strings.Replace(c.baseURL, "/wp/v2", "", 1) + "/wp/v2/settings"
-	
-	resp, err := c.httpClient.R().Get(settingsURL)
+
+	resp, err := c.newRequest(ctx).Get(settingsURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get site info: %w", err)
 	}
 
 	if resp.StatusCode() != 200 {
 		// Try alternative endpoint
-		resp, err = c.httpClient.R().Get(c.baseURL)
+		resp, err = c.newRequest(ctx).Get(c.baseURL)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get site info: %w", err)
 		}
@@ -75,107 +134,248 @@ func (c *Client) GetSiteInfo() (*models.SiteInfo, error) {
 
 // GetPosts retrieves all posts with pagination
 func (c *Client) GetPosts() ([]models.WordPressPost, error) {
-	return c.getAllContent("posts", func() interface{} {
-		return &[]models.WordPressPost{}
-	})
+	return c.GetPostsContext(context.Background())
+}
+
+// GetPostsContext retrieves all posts with pagination, honoring ctx cancellation/deadlines
+func (c *Client) GetPostsContext(ctx context.Context) ([]models.WordPressPost, error) {
+	return c.getAllContent(ctx, "posts", nil)
+}
+
+// GetPostsWithProgress retrieves all posts with pagination, invoking onItem with the
+// cumulative count every few items so a caller can stream progress.
+func (c *Client) GetPostsWithProgress(onItem func(int)) ([]models.WordPressPost, error) {
+	return c.getAllContent(context.Background(), "posts", onItem)
 }
 
 // GetPages retrieves all pages with pagination
 func (c *Client) GetPages() ([]models.WordPressPost, error) {
-	return c.getAllContent("pages", func() interface{} {
-		return &[]models.WordPressPost{}
-	})
+	return c.GetPagesContext(context.Background())
+}
+
+// GetPagesContext retrieves all pages with pagination, honoring ctx cancellation/deadlines
+func (c *Client) GetPagesContext(ctx context.Context) ([]models.WordPressPost, error) {
+	return c.getAllContent(ctx, "pages", nil)
+}
+
+// GetPagesWithProgress retrieves all pages with pagination, invoking onItem with the
+// cumulative count every few items so a caller can stream progress.
+func (c *Client) GetPagesWithProgress(onItem func(int)) ([]models.WordPressPost, error) {
+	return c.getAllContent(context.Background(), "pages", onItem)
 }
 
 // GetMedia retrieves all media items with pagination
 func (c *Client) GetMedia() ([]models.WordPressMedia, error) {
-	var allMedia []models.WordPressMedia
-	page := 1
-	perPage := 100
+	return c.GetMediaContext(context.Background())
+}
 
-	for {
-		url := fmt.Sprintf("%s/media?page=%d&per_page=%d", c.baseURL, page, perPage)
-		
-		resp, err := c.httpClient.R().Get(url)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get media page %d: %w", page, err)
-		}
+// GetMediaContext retrieves all media items with pagination, honoring ctx cancellation/deadlines
+func (c *Client) GetMediaContext(ctx context.Context) ([]models.WordPressMedia, error) {
+	return collect(c.IterateMedia(ctx, IterateOptions{}))
+}
 
-		if resp.StatusCode() == 400 {
-			// No more pages
-			break
-		}
+// GetMediaWithProgress retrieves all media items with pagination, invoking onItem with the
+// cumulative count every few items so a caller can stream progress.
+func (c *Client) GetMediaWithProgress(onItem func(int)) ([]models.WordPressMedia, error) {
+	return collect(c.IterateMedia(context.Background(), IterateOptions{OnItem: onItem}))
+}
 
-		if resp.StatusCode() != 200 {
-			return nil, fmt.Errorf("API returned status %d for media page %d", resp.StatusCode(), page)
-		}
+// GetPostsModifiedAfterContext retrieves posts modified after the given RFC3339 timestamp,
+// for incremental exports driven by internal/state.State.
+func (c *Client) GetPostsModifiedAfterContext(ctx context.Context, modifiedAfter string) ([]models.WordPressPost, error) {
+	return collect(c.IteratePosts(ctx, IterateOptions{ModifiedAfter: modifiedAfter}))
+}
 
-		var media []models.WordPressMedia
-		if err := json.Unmarshal(resp.Body(), &media); err != nil {
-			return nil, fmt.Errorf("failed to parse media response: %w", err)
-		}
+// GetPagesModifiedAfterContext retrieves pages modified after the given RFC3339 timestamp,
+// for incremental exports driven by internal/state.State.
+func (c *Client) GetPagesModifiedAfterContext(ctx context.Context, modifiedAfter string) ([]models.WordPressPost, error) {
+	return collect(c.IteratePages(ctx, IterateOptions{ModifiedAfter: modifiedAfter}))
+}
 
-		if len(media) == 0 {
-			break
-		}
+// GetMediaModifiedAfterContext retrieves media items modified after the given RFC3339
+// timestamp, for incremental exports driven by internal/state.State.
+func (c *Client) GetMediaModifiedAfterContext(ctx context.Context, modifiedAfter string) ([]models.WordPressMedia, error) {
+	return collect(c.IterateMedia(ctx, IterateOptions{ModifiedAfter: modifiedAfter}))
+}
 
-		allMedia = append(allMedia, media...)
-		page++
-	}
+// GetPostsInRangeContext retrieves posts published within [after, before) (either bound
+// may be empty RFC3339 timestamps, in which case it's left open), for a --after/--before
+// date-range export.
+func (c *Client) GetPostsInRangeContext(ctx context.Context, after, before string) ([]models.WordPressPost, error) {
+	return collect(c.IteratePosts(ctx, IterateOptions{After: after, Before: before}))
+}
+
+// GetPagesInRangeContext retrieves pages published within [after, before), mirroring
+// GetPostsInRangeContext.
+func (c *Client) GetPagesInRangeContext(ctx context.Context, after, before string) ([]models.WordPressPost, error) {
+	return collect(c.IteratePages(ctx, IterateOptions{After: after, Before: before}))
+}
 
-	return allMedia, nil
+// GetMediaInRangeContext retrieves media items published within [after, before),
+// mirroring GetPostsInRangeContext.
+func (c *Client) GetMediaInRangeContext(ctx context.Context, after, before string) ([]models.WordPressMedia, error) {
+	return collect(c.IterateMedia(ctx, IterateOptions{After: after, Before: before}))
 }
 
 // GetCategories retrieves all categories
 func (c *Client) GetCategories() ([]models.WordPressCategory, error) {
-	var allCategories []models.WordPressCategory
-	page := 1
-	perPage := 100
+	return c.GetCategoriesContext(context.Background())
+}
 
-	for {
-		url := fmt.Sprintf("%s/categories?page=%d&per_page=%d", c.baseURL, page, perPage)
-		
-		resp, err := c.httpClient.R().Get(url)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get categories page %d: %w", page, err)
-		}
+// GetCategoriesContext retrieves all categories, honoring ctx cancellation/deadlines
+func (c *Client) GetCategoriesContext(ctx context.Context) ([]models.WordPressCategory, error) {
+	return collect(c.IterateCategories(ctx, IterateOptions{}))
+}
 
-		if resp.StatusCode() == 400 {
-			break
-		}
+// GetTags retrieves all tags
+func (c *Client) GetTags() ([]models.WordPressTag, error) {
+	return c.GetTagsContext(context.Background())
+}
 
-		if resp.StatusCode() != 200 {
-			return nil, fmt.Errorf("API returned status %d for categories page %d", resp.StatusCode(), page)
-		}
+// GetTagsContext retrieves all tags, honoring ctx cancellation/deadlines
+func (c *Client) GetTagsContext(ctx context.Context) ([]models.WordPressTag, error) {
+	return collect(c.IterateTags(ctx, IterateOptions{}))
+}
 
-		var categories []models.WordPressCategory
-		if err := json.Unmarshal(resp.Body(), &categories); err != nil {
-			return nil, fmt.Errorf("failed to parse categories response: %w", err)
-		}
+// GetUsers retrieves all users
+func (c *Client) GetUsers() ([]models.WordPressUser, error) {
+	return c.GetUsersContext(context.Background())
+}
 
-		if len(categories) == 0 {
-			break
-		}
+// GetUsersContext retrieves all users, honoring ctx cancellation/deadlines
+func (c *Client) GetUsersContext(ctx context.Context) ([]models.WordPressUser, error) {
+	return collect(c.IterateUsers(ctx, IterateOptions{}))
+}
 
-		allCategories = append(allCategories, categories...)
-		page++
+// GetPostByID retrieves a specific post by ID
+func (c *Client) GetPostByID(id int) (*models.WordPressPost, error) {
+	return c.GetPostByIDContext(context.Background(), id)
+}
+
+// GetPostByIDContext retrieves a specific post by ID, honoring ctx cancellation/deadlines
+func (c *Client) GetPostByIDContext(ctx context.Context, id int) (*models.WordPressPost, error) {
+	url := fmt.Sprintf("%s/posts/%d", c.baseURL, id)
+
+	resp, err := c.newRequest(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post %d: %w", id, err)
 	}
 
-	return allCategories, nil
+	if resp.StatusCode() == 404 {
+		return nil, nil // Post not found
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
+	}
+
+	var post models.WordPressPost
+	if err := json.Unmarshal(resp.Body(), &post); err != nil {
+		return nil, fmt.Errorf("failed to parse post response: %w", err)
+	}
+
+	return &post, nil
 }
 
-// GetTags retrieves all tags
-func (c *Client) GetTags() ([]models.WordPressTag, error) {
-	var allTags []models.WordPressTag
+// GetPageByID retrieves a specific page by ID
+func (c *Client) GetPageByID(id int) (*models.WordPressPost, error) {
+	return c.GetPageByIDContext(context.Background(), id)
+}
+
+// GetPageByIDContext retrieves a specific page by ID, honoring ctx cancellation/deadlines
+func (c *Client) GetPageByIDContext(ctx context.Context, id int) (*models.WordPressPost, error) {
+	url := fmt.Sprintf("%s/pages/%d", c.baseURL, id)
+
+	resp, err := c.newRequest(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page %d: %w", id, err)
+	}
+
+	if resp.StatusCode() == 404 {
+		return nil, nil // Page not found
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
+	}
+
+	var page models.WordPressPost
+	if err := json.Unmarshal(resp.Body(), &page); err != nil {
+		return nil, fmt.Errorf("failed to parse page response: %w", err)
+	}
+
+	return &page, nil
+}
+
+// GetMediaByID retrieves a specific media item by ID
+func (c *Client) GetMediaByID(id int) (*models.WordPressMedia, error) {
+	return c.GetMediaByIDContext(context.Background(), id)
+}
+
+// GetMediaByIDContext retrieves a specific media item by ID, honoring ctx cancellation/deadlines
+func (c *Client) GetMediaByIDContext(ctx context.Context, id int) (*models.WordPressMedia, error) {
+	url := fmt.Sprintf("%s/media/%d", c.baseURL, id)
+
+	resp, err := c.newRequest(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media %d: %w", id, err)
+	}
+
+	if resp.StatusCode() == 404 {
+		return nil, nil // Media not found
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
+	}
+
+	var media models.WordPressMedia
+	if err := json.Unmarshal(resp.Body(), &media); err != nil {
+		return nil, fmt.Errorf("failed to parse media response: %w", err)
+	}
+
+	return &media, nil
+}
+
+// GetComments retrieves all comments across the site with pagination
+func (c *Client) GetComments() ([]models.WordPressComment, error) {
+	return c.GetCommentsContext(context.Background())
+}
+
+// GetCommentsContext retrieves all comments across the site with pagination, honoring ctx
+// cancellation/deadlines
+func (c *Client) GetCommentsContext(ctx context.Context) ([]models.WordPressComment, error) {
+	return c.getAllComments(ctx, "")
+}
+
+// GetCommentsByPost retrieves all comments for a specific post with pagination
+func (c *Client) GetCommentsByPost(postID int) ([]models.WordPressComment, error) {
+	return c.GetCommentsByPostContext(context.Background(), postID)
+}
+
+// GetCommentsByPostContext retrieves all comments for a specific post with pagination, honoring
+// ctx cancellation/deadlines
+func (c *Client) GetCommentsByPostContext(ctx context.Context, postID int) ([]models.WordPressComment, error) {
+	return c.getAllComments(ctx, fmt.Sprintf("&post=%d", postID))
+}
+
+// getAllComments fetches every page of /comments, optionally narrowed by an extra query string
+// (e.g. "&post=123"), matching the pagination style used by getAllContent.
+func (c *Client) getAllComments(ctx context.Context, query string) ([]models.WordPressComment, error) {
+	var allComments []models.WordPressComment
 	page := 1
 	perPage := 100
 
 	for {
-		url := fmt.Sprintf("%s/tags?page=%d&per_page=%d", c.baseURL, page, perPage)
-		
-		resp, err := c.httpClient.R().Get(url)
+		if err := ctx.Err(); err != nil {
+			return allComments, err
+		}
+
+		url := fmt.Sprintf("%s/comments?page=%d&per_page=%d%s", c.baseURL, page, perPage, query)
+
+		resp, err := c.newRequest(ctx).Get(url)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get tags page %d: %w", page, err)
+			return nil, fmt.Errorf("failed to get comments page %d: %w", page, err)
 		}
 
 		if resp.StatusCode() == 400 {
@@ -183,37 +383,166 @@ func (c *Client) GetTags() ([]models.WordPressTag, error) {
 		}
 
 		if resp.StatusCode() != 200 {
-			return nil, fmt.Errorf("API returned status %d for tags page %d", resp.StatusCode(), page)
+			return nil, fmt.Errorf("API returned status %d for comments page %d", resp.StatusCode(), page)
 		}
 
-		var tags []models.WordPressTag
-		if err := json.Unmarshal(resp.Body(), &tags); err != nil {
-			return nil, fmt.Errorf("failed to parse tags response: %w", err)
+		var comments []models.WordPressComment
+		if err := json.Unmarshal(resp.Body(), &comments); err != nil {
+			return nil, fmt.Errorf("failed to parse comments response: %w", err)
 		}
 
-		if len(tags) == 0 {
+		if len(comments) == 0 {
 			break
 		}
 
-		allTags = append(allTags, tags...)
+		allComments = append(allComments, comments...)
 		page++
 	}
 
-	return allTags, nil
+	return allComments, nil
 }
 
-// GetUsers retrieves all users
-func (c *Client) GetUsers() ([]models.WordPressUser, error) {
-	var allUsers []models.WordPressUser
+// GetCommentByID retrieves a specific comment by ID
+func (c *Client) GetCommentByID(id int) (*models.WordPressComment, error) {
+	return c.GetCommentByIDContext(context.Background(), id)
+}
+
+// GetCommentByIDContext retrieves a specific comment by ID, honoring ctx cancellation/deadlines
+func (c *Client) GetCommentByIDContext(ctx context.Context, id int) (*models.WordPressComment, error) {
+	url := fmt.Sprintf("%s/comments/%d", c.baseURL, id)
+
+	resp, err := c.newRequest(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment %d: %w", id, err)
+	}
+
+	if resp.StatusCode() == 404 {
+		return nil, nil // Comment not found
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("API returned status %d for comment %d", resp.StatusCode(), id)
+	}
+
+	var comment models.WordPressComment
+	if err := json.Unmarshal(resp.Body(), &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// GetUserByID retrieves a specific user by ID
+func (c *Client) GetUserByID(id int) (*models.WordPressUser, error) {
+	return c.GetUserByIDContext(context.Background(), id)
+}
+
+// GetUserByIDContext retrieves a specific user by ID, honoring ctx cancellation/deadlines
+func (c *Client) GetUserByIDContext(ctx context.Context, id int) (*models.WordPressUser, error) {
+	url := fmt.Sprintf("%s/users/%d", c.baseURL, id)
+
+	resp, err := c.newRequest(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %d: %w", id, err)
+	}
+
+	if resp.StatusCode() == 404 {
+		return nil, nil // User not found
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
+	}
+
+	var user models.WordPressUser
+	if err := json.Unmarshal(resp.Body(), &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetPostTypes retrieves every post type registered with the site, built-in (post, page) and
+// custom, keyed by slug
+func (c *Client) GetPostTypes() (map[string]models.WordPressPostType, error) {
+	return c.GetPostTypesContext(context.Background())
+}
+
+// GetPostTypesContext retrieves every post type registered with the site, honoring ctx
+// cancellation/deadlines
+func (c *Client) GetPostTypesContext(ctx context.Context) (map[string]models.WordPressPostType, error) {
+	url := fmt.Sprintf("%s/types", c.baseURL)
+
+	resp, err := c.newRequest(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post types: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
+	}
+
+	var types map[string]models.WordPressPostType
+	if err := json.Unmarshal(resp.Body(), &types); err != nil {
+		return nil, fmt.Errorf("failed to parse post types response: %w", err)
+	}
+
+	return types, nil
+}
+
+// GetTaxonomies retrieves every taxonomy registered with the site, built-in (category,
+// post_tag) and custom, keyed by slug
+func (c *Client) GetTaxonomies() (map[string]models.WordPressTaxonomy, error) {
+	return c.GetTaxonomiesContext(context.Background())
+}
+
+// GetTaxonomiesContext retrieves every taxonomy registered with the site, honoring ctx
+// cancellation/deadlines
+func (c *Client) GetTaxonomiesContext(ctx context.Context) (map[string]models.WordPressTaxonomy, error) {
+	url := fmt.Sprintf("%s/taxonomies", c.baseURL)
+
+	resp, err := c.newRequest(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get taxonomies: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
+	}
+
+	var taxonomies map[string]models.WordPressTaxonomy
+	if err := json.Unmarshal(resp.Body(), &taxonomies); err != nil {
+		return nil, fmt.Errorf("failed to parse taxonomies response: %w", err)
+	}
+
+	return taxonomies, nil
+}
+
+// GetCustomContent retrieves every item of a non-core post type or taxonomy, identified by
+// its REST base (e.g. "products" for a "product" post type). The schema of a custom type
+// isn't known ahead of time, so items are returned as raw JSON rather than unmarshaled into
+// models.WordPressPost.
+func (c *Client) GetCustomContent(restBase string) ([]json.RawMessage, error) {
+	return c.GetCustomContentContext(context.Background(), restBase)
+}
+
+// GetCustomContentContext retrieves every item of a non-core post type or taxonomy by REST
+// base, honoring ctx cancellation/deadlines, paginating the same way getAllContent does.
+func (c *Client) GetCustomContentContext(ctx context.Context, restBase string) ([]json.RawMessage, error) {
+	var allItems []json.RawMessage
 	page := 1
 	perPage := 100
 
 	for {
-		url := fmt.Sprintf("%s/users?page=%d&per_page=%d", c.baseURL, page, perPage)
-		
-		resp, err := c.httpClient.R().Get(url)
+		if err := ctx.Err(); err != nil {
+			return allItems, err
+		}
+
+		url := fmt.Sprintf("%s/%s?page=%d&per_page=%d", c.baseURL, restBase, page, perPage)
+
+		resp, err := c.newRequest(ctx).Get(url)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get users page %d: %w", page, err)
+			return nil, fmt.Errorf("failed to get %s page %d: %w", restBase, page, err)
 		}
 
 		if resp.StatusCode() == 400 {
@@ -221,126 +550,271 @@ func (c *Client) GetUsers() ([]models.WordPressUser, error) {
 		}
 
 		if resp.StatusCode() != 200 {
-			return nil, fmt.Errorf("API returned status %d for users page %d", resp.StatusCode(), page)
+			return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
 		}
 
-		var users []models.WordPressUser
-		if err := json.Unmarshal(resp.Body(), &users); err != nil {
-			return nil, fmt.Errorf("failed to parse users response: %w", err)
+		var items []json.RawMessage
+		if err := json.Unmarshal(resp.Body(), &items); err != nil {
+			return nil, fmt.Errorf("failed to parse %s response: %w", restBase, err)
 		}
 
-		if len(users) == 0 {
+		if len(items) == 0 {
 			break
 		}
 
-		allUsers = append(allUsers, users...)
+		allItems = append(allItems, items...)
 		page++
 	}
 
-	return allUsers, nil
+	return allItems, nil
 }
 
-// GetPostByID retrieves a specific post by ID
-func (c *Client) GetPostByID(id int) (*models.WordPressPost, error) {
-	url := fmt.Sprintf("%s/posts/%d", c.baseURL, id)
-	
-	resp, err := c.httpClient.R().Get(url)
+// GetCustomPostByID retrieves a single item of a custom post type, identified by its REST
+// base (e.g. "products" for a "product" post type)
+func (c *Client) GetCustomPostByID(restBase string, id int) (*models.WordPressPost, error) {
+	return c.GetCustomPostByIDContext(context.Background(), restBase, id)
+}
+
+// GetCustomPostByIDContext retrieves a single item of a custom post type by ID, honoring ctx
+// cancellation/deadlines. Custom post types share the standard WordPressPost REST schema.
+func (c *Client) GetCustomPostByIDContext(ctx context.Context, restBase string, id int) (*models.WordPressPost, error) {
+	url := fmt.Sprintf("%s/%s/%d", c.baseURL, restBase, id)
+
+	resp, err := c.newRequest(ctx).Get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get post %d: %w", id, err)
+		return nil, fmt.Errorf("failed to get %s %d: %w", restBase, id, err)
 	}
 
 	if resp.StatusCode() == 404 {
-		return nil, nil // Post not found
+		return nil, nil // Item not found
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("API returned status %d for post %d", resp.StatusCode(), id)
+		return nil, &StatusError{StatusCode: resp.StatusCode(), URL: url, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
 	}
 
 	var post models.WordPressPost
 	if err := json.Unmarshal(resp.Body(), &post); err != nil {
-		return nil, fmt.Errorf("failed to parse post response: %w", err)
+		return nil, fmt.Errorf("failed to parse %s response: %w", restBase, err)
 	}
 
 	return &post, nil
 }
 
-// GetPageByID retrieves a specific page by ID
-func (c *Client) GetPageByID(id int) (*models.WordPressPost, error) {
-	url := fmt.Sprintf("%s/pages/%d", c.baseURL, id)
-	
-	resp, err := c.httpClient.R().Get(url)
+// GetPostRevisions retrieves the revision history for a specific post
+func (c *Client) GetPostRevisions(postID int) ([]models.WordPressRevision, error) {
+	return c.GetPostRevisionsContext(context.Background(), postID)
+}
+
+// GetPostRevisionsContext retrieves the revision history for a specific post, honoring ctx
+// cancellation/deadlines. Revisions are returned by WordPress in a single unpaginated response.
+func (c *Client) GetPostRevisionsContext(ctx context.Context, postID int) ([]models.WordPressRevision, error) {
+	url := fmt.Sprintf("%s/posts/%d/revisions", c.baseURL, postID)
+
+	resp, err := c.newRequest(ctx).Get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get page %d: %w", id, err)
+		return nil, fmt.Errorf("failed to get revisions for post %d: %w", postID, err)
 	}
 
 	if resp.StatusCode() == 404 {
-		return nil, nil // Page not found
+		return nil, nil // Post not found, or caller lacks permission to view its revisions
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("API returned status %d for page %d", resp.StatusCode(), id)
+		return nil, fmt.Errorf("API returned status %d for post %d revisions", resp.StatusCode(), postID)
 	}
 
-	var page models.WordPressPost
-	if err := json.Unmarshal(resp.Body(), &page); err != nil {
-		return nil, fmt.Errorf("failed to parse page response: %w", err)
+	var revisions []models.WordPressRevision
+	if err := json.Unmarshal(resp.Body(), &revisions); err != nil {
+		return nil, fmt.Errorf("failed to parse revisions response: %w", err)
 	}
 
-	return &page, nil
+	return revisions, nil
 }
 
-// GetMediaByID retrieves a specific media item by ID
-func (c *Client) GetMediaByID(id int) (*models.WordPressMedia, error) {
-	url := fmt.Sprintf("%s/media/%d", c.baseURL, id)
-	
-	resp, err := c.httpClient.R().Get(url)
+// getAllContent retrieves all content for endpoint with pagination. When a cache is
+// configured via SetCache, the aggregate result is stored under it and a conditional GET
+// is issued on the next call: a 304 Not Modified on the first page short-circuits the
+// whole fetch (no further pages are requested) and replays the cached slice. Once a
+// prior run has recorded a maximum post.Modified, a modified_after filter is also
+// applied, falling back to an unfiltered full scan if the server rejects it.
+func (c *Client) getAllContent(ctx context.Context, endpoint string, onItem func(int)) ([]models.WordPressPost, error) {
+	var entry CacheEntry
+	var hasEntry bool
+	var cacheKey string
+
+	if c.cache != nil {
+		cacheKey = c.baseURL + "/" + endpoint
+		entry, hasEntry = c.cache.Get(cacheKey)
+	}
+
+	query := ""
+	if c.auth != nil {
+		// Authenticated requests can see drafts, private posts, and the raw (unrendered)
+		// content/title/excerpt fields, so pull everything in one pass instead of the
+		// anonymous default of published-only, view-context content.
+		query = "&status=any&context=edit"
+	}
+	if hasEntry && entry.ModifiedAfter != "" {
+		query += "&modified_after=" + url.QueryEscape(entry.ModifiedAfter)
+	}
+
+	content, etag, lastModified, notModified, err := c.fetchAllContentConditional(ctx, endpoint, query, entry, hasEntry, onItem)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get media %d: %w", id, err)
+		return nil, err
 	}
 
-	if resp.StatusCode() == 404 {
-		return nil, nil // Media not found
+	if notModified {
+		var cached []models.WordPressPost
+		if err := json.Unmarshal(entry.Body, &cached); err != nil {
+			return nil, fmt.Errorf("failed to parse cached %s response: %w", endpoint, err)
+		}
+		return cached, nil
 	}
 
-	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("API returned status %d for media %d", resp.StatusCode(), id)
+	if c.cache != nil {
+		c.saveListCache(cacheKey, content, etag, lastModified)
 	}
 
-	var media models.WordPressMedia
-	if err := json.Unmarshal(resp.Body(), &media); err != nil {
-		return nil, fmt.Errorf("failed to parse media response: %w", err)
+	return content, nil
+}
+
+// fetchAllContentConditional performs getAllContent's pagination loop, applying
+// If-None-Match / If-Modified-Since (from entry, when hasEntry) to the first page only.
+// If the server answers that request with 304, it returns immediately with notModified
+// set and no further pages requested. If the server rejects a modified_after filter
+// (query) with a 400 on the first page, it retries the whole scan unfiltered.
+func (c *Client) fetchAllContentConditional(ctx context.Context, endpoint, query string, entry CacheEntry, hasEntry bool, onItem func(int)) (content []models.WordPressPost, etag, lastModified string, notModified bool, err error) {
+	page := 1
+	perPage := 100
+
+	for {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return content, "", "", false, ctxErr
+		}
+
+		req := c.newRequest(ctx)
+		if page == 1 && hasEntry {
+			if entry.ETag != "" {
+				req.SetHeader("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.SetHeader("If-Modified-Since", entry.LastModified)
+			}
+		}
+
+		pageURL := fmt.Sprintf("%s/%s?page=%d&per_page=%d%s", c.baseURL, endpoint, page, perPage, query)
+
+		resp, reqErr := req.Get(pageURL)
+		if reqErr != nil {
+			return nil, "", "", false, fmt.Errorf("failed to get %s page %d: %w", endpoint, page, reqErr)
+		}
+
+		if page == 1 && resp.StatusCode() == 304 {
+			return nil, entry.ETag, entry.LastModified, true, nil
+		}
+
+		if resp.StatusCode() == 400 {
+			if page == 1 && query != "" {
+				return c.fetchAllContentConditional(ctx, endpoint, "", entry, hasEntry, onItem)
+			}
+			break
+		}
+
+		if resp.StatusCode() != 200 {
+			return nil, "", "", false, fmt.Errorf("API returned status %d for %s page %d", resp.StatusCode(), endpoint, page)
+		}
+
+		var pageContent []models.WordPressPost
+		if unmarshalErr := json.Unmarshal(resp.Body(), &pageContent); unmarshalErr != nil {
+			return nil, "", "", false, fmt.Errorf("failed to parse %s response: %w", endpoint, unmarshalErr)
+		}
+
+		if len(pageContent) == 0 {
+			break
+		}
+
+		if page == 1 {
+			etag = resp.Header().Get("ETag")
+			lastModified = resp.Header().Get("Last-Modified")
+		}
+
+		content = append(content, pageContent...)
+		if onItem != nil && len(content)/progressEvery != (len(content)-len(pageContent))/progressEvery {
+			onItem(len(content))
+		}
+		page++
 	}
 
-	return &media, nil
+	return content, etag, lastModified, false, nil
+}
+
+// saveListCache persists the aggregated list response plus the maximum post.Modified
+// seen across it, so the next call can send a conditional GET and a modified_after
+// filter.
+func (c *Client) saveListCache(key string, content []models.WordPressPost, etag, lastModified string) {
+	body, err := json.Marshal(content)
+	if err != nil {
+		return
+	}
+
+	var maxModified time.Time
+	for _, item := range content {
+		if item.Modified.After(maxModified) {
+			maxModified = item.Modified.Time
+		}
+	}
+
+	entry := CacheEntry{ETag: etag, LastModified: lastModified, Body: body}
+	if !maxModified.IsZero() {
+		entry.ModifiedAfter = maxModified.UTC().Format(time.RFC3339)
+	}
+
+	c.cache.Set(key, entry)
+}
+
+// GetDraftPostsContext retrieves draft posts, which require an authenticated request with
+// edit-level permissions.
+func (c *Client) GetDraftPostsContext(ctx context.Context) ([]models.WordPressPost, error) {
+	return c.getAllContentWithStatus(ctx, "posts", "draft")
 }
 
-// getAllContent is a generic function to retrieve all content with pagination
-func (c *Client) getAllContent(endpoint string, factory func() interface{}) ([]models.WordPressPost, error) {
+// GetPrivatePostsContext retrieves private posts, which require an authenticated request with
+// edit-level permissions.
+func (c *Client) GetPrivatePostsContext(ctx context.Context) ([]models.WordPressPost, error) {
+	return c.getAllContentWithStatus(ctx, "posts", "private")
+}
+
+// getAllContentWithStatus fetches every page of endpoint filtered to the given post status,
+// using ?context=edit so statuses other than "publish" are visible to an authenticated user.
+func (c *Client) getAllContentWithStatus(ctx context.Context, endpoint, status string) ([]models.WordPressPost, error) {
 	var allContent []models.WordPressPost
 	page := 1
 	perPage := 100
 
 	for {
-		url := fmt.Sprintf("%s/%s?page=%d&per_page=%d", c.baseURL, endpoint, page, perPage)
-		
-		resp, err := c.httpClient.R().Get(url)
+		if err := ctx.Err(); err != nil {
+			return allContent, err
+		}
+
+		url := fmt.Sprintf("%s/%s?page=%d&per_page=%d&status=%s&context=edit", c.baseURL, endpoint, page, perPage, status)
+
+		resp, err := c.newRequest(ctx).Get(url)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get %s page %d: %w", endpoint, page, err)
+			return nil, fmt.Errorf("failed to get %s %s page %d: %w", status, endpoint, page, err)
 		}
 
 		if resp.StatusCode() == 400 {
-			// No more pages
 			break
 		}
 
 		if resp.StatusCode() != 200 {
-			return nil, fmt.Errorf("API returned status %d for %s page %d", resp.StatusCode(), endpoint, page)
+			return nil, fmt.Errorf("API returned status %d for %s %s page %d", resp.StatusCode(), status, endpoint, page)
 		}
 
 		var content []models.WordPressPost
 		if err := json.Unmarshal(resp.Body(), &content); err != nil {
-			return nil, fmt.Errorf("failed to parse %s response: %w", endpoint, err)
+			return nil, fmt.Errorf("failed to parse %s %s response: %w", status, endpoint, err)
 		}
 
 		if len(content) == 0 {
@@ -354,37 +828,67 @@ func (c *Client) getAllContent(endpoint string, factory func() interface{}) ([]m
 	return allContent, nil
 }
 
-// BruteForceContent attempts to discover content by ID enumeration
-func (c *Client) BruteForceContent(contentType string, maxID int, found chan<- interface{}, progress chan<- int) {
-	defer close(found)
-	defer close(progress)
+// GetUsersWithEmailContext retrieves all users with their email addresses populated, which the
+// WordPress REST API only exposes in the authenticated "edit" context.
+func (c *Client) GetUsersWithEmailContext(ctx context.Context) ([]models.WordPressUser, error) {
+	var allUsers []models.WordPressUser
+	page := 1
+	perPage := 100
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return allUsers, err
+		}
 
-	for id := 1; id <= maxID; id++ {
-		var content interface{}
-		var err error
+		url := fmt.Sprintf("%s/users?page=%d&per_page=%d&context=edit", c.baseURL, page, perPage)
+
+		resp, err := c.newRequest(ctx).Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users (edit context) page %d: %w", page, err)
+		}
+
+		if resp.StatusCode() == 400 {
+			break
+		}
 
-		switch contentType {
-		case "posts":
-			content, err = c.GetPostByID(id)
-		case "pages":
-			content, err = c.GetPageByID(id)
-		case "media":
-			content, err = c.GetMediaByID(id)
-		default:
-			continue
+		if resp.StatusCode() != 200 {
+			return nil, fmt.Errorf("API returned status %d for users (edit context) page %d", resp.StatusCode(), page)
 		}
 
-		if err == nil && content != nil {
-			found <- content
+		var users []models.WordPressUser
+		if err := json.Unmarshal(resp.Body(), &users); err != nil {
+			return nil, fmt.Errorf("failed to parse users (edit context) response: %w", err)
 		}
 
-		// Send progress update
-		select {
-		case progress <- id:
-		default:
+		if len(users) == 0 {
+			break
 		}
 
-		// Small delay to avoid overwhelming the server
-		time.Sleep(10 * time.Millisecond)
+		allUsers = append(allUsers, users...)
+		page++
+	}
+
+	return allUsers, nil
+}
+
+// GetSiteSettingsEditContext retrieves the full /wp/v2/settings payload, which WordPress only
+// returns to an authenticated user with the manage_options capability.
+func (c *Client) GetSiteSettingsEditContext(ctx context.Context) (map[string]interface{}, error) {
+	settingsURL := strings.Replace(c.baseURL, "/wp/v2", "", 1) + "/wp/v2/settings?context=edit"
+
+	resp, err := c.newRequest(ctx).Get(settingsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get site settings: %w", err)
 	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("API returned status %d for site settings (edit context)", resp.StatusCode())
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(resp.Body(), &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse site settings response: %w", err)
+	}
+
+	return settings, nil
 }
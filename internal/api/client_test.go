@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -777,3 +778,128 @@ func TestPaginationHandling(t *testing.T) {
 		t.Errorf("Expected at least 3 API calls for pagination, got %d", callCount)
 	}
 }
+
+func TestGetPostByIDContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   0,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetPostByIDContext(ctx, 1); err == nil {
+		t.Error("GetPostByIDContext() with an expired context should return an error")
+	}
+}
+
+func TestBruteForceContentContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   0,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	found := make(chan interface{}, 10)
+	progress := make(chan int, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client.BruteForceContentContext(ctx, "posts", 1000, found, progress)
+
+	if len(found) > 0 {
+		t.Error("BruteForceContentContext() should not find anything once ctx is already cancelled")
+	}
+}
+
+func TestBruteForceContentConcurrentWorkers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:         server.URL,
+		Timeout:     5,
+		Retries:     0,
+		UserAgent:   "test-agent",
+		Concurrency: 4,
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	found := make(chan interface{}, 20)
+	progress := make(chan int, 20)
+
+	client.BruteForceContent("posts", 10, found, progress)
+
+	count := 0
+	for range found {
+		count++
+	}
+
+	if count != 10 {
+		t.Errorf("BruteForceContent() with Concurrency=4 found %d items, want %d", count, 10)
+	}
+}
+
+func TestBruteForceContentStopsAfterConsecutiveMisses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:                        server.URL,
+		Timeout:                    5,
+		Retries:                    0,
+		UserAgent:                  "test-agent",
+		Concurrency:                1,
+		StopAfterConsecutiveMisses: 3,
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	found := make(chan interface{}, 10)
+	progress := make(chan int, 100)
+
+	client.BruteForceContent("posts", 1000, found, progress)
+
+	if len(progress) >= 1000 {
+		t.Errorf("BruteForceContent() should stop early after %d consecutive misses, scanned %d of 1000", cfg.StopAfterConsecutiveMisses, len(progress))
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestGetComments(t *testing.T) {
+	comments := []models.WordPressComment{
+		{ID: 1, Post: 10, AuthorName: "Alice", Content: models.RenderedContent{Rendered: "First!"}},
+		{ID: 2, Post: 11, AuthorName: "Bob", Content: models.RenderedContent{Rendered: "Nice post"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/wp/v2/comments" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte("[]"))
+				return
+			}
+
+			response, _ := json.Marshal(comments)
+			_, _ = w.Write(response)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   1,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetComments()
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Errorf("GetComments() returned %d comments, want %d", len(result), 2)
+	}
+
+	if result[0].ID != 1 {
+		t.Errorf("GetComments() first comment ID = %d, want %d", result[0].ID, 1)
+	}
+}
+
+func TestGetCommentsByPost(t *testing.T) {
+	var gotPost string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/wp/v2/comments" {
+			gotPost = r.URL.Query().Get("post")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"id":5,"post":42}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   1,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetCommentsByPost(42)
+	if err != nil {
+		t.Fatalf("GetCommentsByPost() error = %v", err)
+	}
+
+	if gotPost != "42" {
+		t.Errorf("GetCommentsByPost() sent post=%q, want %q", gotPost, "42")
+	}
+
+	if len(result) != 1 || result[0].Post != 42 {
+		t.Errorf("GetCommentsByPost() = %+v, want a single comment on post 42", result)
+	}
+}
+
+func TestGetCommentByID(t *testing.T) {
+	comment := models.WordPressComment{ID: 7, Post: 1, AuthorName: "Carol"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/wp/v2/comments/7" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			response, _ := json.Marshal(comment)
+			_, _ = w.Write(response)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   1,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetCommentByID(7)
+	if err != nil {
+		t.Fatalf("GetCommentByID() error = %v", err)
+	}
+
+	if result == nil || result.ID != 7 {
+		t.Errorf("GetCommentByID() = %+v, want ID 7", result)
+	}
+}
+
+func TestGetCommentByIDNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   1,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetCommentByID(999)
+	if err != nil {
+		t.Fatalf("GetCommentByID() error = %v", err)
+	}
+
+	if result != nil {
+		t.Errorf("GetCommentByID() = %+v, want nil for a missing comment", result)
+	}
+}
+
+func TestGetPostRevisions(t *testing.T) {
+	revisions := []models.WordPressRevision{
+		{ID: 101, Parent: 10, Title: models.RenderedContent{Rendered: "Draft v2"}},
+		{ID: 100, Parent: 10, Title: models.RenderedContent{Rendered: "Draft v1"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/wp/v2/posts/10/revisions" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			response, _ := json.Marshal(revisions)
+			_, _ = w.Write(response)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   1,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetPostRevisions(10)
+	if err != nil {
+		t.Fatalf("GetPostRevisions() error = %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Errorf("GetPostRevisions() returned %d revisions, want %d", len(result), 2)
+	}
+
+	if result[0].ID != 101 {
+		t.Errorf("GetPostRevisions() first revision ID = %d, want %d", result[0].ID, 101)
+	}
+}
+
+func TestGetPostRevisionsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:       server.URL,
+		Timeout:   10,
+		Retries:   1,
+		UserAgent: "test-agent",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetPostRevisions(999)
+	if err != nil {
+		t.Fatalf("GetPostRevisions() error = %v", err)
+	}
+
+	if result != nil {
+		t.Errorf("GetPostRevisions() = %+v, want nil for a missing post", result)
+	}
+}
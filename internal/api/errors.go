@@ -0,0 +1,51 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StatusError wraps an unexpected HTTP response status from the WordPress REST API,
+// letting callers like BruteForceContentWithOptions distinguish throttling (429/5xx)
+// from other failures via errors.As.
+type StatusError struct {
+	StatusCode int
+	URL        string
+	// RetryAfter is the server's requested backoff from a Retry-After response header
+	// (supporting both the delay-seconds and HTTP-date forms), or zero if the response
+	// didn't set one.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned status %d for %s", e.StatusCode, e.URL)
+}
+
+// Throttled reports whether the response indicates the server wants the caller to slow
+// down (429 Too Many Requests) or is struggling (5xx).
+func (e *StatusError) Throttled() bool {
+	return e.StatusCode == 429 || e.StatusCode >= 500
+}
+
+// parseRetryAfter parses a Retry-After header value in either of its two valid forms
+// (an integer number of seconds, or an HTTP-date), returning zero if v is empty or
+// doesn't parse as either.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
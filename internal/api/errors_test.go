@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfterDelaySeconds(t *testing.T) {
+	if got := parseRetryAfter("5"); got != 5*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want 5s", "5", got)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	got := parseRetryAfter(future.Format(http.TimeFormat))
+	if got <= 0 || got > 10*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want a positive duration up to 10s", future.Format(http.TimeFormat), got)
+	}
+}
+
+func TestParseRetryAfterEmptyOrInvalid(t *testing.T) {
+	for _, v := range []string{"", "not-a-date", "-5"} {
+		if got := parseRetryAfter(v); got != 0 {
+			t.Errorf("parseRetryAfter(%q) = %v, want 0", v, got)
+		}
+	}
+}
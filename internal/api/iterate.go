@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// IterateOptions configures a paginated iterator's page size and resume behavior.
+type IterateOptions struct {
+	// Checkpoint, when non-nil, is consulted for where a prior scan of this endpoint left
+	// off and updated after each page so a later call can resume instead of re-fetching
+	// completed pages.
+	Checkpoint *Checkpoint
+	// PerPage overrides the default page size of 100.
+	PerPage int
+	// ModifiedAfter, when set, restricts results to items modified after this RFC3339
+	// timestamp via ?modified_after=, letting an incremental export fetch only what
+	// changed since a prior run's internal/state.State.LastExportedAt.
+	ModifiedAfter string
+	// After, when set, restricts results to items published on or after this RFC3339
+	// timestamp via ?after=, for a user-specified --after date range.
+	After string
+	// Before, when set, restricts results to items published before this RFC3339
+	// timestamp via ?before=, for a user-specified --before date range.
+	Before string
+	// OnItem, when set, is invoked with the cumulative item count every progressEvery
+	// items, so a caller can stream incremental progress instead of waiting for the
+	// iteration to finish.
+	OnItem func(count int)
+}
+
+// progressEvery is how many items pass between OnItem callbacks.
+const progressEvery = 10
+
+// IteratePosts lazily paginates posts, honoring opts.Checkpoint to resume a prior scan.
+// Authenticated clients additionally pass ?status=any&context=edit, matching GetPosts.
+func (c *Client) IteratePosts(ctx context.Context, opts IterateOptions) iter.Seq2[*models.WordPressPost, error] {
+	return paginate[models.WordPressPost](c, ctx, "posts", c.authQuery()+modifiedAfterQuery(opts)+dateRangeQuery(opts), opts, func(p *models.WordPressPost) int { return p.ID })
+}
+
+// IteratePages lazily paginates pages, honoring opts.Checkpoint to resume a prior scan.
+func (c *Client) IteratePages(ctx context.Context, opts IterateOptions) iter.Seq2[*models.WordPressPost, error] {
+	return paginate[models.WordPressPost](c, ctx, "pages", c.authQuery()+modifiedAfterQuery(opts)+dateRangeQuery(opts), opts, func(p *models.WordPressPost) int { return p.ID })
+}
+
+// IterateMedia lazily paginates media items, honoring opts.Checkpoint to resume a prior scan.
+func (c *Client) IterateMedia(ctx context.Context, opts IterateOptions) iter.Seq2[*models.WordPressMedia, error] {
+	return paginate[models.WordPressMedia](c, ctx, "media", modifiedAfterQuery(opts)+dateRangeQuery(opts), opts, func(m *models.WordPressMedia) int { return m.ID })
+}
+
+// modifiedAfterQuery returns the "&modified_after=..." query suffix for opts.ModifiedAfter,
+// or "" when unset.
+func modifiedAfterQuery(opts IterateOptions) string {
+	if opts.ModifiedAfter == "" {
+		return ""
+	}
+	return "&modified_after=" + url.QueryEscape(opts.ModifiedAfter)
+}
+
+// dateRangeQuery returns the "&after=...&before=..." query suffix for opts.After/opts.Before,
+// or "" when neither is set.
+func dateRangeQuery(opts IterateOptions) string {
+	var q string
+	if opts.After != "" {
+		q += "&after=" + url.QueryEscape(opts.After)
+	}
+	if opts.Before != "" {
+		q += "&before=" + url.QueryEscape(opts.Before)
+	}
+	return q
+}
+
+// IterateCategories lazily paginates categories, honoring opts.Checkpoint to resume a prior scan.
+func (c *Client) IterateCategories(ctx context.Context, opts IterateOptions) iter.Seq2[*models.WordPressCategory, error] {
+	return paginate[models.WordPressCategory](c, ctx, "categories", "", opts, func(t *models.WordPressCategory) int { return t.ID })
+}
+
+// IterateTags lazily paginates tags, honoring opts.Checkpoint to resume a prior scan.
+func (c *Client) IterateTags(ctx context.Context, opts IterateOptions) iter.Seq2[*models.WordPressTag, error] {
+	return paginate[models.WordPressTag](c, ctx, "tags", "", opts, func(t *models.WordPressTag) int { return t.ID })
+}
+
+// IterateUsers lazily paginates users, honoring opts.Checkpoint to resume a prior scan.
+func (c *Client) IterateUsers(ctx context.Context, opts IterateOptions) iter.Seq2[*models.WordPressUser, error] {
+	return paginate[models.WordPressUser](c, ctx, "users", "", opts, func(u *models.WordPressUser) int { return u.ID })
+}
+
+// authQuery returns the ?status=any&context=edit suffix getAllContent applies for
+// authenticated clients, so IteratePosts/IteratePages see the same content GetPosts/GetPages do.
+func (c *Client) authQuery() string {
+	if c.auth != nil {
+		return "&status=any&context=edit"
+	}
+	return ""
+}
+
+// paginate is the shared lazy-pagination engine behind Iterate*: it walks endpoint
+// page-by-page, reading X-WP-TotalPages off the first response to know when to stop,
+// yielding one (item, nil) per element and stopping after a single (nil, err). When
+// opts.Checkpoint is set, it resumes from the page after the last one recorded and
+// persists progress (including the page's ETag) after each page completes.
+func paginate[T any](c *Client, ctx context.Context, endpoint, extraQuery string, opts IterateOptions, idOf func(*T) int) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		perPage := opts.PerPage
+		if perPage <= 0 {
+			perPage = 100
+		}
+
+		page := 1
+		count := 0
+		if opts.Checkpoint != nil {
+			if ec, ok := opts.Checkpoint.Get(endpoint); ok && ec.LastPage > 0 {
+				page = ec.LastPage + 1
+			}
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			pageURL := fmt.Sprintf("%s/%s?page=%d&per_page=%d%s", c.baseURL, endpoint, page, perPage, extraQuery)
+
+			resp, err := c.newRequest(ctx).Get(pageURL)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to get %s page %d: %w", endpoint, page, err))
+				return
+			}
+
+			if resp.StatusCode() == 400 {
+				return
+			}
+
+			if resp.StatusCode() != 200 {
+				yield(nil, &StatusError{StatusCode: resp.StatusCode(), URL: pageURL, RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))})
+				return
+			}
+
+			var items []T
+			if err := json.Unmarshal(resp.Body(), &items); err != nil {
+				yield(nil, fmt.Errorf("failed to parse %s response: %w", endpoint, err))
+				return
+			}
+
+			if len(items) == 0 {
+				return
+			}
+
+			etag := resp.Header().Get("ETag")
+			totalPages, _ := strconv.Atoi(resp.Header().Get("X-WP-TotalPages"))
+
+			for i := range items {
+				if !yield(&items[i], nil) {
+					return
+				}
+				count++
+				if opts.OnItem != nil && count%progressEvery == 0 {
+					opts.OnItem(count)
+				}
+			}
+
+			if opts.Checkpoint != nil {
+				lastID := idOf(&items[len(items)-1])
+				if err := opts.Checkpoint.Set(endpoint, EndpointCheckpoint{LastPage: page, LastID: lastID, ETag: etag}); err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			if totalPages > 0 && page >= totalPages {
+				return
+			}
+
+			page++
+		}
+	}
+}
+
+// collect drains seq into a slice, stopping and returning the error on the first one.
+func collect[T any](seq iter.Seq2[*T, error]) ([]T, error) {
+	var out []T
+	for item, err := range seq {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, *item)
+	}
+	return out, nil
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func newPagedPostsServer(t *testing.T, pages [][]int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			if _, err := fmt.Sscanf(p, "%d", &page); err != nil {
+				t.Fatalf("invalid page query param %q: %v", p, err)
+			}
+		}
+
+		if page < 1 || page > len(pages) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		w.Header().Set("X-WP-TotalPages", fmt.Sprintf("%d", len(pages)))
+		w.Header().Set("ETag", fmt.Sprintf("etag-%d", page))
+		w.WriteHeader(http.StatusOK)
+
+		body := "["
+		for i, id := range pages[page-1] {
+			if i > 0 {
+				body += ","
+			}
+			body += fmt.Sprintf(`{"id":%d}`, id)
+		}
+		body += "]"
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestIteratePostsYieldsAllPages(t *testing.T) {
+	server := newPagedPostsServer(t, [][]int{{1, 2}, {3, 4}, {5}})
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var ids []int
+	for post, err := range client.IteratePosts(context.Background(), IterateOptions{}) {
+		if err != nil {
+			t.Fatalf("IteratePosts() error = %v", err)
+		}
+		ids = append(ids, post.ID)
+	}
+
+	want := []int{1, 2, 3, 4, 5}
+	if len(ids) != len(want) {
+		t.Fatalf("got %v ids, want %v", ids, want)
+	}
+	for i, id := range ids {
+		if id != want[i] {
+			t.Errorf("ids[%d] = %d, want %d", i, id, want[i])
+		}
+	}
+}
+
+func TestIteratePostsResumesFromCheckpoint(t *testing.T) {
+	var requestedPages []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+
+		page := r.URL.Query().Get("page")
+		w.Header().Set("X-WP-TotalPages", "3")
+		w.Header().Set("ETag", "etag-"+page)
+		w.WriteHeader(http.StatusOK)
+
+		switch page {
+		case "2":
+			_, _ = w.Write([]byte(`[{"id":3},{"id":4}]`))
+		case "3":
+			_, _ = w.Write([]byte(`[{"id":5}]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	cp, err := LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error = %v", err)
+	}
+	if err := cp.Set("posts", EndpointCheckpoint{LastPage: 1, LastID: 2, ETag: "etag-1"}); err != nil {
+		t.Fatalf("Checkpoint.Set() error = %v", err)
+	}
+
+	var ids []int
+	for post, err := range client.IteratePosts(context.Background(), IterateOptions{Checkpoint: cp}) {
+		if err != nil {
+			t.Fatalf("IteratePosts() error = %v", err)
+		}
+		ids = append(ids, post.ID)
+	}
+
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 4 || ids[2] != 5 {
+		t.Fatalf("IteratePosts() ids = %v, want [3 4 5]", ids)
+	}
+	if len(requestedPages) == 0 || requestedPages[0] == "1" {
+		t.Errorf("requested pages = %v, want to skip page 1 (already checkpointed)", requestedPages)
+	}
+}
+
+func TestCheckpointSaveAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cp, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error = %v", err)
+	}
+	if err := cp.Set("media", EndpointCheckpoint{LastPage: 4, LastID: 42, ETag: "abc"}); err != nil {
+		t.Fatalf("Checkpoint.Set() error = %v", err)
+	}
+
+	reloaded, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() reload error = %v", err)
+	}
+
+	ec, ok := reloaded.Get("media")
+	if !ok {
+		t.Fatal("Get(media) ok = false, want true after reload")
+	}
+	if ec.LastPage != 4 || ec.LastID != 42 || ec.ETag != "abc" {
+		t.Errorf("Get(media) = %+v, want {LastPage:4 LastID:42 ETag:abc}", ec)
+	}
+}
+
+func TestGetPostsModifiedAfterContextSimulatesTwoRuns(t *testing.T) {
+	var gotModifiedAfter []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		modifiedAfter := r.URL.Query().Get("modified_after")
+		gotModifiedAfter = append(gotModifiedAfter, modifiedAfter)
+
+		w.Header().Set("X-WP-TotalPages", "1")
+		w.WriteHeader(http.StatusOK)
+		if modifiedAfter == "" {
+			_, _ = w.Write([]byte(`[{"id":1},{"id":2},{"id":3}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":2}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	first, err := client.GetPostsModifiedAfterContext(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetPostsModifiedAfterContext() first run error = %v", err)
+	}
+	if len(first) != 3 {
+		t.Fatalf("first run got %d posts, want 3", len(first))
+	}
+
+	second, err := client.GetPostsModifiedAfterContext(context.Background(), "2024-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetPostsModifiedAfterContext() second run error = %v", err)
+	}
+	if len(second) != 1 || second[0].ID != 2 {
+		t.Fatalf("second run got %+v, want only post 2 (the one modified since the first run)", second)
+	}
+
+	if gotModifiedAfter[0] != "" || gotModifiedAfter[1] != "2024-01-01T00:00:00Z" {
+		t.Errorf("requested modified_after values = %v, want [\"\" \"2024-01-01T00:00:00Z\"]", gotModifiedAfter)
+	}
+}
+
+func TestGetPostsInRangeContextSendsAfterAndBefore(t *testing.T) {
+	var gotAfter, gotBefore string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAfter = r.URL.Query().Get("after")
+		gotBefore = r.URL.Query().Get("before")
+
+		w.Header().Set("X-WP-TotalPages", "1")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"id":1}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	posts, err := client.GetPostsInRangeContext(context.Background(), "2024-01-01T00:00:00Z", "2024-06-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetPostsInRangeContext() error = %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("got %d posts, want 1", len(posts))
+	}
+
+	if gotAfter != "2024-01-01T00:00:00Z" {
+		t.Errorf("after = %q, want 2024-01-01T00:00:00Z", gotAfter)
+	}
+	if gotBefore != "2024-06-01T00:00:00Z" {
+		t.Errorf("before = %q, want 2024-06-01T00:00:00Z", gotBefore)
+	}
+}
+
+func TestGetMediaContextUsesIterator(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		if calls == 1 {
+			_, _ = w.Write([]byte(`[{"id":1},{"id":2}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	media, err := client.GetMediaContext(context.Background())
+	if err != nil {
+		t.Fatalf("GetMediaContext() error = %v", err)
+	}
+	if len(media) != 2 || media[0].ID != 1 || media[1].ID != 2 {
+		t.Errorf("GetMediaContext() = %+v, want two items with ids 1,2", media)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestNewClientNonceAuthAppliesHeaderAndCookies(t *testing.T) {
+	var gotNonce, gotCookie string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotNonce = r.Header.Get("X-WP-Nonce")
+		if c, err := r.Cookie("wordpress_logged_in"); err == nil {
+			gotCookie = c.Value
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:          server.URL,
+		Timeout:      5,
+		Retries:      1,
+		UserAgent:    "test-agent",
+		AuthType:     "nonce",
+		Nonce:        "abc123",
+		NonceCookies: map[string]string{"wordpress_logged_in": "session-value"},
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetPostByID(1); err != nil {
+		t.Fatalf("GetPostByID() error = %v", err)
+	}
+
+	if gotNonce != "abc123" {
+		t.Errorf("X-WP-Nonce header = %q, want %q", gotNonce, "abc123")
+	}
+	if gotCookie != "session-value" {
+		t.Errorf("wordpress_logged_in cookie = %q, want %q", gotCookie, "session-value")
+	}
+}
+
+func TestNewClientNonceAuthRequiresCredentials(t *testing.T) {
+	cfg := &config.Config{
+		URL:      "https://example.com",
+		Timeout:  5,
+		AuthType: "nonce",
+	}
+
+	if _, err := NewClient(cfg); err == nil {
+		t.Error("NewClient() error = nil, want an error for nonce auth with no nonce or cookies")
+	}
+}
+
+func TestNewClientNonceAuthRefreshesOn401(t *testing.T) {
+	var refreshCalls, protectedCalls int
+	var seenNonces []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/refresh-nonce":
+			refreshCalls++
+			_, _ = w.Write([]byte("fresh-nonce"))
+		default:
+			protectedCalls++
+			nonce := r.Header.Get("X-WP-Nonce")
+			seenNonces = append(seenNonces, nonce)
+			if nonce != "fresh-nonce" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":1,"content":{"rendered":"","protected":true}}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:             server.URL,
+		Timeout:         5,
+		Retries:         1,
+		UserAgent:       "test-agent",
+		AuthType:        "nonce",
+		Nonce:           "stale-nonce",
+		NonceRefreshURL: server.URL + "/refresh-nonce",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	post, err := client.GetPostByID(1)
+	if err != nil {
+		t.Fatalf("GetPostByID() error = %v", err)
+	}
+	if !post.Content.Protected {
+		t.Errorf("post.Content.Protected = false, want true")
+	}
+
+	if refreshCalls != 1 {
+		t.Errorf("refreshCalls = %d, want 1", refreshCalls)
+	}
+	if protectedCalls != 2 {
+		t.Errorf("protectedCalls = %d, want 2 (one 401, one retry)", protectedCalls)
+	}
+	if len(seenNonces) != 2 || seenNonces[0] != "stale-nonce" || seenNonces[1] != "fresh-nonce" {
+		t.Errorf("seenNonces = %v, want [stale-nonce fresh-nonce]", seenNonces)
+	}
+}
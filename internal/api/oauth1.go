@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// OAuth1Auth authenticates against the wp-api/OAuth1 plugin's three-legged flow, signing
+// each request per RFC 5849 using HMAC-SHA1. Token/TokenSecret are the access token pair
+// obtained by completing RequestToken/AuthorizeURL/AccessToken (or supplied directly, e.g.
+// from config.Config.OAuth1Token/OAuth1TokenSecret, when the flow was already completed
+// out-of-band).
+type OAuth1Auth struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	Token          string
+	TokenSecret    string
+}
+
+// Apply is a no-op: HMAC-SHA1 signing needs the request's final method, URL, and query
+// params, which aren't settled yet when Apply runs. NewClient instead registers sign as
+// an OnBeforeRequest middleware, which runs once those are fixed.
+func (a *OAuth1Auth) Apply(req *resty.Request) {}
+
+// sign computes and attaches the OAuth1 Authorization header for req. Registered as a
+// resty OnBeforeRequest middleware so it runs with req's method, URL, and query params
+// already finalized.
+func (a *OAuth1Auth) sign(_ *resty.Client, req *resty.Request) error {
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	parsed, err := url.Parse(req.URL)
+	if err != nil {
+		return fmt.Errorf("oauth1: invalid request URL %q: %w", req.URL, err)
+	}
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     a.ConsumerKey,
+		"oauth_token":            a.Token,
+		"oauth_nonce":            oauthNonce(),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+
+	signingParams := url.Values{}
+	for k, v := range oauthParams {
+		signingParams.Set(k, v)
+	}
+	for k, vs := range parsed.Query() {
+		for _, v := range vs {
+			signingParams.Add(k, v)
+		}
+	}
+	for k, vs := range req.QueryParam {
+		for _, v := range vs {
+			signingParams.Add(k, v)
+		}
+	}
+
+	baseURL := parsed.Scheme + "://" + parsed.Host + parsed.Path
+	oauthParams["oauth_signature"] = oauth1Signature(method, baseURL, signingParams, a.ConsumerSecret, a.TokenSecret)
+
+	req.SetHeader("Authorization", buildOAuthHeader(oauthParams))
+	return nil
+}
+
+// OAuth1RequestToken is the temporary token/secret pair returned by the first leg of the
+// OAuth1 flow, used to build the authorization URL and later exchanged for an access token.
+type OAuth1RequestToken struct {
+	Token  string
+	Secret string
+}
+
+// RequestToken performs the first leg of the OAuth1 flow against
+// {siteOrigin}/oauth1/request, obtaining a temporary request token to send the user to
+// AuthorizeURL for.
+func (a *OAuth1Auth) RequestToken(ctx context.Context, client *resty.Client, siteOrigin, callbackURL string) (*OAuth1RequestToken, error) {
+	endpoint := siteOrigin + "/oauth1/request"
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     a.ConsumerKey,
+		"oauth_callback":         callbackURL,
+		"oauth_nonce":            oauthNonce(),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	signingParams := url.Values{}
+	for k, v := range oauthParams {
+		signingParams.Set(k, v)
+	}
+	oauthParams["oauth_signature"] = oauth1Signature(http.MethodPost, endpoint, signingParams, a.ConsumerSecret, "")
+
+	resp, err := client.R().SetContext(ctx).SetHeader("Authorization", buildOAuthHeader(oauthParams)).Post(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("oauth1: request token call failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("oauth1: request token endpoint returned status %d", resp.StatusCode())
+	}
+
+	values, err := url.ParseQuery(string(resp.Body()))
+	if err != nil {
+		return nil, fmt.Errorf("oauth1: failed to parse request token response: %w", err)
+	}
+	if values.Get("oauth_callback_confirmed") != "true" {
+		return nil, fmt.Errorf("oauth1: request token endpoint did not confirm the callback")
+	}
+
+	return &OAuth1RequestToken{Token: values.Get("oauth_token"), Secret: values.Get("oauth_token_secret")}, nil
+}
+
+// AuthorizeURL returns the URL the resource owner must visit to grant reqToken access.
+func (a *OAuth1Auth) AuthorizeURL(siteOrigin string, reqToken *OAuth1RequestToken) string {
+	return fmt.Sprintf("%s/oauth1/authorize?oauth_token=%s", siteOrigin, url.QueryEscape(reqToken.Token))
+}
+
+// AccessToken performs the third leg, exchanging reqToken and the verifier the resource
+// owner obtained from AuthorizeURL for a permanent access token/secret, which it stores on
+// a for subsequent sign calls.
+func (a *OAuth1Auth) AccessToken(ctx context.Context, client *resty.Client, siteOrigin string, reqToken *OAuth1RequestToken, verifier string) error {
+	endpoint := siteOrigin + "/oauth1/access"
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     a.ConsumerKey,
+		"oauth_token":            reqToken.Token,
+		"oauth_verifier":         verifier,
+		"oauth_nonce":            oauthNonce(),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	signingParams := url.Values{}
+	for k, v := range oauthParams {
+		signingParams.Set(k, v)
+	}
+	oauthParams["oauth_signature"] = oauth1Signature(http.MethodPost, endpoint, signingParams, a.ConsumerSecret, reqToken.Secret)
+
+	resp, err := client.R().SetContext(ctx).SetHeader("Authorization", buildOAuthHeader(oauthParams)).Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("oauth1: access token call failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("oauth1: access token endpoint returned status %d", resp.StatusCode())
+	}
+
+	values, err := url.ParseQuery(string(resp.Body()))
+	if err != nil {
+		return fmt.Errorf("oauth1: failed to parse access token response: %w", err)
+	}
+
+	a.Token = values.Get("oauth_token")
+	a.TokenSecret = values.Get("oauth_token_secret")
+	return nil
+}
+
+// oauth1Signature computes the RFC 5849 HMAC-SHA1 signature for method+baseURL+params.
+func oauth1Signature(method, baseURL string, params url.Values, consumerSecret, tokenSecret string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range params[k] {
+			pairs = append(pairs, percentEncode(k)+"="+percentEncode(v))
+		}
+	}
+
+	baseString := strings.ToUpper(method) + "&" + percentEncode(baseURL) + "&" + percentEncode(strings.Join(pairs, "&"))
+	signingKey := percentEncode(consumerSecret) + "&" + percentEncode(tokenSecret)
+
+	mac := hmac.New(sha1.New, []byte(signingKey))
+	mac.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// buildOAuthHeader renders params as an RFC 5849 "OAuth ..." Authorization header value.
+func buildOAuthHeader(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, percentEncode(k), percentEncode(params[k])))
+	}
+	return "OAuth " + strings.Join(pairs, ", ")
+}
+
+// percentEncode implements RFC 3986 unreserved-character percent-encoding, which OAuth1
+// requires and differs from url.QueryEscape's form-encoding (space as "+", "~" escaped).
+func percentEncode(s string) string {
+	encoded := url.QueryEscape(s)
+	encoded = strings.ReplaceAll(encoded, "+", "%20")
+	encoded = strings.ReplaceAll(encoded, "%7E", "~")
+	return encoded
+}
+
+// oauthNonce generates a random hex string suitable for oauth_nonce.
+func oauthNonce() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(buf)
+}
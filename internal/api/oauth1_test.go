@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestNewClientOAuth1SignsRequest(t *testing.T) {
+	var gotAuthHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:                  server.URL,
+		Timeout:              5,
+		Retries:              1,
+		UserAgent:            "test-agent",
+		AuthType:             "oauth1",
+		OAuth1ConsumerKey:    "consumer-key",
+		OAuth1ConsumerSecret: "consumer-secret",
+		OAuth1Token:          "access-token",
+		OAuth1TokenSecret:    "access-token-secret",
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetPostByID(1); err != nil {
+		t.Fatalf("GetPostByID() error = %v", err)
+	}
+
+	if !strings.HasPrefix(gotAuthHeader, "OAuth ") {
+		t.Fatalf("Authorization header = %q, want it to start with %q", gotAuthHeader, "OAuth ")
+	}
+	for _, want := range []string{`oauth_consumer_key="consumer-key"`, `oauth_token="access-token"`, `oauth_signature="`} {
+		if !strings.Contains(gotAuthHeader, want) {
+			t.Errorf("Authorization header = %q, want it to contain %q", gotAuthHeader, want)
+		}
+	}
+}
+
+func TestNewClientOAuth1RequiresCredentials(t *testing.T) {
+	cfg := &config.Config{
+		URL:               "https://example.com",
+		Timeout:           5,
+		AuthType:          "oauth1",
+		OAuth1ConsumerKey: "consumer-key",
+	}
+
+	if _, err := NewClient(cfg); err == nil {
+		t.Error("NewClient() error = nil, want an error for a missing oauth1 access token")
+	}
+}
+
+func TestOAuth1RequestAndAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "OAuth ") {
+			t.Errorf("request to %s missing OAuth Authorization header, got %q", r.URL.Path, auth)
+		}
+
+		w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+		switch r.URL.Path {
+		case "/oauth1/request":
+			_, _ = w.Write([]byte("oauth_token=req-token&oauth_token_secret=req-secret&oauth_callback_confirmed=true"))
+		case "/oauth1/access":
+			_, _ = w.Write([]byte("oauth_token=access-token&oauth_token_secret=access-secret"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	auth := &OAuth1Auth{ConsumerKey: "consumer-key", ConsumerSecret: "consumer-secret"}
+
+	reqToken, err := auth.RequestToken(context.Background(), resty.New(), server.URL, "https://app.example.com/callback")
+	if err != nil {
+		t.Fatalf("RequestToken() error = %v", err)
+	}
+	if reqToken.Token != "req-token" || reqToken.Secret != "req-secret" {
+		t.Fatalf("RequestToken() = %+v, want token=req-token secret=req-secret", reqToken)
+	}
+
+	wantAuthorizeURL := server.URL + "/oauth1/authorize?oauth_token=req-token"
+	if got := auth.AuthorizeURL(server.URL, reqToken); got != wantAuthorizeURL {
+		t.Errorf("AuthorizeURL() = %q, want %q", got, wantAuthorizeURL)
+	}
+
+	if err := auth.AccessToken(context.Background(), resty.New(), server.URL, reqToken, "the-verifier"); err != nil {
+		t.Fatalf("AccessToken() error = %v", err)
+	}
+	if auth.Token != "access-token" || auth.TokenSecret != "access-secret" {
+		t.Errorf("after AccessToken(): Token=%q TokenSecret=%q, want access-token/access-secret", auth.Token, auth.TokenSecret)
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestNewClientResolvesRedirectWhenEnabled(t *testing.T) {
+	finalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer finalServer.Close()
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, finalServer.URL, http.StatusMovedPermanently)
+	}))
+	defer redirectServer.Close()
+
+	cfg := &config.Config{
+		URL:                     redirectServer.URL,
+		Timeout:                 5,
+		ResolveRedirects:        true,
+		AllowCrossHostRedirects: true,
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if !strings.HasPrefix(client.baseURL, finalServer.URL) {
+		t.Errorf("baseURL = %q, want it to start with the resolved origin %q", client.baseURL, finalServer.URL)
+	}
+}
+
+func TestNewClientSkipsResolutionByDefault(t *testing.T) {
+	finalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer finalServer.Close()
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, finalServer.URL, http.StatusMovedPermanently)
+	}))
+	defer redirectServer.Close()
+
+	cfg := &config.Config{URL: redirectServer.URL, Timeout: 5}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if !strings.HasPrefix(client.baseURL, redirectServer.URL) {
+		t.Errorf("baseURL = %q, want it to keep the configured origin %q when ResolveRedirects is unset", client.baseURL, redirectServer.URL)
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// configureRetry installs resty retry hooks that back off exponentially (with full jitter,
+// base 500ms, factor 2, capped at 30s) on transient failures, and that honor a server's
+// Retry-After header (seconds or HTTP-date) when one is present on 429/503 responses.
+func configureRetry(httpClient *resty.Client, retries int) {
+	httpClient.SetRetryCount(retries)
+	httpClient.AddRetryCondition(shouldRetry)
+	httpClient.SetRetryAfter(retryAfter)
+}
+
+// shouldRetry reports whether a GET should be retried: transient network errors, or HTTP
+// 429/502/503/504 responses from the server.
+func shouldRetry(resp *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp.Request.Method != http.MethodGet {
+		return false
+	}
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter computes how long to wait before the next attempt: it honors a Retry-After
+// response header when present, otherwise falls back to exponential backoff with full jitter.
+func retryAfter(c *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp != nil {
+		if d, ok := parseRetryAfterOK(resp.Header().Get("Retry-After")); ok {
+			return d, nil
+		}
+	}
+
+	attempt := 0
+	if resp != nil && resp.Request != nil {
+		attempt = resp.Request.Attempt
+	}
+
+	backoff := retryBaseDelay * time.Duration(1<<uint(attempt))
+	if backoff > retryMaxDelay {
+		backoff = retryMaxDelay
+	}
+
+	// Full jitter: a uniformly random delay between 0 and backoff.
+	return time.Duration(rand.Int63n(int64(backoff) + 1)), nil
+}
+
+// parseRetryAfterOK parses a Retry-After header value expressed either as a number of
+// seconds or as an HTTP-date, returning the resulting delay from now and whether value
+// actually carried a usable Retry-After (distinct from errors.go's parseRetryAfter, whose
+// many StatusError callers don't need to distinguish "absent" from "zero delay").
+func parseRetryAfterOK(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
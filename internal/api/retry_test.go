@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestParseRetryAfterOK(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantOK  bool
+		wantMin time.Duration
+	}{
+		{"empty", "", false, 0},
+		{"seconds", "5", true, 5 * time.Second},
+		{"negative seconds", "-1", false, 0},
+		{"not a number or date", "soon", false, 0},
+		{"http-date in the future", time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), true, 1 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseRetryAfterOK(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfterOK(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if ok && d < tt.wantMin {
+				t.Errorf("parseRetryAfterOK(%q) = %v, want at least %v", tt.value, d, tt.wantMin)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := resty.New()
+	resp, err := client.R().Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if !shouldRetry(resp, nil) {
+		t.Error("shouldRetry() should retry a 429 response")
+	}
+
+	if !shouldRetry(nil, errors.New("network error")) {
+		t.Error("shouldRetry() should retry on a transport error")
+	}
+}
+
+func TestGetPostByIDRetriesOn503ThenSucceeds(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":42}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{
+		URL:       server.URL,
+		Timeout:   5,
+		Retries:   3,
+		UserAgent: "test-agent",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	post, err := client.GetPostByID(42)
+	if err != nil {
+		t.Fatalf("GetPostByID() error = %v", err)
+	}
+
+	if post == nil || post.ID != 42 {
+		t.Errorf("GetPostByID() = %+v, want ID 42", post)
+	}
+
+	if attempts < 2 {
+		t.Errorf("expected at least 2 attempts, got %d", attempts)
+	}
+}
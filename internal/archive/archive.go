@@ -0,0 +1,152 @@
+// Package archive packages an export directory into a single distributable file
+// alongside a SHA-256 MANIFEST.json (and an optional detached ed25519 signature), so
+// downstream consumers can verify a large export's integrity without trusting the
+// transport it arrived over.
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// Archiver packages sourceDir into a single archive file at targetBase plus this
+// Archiver's own extension (".zip" or ".tar.gz"), and returns the path written.
+type Archiver interface {
+	Archive(sourceDir, targetBase string) (string, error)
+}
+
+// Archivers maps the --archive-format flag to the Archiver implementing it.
+var Archivers = map[string]Archiver{
+	"zip":    ZipArchiver{},
+	"tar.gz": TarGzArchiver{},
+}
+
+// Manifest lists every file an archive's source directory contained at packaging time,
+// so a downstream consumer can verify the archive wasn't truncated or tampered with in
+// transit.
+type Manifest struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Files       []ManifestFile `json:"files"`
+}
+
+// ManifestFile is one entry in a Manifest.
+type ManifestFile struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+	MimeType string `json:"mime_type"`
+}
+
+// BuildManifest walks dir and returns a Manifest covering every regular file in it, with
+// paths relative to dir using forward slashes regardless of OS.
+func BuildManifest(dir string) (*Manifest, error) {
+	manifest := &Manifest{GeneratedAt: time.Now()}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		sha, mimeType, err := hashAndSniff(path)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+
+		manifest.Files = append(manifest.Files, ManifestFile{
+			Path:     filepath.ToSlash(relPath),
+			Size:     info.Size(),
+			SHA256:   sha,
+			MimeType: mimeType,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].Path < manifest.Files[j].Path })
+
+	return manifest, nil
+}
+
+// hashAndSniff returns a file's SHA-256 digest (hex-encoded) and its MIME type, sniffed
+// via http.DetectContentType from the file's first 512 bytes.
+func hashAndSniff(path string) (sha, mimeType string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	var buf [512]byte
+	n, readErr := f.Read(buf[:])
+	if readErr != nil && !errors.Is(readErr, io.EOF) {
+		return "", "", readErr
+	}
+	mimeType = http.DetectContentType(buf[:n])
+
+	if _, err := f.Seek(0, 0); err != nil {
+		return "", "", err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), mimeType, nil
+}
+
+// WriteManifest builds a Manifest for dir and writes it to dir/MANIFEST.json, so it's
+// picked up alongside the rest of dir's content when an Archiver packages it.
+func WriteManifest(dir string) (*Manifest, error) {
+	manifest, err := BuildManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "MANIFEST.json"), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write MANIFEST.json: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// WriteOptions marshals cfg as dir/options.json, recording the effective configuration
+// that produced dir's content, so an archive remains self-describing once extracted.
+func WriteOptions(dir string, cfg *config.Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal options: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "options.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write options.json: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", p, err)
+	}
+	return p
+}
+
+func TestBuildManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "export.json", `{"hello":"world"}`)
+	if err := os.Mkdir(filepath.Join(dir, "media"), 0755); err != nil {
+		t.Fatalf("failed to mkdir: %v", err)
+	}
+	writeTestFile(t, filepath.Join(dir, "media"), "photo.txt", "fake-image-bytes")
+
+	manifest, err := BuildManifest(dir)
+	if err != nil {
+		t.Fatalf("BuildManifest() error = %v", err)
+	}
+
+	if len(manifest.Files) != 2 {
+		t.Fatalf("Files = %+v, want 2 entries", manifest.Files)
+	}
+
+	want := sha256.Sum256([]byte(`{"hello":"world"}`))
+	wantHex := hex.EncodeToString(want[:])
+
+	found := false
+	for _, f := range manifest.Files {
+		if f.Path == "export.json" {
+			found = true
+			if f.SHA256 != wantHex {
+				t.Errorf("export.json SHA256 = %s, want %s", f.SHA256, wantHex)
+			}
+			if f.Size != int64(len(`{"hello":"world"}`)) {
+				t.Errorf("export.json Size = %d, want %d", f.Size, len(`{"hello":"world"}`))
+			}
+		}
+	}
+	if !found {
+		t.Errorf("manifest missing export.json entry: %+v", manifest.Files)
+	}
+}
+
+func TestWriteManifestAndOptions(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "export.json", `{}`)
+
+	if _, err := WriteManifest(dir); err != nil {
+		t.Fatalf("WriteManifest() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "MANIFEST.json")); err != nil {
+		t.Errorf("MANIFEST.json not written: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.URL = "https://example.com"
+	if err := WriteOptions(dir, cfg); err != nil {
+		t.Fatalf("WriteOptions() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "options.json")); err != nil {
+		t.Errorf("options.json not written: %v", err)
+	}
+}
+
+func TestZipArchiverRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "export.json", `{"a":1}`)
+
+	target := filepath.Join(t.TempDir(), "export")
+	path, err := ZipArchiver{}.Archive(dir, target)
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if filepath.Ext(path) != ".zip" {
+		t.Errorf("Archive() path = %s, want .zip extension", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("archive file not created: %v", err)
+	}
+}
+
+func TestTarGzArchiverRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "export.json", `{"a":1}`)
+
+	target := filepath.Join(t.TempDir(), "export")
+	path, err := TarGzArchiver{}.Archive(dir, target)
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if filepath.Ext(path) != ".gz" {
+		t.Errorf("Archive() path = %s, want .tar.gz extension", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("archive file not created: %v", err)
+	}
+}
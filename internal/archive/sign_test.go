@@ -0,0 +1,76 @@
+package archive
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestKey(t *testing.T, dir string) (keyPath string, pub ed25519.PublicKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+
+	keyPath = filepath.Join(dir, "sign.key")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(keyPath, pemBytes, 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	return keyPath, pub
+}
+
+func TestSignManifest(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := WriteManifest(dir); err != nil {
+		t.Fatalf("WriteManifest() error = %v", err)
+	}
+
+	keyPath, pub := writeTestKey(t, dir)
+
+	sigPath, err := SignManifest(dir, keyPath)
+	if err != nil {
+		t.Fatalf("SignManifest() error = %v", err)
+	}
+
+	manifestData, err := os.ReadFile(filepath.Join(dir, "MANIFEST.json"))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		t.Fatalf("failed to read signature: %v", err)
+	}
+
+	if !ed25519.Verify(pub, manifestData, sig) {
+		t.Errorf("signature at %s does not verify against MANIFEST.json", sigPath)
+	}
+}
+
+func TestSignManifestRejectsNonEd25519Key(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := WriteManifest(dir); err != nil {
+		t.Fatalf("WriteManifest() error = %v", err)
+	}
+
+	keyPath := filepath.Join(dir, "bad.key")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("not a real key")})
+	if err := os.WriteFile(keyPath, pemBytes, 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	if _, err := SignManifest(dir, keyPath); err == nil {
+		t.Error("SignManifest() error = nil, want error for malformed key")
+	}
+}
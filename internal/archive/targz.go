@@ -0,0 +1,73 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// TarGzArchiver packages a directory into a gzip-compressed tar file.
+type TarGzArchiver struct{}
+
+func (TarGzArchiver) Archive(sourceDir, targetBase string) (string, error) {
+	targetPath := targetBase + ".tar.gz"
+
+	tarFile, err := os.Create(targetPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create tar.gz file: %w", err)
+	}
+	defer func() { _ = tarFile.Close() }()
+
+	gzWriter := gzip.NewWriter(tarFile)
+	defer func() { _ = gzWriter.Close() }()
+
+	tarWriter := tar.NewWriter(gzWriter)
+	defer func() { _ = tarWriter.Close() }()
+
+	err = filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return targetPath, nil
+}
@@ -0,0 +1,71 @@
+package archive
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ZipArchiver packages a directory into a DEFLATE-compressed ZIP file.
+type ZipArchiver struct{}
+
+func (ZipArchiver) Archive(sourceDir, targetBase string) (string, error) {
+	targetPath := targetBase + ".zip"
+
+	zipFile, err := os.Create(targetPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create zip file: %w", err)
+	}
+	defer func() { _ = zipFile.Close() }()
+
+	zipWriter := zip.NewWriter(zipFile)
+	defer func() { _ = zipWriter.Close() }()
+
+	err = filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+
+		if info.IsDir() {
+			header.Name += "/"
+			_, err := zipWriter.CreateHeader(header)
+			return err
+		}
+		header.Method = zip.Deflate
+
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+
+		_, err = io.Copy(writer, file)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return targetPath, nil
+}
@@ -0,0 +1,70 @@
+package bruteforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ContentTypeProgress tracks one content type's (posts, pages, media) brute force scan
+// progress: the highest ID attempted so far (the resume cursor) and the IDs confirmed to
+// exist, so a later run can report them without re-probing.
+type ContentTypeProgress struct {
+	LastID int   `json:"last_id"`
+	Found  []int `json:"found"`
+}
+
+// ScanCheckpoint is a small sidecar JSON file recording how far a brute force scan got
+// through each content type's ID space, letting Scanner.Resume pick up after an
+// interruption (Ctrl-C or crash) instead of re-probing IDs already attempted. It follows
+// the same load/save idiom as internal/api.Checkpoint and internal/state.State.
+type ScanCheckpoint struct {
+	ContentTypes map[string]ContentTypeProgress `json:"content_types"`
+
+	path string
+}
+
+// LoadScanCheckpoint reads a ScanCheckpoint from path, returning an empty, unsaved one if
+// the file doesn't exist yet (the first scan).
+func LoadScanCheckpoint(path string) (*ScanCheckpoint, error) {
+	cp := &ScanCheckpoint{ContentTypes: map[string]ContentTypeProgress{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cp, nil
+		}
+		return nil, fmt.Errorf("failed to read scan checkpoint %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("failed to parse scan checkpoint %s: %w", path, err)
+	}
+	cp.path = path
+
+	return cp, nil
+}
+
+// Get returns the progress recorded for contentType, and whether any exists.
+func (cp *ScanCheckpoint) Get(contentType string) (ContentTypeProgress, bool) {
+	p, ok := cp.ContentTypes[contentType]
+	return p, ok
+}
+
+// Set records contentType's progress and persists the checkpoint to its backing file.
+func (cp *ScanCheckpoint) Set(contentType string, p ContentTypeProgress) error {
+	cp.ContentTypes[contentType] = p
+	return cp.save()
+}
+
+// save writes the checkpoint to its backing path.
+func (cp *ScanCheckpoint) save() error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan checkpoint: %w", err)
+	}
+	if err := os.WriteFile(cp.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write scan checkpoint %s: %w", cp.path, err)
+	}
+	return nil
+}
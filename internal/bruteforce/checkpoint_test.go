@@ -0,0 +1,50 @@
+package bruteforce
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadScanCheckpointMissingFileReturnsEmpty(t *testing.T) {
+	cp, err := LoadScanCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err != nil {
+		t.Fatalf("LoadScanCheckpoint() error = %v", err)
+	}
+	if _, ok := cp.Get("posts"); ok {
+		t.Error("Get() on a fresh checkpoint should report no progress")
+	}
+}
+
+func TestScanCheckpointSetAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cp, err := LoadScanCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadScanCheckpoint() error = %v", err)
+	}
+
+	if err := cp.Set("posts", ContentTypeProgress{LastID: 42, Found: []int{5, 12}}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	reloaded, err := LoadScanCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadScanCheckpoint() reload error = %v", err)
+	}
+
+	p, ok := reloaded.Get("posts")
+	if !ok {
+		t.Fatal("Get() after reload should find the persisted progress")
+	}
+	if p.LastID != 42 {
+		t.Errorf("LastID = %d, want 42", p.LastID)
+	}
+	if len(p.Found) != 2 || p.Found[0] != 5 || p.Found[1] != 12 {
+		t.Errorf("Found = %v, want [5 12]", p.Found)
+	}
+
+	// An unrelated content type should still report no progress.
+	if _, ok := reloaded.Get("media"); ok {
+		t.Error("Get(\"media\") should report no progress before it's ever Set")
+	}
+}
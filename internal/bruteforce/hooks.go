@@ -0,0 +1,67 @@
+package bruteforce
+
+import (
+	"context"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// DiscoveryHook lets third-party code inspect, modify, or reject a brute-force-discovered
+// post, page, or media item before it's stored into a ScanResult - the same "intercept
+// before commit" shape as server plugin systems that let a hook see and veto an incoming
+// item before it's persisted. Each method returns the item to keep (the original pointer,
+// or a replacement), whether to keep it at all, and an error. Returning keep=false drops the
+// item entirely: it won't appear in ScanResult and isn't counted toward Found. A non-nil
+// error aborts applying any further hooks to that item and also drops it.
+//
+// There's no hook method for custom post types, users, or comments yet - RegisterHook's
+// chain only runs against the built-in posts/pages/media tasks.
+type DiscoveryHook interface {
+	OnPostDiscovered(ctx context.Context, post *models.WordPressPost) (*models.WordPressPost, bool, error)
+	OnPageDiscovered(ctx context.Context, page *models.WordPressPost) (*models.WordPressPost, bool, error)
+	OnMediaDiscovered(ctx context.Context, media *models.WordPressMedia) (*models.WordPressMedia, bool, error)
+}
+
+// RegisterHook adds h to this Scanner's discovery hook chain, run in registration order
+// against every post/page/media item runTask discovers, before it's stored into the
+// ScanResult. A hook that rejects an item short-circuits the rest of the chain for it.
+func (s *Scanner) RegisterHook(h DiscoveryHook) {
+	s.hooks = append(s.hooks, h)
+}
+
+// applyHooks runs every hook registered via RegisterHook against an item task.Fetch just
+// found, dispatching on name ("posts", "pages", or "media" - the only task names any
+// DiscoveryHook method covers). Task names outside those three pass item through unchanged;
+// there's no way to express "reject" for them yet.
+func (s *Scanner) applyHooks(ctx context.Context, name string, item interface{}) (interface{}, bool, error) {
+	for _, hook := range s.hooks {
+		var keep bool
+		var err error
+
+		switch name {
+		case "posts":
+			var post *models.WordPressPost
+			post, keep, err = hook.OnPostDiscovered(ctx, item.(*models.WordPressPost))
+			item = post
+		case "pages":
+			var page *models.WordPressPost
+			page, keep, err = hook.OnPageDiscovered(ctx, item.(*models.WordPressPost))
+			item = page
+		case "media":
+			var media *models.WordPressMedia
+			media, keep, err = hook.OnMediaDiscovered(ctx, item.(*models.WordPressMedia))
+			item = media
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+		if !keep {
+			return nil, false, nil
+		}
+	}
+
+	return item, true, nil
+}
@@ -0,0 +1,105 @@
+package bruteforce
+
+import (
+	"context"
+	"crypto/sha256"
+	"path"
+	"sync"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// ContentHashDedupeHook rejects a discovered post/page whose rendered title+content
+// SHA-256 hash has already been seen, the common case of the same content being exposed
+// under more than one ID (e.g. a site migration leaving old and new permalink ID ranges
+// both live). Media isn't deduped since WordPressMedia carries no rendered body to hash.
+type ContentHashDedupeHook struct {
+	mu   sync.Mutex
+	seen map[[32]byte]bool
+}
+
+// NewContentHashDedupeHook creates a ContentHashDedupeHook ready to register via
+// Scanner.RegisterHook.
+func NewContentHashDedupeHook() *ContentHashDedupeHook {
+	return &ContentHashDedupeHook{seen: map[[32]byte]bool{}}
+}
+
+func (h *ContentHashDedupeHook) OnPostDiscovered(_ context.Context, post *models.WordPressPost) (*models.WordPressPost, bool, error) {
+	return post, h.keep(post.Title.Rendered, post.Content.Rendered), nil
+}
+
+func (h *ContentHashDedupeHook) OnPageDiscovered(_ context.Context, page *models.WordPressPost) (*models.WordPressPost, bool, error) {
+	return page, h.keep(page.Title.Rendered, page.Content.Rendered), nil
+}
+
+func (h *ContentHashDedupeHook) OnMediaDiscovered(_ context.Context, media *models.WordPressMedia) (*models.WordPressMedia, bool, error) {
+	return media, true, nil
+}
+
+func (h *ContentHashDedupeHook) keep(title, content string) bool {
+	sum := sha256.Sum256([]byte(title + content))
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.seen[sum] {
+		return false
+	}
+	h.seen[sum] = true
+	return true
+}
+
+// TaxonomyFilterHook keeps or rejects a discovered post/page by matching its slug and
+// category names against include/exclude glob patterns (path.Match syntax, e.g.
+// "news-*"). Exclude always wins over Include; an empty Include list means "include
+// everything not excluded". Media has no slug/category of its own to filter on, so it
+// always passes through unchanged.
+type TaxonomyFilterHook struct {
+	Include []string
+	Exclude []string
+	// CategoryNames resolves a post/page's numeric category IDs to names for glob
+	// matching. Left nil, only slug is matched.
+	CategoryNames map[int]string
+}
+
+func (h *TaxonomyFilterHook) OnPostDiscovered(_ context.Context, post *models.WordPressPost) (*models.WordPressPost, bool, error) {
+	return post, h.matches(post.Slug, post.Categories), nil
+}
+
+func (h *TaxonomyFilterHook) OnPageDiscovered(_ context.Context, page *models.WordPressPost) (*models.WordPressPost, bool, error) {
+	return page, h.matches(page.Slug, page.Categories), nil
+}
+
+func (h *TaxonomyFilterHook) OnMediaDiscovered(_ context.Context, media *models.WordPressMedia) (*models.WordPressMedia, bool, error) {
+	return media, true, nil
+}
+
+func (h *TaxonomyFilterHook) matches(slug string, categories []int) bool {
+	candidates := make([]string, 0, len(categories)+1)
+	candidates = append(candidates, slug)
+	for _, id := range categories {
+		if name, ok := h.CategoryNames[id]; ok {
+			candidates = append(candidates, name)
+		}
+	}
+
+	for _, pattern := range h.Exclude {
+		for _, c := range candidates {
+			if ok, _ := path.Match(pattern, c); ok {
+				return false
+			}
+		}
+	}
+
+	if len(h.Include) == 0 {
+		return true
+	}
+	for _, pattern := range h.Include {
+		for _, c := range candidates {
+			if ok, _ := path.Match(pattern, c); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package bruteforce
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// recordingHook records every post it's offered and rejects any whose slug is in reject.
+type recordingHook struct {
+	seenPosts []string
+	reject    map[string]bool
+}
+
+func (h *recordingHook) OnPostDiscovered(_ context.Context, post *models.WordPressPost) (*models.WordPressPost, bool, error) {
+	h.seenPosts = append(h.seenPosts, post.Slug)
+	return post, !h.reject[post.Slug], nil
+}
+
+func (h *recordingHook) OnPageDiscovered(_ context.Context, page *models.WordPressPost) (*models.WordPressPost, bool, error) {
+	return page, true, nil
+}
+
+func (h *recordingHook) OnMediaDiscovered(_ context.Context, media *models.WordPressMedia) (*models.WordPressMedia, bool, error) {
+	return media, true, nil
+}
+
+func TestScanForContentInvokesRegisteredHooks(t *testing.T) {
+	server := newTestSiteServer(t, map[int]bool{5: true, 6: true}, nil, nil)
+	cfg := &config.Config{BruteForce: true, MaxID: 10, Concurrent: 2}
+	scanner := newTestScanner(t, cfg, server)
+
+	hook := &recordingHook{reject: map[string]bool{"item-6": true}}
+	scanner.RegisterHook(hook)
+
+	result, err := scanner.ScanForContent(nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ScanForContent() error = %v", err)
+	}
+
+	if len(result.Posts) != 1 || result.Posts[0].ID != 5 {
+		t.Errorf("ScanForContent() Posts = %+v, want only item 5 (item 6 rejected by hook)", result.Posts)
+	}
+	if result.Found != 1 {
+		t.Errorf("ScanForContent() Found = %d, want 1", result.Found)
+	}
+
+	if len(hook.seenPosts) != 2 {
+		t.Errorf("hook saw %d posts, want 2 (both discovered items offered to the hook)", len(hook.seenPosts))
+	}
+}
+
+func TestContentHashDedupeHookRejectsRepeatedContent(t *testing.T) {
+	hook := NewContentHashDedupeHook()
+
+	first := &models.WordPressPost{ID: 1, Title: models.RenderedContent{Rendered: "Hello"}, Content: models.RenderedContent{Rendered: "World"}}
+	dup := &models.WordPressPost{ID: 2, Title: models.RenderedContent{Rendered: "Hello"}, Content: models.RenderedContent{Rendered: "World"}}
+	distinct := &models.WordPressPost{ID: 3, Title: models.RenderedContent{Rendered: "Other"}, Content: models.RenderedContent{Rendered: "Content"}}
+
+	if _, keep, err := hook.OnPostDiscovered(context.Background(), first); err != nil || !keep {
+		t.Fatalf("OnPostDiscovered(first) = keep=%v, err=%v, want keep=true", keep, err)
+	}
+	if _, keep, err := hook.OnPostDiscovered(context.Background(), dup); err != nil || keep {
+		t.Fatalf("OnPostDiscovered(dup) = keep=%v, err=%v, want keep=false", keep, err)
+	}
+	if _, keep, err := hook.OnPostDiscovered(context.Background(), distinct); err != nil || !keep {
+		t.Fatalf("OnPostDiscovered(distinct) = keep=%v, err=%v, want keep=true", keep, err)
+	}
+}
+
+func TestTaxonomyFilterHookIncludeExclude(t *testing.T) {
+	hook := &TaxonomyFilterHook{
+		Include:       []string{"news-*"},
+		Exclude:       []string{"news-draft-*"},
+		CategoryNames: map[int]string{1: "announcements"},
+	}
+
+	keep := func(slug string, categories []int) bool {
+		_, keep, err := hook.OnPostDiscovered(context.Background(), &models.WordPressPost{Slug: slug, Categories: categories})
+		if err != nil {
+			t.Fatalf("OnPostDiscovered(%q) error = %v", slug, err)
+		}
+		return keep
+	}
+
+	if !keep("news-launch", nil) {
+		t.Error("news-launch should match the Include pattern")
+	}
+	if keep("news-draft-launch", nil) {
+		t.Error("news-draft-launch should be rejected by the Exclude pattern")
+	}
+	if keep("other-post", nil) {
+		t.Error("other-post matches neither slug Include pattern nor a category name, should be rejected")
+	}
+
+	categoryHook := &TaxonomyFilterHook{
+		Include:       []string{"announcements"},
+		CategoryNames: map[int]string{1: "announcements"},
+	}
+	_, keepByCategory, err := categoryHook.OnPostDiscovered(context.Background(), &models.WordPressPost{Slug: "other-post", Categories: []int{1}})
+	if err != nil {
+		t.Fatalf("OnPostDiscovered() error = %v", err)
+	}
+	if !keepByCategory {
+		t.Error("a post whose category name matches an Include pattern should be kept even if its slug doesn't match")
+	}
+}
@@ -1,20 +1,38 @@
 package bruteforce
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
-	"github.com/schollz/progressbar/v3"
-	"github.com/tradik/wpexportjson/internal/api"
-	"github.com/tradik/wpexportjson/internal/config"
-	"github.com/tradik/wpexportjson/pkg/models"
+	"github.com/tradik/wpexporter/internal/api"
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/internal/progress"
+	"github.com/tradik/wpexporter/pkg/models"
 )
 
+// checkpointEvery is how many IDs pass between persisting a resumable scan's checkpoint to
+// disk, bounding how much re-work a crash (as opposed to a graceful Ctrl-C) can cause.
+const checkpointEvery = 50
+
+// defaultBatchSize is how many IDs runTask's batch discovery pre-pass requests per
+// `?include=` call when Config.BatchSize isn't set - the WP REST API's own per_page
+// ceiling.
+const defaultBatchSize = 100
+
 // Scanner handles brute force content discovery
 type Scanner struct {
 	config    *config.Config
 	apiClient *api.Client
+	progress  *progress.Manager
+
+	checkpoint *ScanCheckpoint
+	hooks      []DiscoveryHook
+	stats      *ScanStats
 }
 
 // NewScanner creates a new brute force scanner
@@ -22,9 +40,51 @@ func NewScanner(cfg *config.Config, client *api.Client) *Scanner {
 	return &Scanner{
 		config:    cfg,
 		apiClient: client,
+		progress:  progress.New(cfg),
+		stats:     newScanStats(),
 	}
 }
 
+// Stats returns the Scanner's accumulated ScanStats, live-updated as ScanForContent, Resume,
+// or ScanSpecificRange runs - callers can poll it mid-scan, or inspect it once the scan
+// returns, without waiting on WriteReport.
+func (s *Scanner) Stats() *ScanStats {
+	return s.stats
+}
+
+// WriteReport writes the Scanner's accumulated stats as JSON to path, covering every probe
+// issued so far across every content type this Scanner has scanned.
+func (s *Scanner) WriteReport(path string) error {
+	return s.stats.WriteReport(path, s.config.MaxID)
+}
+
+// Checkpoint returns the scanner's on-disk resume checkpoint (see ScanCheckpoint), loading
+// it from disk on first call. It's nil, with no error, when Config.Resume isn't set.
+func (s *Scanner) Checkpoint() (*ScanCheckpoint, error) {
+	if !s.config.Resume {
+		return nil, nil
+	}
+	if s.checkpoint != nil {
+		return s.checkpoint, nil
+	}
+
+	cp, err := LoadScanCheckpoint(s.checkpointPath())
+	if err != nil {
+		return nil, err
+	}
+	s.checkpoint = cp
+	return cp, nil
+}
+
+// checkpointPath returns Config.ScanCheckpointPath, or its default location under the
+// export output directory when unset.
+func (s *Scanner) checkpointPath() string {
+	if s.config.ScanCheckpointPath != "" {
+		return s.config.ScanCheckpointPath
+	}
+	return filepath.Join(s.config.Output, ".wpexport-scan-checkpoint.json")
+}
+
 // ScanResult represents the result of a brute force scan
 type ScanResult struct {
 	Posts []models.WordPressPost
@@ -33,15 +93,46 @@ type ScanResult struct {
 	Found int
 }
 
-// ScanForContent performs brute force scanning for missing content
+// ScanForContent performs brute force scanning for missing content. The posts, pages, and
+// media tasks share a single api.RateLimiter (seeded from Config.RateLimit/MaxQPS) so their
+// combined request rate against the site stays bounded, rather than each racing ahead with
+// its own independent budget.
 func (s *Scanner) ScanForContent(existingPosts, existingPages []models.WordPressPost, existingMedia []models.WordPressMedia) (*ScanResult, error) {
+	return s.scan(context.Background(), nil, existingPosts, existingPages, existingMedia)
+}
+
+// Resume behaves like ScanForContent but consults and updates a persistent on-disk
+// ScanCheckpoint (see Checkpoint), so each content type's scan picks up from the highest ID
+// it previously left off on instead of starting over. ctx additionally lets a caller cancel
+// the scan early (e.g. on SIGINT) while still leaving a checkpoint a later call can resume
+// from, so the scan survives being interrupted or the process crashing mid-run.
+func (s *Scanner) Resume(ctx context.Context, existingPosts, existingPages []models.WordPressPost, existingMedia []models.WordPressMedia) (*ScanResult, error) {
+	if !s.config.BruteForce {
+		return &ScanResult{}, nil
+	}
+
+	cp, err := s.Checkpoint()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scan checkpoint: %w", err)
+	}
+
+	return s.scan(ctx, cp, existingPosts, existingPages, existingMedia)
+}
+
+// scan runs the built-in posts/pages/media tasks concurrently through the shared taskRunner
+// (see runTask), and is what ScanForContent and Resume both boil down to - the only
+// difference between them is whether cp is nil.
+func (s *Scanner) scan(ctx context.Context, cp *ScanCheckpoint, existingPosts, existingPages []models.WordPressPost, existingMedia []models.WordPressMedia) (*ScanResult, error) {
 	if !s.config.BruteForce {
 		return &ScanResult{}, nil
 	}
 
-	fmt.Println("Starting brute force content discovery...")
+	if cp != nil {
+		fmt.Println("Resuming brute force content discovery...")
+	} else {
+		fmt.Println("Starting brute force content discovery...")
+	}
 
-	// Create maps of existing IDs for quick lookup
 	existingPostIDs := make(map[int]bool)
 	for _, post := range existingPosts {
 		existingPostIDs[post.ID] = true
@@ -57,34 +148,41 @@ func (s *Scanner) ScanForContent(existingPosts, existingPages []models.WordPress
 		existingMediaIDs[media.ID] = true
 	}
 
+	burst := s.config.Concurrent
+	if burst <= 0 {
+		burst = 1
+	}
+	limiter := api.NewRateLimiter(s.config.RateLimit, s.config.MaxQPS, burst)
+
+	posts := &postsTask{client: s.apiClient}
+	pages := &pagesTask{client: s.apiClient}
+	media := &mediaTask{client: s.apiClient}
+
 	result := &ScanResult{}
 	var wg sync.WaitGroup
 
-	// Scan for posts
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		posts := s.scanPosts(existingPostIDs)
-		result.Posts = posts
-		result.Found += len(posts)
+		s.runTask(ctx, posts, limiter, existingPostIDs, cp)
+		result.Posts = posts.found
+		result.Found += len(posts.found)
 	}()
 
-	// Scan for pages
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		pages := s.scanPages(existingPageIDs)
-		result.Pages = pages
-		result.Found += len(pages)
+		s.runTask(ctx, pages, limiter, existingPageIDs, cp)
+		result.Pages = pages.found
+		result.Found += len(pages.found)
 	}()
 
-	// Scan for media
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		media := s.scanMedia(existingMediaIDs)
-		result.Media = media
-		result.Found += len(media)
+		s.runTask(ctx, media, limiter, existingMediaIDs, cp)
+		result.Media = media.found
+		result.Found += len(media.found)
 	}()
 
 	wg.Wait()
@@ -98,244 +196,416 @@ func (s *Scanner) ScanForContent(existingPosts, existingPages []models.WordPress
 	return result, nil
 }
 
-// scanPosts scans for posts using brute force
-func (s *Scanner) scanPosts(existingIDs map[int]bool) []models.WordPressPost {
-	fmt.Println("Scanning for missing posts...")
-	
-	progress := progressbar.NewOptions(s.config.MaxID,
-		progressbar.OptionSetDescription("Scanning posts"),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "=",
-			SaucerHead:    ">",
-			SaucerPadding: " ",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-	)
-
-	var foundPosts []models.WordPressPost
-	var mutex sync.Mutex
-
-	// Create worker pool
-	jobs := make(chan int, s.config.MaxID)
-	var wg sync.WaitGroup
+// bruteForceOptions returns the api.BruteForceOptions shared by every ScanTask run through
+// runTask: the configured worker count and early-stop heuristic, limiter pooled across
+// concurrent tasks, and skipIDs so already-known content is counted toward progress without
+// spending a rate-limited request reconfirming it.
+func (s *Scanner) bruteForceOptions(limiter *api.RateLimiter, skipIDs map[int]bool) api.BruteForceOptions {
+	return api.BruteForceOptions{
+		Workers:                    s.config.Concurrent,
+		StopAfterConsecutiveMisses: s.config.StopAfterConsecutiveMisses,
+		Limiter:                    limiter,
+		SkipIDs:                    skipIDs,
+	}
+}
 
-	// Start workers
-	for i := 0; i < s.config.Concurrent; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for id := range jobs {
-				if !existingIDs[id] {
-					post, err := s.apiClient.GetPostByID(id)
-					if err == nil && post != nil {
-						mutex.Lock()
-						foundPosts = append(foundPosts, *post)
-						mutex.Unlock()
-						
-						if s.config.Verbose {
-							fmt.Printf("Found post: ID %d - %s\n", post.ID, post.Title.Rendered)
-						}
-					}
-				}
-				progress.Add(1)
-				
-				// Small delay to avoid overwhelming the server
-				time.Sleep(10 * time.Millisecond)
-			}
-		}()
+// batchSize returns Config.BatchSize, or defaultBatchSize when it's unset (<= 0). Set
+// Config.BatchSize to 1 to disable batch discovery entirely and fall back to the original
+// one-request-per-ID behavior, for servers that reject long query strings.
+func (s *Scanner) batchSize() int {
+	if s.config.BatchSize > 0 {
+		return s.config.BatchSize
 	}
+	return defaultBatchSize
+}
+
+// discoverBatch resolves [minID, maxID] in batchSize()-sized groups via task's FetchBatch,
+// marking every ID it confirms found directly in existingIDs (by reference - the caller
+// passes the same map it's about to hand runTask as opts.SkipIDs) so the per-ID fallback
+// pass that follows never re-requests them. IDs a batch already marked existing are left out
+// of the next request instead of being re-checked. A chunk that errors (e.g. the server
+// rejects a long query string) is logged in verbose mode and left entirely for the per-ID
+// fallback pass, rather than aborting the whole discovery.
+func (s *Scanner) discoverBatch(ctx context.Context, task BatchScanTask, minID, maxID int, limiter *api.RateLimiter, existingIDs map[int]bool) []int {
+	name := task.Name()
+	size := s.batchSize()
+
+	var foundIDs []int
+	for start := minID; start <= maxID; start += size {
+		if ctx.Err() != nil {
+			return foundIDs
+		}
+
+		end := start + size - 1
+		if end > maxID {
+			end = maxID
+		}
+
+		ids := make([]int, 0, end-start+1)
+		for id := start; id <= end; id++ {
+			if !existingIDs[id] {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return foundIDs
+			}
+		}
+
+		items, err := task.FetchBatch(ctx, ids)
+		if err != nil {
+			if s.config.Verbose {
+				fmt.Printf("%s batch discovery error (IDs %d-%d): %v\n", name, start, end, err)
+			}
+			continue
+		}
 
-	// Send jobs
-	for id := 1; id <= s.config.MaxID; id++ {
-		jobs <- id
+		for id, item := range items {
+			existingIDs[id] = true
+			foundIDs = append(foundIDs, task.Store(item))
+		}
 	}
-	close(jobs)
 
-	wg.Wait()
-	progress.Finish()
+	return foundIDs
+}
 
-	return foundPosts
+// resumeMinID returns the ID a task's scan should start from: 1 with no checkpoint (or no
+// prior progress recorded under name), otherwise one past the last ID it previously reached.
+func resumeMinID(cp *ScanCheckpoint, name string) int {
+	if cp == nil {
+		return 1
+	}
+	p, ok := cp.Get(name)
+	if !ok {
+		return 1
+	}
+	return p.LastID + 1
 }
 
-// scanPages scans for pages using brute force
-func (s *Scanner) scanPages(existingIDs map[int]bool) []models.WordPressPost {
-	fmt.Println("Scanning for missing pages...")
-	
-	progress := progressbar.NewOptions(s.config.MaxID,
-		progressbar.OptionSetDescription("Scanning pages"),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "=",
-			SaucerHead:    ">",
-			SaucerPadding: " ",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-	)
-
-	var foundPages []models.WordPressPost
-	var mutex sync.Mutex
-
-	// Create worker pool
-	jobs := make(chan int, s.config.MaxID)
-	var wg sync.WaitGroup
+// instrumentedFetch wraps fetch so every call records a probe into s.stats: latency, the
+// HTTP status code when the error is an *api.StatusError (200 for a hit, 0 for a transport
+// error that never got a response or for a clean 404), and an estimate of bytes downloaded (the fetched
+// item's marshaled size - the only measure available without threading response size through
+// every api.Client getter). Used for both task.Fetch (runTask) and the per-content-type
+// fetch ScanSpecificRange builds, so stats cover every probe this Scanner issues.
+func (s *Scanner) instrumentedFetch(contentType string, fetch func(ctx context.Context, id int) (interface{}, error)) func(ctx context.Context, id int) (interface{}, error) {
+	return func(ctx context.Context, id int) (interface{}, error) {
+		start := time.Now()
+		item, err := fetch(ctx, id)
+		latency := time.Since(start)
+
+		status := 0
+		var statusErr *api.StatusError
+		if errors.As(err, &statusErr) {
+			status = statusErr.StatusCode
+		} else if err == nil && !isNilItem(item) {
+			status = 200
+		}
 
-	// Start workers
-	for i := 0; i < s.config.Concurrent; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for id := range jobs {
-				if !existingIDs[id] {
-					page, err := s.apiClient.GetPageByID(id)
-					if err == nil && page != nil {
-						mutex.Lock()
-						foundPages = append(foundPages, *page)
-						mutex.Unlock()
-						
-						if s.config.Verbose {
-							fmt.Printf("Found page: ID %d - %s\n", page.ID, page.Title.Rendered)
-						}
-					}
-				}
-				progress.Add(1)
-				
-				// Small delay to avoid overwhelming the server
-				time.Sleep(10 * time.Millisecond)
+		var bytes int64
+		if !isNilItem(item) {
+			if data, mErr := json.Marshal(item); mErr == nil {
+				bytes = int64(len(data))
 			}
-		}()
+		}
+
+		s.stats.record(contentType, id, latency, status, item, err, bytes)
+		return item, err
 	}
+}
 
-	// Send jobs
-	for id := 1; id <= s.config.MaxID; id++ {
-		jobs <- id
+// strategy resolves Config.ScanStrategy into a concrete Strategy, defaulting to
+// LinearStrategy - an unrecognized value (already rejected by Config.Validate, but cheap to
+// guard here too) falls back to Linear rather than panicking.
+func (s *Scanner) strategy() Strategy {
+	switch s.config.ScanStrategy {
+	case "exponential":
+		return ExponentialProbeStrategy{}
+	case "sparse":
+		return SparseSamplingStrategy{MinHitRate: s.config.SparseMinHitRate}
+	default:
+		return LinearStrategy{}
 	}
-	close(jobs)
+}
 
-	wg.Wait()
-	progress.Finish()
+// peekResult is what planIDs's peek closure learned about one ID, kept so runTask can reuse
+// it instead of asking the dense pass to fetch the same ID a second time.
+type peekResult struct {
+	item interface{}
+	err  error
+}
+
+// planIDs resolves name's Strategy into the IDs worth a dense scan in [minID, maxID], peeking
+// via fetch (rate-limited the same as the dense scan that follows, and already wrapped by
+// instrumentedFetch so a Strategy's probes count toward its ScanStats same as the dense scan
+// that follows) when the strategy needs it. A Plan error falls back to the full linear range
+// rather than aborting the scan outright. The returned peeked map records every ID peek was
+// actually called on, so runTask can skip re-fetching them - for SparseSamplingStrategy in
+// particular, peek is called on every ID Plan returns, so without this every one of those IDs
+// would otherwise be fetched twice.
+func (s *Scanner) planIDs(ctx context.Context, name string, fetch func(ctx context.Context, id int) (interface{}, error), limiter *api.RateLimiter, minID, maxID int) (ids []int, peeked map[int]peekResult) {
+	peeked = make(map[int]peekResult)
+	peek := func(pctx context.Context, id int) (bool, error) {
+		if limiter != nil {
+			if err := limiter.Wait(pctx); err != nil {
+				return false, err
+			}
+		}
+		item, err := fetch(pctx, id)
+		peeked[id] = peekResult{item: item, err: err}
+		return !isNilItem(item), err
+	}
 
-	return foundPages
+	ids, err := s.strategy().Plan(ctx, minID, maxID, peek)
+	if err != nil {
+		if s.config.Verbose {
+			fmt.Printf("%s scan strategy error (falling back to a linear range): %v\n", name, err)
+		}
+		return idRange(minID, maxID), peeked
+	}
+	return ids, peeked
 }
 
-// scanMedia scans for media using brute force
-func (s *Scanner) scanMedia(existingIDs map[int]bool) []models.WordPressMedia {
-	fmt.Println("Scanning for missing media...")
-	
-	progress := progressbar.NewOptions(s.config.MaxID,
-		progressbar.OptionSetDescription("Scanning media"),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "=",
-			SaucerHead:    ">",
-			SaucerPadding: " ",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-	)
-
-	var foundMedia []models.WordPressMedia
-	var mutex sync.Mutex
-
-	// Create worker pool
-	jobs := make(chan int, s.config.MaxID)
-	var wg sync.WaitGroup
+// runTask is the shared scheduler that scanPosts/scanPages/scanMedia used to each
+// reimplement by hand: it owns the worker pool (delegated to
+// api.BruteForceContentWithOptions via task.Fetch), the progress bar, and checkpoint
+// persistence, for any ScanTask. Adding a new content type only requires a new ScanTask
+// implementation (see task.go), not a new copy of this loop. When cp is non-nil, the scan
+// resumes from cp's last recorded progress for task.Name() and periodically persists its own
+// progress back to cp (every checkpointEvery IDs, and once more before returning).
+func (s *Scanner) runTask(ctx context.Context, task ScanTask, limiter *api.RateLimiter, existingIDs map[int]bool, cp *ScanCheckpoint) {
+	name := task.Name()
+	fmt.Printf("Scanning for missing %s...\n", name)
+
+	minID := resumeMinID(cp, name)
+
+	var foundIDs []int
+	_, linear := s.strategy().(LinearStrategy)
+	if batchTask, ok := task.(BatchScanTask); ok && linear && s.batchSize() > 1 {
+		foundIDs = s.discoverBatch(ctx, batchTask, minID, s.config.MaxID, limiter, existingIDs)
+		if s.config.Verbose && len(foundIDs) > 0 {
+			fmt.Printf("Batch discovery found %d %s\n", len(foundIDs), name)
+		}
+	}
+
+	fetch := s.instrumentedFetch(name, task.Fetch)
+
+	scanIDs, peeked := s.planIDs(ctx, name, fetch, limiter, minID, s.config.MaxID)
+	if s.config.Verbose && !linear {
+		fmt.Printf("%s scan strategy narrowed the scan to %d IDs (of a possible %d)\n", name, len(scanIDs), s.config.MaxID-minID+1)
+	}
 
-	// Start workers
-	for i := 0; i < s.config.Concurrent; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for id := range jobs {
-				if !existingIDs[id] {
-					media, err := s.apiClient.GetMediaByID(id)
-					if err == nil && media != nil {
-						mutex.Lock()
-						foundMedia = append(foundMedia, *media)
-						mutex.Unlock()
-						
-						if s.config.Verbose {
-							fmt.Printf("Found media: ID %d - %s\n", media.ID, media.Title.Rendered)
-						}
-					}
+	bar := s.progress.NewCountBar("Scanning "+name, len(scanIDs))
+
+	lastID := minID - 1
+	sinceCheckpoint := 0
+	recordProgress := func(id int) {
+		bar.Add(1)
+		if cp == nil {
+			return
+		}
+		if id > lastID {
+			lastID = id
+		}
+		sinceCheckpoint++
+		if sinceCheckpoint >= checkpointEvery {
+			sinceCheckpoint = 0
+			_ = cp.Set(name, ContentTypeProgress{LastID: lastID, Found: foundIDs})
+		}
+	}
+
+	// Any ID planIDs already peeked has a known answer - replay it here instead of handing
+	// it to the dense pass below, which would otherwise fetch it a second time (the whole
+	// ID space, for SparseSamplingStrategy, since it peeks every ID it returns).
+	denseIDs := make([]int, 0, len(scanIDs))
+	for _, id := range scanIDs {
+		res, ok := peeked[id]
+		if !ok {
+			denseIDs = append(denseIDs, id)
+			continue
+		}
+		switch {
+		case res.err != nil:
+			if s.config.Verbose {
+				fmt.Printf("%s scan error: %v\n", name, res.err)
+			}
+		case !isNilItem(res.item):
+			item, keep, err := s.applyHooks(ctx, name, res.item)
+			if err != nil {
+				if s.config.Verbose {
+					fmt.Printf("%s discovery hook error: %v\n", name, err)
+				}
+			} else if keep {
+				storedID := task.Store(item)
+				foundIDs = append(foundIDs, storedID)
+				if s.config.Verbose {
+					fmt.Printf("Found %s: ID %d\n", name, storedID)
 				}
-				progress.Add(1)
-				
-				// Small delay to avoid overwhelming the server
-				time.Sleep(10 * time.Millisecond)
 			}
-		}()
+		}
+		recordProgress(id)
 	}
 
-	// Send jobs
-	for id := 1; id <= s.config.MaxID; id++ {
-		jobs <- id
+	idsCh := make(chan int)
+	go func() {
+		defer close(idsCh)
+		for _, id := range denseIDs {
+			select {
+			case <-ctx.Done():
+				return
+			case idsCh <- id:
+			}
+		}
+	}()
+
+	found := make(chan interface{})
+	prog := make(chan int)
+	errs := make(chan error)
+	stats := make(chan api.BruteForceStats)
+
+	opts := s.bruteForceOptions(limiter, existingIDs)
+	opts.IDs = idsCh
+	opts.Fetch = fetch
+	go s.apiClient.BruteForceContentWithOptions(ctx, name, s.config.MaxID, opts, found, prog, errs, stats)
+
+	foundDone, progDone := false, false
+	for !foundDone || !progDone {
+		select {
+		case item, ok := <-found:
+			if !ok {
+				foundDone = true
+				found = nil
+				continue
+			}
+			item, keep, err := s.applyHooks(ctx, name, item)
+			if err != nil {
+				if s.config.Verbose {
+					fmt.Printf("%s discovery hook error: %v\n", name, err)
+				}
+				continue
+			}
+			if !keep {
+				continue
+			}
+			id := task.Store(item)
+			foundIDs = append(foundIDs, id)
+			if s.config.Verbose {
+				fmt.Printf("Found %s: ID %d\n", name, id)
+			}
+		case id, ok := <-prog:
+			if !ok {
+				progDone = true
+				prog = nil
+				continue
+			}
+			recordProgress(id)
+		case err := <-errs:
+			if s.config.Verbose {
+				fmt.Printf("%s scan error: %v\n", name, err)
+			}
+		case st := <-stats:
+			bar.Describe(fmt.Sprintf("Scanning %s (%.1f req/s)", name, st.QPS))
+		}
 	}
-	close(jobs)
 
-	wg.Wait()
-	progress.Finish()
+	if cp != nil {
+		_ = cp.Set(name, ContentTypeProgress{LastID: lastID, Found: foundIDs})
+	}
+
+	bar.Finish()
+}
 
-	return foundMedia
+// defaultFetch returns the api.Client getter for one of the built-in content types, wrapped
+// to match ScanTask.Fetch's signature - the same dispatch api.BruteForceContentWithOptions's
+// own contentType switch performs internally, pulled out here so ScanSpecificRange's probes
+// can be wrapped by instrumentedFetch too.
+func (s *Scanner) defaultFetch(contentType string) func(ctx context.Context, id int) (interface{}, error) {
+	switch contentType {
+	case "posts":
+		return func(ctx context.Context, id int) (interface{}, error) { return s.apiClient.GetPostByIDContext(ctx, id) }
+	case "pages":
+		return func(ctx context.Context, id int) (interface{}, error) { return s.apiClient.GetPageByIDContext(ctx, id) }
+	case "media":
+		return func(ctx context.Context, id int) (interface{}, error) {
+			return s.apiClient.GetMediaByIDContext(ctx, id)
+		}
+	default:
+		return nil
+	}
 }
 
-// ScanSpecificRange scans a specific range of IDs for a content type
+// ScanSpecificRange scans a specific range of IDs for a content type, sharing the same
+// rate-limited BruteForceContentWithOptions path as ScanForContent rather than enumerating
+// the range with its own fixed delay.
 func (s *Scanner) ScanSpecificRange(contentType string, startID, endID int) (interface{}, error) {
+	switch contentType {
+	case "posts", "pages", "media":
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
 	fmt.Printf("Scanning %s IDs from %d to %d...\n", contentType, startID, endID)
-	
+
 	total := endID - startID + 1
-	progress := progressbar.NewOptions(total,
-		progressbar.OptionSetDescription(fmt.Sprintf("Scanning %s", contentType)),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-	)
+	bar := s.progress.NewCountBar(fmt.Sprintf("Scanning %s", contentType), total)
+	defer bar.Finish()
 
-	switch contentType {
-	case "posts":
-		var posts []models.WordPressPost
-		for id := startID; id <= endID; id++ {
-			post, err := s.apiClient.GetPostByID(id)
-			if err == nil && post != nil {
-				posts = append(posts, *post)
+	burst := s.config.Concurrent
+	if burst <= 0 {
+		burst = 1
+	}
+	limiter := api.NewRateLimiter(s.config.RateLimit, s.config.MaxQPS, burst)
+
+	found := make(chan interface{})
+	prog := make(chan int)
+	errs := make(chan error)
+	stats := make(chan api.BruteForceStats)
+
+	opts := s.bruteForceOptions(limiter, nil)
+	opts.MinID = startID
+	opts.Fetch = s.instrumentedFetch(contentType, s.defaultFetch(contentType))
+	go s.apiClient.BruteForceContentWithOptions(context.Background(), contentType, endID, opts, found, prog, errs, stats)
+
+	var posts []models.WordPressPost
+	var media []models.WordPressMedia
+	foundDone, progDone := false, false
+	for !foundDone || !progDone {
+		select {
+		case item, ok := <-found:
+			if !ok {
+				foundDone = true
+				found = nil
+				continue
 			}
-			progress.Add(1)
-			time.Sleep(10 * time.Millisecond)
-		}
-		progress.Finish()
-		return posts, nil
-		
-	case "pages":
-		var pages []models.WordPressPost
-		for id := startID; id <= endID; id++ {
-			page, err := s.apiClient.GetPageByID(id)
-			if err == nil && page != nil {
-				pages = append(pages, *page)
+			switch v := item.(type) {
+			case *models.WordPressPost:
+				posts = append(posts, *v)
+			case *models.WordPressMedia:
+				media = append(media, *v)
 			}
-			progress.Add(1)
-			time.Sleep(10 * time.Millisecond)
-		}
-		progress.Finish()
-		return pages, nil
-		
-	case "media":
-		var media []models.WordPressMedia
-		for id := startID; id <= endID; id++ {
-			mediaItem, err := s.apiClient.GetMediaByID(id)
-			if err == nil && mediaItem != nil {
-				media = append(media, *mediaItem)
+		case _, ok := <-prog:
+			if !ok {
+				progDone = true
+				prog = nil
+				continue
+			}
+			bar.Add(1)
+		case err := <-errs:
+			if s.config.Verbose {
+				fmt.Printf("%s scan error: %v\n", contentType, err)
 			}
-			progress.Add(1)
-			time.Sleep(10 * time.Millisecond)
+		case st := <-stats:
+			bar.Describe(fmt.Sprintf("Scanning %s (%.1f req/s)", contentType, st.QPS))
 		}
-		progress.Finish()
+	}
+
+	if contentType == "media" {
 		return media, nil
-		
-	default:
-		return nil, fmt.Errorf("unsupported content type: %s", contentType)
 	}
+	return posts, nil
 }
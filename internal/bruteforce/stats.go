@@ -0,0 +1,341 @@
+package bruteforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// suspiciousSlugPattern flags slugs that look like leftover WordPress internals rather than
+// real published content, such as an autosave or revision slug that leaked past the editor.
+var suspiciousSlugPattern = regexp.MustCompile(`^(draft|revision)-`)
+
+// typeStats is one content type's (posts/pages/media/...) probe accounting within a
+// ScanStats: how many IDs were actually requested against the site (as opposed to skipped
+// via BruteForceOptions.SkipIDs, which never reach task.Fetch), how many turned up content
+// vs. a clean 404 vs. a transport-level failure, an HTTP status histogram, and enough raw
+// latency samples to report percentiles. Guarded by ScanStats.mu rather than its own lock,
+// since every update already goes through ScanStats.record.
+type typeStats struct {
+	probes          int
+	hits            int
+	notFound        int
+	transportErrors int
+	statusCounts    map[int]int
+	bytesDownloaded int64
+	latencies       []time.Duration
+	hitIDs          []int
+	missIDs         []int
+	firstProbe      time.Time
+	lastProbe       time.Time
+}
+
+// ScanStats collects per-content-type probe metrics across a single ScanForContent, Resume,
+// or ScanSpecificRange call: request counts, an HTTP status histogram, latency percentiles,
+// bytes downloaded, and the probed IDs needed to report gaps and suspicious slugs afterward.
+// A Scanner owns exactly one ScanStats for its lifetime (see Scanner.Stats), so repeated
+// scans against the same Scanner (e.g. a --watch re-export loop) accumulate into it rather
+// than starting over.
+type ScanStats struct {
+	mu    sync.Mutex
+	start time.Time
+	types map[string]*typeStats
+
+	suspicious map[string]bool
+}
+
+func newScanStats() *ScanStats {
+	return &ScanStats{
+		start:      time.Now(),
+		types:      make(map[string]*typeStats),
+		suspicious: make(map[string]bool),
+	}
+}
+
+func (s *ScanStats) typeStatsLocked(contentType string) *typeStats {
+	ts, ok := s.types[contentType]
+	if !ok {
+		ts = &typeStats{statusCounts: make(map[int]int)}
+		s.types[contentType] = ts
+	}
+	return ts
+}
+
+// record logs one probe: id is the ID that was fetched, latency how long task.Fetch took,
+// status the HTTP status code if known (0 when a transport error never got a response),
+// item the fetched value (nil for a 404), fetchErr any non-nil error Fetch returned, and
+// bytes an estimate of how much response data the probe downloaded.
+func (s *ScanStats) record(contentType string, id int, latency time.Duration, status int, item interface{}, fetchErr error, bytes int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := s.typeStatsLocked(contentType)
+	ts.probes++
+	ts.latencies = append(ts.latencies, latency)
+	ts.bytesDownloaded += bytes
+	if ts.firstProbe.IsZero() {
+		ts.firstProbe = time.Now().Add(-latency)
+	}
+	ts.lastProbe = time.Now()
+
+	if status != 0 {
+		ts.statusCounts[status]++
+	}
+
+	switch {
+	case fetchErr != nil && status == 0:
+		ts.transportErrors++
+	case isNilItem(item):
+		ts.notFound++
+		ts.missIDs = append(ts.missIDs, id)
+	default:
+		ts.hits++
+		ts.hitIDs = append(ts.hitIDs, id)
+		s.recordSlug(item)
+	}
+}
+
+// isNilItem reports whether item is untyped nil or a typed nil pointer wrapped in an
+// interface{} (e.g. a (*models.WordPressPost)(nil) returned by a 404 and implicitly
+// converted to interface{} by task.Fetch's signature) - a plain `item == nil` check misses
+// the latter, since a non-nil interface can still wrap a nil pointer.
+func isNilItem(item interface{}) bool {
+	if item == nil {
+		return true
+	}
+	v := reflect.ValueOf(item)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// recordSlug flags item's slug as suspicious when it matches suspiciousSlugPattern or
+// contains a non-printable character - either a sign the brute force scan turned up a
+// WordPress internal (an autosave, a revision) rather than real published content.
+func (s *ScanStats) recordSlug(item interface{}) {
+	var slug string
+	switch v := item.(type) {
+	case *models.WordPressPost:
+		if v == nil {
+			return
+		}
+		slug = v.Slug
+	case *models.WordPressMedia:
+		if v == nil {
+			return
+		}
+		slug = v.Slug
+	default:
+		return
+	}
+
+	if slug == "" {
+		return
+	}
+	if suspiciousSlugPattern.MatchString(slug) || !isPrintableASCII(slug) {
+		s.suspicious[slug] = true
+	}
+}
+
+func isPrintableASCII(slug string) bool {
+	for _, r := range slug {
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// percentiles returns ts's p50/p95/p99 latency using the nearest-rank method over every
+// recorded sample. This is an exact, sorted-sample calculation rather than a streaming
+// t-digest/HDR histogram approximation - simple, and accurate at the request volumes a
+// brute force scan actually produces.
+func (ts *typeStats) percentiles() (p50, p95, p99 time.Duration) {
+	if len(ts.latencies) == 0 {
+		return 0, 0, 0
+	}
+	sorted := make([]time.Duration, len(ts.latencies))
+	copy(sorted, ts.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) time.Duration {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= len(sorted) {
+			idx = len(sorted) - 1
+		}
+		return sorted[idx]
+	}
+	return rank(0.50), rank(0.95), rank(0.99)
+}
+
+// gaps groups ts's missed IDs into contiguous [start, end] ranges, in ascending ID order - each
+// range is a run of IDs that were requested but came back empty, a candidate for deleted or
+// never-published content sitting between real posts.
+func (ts *typeStats) gaps() []IDRange {
+	if len(ts.missIDs) == 0 {
+		return nil
+	}
+	ids := make([]int, len(ts.missIDs))
+	copy(ids, ts.missIDs)
+	sort.Ints(ids)
+
+	var ranges []IDRange
+	start, end := ids[0], ids[0]
+	for _, id := range ids[1:] {
+		if id == end+1 {
+			end = id
+			continue
+		}
+		ranges = append(ranges, IDRange{Start: start, End: end})
+		start, end = id, id
+	}
+	ranges = append(ranges, IDRange{Start: start, End: end})
+	return ranges
+}
+
+// IDRange is an inclusive [Start, End] run of IDs, used to report gaps in the discovered
+// content ID sequence without listing every individual missing ID.
+type IDRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// TypeReport is one content type's section of a ScanStatsReport.
+type TypeReport struct {
+	Probes          int         `json:"probes"`
+	Hits            int         `json:"hits"`
+	NotFound        int         `json:"not_found"`
+	TransportErrors int         `json:"transport_errors"`
+	StatusCounts    map[int]int `json:"status_counts,omitempty"`
+	BytesDownloaded int64       `json:"bytes_downloaded"`
+	ElapsedSeconds  float64     `json:"elapsed_seconds"`
+	LatencyP50Ms    float64     `json:"latency_p50_ms"`
+	LatencyP95Ms    float64     `json:"latency_p95_ms"`
+	LatencyP99Ms    float64     `json:"latency_p99_ms"`
+	Gaps            []IDRange   `json:"gaps,omitempty"`
+}
+
+// ScanStatsReport is the JSON shape WriteReport writes out, and the value PrometheusText
+// renders as a text exposition.
+type ScanStatsReport struct {
+	MaxID           int                    `json:"max_id"`
+	ElapsedSeconds  float64                `json:"elapsed_seconds"`
+	Types           map[string]*TypeReport `json:"types"`
+	SuspiciousSlugs []string               `json:"suspicious_slugs,omitempty"`
+}
+
+// Report builds a ScanStatsReport from the stats accumulated so far against maxID, the
+// effective upper bound the scan was run with.
+func (s *ScanStats) Report(maxID int) *ScanStatsReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := &ScanStatsReport{
+		MaxID:          maxID,
+		ElapsedSeconds: time.Since(s.start).Seconds(),
+		Types:          make(map[string]*TypeReport, len(s.types)),
+	}
+
+	for name, ts := range s.types {
+		p50, p95, p99 := ts.percentiles()
+		elapsed := ts.lastProbe.Sub(ts.firstProbe)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		report.Types[name] = &TypeReport{
+			Probes:          ts.probes,
+			Hits:            ts.hits,
+			NotFound:        ts.notFound,
+			TransportErrors: ts.transportErrors,
+			StatusCounts:    ts.statusCounts,
+			BytesDownloaded: ts.bytesDownloaded,
+			ElapsedSeconds:  elapsed.Seconds(),
+			LatencyP50Ms:    float64(p50.Microseconds()) / 1000,
+			LatencyP95Ms:    float64(p95.Microseconds()) / 1000,
+			LatencyP99Ms:    float64(p99.Microseconds()) / 1000,
+			Gaps:            ts.gaps(),
+		}
+	}
+
+	for slug := range s.suspicious {
+		report.SuspiciousSlugs = append(report.SuspiciousSlugs, slug)
+	}
+	sort.Strings(report.SuspiciousSlugs)
+
+	return report
+}
+
+// PrometheusText renders the stats accumulated so far as a Prometheus text exposition,
+// labeled by content_type, for a CI job to scrape via a sidecar rather than parse JSON.
+func (s *ScanStats) PrometheusText(maxID int) string {
+	report := s.Report(maxID)
+
+	var b strings.Builder
+	writeGauge := func(name, help string, values map[string]float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		names := make([]string, 0, len(values))
+		for contentType := range values {
+			names = append(names, contentType)
+		}
+		sort.Strings(names)
+		for _, contentType := range names {
+			fmt.Fprintf(&b, "%s{content_type=%q} %v\n", name, contentType, values[contentType])
+		}
+	}
+
+	metrics := []struct {
+		name, help string
+		get        func(*TypeReport) float64
+	}{
+		{"wpexporter_scan_probes_total", "IDs actually requested against the site", func(t *TypeReport) float64 { return float64(t.Probes) }},
+		{"wpexporter_scan_hits_total", "Probes that found content", func(t *TypeReport) float64 { return float64(t.Hits) }},
+		{"wpexporter_scan_not_found_total", "Probes that came back 404", func(t *TypeReport) float64 { return float64(t.NotFound) }},
+		{"wpexporter_scan_transport_errors_total", "Probes that failed before getting an HTTP response", func(t *TypeReport) float64 { return float64(t.TransportErrors) }},
+		{"wpexporter_scan_bytes_downloaded_total", "Estimated response bytes downloaded", func(t *TypeReport) float64 { return float64(t.BytesDownloaded) }},
+		{"wpexporter_scan_latency_p50_ms", "Probe latency, 50th percentile", func(t *TypeReport) float64 { return t.LatencyP50Ms }},
+		{"wpexporter_scan_latency_p95_ms", "Probe latency, 95th percentile", func(t *TypeReport) float64 { return t.LatencyP95Ms }},
+		{"wpexporter_scan_latency_p99_ms", "Probe latency, 99th percentile", func(t *TypeReport) float64 { return t.LatencyP99Ms }},
+	}
+
+	for _, m := range metrics {
+		values := make(map[string]float64, len(report.Types))
+		for contentType, t := range report.Types {
+			values[contentType] = m.get(t)
+		}
+		writeGauge(m.name, m.help, values)
+	}
+
+	return b.String()
+}
+
+// WriteReport writes the stats accumulated so far, as JSON, to path - Scanner.WriteReport's
+// default destination is wpexporter_scan_stats.json alongside the rest of the export.
+func (s *ScanStats) WriteReport(path string, maxID int) error {
+	report := s.Report(maxID)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan stats report: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create scan stats report directory: %w", err)
+		}
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,176 @@
+package bruteforce
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestScanStatsRecordCountsHitsMissesAndTransportErrors(t *testing.T) {
+	s := newScanStats()
+
+	s.record("posts", 1, 10*time.Millisecond, 200, &models.WordPressPost{ID: 1, Slug: "hello"}, nil, 42)
+	s.record("posts", 2, 5*time.Millisecond, 404, nil, nil, 0)
+	s.record("posts", 3, 1*time.Millisecond, 0, nil, errors.New("dial tcp: connection refused"), 0)
+
+	report := s.Report(10)
+	posts, ok := report.Types["posts"]
+	if !ok {
+		t.Fatalf("Report().Types[\"posts\"] missing, got %+v", report.Types)
+	}
+	if posts.Probes != 3 || posts.Hits != 1 || posts.NotFound != 1 || posts.TransportErrors != 1 {
+		t.Errorf("posts stats = %+v, want Probes=3 Hits=1 NotFound=1 TransportErrors=1", posts)
+	}
+	if posts.StatusCounts[200] != 1 || posts.StatusCounts[404] != 1 {
+		t.Errorf("posts StatusCounts = %v, want {200:1, 404:1}", posts.StatusCounts)
+	}
+	if posts.BytesDownloaded != 42 {
+		t.Errorf("posts BytesDownloaded = %d, want 42", posts.BytesDownloaded)
+	}
+}
+
+func TestScanStatsRecordIgnoresTypedNilItem(t *testing.T) {
+	s := newScanStats()
+
+	var nilPost *models.WordPressPost
+	s.record("posts", 1, time.Millisecond, 404, nilPost, nil, 0)
+
+	report := s.Report(10)
+	posts := report.Types["posts"]
+	if posts.Hits != 0 || posts.NotFound != 1 {
+		t.Errorf("posts stats = %+v, want a typed-nil item counted as NotFound, not a Hit", posts)
+	}
+}
+
+func TestScanStatsPercentilesOrderedCorrectly(t *testing.T) {
+	s := newScanStats()
+	for i := 1; i <= 100; i++ {
+		s.record("posts", i, time.Duration(i)*time.Millisecond, 200, &models.WordPressPost{ID: i}, nil, 0)
+	}
+
+	report := s.Report(100)
+	posts := report.Types["posts"]
+	if !(posts.LatencyP50Ms <= posts.LatencyP95Ms && posts.LatencyP95Ms <= posts.LatencyP99Ms) {
+		t.Errorf("percentiles not ordered: p50=%v p95=%v p99=%v", posts.LatencyP50Ms, posts.LatencyP95Ms, posts.LatencyP99Ms)
+	}
+	if posts.LatencyP99Ms < 90 {
+		t.Errorf("LatencyP99Ms = %v, want close to the 100ms max sample", posts.LatencyP99Ms)
+	}
+}
+
+func TestScanStatsGapsGroupsConsecutiveMisses(t *testing.T) {
+	s := newScanStats()
+	hits := map[int]bool{1: true, 5: true, 9: true}
+	for id := 1; id <= 9; id++ {
+		item := interface{}(nil)
+		if hits[id] {
+			item = &models.WordPressPost{ID: id}
+		}
+		s.record("posts", id, time.Millisecond, 0, item, nil, 0)
+	}
+
+	report := s.Report(9)
+	gaps := report.Types["posts"].Gaps
+	want := []IDRange{{Start: 2, End: 4}, {Start: 6, End: 8}}
+	if len(gaps) != len(want) {
+		t.Fatalf("Gaps = %v, want %v", gaps, want)
+	}
+	for i := range want {
+		if gaps[i] != want[i] {
+			t.Errorf("Gaps[%d] = %+v, want %+v", i, gaps[i], want[i])
+		}
+	}
+}
+
+func TestScanStatsFlagsSuspiciousSlugs(t *testing.T) {
+	s := newScanStats()
+	s.record("posts", 1, time.Millisecond, 200, &models.WordPressPost{ID: 1, Slug: "normal-post"}, nil, 0)
+	s.record("posts", 2, time.Millisecond, 200, &models.WordPressPost{ID: 2, Slug: "draft-autosave"}, nil, 0)
+	s.record("posts", 3, time.Millisecond, 200, &models.WordPressPost{ID: 3, Slug: "revision-3"}, nil, 0)
+	s.record("posts", 4, time.Millisecond, 200, &models.WordPressPost{ID: 4, Slug: "weird\x00slug"}, nil, 0)
+
+	report := s.Report(4)
+	want := []string{"draft-autosave", "revision-3", "weird\x00slug"}
+	if len(report.SuspiciousSlugs) != len(want) {
+		t.Fatalf("SuspiciousSlugs = %v, want %v", report.SuspiciousSlugs, want)
+	}
+	for _, slug := range want {
+		found := false
+		for _, got := range report.SuspiciousSlugs {
+			if got == slug {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("SuspiciousSlugs = %v, want to include %q", report.SuspiciousSlugs, slug)
+		}
+	}
+}
+
+func TestScanStatsWriteReportWritesValidJSON(t *testing.T) {
+	s := newScanStats()
+	s.record("posts", 1, time.Millisecond, 200, &models.WordPressPost{ID: 1, Slug: "hello"}, nil, 10)
+
+	path := filepath.Join(t.TempDir(), "nested", "wpexporter_scan_stats.json")
+	if err := s.WriteReport(path, 100); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var report ScanStatsReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if report.MaxID != 100 || report.Types["posts"].Hits != 1 {
+		t.Errorf("report = %+v, want MaxID=100 and posts.Hits=1", report)
+	}
+}
+
+func TestScanStatsPrometheusTextIncludesContentTypeLabels(t *testing.T) {
+	s := newScanStats()
+	s.record("posts", 1, time.Millisecond, 200, &models.WordPressPost{ID: 1}, nil, 0)
+
+	text := s.PrometheusText(10)
+	if !strings.Contains(text, `wpexporter_scan_hits_total{content_type="posts"} 1`) {
+		t.Errorf("PrometheusText() = %q, want a wpexporter_scan_hits_total sample for posts", text)
+	}
+	if !strings.Contains(text, "# HELP wpexporter_scan_probes_total") {
+		t.Errorf("PrometheusText() = %q, want a HELP line for wpexporter_scan_probes_total", text)
+	}
+}
+
+func TestScanForContentPopulatesStatsAndWriteReport(t *testing.T) {
+	server := newTestSiteServer(t, map[int]bool{2: true, 4: true}, nil, nil)
+	cfg := &config.Config{BruteForce: true, MaxID: 5, Concurrent: 2}
+	scanner := newTestScanner(t, cfg, server)
+
+	if _, err := scanner.ScanForContent(nil, nil, nil); err != nil {
+		t.Fatalf("ScanForContent() error = %v", err)
+	}
+
+	posts := scanner.Stats().Report(cfg.MaxID).Types["posts"]
+	if posts == nil {
+		t.Fatal("Stats().Report().Types[\"posts\"] is nil after a posts scan")
+	}
+	if posts.Probes != cfg.MaxID || posts.Hits != 2 || posts.NotFound != 3 {
+		t.Errorf("posts stats = %+v, want Probes=5 Hits=2 NotFound=3", posts)
+	}
+
+	path := filepath.Join(t.TempDir(), "wpexporter_scan_stats.json")
+	if err := scanner.WriteReport(path); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("WriteReport() didn't create %s: %v", path, err)
+	}
+}
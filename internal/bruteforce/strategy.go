@@ -0,0 +1,177 @@
+package bruteforce
+
+import "context"
+
+// peekFunc reports whether id exists, without the caller needing to know how that's
+// determined - runTask wires it to the current ScanTask's Fetch, rate-limited the same as
+// the dense scan that follows.
+type peekFunc func(ctx context.Context, id int) (bool, error)
+
+// Strategy decides which IDs in [minID, maxID] are worth scanning densely for a content
+// type, replacing a flat sweep over every ID. Implementations may call peek to explore the
+// ID space first (e.g. binary-searching for where content actually ends) before deciding
+// what to return.
+type Strategy interface {
+	Plan(ctx context.Context, minID, maxID int, peek peekFunc) ([]int, error)
+}
+
+// LinearStrategy returns every ID in [minID, maxID] unchanged, spending no peek calls up
+// front - the long-standing default behavior, best for sites where MaxID is already a tight
+// bound on how much content exists.
+type LinearStrategy struct{}
+
+func (LinearStrategy) Plan(_ context.Context, minID, maxID int, _ peekFunc) ([]int, error) {
+	return idRange(minID, maxID), nil
+}
+
+func idRange(minID, maxID int) []int {
+	if maxID < minID {
+		return nil
+	}
+	ids := make([]int, 0, maxID-minID+1)
+	for id := minID; id <= maxID; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ExponentialProbeStrategy probes IDs at minID, minID+1, minID+2, minID+4, minID+8, ...
+// (doubling the stride each hit) until peek reports a miss, then binary-searches between
+// the last hit and first miss to find the true upper bound, and finally returns only
+// [minID, upperBound] for dense scanning - typically cutting scan time dramatically on
+// sites where maxID is a gross overestimate of how much content actually exists.
+type ExponentialProbeStrategy struct{}
+
+func (ExponentialProbeStrategy) Plan(ctx context.Context, minID, maxID int, peek peekFunc) ([]int, error) {
+	if maxID < minID {
+		return nil, nil
+	}
+
+	lastHit := minID - 1
+	firstMiss := maxID + 1
+	offset := 0
+	for probe := minID; probe <= maxID; {
+		ok, err := peek(ctx, probe)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			firstMiss = probe
+			break
+		}
+		lastHit = probe
+
+		if offset == 0 {
+			offset = 1
+		} else {
+			offset *= 2
+		}
+		probe = minID + offset
+	}
+
+	if firstMiss > maxID {
+		// Every doubling probe hit all the way to maxID - the whole range may hold
+		// content, so there's nothing to narrow.
+		return idRange(minID, maxID), nil
+	}
+	if lastHit < minID {
+		// The very first probe missed - nothing beyond minID itself is worth a dense scan.
+		return idRange(minID, minID), nil
+	}
+
+	lo, hi := lastHit, firstMiss
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		ok, err := peek(ctx, mid)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return idRange(minID, lo), nil
+}
+
+// defaultSparseWindowSize is how many recent probes SparseSamplingStrategy's rolling hit
+// rate is computed over.
+const defaultSparseWindowSize = 500
+
+// defaultSparseMinHitRate is the rolling hit rate below which SparseSamplingStrategy starts
+// skipping ahead instead of probing every ID.
+const defaultSparseMinHitRate = 0.02
+
+// SparseSamplingStrategy scans densely until a rolling window of the last WindowSize probes
+// has a hit rate below MinHitRate, then skips ahead by a stride that doubles on each
+// consecutive miss until it finds another hit, at which point it resumes dense scanning
+// from there. It suits large, sparse ID spaces where content clusters in pockets separated
+// by long stretches of deleted or never-used IDs.
+type SparseSamplingStrategy struct {
+	// WindowSize is how many recent probes the rolling hit rate is computed over. Defaults
+	// to defaultSparseWindowSize when <= 0.
+	WindowSize int
+	// MinHitRate is the rolling hit rate (0-1) below which scanning switches to skip-ahead
+	// mode. Defaults to defaultSparseMinHitRate when <= 0.
+	MinHitRate float64
+}
+
+func (s SparseSamplingStrategy) Plan(ctx context.Context, minID, maxID int, peek peekFunc) ([]int, error) {
+	if maxID < minID {
+		return nil, nil
+	}
+
+	window := s.WindowSize
+	if window <= 0 {
+		window = defaultSparseWindowSize
+	}
+	minRate := s.MinHitRate
+	if minRate <= 0 {
+		minRate = defaultSparseMinHitRate
+	}
+
+	var ids []int
+	var recent []bool
+	hits := 0
+	stride := 1
+
+	for id := minID; id <= maxID; {
+		if err := ctx.Err(); err != nil {
+			return ids, err
+		}
+
+		ok, err := peek(ctx, id)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+
+		recent = append(recent, ok)
+		if ok {
+			hits++
+		}
+		if len(recent) > window {
+			if recent[0] {
+				hits--
+			}
+			recent = recent[1:]
+		}
+
+		if len(recent) >= window && float64(hits)/float64(len(recent)) < minRate {
+			if ok {
+				stride = 1
+			} else {
+				stride *= 2
+			}
+			id += stride
+			continue
+		}
+
+		stride = 1
+		id++
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,150 @@
+package bruteforce
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeExisting backs a peekFunc with a fixed set of "existing" IDs, tracking how many times
+// each ID was probed so tests can assert a strategy isn't over- or under-probing.
+type fakeExisting struct {
+	exists map[int]bool
+	calls  map[int]int
+}
+
+func newFakeExisting(existing ...int) *fakeExisting {
+	f := &fakeExisting{exists: make(map[int]bool), calls: make(map[int]int)}
+	for _, id := range existing {
+		f.exists[id] = true
+	}
+	return f
+}
+
+func (f *fakeExisting) peek(_ context.Context, id int) (bool, error) {
+	f.calls[id]++
+	return f.exists[id], nil
+}
+
+func TestLinearStrategyReturnsFullRangeWithoutPeeking(t *testing.T) {
+	f := newFakeExisting()
+	ids, err := LinearStrategy{}.Plan(context.Background(), 5, 8, f.peek)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if want := []int{5, 6, 7, 8}; !intSliceEqual(ids, want) {
+		t.Errorf("Plan() = %v, want %v", ids, want)
+	}
+	if len(f.calls) != 0 {
+		t.Errorf("Plan() called peek %d times, want 0", len(f.calls))
+	}
+}
+
+func TestExponentialProbeStrategyNarrowsToTrueUpperBound(t *testing.T) {
+	f := newFakeExisting(1, 2, 3, 4, 5, 6, 7)
+	ids, err := ExponentialProbeStrategy{}.Plan(context.Background(), 1, 1000, f.peek)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7}; !intSliceEqual(ids, want) {
+		t.Errorf("Plan() = %v, want %v", ids, want)
+	}
+}
+
+func TestExponentialProbeStrategyEntireRangeHits(t *testing.T) {
+	f := newFakeExisting(1, 2, 3, 4, 5)
+	ids, err := ExponentialProbeStrategy{}.Plan(context.Background(), 1, 5, f.peek)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !intSliceEqual(ids, want) {
+		t.Errorf("Plan() = %v, want %v (every doubling probe hit, nothing to narrow)", ids, want)
+	}
+}
+
+func TestExponentialProbeStrategyFirstProbeMisses(t *testing.T) {
+	f := newFakeExisting()
+	ids, err := ExponentialProbeStrategy{}.Plan(context.Background(), 10, 100, f.peek)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if want := []int{10}; !intSliceEqual(ids, want) {
+		t.Errorf("Plan() = %v, want %v (minID itself missed, nothing beyond it worth a dense scan)", ids, want)
+	}
+}
+
+func TestExponentialProbeStrategyPropagatesPeekError(t *testing.T) {
+	boom := errors.New("boom")
+	peek := func(context.Context, int) (bool, error) { return false, boom }
+	if _, err := (ExponentialProbeStrategy{}).Plan(context.Background(), 1, 10, peek); !errors.Is(err, boom) {
+		t.Errorf("Plan() error = %v, want %v", err, boom)
+	}
+}
+
+func TestSparseSamplingStrategyProbesEveryIDBeforeThresholdReached(t *testing.T) {
+	f := newFakeExisting(1, 2, 3, 4, 5)
+	s := SparseSamplingStrategy{WindowSize: 10, MinHitRate: 0.5}
+	ids, err := s.Plan(context.Background(), 1, 5, f.peek)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !intSliceEqual(ids, want) {
+		t.Errorf("Plan() = %v, want %v (window never fills, so every ID is probed densely)", ids, want)
+	}
+}
+
+func TestSparseSamplingStrategySkipsAheadOnceBelowThreshold(t *testing.T) {
+	// IDs 1-3 exist; everything from 4 on is empty. With a tiny window/threshold, the
+	// strategy should fall into skip-ahead mode well before scanning all the way to 1000,
+	// so it must probe far fewer IDs than a linear scan would, with a growing gap between
+	// consecutive probed IDs once skipping starts.
+	f := newFakeExisting(1, 2, 3)
+	s := SparseSamplingStrategy{WindowSize: 4, MinHitRate: 0.5}
+	ids, err := s.Plan(context.Background(), 1, 1000, f.peek)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(ids) >= 100 {
+		t.Errorf("Plan() probed %d IDs, want far fewer than the full 1000-ID range once skip-ahead kicks in", len(ids))
+	}
+	lastGap := ids[len(ids)-1] - ids[len(ids)-2]
+	if lastGap <= 1 {
+		t.Errorf("Plan() ended with consecutive probes %d apart, want a growing skip-ahead stride", lastGap)
+	}
+}
+
+func TestSparseSamplingStrategyResumesDenseScanOnHit(t *testing.T) {
+	// A single hit deep in a sparse region should reset the stride to 1, so the very next ID
+	// is probed densely rather than skipped.
+	f := newFakeExisting(4)
+	s := SparseSamplingStrategy{WindowSize: 2, MinHitRate: 0.5}
+	ids, err := s.Plan(context.Background(), 1, 10, f.peek)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	hitAt := -1
+	for i, id := range ids {
+		if id == 4 {
+			hitAt = i
+			break
+		}
+	}
+	if hitAt < 0 {
+		t.Fatalf("Plan() never probed ID 4, ids = %v", ids)
+	}
+	if hitAt+1 >= len(ids) || ids[hitAt+1] != 5 {
+		t.Errorf("Plan() probed %v after the hit at 4, want the very next ID 5 (stride reset)", ids)
+	}
+}
+
+func intSliceEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+package bruteforce
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tradik/wpexporter/internal/api"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// ScanTask is one content type's brute force discovery target. Name identifies it to
+// api.BruteForceContentWithOptions (as the contentType string), the progress bar, and the
+// resume checkpoint; Fetch retrieves a single ID (nil, nil for "doesn't exist"); Store
+// records a found item and returns its ID, so the shared taskRunner can track checkpoint
+// progress without needing to know the concrete item type.
+type ScanTask interface {
+	Name() string
+	Fetch(ctx context.Context, id int) (interface{}, error)
+	Store(item interface{}) int
+}
+
+// BatchScanTask is a ScanTask that can also resolve a whole batch of IDs in one REST call
+// (WP REST's `?include=id1,id2,...` filter), rather than one GET per ID. FetchBatch returns
+// a map keyed by the IDs it actually found - IDs from the request that are absent from the
+// result are "gaps" (either truly missing, or private/inaccessible to this client) and are
+// left for the caller to resolve one at a time via the embedded ScanTask's Fetch, the same
+// way a 404 always has been.
+type BatchScanTask interface {
+	ScanTask
+	FetchBatch(ctx context.Context, ids []int) (map[int]interface{}, error)
+}
+
+// postsTask, pagesTask, and mediaTask are the built-in ScanTask implementations that
+// scanPosts/scanPages/scanMedia used to duplicate by hand.
+
+type postsTask struct {
+	client *api.Client
+	found  []models.WordPressPost
+}
+
+func (t *postsTask) Name() string { return "posts" }
+
+func (t *postsTask) Fetch(ctx context.Context, id int) (interface{}, error) {
+	return t.client.GetPostByIDContext(ctx, id)
+}
+
+func (t *postsTask) Store(item interface{}) int {
+	post := item.(*models.WordPressPost)
+	t.found = append(t.found, *post)
+	return post.ID
+}
+
+func (t *postsTask) FetchBatch(ctx context.Context, ids []int) (map[int]interface{}, error) {
+	posts, err := t.client.GetPostsByIDsContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int]interface{}, len(posts))
+	for i := range posts {
+		result[posts[i].ID] = &posts[i]
+	}
+	return result, nil
+}
+
+type pagesTask struct {
+	client *api.Client
+	found  []models.WordPressPost
+}
+
+func (t *pagesTask) Name() string { return "pages" }
+
+func (t *pagesTask) Fetch(ctx context.Context, id int) (interface{}, error) {
+	return t.client.GetPageByIDContext(ctx, id)
+}
+
+func (t *pagesTask) Store(item interface{}) int {
+	page := item.(*models.WordPressPost)
+	t.found = append(t.found, *page)
+	return page.ID
+}
+
+func (t *pagesTask) FetchBatch(ctx context.Context, ids []int) (map[int]interface{}, error) {
+	pages, err := t.client.GetPagesByIDsContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int]interface{}, len(pages))
+	for i := range pages {
+		result[pages[i].ID] = &pages[i]
+	}
+	return result, nil
+}
+
+type mediaTask struct {
+	client *api.Client
+	found  []models.WordPressMedia
+}
+
+func (t *mediaTask) Name() string { return "media" }
+
+func (t *mediaTask) Fetch(ctx context.Context, id int) (interface{}, error) {
+	return t.client.GetMediaByIDContext(ctx, id)
+}
+
+func (t *mediaTask) Store(item interface{}) int {
+	media := item.(*models.WordPressMedia)
+	t.found = append(t.found, *media)
+	return media.ID
+}
+
+func (t *mediaTask) FetchBatch(ctx context.Context, ids []int) (map[int]interface{}, error) {
+	media, err := t.client.GetMediaByIDsContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int]interface{}, len(media))
+	for i := range media {
+		result[media[i].ID] = &media[i]
+	}
+	return result, nil
+}
+
+// usersTask and commentsTask are ScanTask implementations for WordPress user and comment
+// IDs. No Scanner method constructs or runs them: scan runs only posts, pages, and media.
+
+type usersTask struct {
+	client *api.Client
+	found  []models.WordPressUser
+}
+
+func (t *usersTask) Name() string { return "users" }
+
+func (t *usersTask) Fetch(ctx context.Context, id int) (interface{}, error) {
+	return t.client.GetUserByIDContext(ctx, id)
+}
+
+func (t *usersTask) Store(item interface{}) int {
+	user := item.(*models.WordPressUser)
+	t.found = append(t.found, *user)
+	return user.ID
+}
+
+type commentsTask struct {
+	client *api.Client
+	found  []models.WordPressComment
+}
+
+func (t *commentsTask) Name() string { return "comments" }
+
+func (t *commentsTask) Fetch(ctx context.Context, id int) (interface{}, error) {
+	return t.client.GetCommentByIDContext(ctx, id)
+}
+
+func (t *commentsTask) Store(item interface{}) int {
+	comment := item.(*models.WordPressComment)
+	t.found = append(t.found, *comment)
+	return comment.ID
+}
+
+// customPostTypeTask brute forces one custom post type, discovered via api.Client's
+// GetPostTypesContext. Its Name is the post type's own REST base (e.g. "products") so each
+// CPT gets its own checkpoint entry and progress bar, distinct from "posts"/"pages".
+type customPostTypeTask struct {
+	client   *api.Client
+	restBase string
+	found    []models.WordPressPost
+}
+
+func (t *customPostTypeTask) Name() string { return t.restBase }
+
+func (t *customPostTypeTask) Fetch(ctx context.Context, id int) (interface{}, error) {
+	return t.client.GetCustomPostByIDContext(ctx, t.restBase, id)
+}
+
+func (t *customPostTypeTask) Store(item interface{}) int {
+	post := item.(*models.WordPressPost)
+	t.found = append(t.found, *post)
+	return post.ID
+}
+
+// TaskFactory builds a ScanTask bound to client. Third-party code registers one via
+// RegisterTask, and Scanner.ExtraTasks builds it; ScanForContent and Resume do not run
+// registered tasks.
+type TaskFactory func(client *api.Client) ScanTask
+
+var registeredTasks = map[string]TaskFactory{}
+
+// RegisterTask adds a TaskFactory under name, so a later Scanner.ExtraTasks() call builds
+// it. Registering under a name that's already registered replaces the previous factory.
+func RegisterTask(name string, factory TaskFactory) {
+	registeredTasks[name] = factory
+}
+
+// ExtraTasks builds every ScanTask registered via RegisterTask, bound to this Scanner's API
+// client. Built-in tasks (posts, pages, media, users, comments) aren't included here. The
+// returned tasks are only built; no exported Scanner method scans them.
+func (s *Scanner) ExtraTasks() []ScanTask {
+	tasks := make([]ScanTask, 0, len(registeredTasks))
+	for _, factory := range registeredTasks {
+		tasks = append(tasks, factory(s.apiClient))
+	}
+	return tasks
+}
+
+// CustomPostTypeTasks discovers every non-built-in post type registered with the site (via
+// GetPostTypesContext) and returns a ScanTask for each, keyed by its REST base.
+func (s *Scanner) CustomPostTypeTasks(ctx context.Context) ([]ScanTask, error) {
+	types, err := s.apiClient.GetPostTypesContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post types: %w", err)
+	}
+
+	var tasks []ScanTask
+	for slug, pt := range types {
+		switch slug {
+		case "post", "page", "attachment":
+			continue // already covered by postsTask/pagesTask/mediaTask
+		}
+		restBase := pt.RestBase
+		if restBase == "" {
+			restBase = slug
+		}
+		tasks = append(tasks, &customPostTypeTask{client: s.apiClient, restBase: restBase})
+	}
+	return tasks, nil
+}
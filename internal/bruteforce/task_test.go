@@ -0,0 +1,95 @@
+package bruteforce
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/api"
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+type stubTask struct {
+	name  string
+	found []int
+}
+
+func (t *stubTask) Name() string { return t.name }
+
+func (t *stubTask) Fetch(_ context.Context, id int) (interface{}, error) {
+	return id, nil
+}
+
+func (t *stubTask) Store(item interface{}) int {
+	id := item.(int)
+	t.found = append(t.found, id)
+	return id
+}
+
+type stubBatchTask struct {
+	stubTask
+	batches [][]int
+	gaps    map[int]bool
+}
+
+func (t *stubBatchTask) FetchBatch(_ context.Context, ids []int) (map[int]interface{}, error) {
+	t.batches = append(t.batches, ids)
+	result := make(map[int]interface{})
+	for _, id := range ids {
+		if !t.gaps[id] {
+			result[id] = id
+		}
+	}
+	return result, nil
+}
+
+func TestDiscoverBatchMarksFoundIDsAndLeavesGaps(t *testing.T) {
+	task := &stubBatchTask{stubTask: stubTask{name: "stub"}, gaps: map[int]bool{2: true}}
+	existingIDs := map[int]bool{}
+
+	s := &Scanner{config: &config.Config{BatchSize: 2}}
+	foundIDs := s.discoverBatch(context.Background(), task, 1, 3, nil, existingIDs)
+
+	if len(foundIDs) != 2 {
+		t.Fatalf("discoverBatch() foundIDs = %v, want 2 ids (1 and 3)", foundIDs)
+	}
+	if !existingIDs[1] || existingIDs[2] || !existingIDs[3] {
+		t.Errorf("existingIDs = %v, want {1:true, 3:true} and no entry for gap id 2", existingIDs)
+	}
+	if len(task.found) != 2 {
+		t.Errorf("task.found = %v, want 2 stored items", task.found)
+	}
+}
+
+func TestDiscoverBatchChunksByBatchSize(t *testing.T) {
+	task := &stubBatchTask{stubTask: stubTask{name: "stub"}, gaps: map[int]bool{}}
+	s := &Scanner{config: &config.Config{BatchSize: 2}}
+
+	s.discoverBatch(context.Background(), task, 1, 5, nil, map[int]bool{})
+
+	if len(task.batches) != 3 {
+		t.Fatalf("discoverBatch() issued %d batch requests, want 3 for ids 1-5 at batch size 2", len(task.batches))
+	}
+	if len(task.batches[0]) != 2 || len(task.batches[1]) != 2 || len(task.batches[2]) != 1 {
+		t.Errorf("discoverBatch() batches = %v, want sizes [2 2 1]", task.batches)
+	}
+}
+
+func TestRegisterTaskAddsToExtraTasks(t *testing.T) {
+	RegisterTask("stub", func(client *api.Client) ScanTask {
+		return &stubTask{name: "stub"}
+	})
+	defer delete(registeredTasks, "stub")
+
+	s := &Scanner{}
+	tasks := s.ExtraTasks()
+
+	found := false
+	for _, task := range tasks {
+		if task.Name() == "stub" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("ExtraTasks() should include a task registered via RegisterTask")
+	}
+}
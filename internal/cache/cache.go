@@ -0,0 +1,188 @@
+// Package cache provides a content-addressed, TTL-expiring file cache used to avoid
+// re-fetching content that hasn't changed since a previous export run. It's patterned
+// after Hugo's filecache: a root directory holding one subdirectory per named sub-cache
+// (e.g. "posts", "media"), entries keyed by an opaque string (typically Key's hash),
+// each write made atomically so a crash mid-write can never leave a corrupt entry, and a
+// per-key mutex so concurrent writers to the same key serialize instead of corrupting
+// each other while writers to different keys run unblocked.
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is a root directory of named sub-caches, each a flat directory of entries keyed
+// by an opaque string. A zero TTL disables expiry: entries are kept until GC'd or
+// overwritten.
+type Cache struct {
+	root string
+	ttl  time.Duration
+
+	locks keyedMutex
+}
+
+// New returns a Cache rooted at root, expiring entries after ttl by default (Get and GC
+// callers may override ttl per sub-cache). root is created on first write, not by New.
+func New(root string, ttl time.Duration) *Cache {
+	return &Cache{root: root, ttl: ttl}
+}
+
+// Key hashes parts into a single opaque cache key, joined by "|" before hashing so e.g.
+// Key("url", "1", "2024-05-01") and Key("url1", "12024-05-01") never collide.
+func Key(parts ...string) string {
+	h := sha1.New()
+	h.Write([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// path returns the on-disk path of sub's entry for key.
+func (c *Cache) path(sub, key string) string {
+	return filepath.Join(c.root, sub, key)
+}
+
+// Get reads sub's entry for key. ok is false if the entry doesn't exist or is older than
+// ttl (the Cache's default ttl is used when ttl is zero). A ttl of zero on a Cache with no
+// default means entries never expire on their own.
+func (c *Cache) Get(sub, key string, ttl time.Duration) (data []byte, ok bool, err error) {
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+
+	path := c.path(sub, key)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to stat cache entry %s: %w", path, err)
+	}
+
+	if ttl > 0 && time.Since(info.ModTime()) > ttl {
+		return nil, false, nil
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache entry %s: %w", path, err)
+	}
+	return data, true, nil
+}
+
+// Set writes sub's entry for key, creating sub's directory if needed. The write is
+// atomic: data lands in a temp file in the same directory, then is renamed into place, so
+// a concurrent Get never observes a partial write.
+func (c *Cache) Set(sub, key string, data []byte) error {
+	unlock := c.locks.Lock(sub + "/" + key)
+	defer unlock()
+
+	dir := filepath.Join(c.root, sub)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create cache dir %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, key+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write cache entry %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to close cache temp file %s: %w", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, filepath.Join(dir, key)); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to commit cache entry %s: %w", key, err)
+	}
+	return nil
+}
+
+// GC walks every sub-cache under root and removes entries whose ttl (the sub-cache's
+// override, falling back to the Cache's default) has elapsed since they were last
+// written, returning how many entries it evicted. ttls maps sub-cache name to its TTL
+// override; a sub-cache absent from ttls uses the Cache's default.
+func (c *Cache) GC(ttls map[string]time.Duration) (int, error) {
+	subs, err := os.ReadDir(c.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read cache root %s: %w", c.root, err)
+	}
+
+	evicted := 0
+	for _, sub := range subs {
+		if !sub.IsDir() {
+			continue
+		}
+
+		ttl := c.ttl
+		if override, ok := ttls[sub.Name()]; ok {
+			ttl = override
+		}
+		if ttl <= 0 {
+			continue
+		}
+
+		subDir := filepath.Join(c.root, sub.Name())
+		entries, err := os.ReadDir(subDir)
+		if err != nil {
+			return evicted, fmt.Errorf("failed to read sub-cache %s: %w", subDir, err)
+		}
+
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			if time.Since(info.ModTime()) <= ttl {
+				continue
+			}
+			if err := os.Remove(filepath.Join(subDir, entry.Name())); err != nil {
+				return evicted, fmt.Errorf("failed to evict cache entry %s: %w", entry.Name(), err)
+			}
+			evicted++
+		}
+	}
+
+	return evicted, nil
+}
+
+// keyedMutex is a dynamically growing set of named mutexes, so concurrent Sets to
+// different keys don't block each other while Sets to the SAME key serialize.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// Lock acquires the mutex for key, creating it on first use, and returns a function that
+// releases it.
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*sync.Mutex)
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		k.locks[key] = l
+	}
+	k.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
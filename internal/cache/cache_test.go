@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetAndGetRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	c := New(t.TempDir(), time.Hour)
+
+	if err := c.Set("posts", "abc", []byte("hello")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	data, ok, err := c.Get("posts", "abc", 0)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if string(data) != "hello" {
+		t.Errorf("Get() data = %q, want %q", data, "hello")
+	}
+}
+
+func TestGetMissingEntry(t *testing.T) {
+	t.Parallel()
+
+	c := New(t.TempDir(), time.Hour)
+
+	_, ok, err := c.Get("posts", "missing", 0)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if ok {
+		t.Error("Get() ok = true for a missing entry, want false")
+	}
+}
+
+func TestGetExpiresPastTTL(t *testing.T) {
+	t.Parallel()
+
+	c := New(t.TempDir(), time.Millisecond)
+
+	if err := c.Set("media", "key", []byte("data")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, err := c.Get("media", "key", 0)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if ok {
+		t.Error("Get() ok = true for an expired entry, want false")
+	}
+}
+
+func TestGetTTLOverrideTakesPrecedenceOverDefault(t *testing.T) {
+	t.Parallel()
+
+	c := New(t.TempDir(), time.Hour)
+
+	if err := c.Set("media", "key", []byte("data")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, err := c.Get("media", "key", time.Millisecond)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if ok {
+		t.Error("Get() ok = true past an overridden ttl, want false")
+	}
+}
+
+func TestKeyIsStableAndUnambiguous(t *testing.T) {
+	t.Parallel()
+
+	if Key("a", "b") != Key("a", "b") {
+		t.Error("Key() is not stable for identical input")
+	}
+	if Key("a", "b") == Key("ab") {
+		t.Error("Key(\"a\", \"b\") collided with Key(\"ab\"), want distinct hashes")
+	}
+}
+
+func TestGCEvictsOnlyExpiredEntries(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	c := New(root, time.Hour)
+
+	if err := c.Set("posts", "fresh", []byte("1")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := c.Set("posts", "stale", []byte("2")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	stalePath := filepath.Join(root, "posts", "stale")
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(stalePath, old, old); err != nil {
+		t.Fatalf("failed to backdate stale entry: %v", err)
+	}
+
+	evicted, err := c.GC(nil)
+	if err != nil {
+		t.Fatalf("GC() error = %v", err)
+	}
+	if evicted != 1 {
+		t.Fatalf("GC() evicted = %d, want 1", evicted)
+	}
+
+	if _, ok, _ := c.Get("posts", "fresh", 0); !ok {
+		t.Error("GC() evicted the fresh entry, want it kept")
+	}
+	if _, ok, _ := c.Get("posts", "stale", 0); ok {
+		t.Error("GC() kept the stale entry, want it evicted")
+	}
+}
+
+func TestGCHonorsPerSubCacheOverride(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	c := New(root, 0) // no default TTL: nothing expires unless overridden
+
+	if err := c.Set("media", "key", []byte("data")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(root, "media", "key"), old, old); err != nil {
+		t.Fatalf("failed to backdate entry: %v", err)
+	}
+
+	evicted, err := c.GC(map[string]time.Duration{"media": time.Minute})
+	if err != nil {
+		t.Fatalf("GC() error = %v", err)
+	}
+	if evicted != 1 {
+		t.Fatalf("GC() evicted = %d, want 1", evicted)
+	}
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -9,6 +11,7 @@ import (
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/tradik/wpexporter/internal/plugin"
 )
 
 // Config represents the application configuration
@@ -24,6 +27,479 @@ type Config struct {
 	Retries       int    `mapstructure:"retries" json:"retries"`
 	UserAgent     string `mapstructure:"user_agent" json:"user_agent"`
 	Verbose       bool   `mapstructure:"verbose" json:"verbose"`
+
+	// Concurrency controls the worker pool size used by BruteForceContent's ID
+	// enumeration (falling back to Concurrent, then a single worker, when zero) and by
+	// the markdown exporter's per-post worker pool (see Exporter.exportConcurrency),
+	// which falls back to runtime.NumCPU() instead since it isn't network-bound.
+	Concurrency int `mapstructure:"concurrency" json:"concurrency"`
+	// Force bypasses exportMarkdownPlain's incremental-export manifest (see
+	// export.ExportManifest), rewriting every post/page even when its content hash hasn't
+	// changed since the previous run.
+	Force bool `mapstructure:"force" json:"force"`
+	// Prune removes any previously-exported post/page file not present in the current
+	// export set - e.g. one deleted in WordPress since the last run - once
+	// exportMarkdownPlain's incremental manifest shows it's gone.
+	Prune bool `mapstructure:"prune" json:"prune"`
+	// StopAfterConsecutiveMisses ends a brute force scan early once this many
+	// probes in a row come back empty. Zero disables the adaptive cutoff and
+	// scans through MaxID unconditionally.
+	StopAfterConsecutiveMisses int `mapstructure:"stop_after_consecutive_misses" json:"stop_after_consecutive_misses"`
+	// RateLimit is the brute force scanner's starting requests-per-second, shared across
+	// its posts/pages/media worker pools via a single api.RateLimiter. Zero falls back to
+	// the limiter's own default.
+	RateLimit float64 `mapstructure:"rate_limit" json:"rate_limit"`
+	// MaxQPS caps how high the AIMD limiter may climb back to after throttling eases.
+	// Zero means it never recovers past RateLimit.
+	MaxQPS float64 `mapstructure:"max_qps" json:"max_qps"`
+	// RespectRetryAfter honors a throttled response's Retry-After header verbatim as the
+	// limiter's backoff, instead of the limiter's own computed exponential backoff.
+	RespectRetryAfter bool `mapstructure:"respect_retry_after" json:"respect_retry_after"`
+
+	// Auth selects and configures the authenticator used for private-content
+	// access. AuthType is one of "", "app_password", "oauth2", "jwt", "oauth1", or "nonce".
+	AuthType          string `mapstructure:"auth_type" json:"auth_type"`
+	Username          string `mapstructure:"username" json:"username"`
+	AppPassword       string `mapstructure:"app_password" json:"app_password"`
+	Token             string `mapstructure:"token" json:"token"`
+	OAuthClientID     string `mapstructure:"oauth_client_id" json:"oauth_client_id"`
+	OAuthClientSecret string `mapstructure:"oauth_client_secret" json:"oauth_client_secret"`
+	// JWTToken lets the caller supply an already-obtained JWT bearer token directly,
+	// skipping the Username/AppPassword exchange against /wp-json/jwt-auth/v1/token.
+	JWTToken string `mapstructure:"jwt_token" json:"jwt_token"`
+
+	// OAuth1* configure the three-legged OAuth1 flow used by the wp-api/OAuth1 plugin.
+	// ConsumerKey/ConsumerSecret identify the registered application; Token/TokenSecret
+	// are the access token pair obtained by completing that flow out-of-band (see
+	// OAuth1Auth.RequestToken/AuthorizeURL/AccessToken) and are the only pair actually
+	// required to authenticate a request.
+	OAuth1ConsumerKey    string `mapstructure:"oauth1_consumer_key" json:"oauth1_consumer_key"`
+	OAuth1ConsumerSecret string `mapstructure:"oauth1_consumer_secret" json:"oauth1_consumer_secret"`
+	OAuth1Token          string `mapstructure:"oauth1_token" json:"oauth1_token"`
+	OAuth1TokenSecret    string `mapstructure:"oauth1_token_secret" json:"oauth1_token_secret"`
+
+	// Nonce* configure NonceAuth, which replays a logged-in wp-admin session's cookies
+	// and X-WP-Nonce header for endpoints that are nonce-gated rather than REST API
+	// key/token gated (password-protected post content, draft previews). NonceCookies
+	// is a name->value map (the form that round-trips through viper/env vars); Nonce is
+	// the initial X-WP-Nonce value; NonceRefreshURL, if set, is re-fetched for a fresh
+	// nonce the first time a request comes back 401/403.
+	NonceCookies    map[string]string `mapstructure:"nonce_cookies" json:"nonce_cookies"`
+	Nonce           string            `mapstructure:"nonce" json:"nonce"`
+	NonceRefreshURL string            `mapstructure:"nonce_refresh_url" json:"nonce_refresh_url"`
+
+	// ResolveRedirects enables a HEAD/GET probe in NewClient that follows redirects and
+	// normalizes URL to the canonical origin the site actually resolves to (absorbing
+	// http->https and www/trailing-slash redirects) before building the client's
+	// base URL/endpoint. Off by default since it adds a network round trip to client
+	// construction.
+	ResolveRedirects bool `mapstructure:"resolve_redirects" json:"resolve_redirects"`
+	// MaxRedirects bounds the redirect chain ResolveBaseURL will follow. Zero falls
+	// back to a default of 5.
+	MaxRedirects int `mapstructure:"max_redirects" json:"max_redirects"`
+	// AllowCrossHostRedirects lets ResolveBaseURL follow a redirect to a different
+	// host (e.g. a site migrated to a new domain). When false, resolution stops at
+	// the last same-host response.
+	AllowCrossHostRedirects bool `mapstructure:"allow_cross_host_redirects" json:"allow_cross_host_redirects"`
+
+	// CompressRequests gzips the outbound XML-RPC request body (setting
+	// Content-Encoding: gzip) in addition to advertising AcceptEncoding for the
+	// response. Off by default since not every WordPress install's XML-RPC
+	// handler (or the proxy in front of it) understands a compressed request.
+	CompressRequests bool `mapstructure:"compress_requests" json:"compress_requests"`
+	// AcceptEncoding lists the content codings advertised via Accept-Encoding on
+	// XML-RPC requests and transparently decoded from the response. Defaults to
+	// ["gzip", "deflate"] when empty.
+	AcceptEncoding []string `mapstructure:"accept_encoding" json:"accept_encoding"`
+
+	// ProxyURL routes XML-RPC requests through an HTTP(S) or SOCKS5 proxy (scheme
+	// one of "http", "https", "socks5"). Ignored when UnixSocket is set.
+	ProxyURL string `mapstructure:"proxy_url" json:"proxy_url"`
+	// UnixSocket dials XML-RPC requests over a Unix domain socket at this path
+	// instead of TCP, for WordPress instances only reachable that way (e.g. behind
+	// nginx-fpm in a Docker setup). The configured URL's host is then just a
+	// placeholder and is never resolved.
+	UnixSocket string `mapstructure:"unix_socket" json:"unix_socket"`
+
+	// Retry controls makeRequest's retry/backoff behavior for the XML-RPC client.
+	// Zero-valued fields fall back to the defaults documented on RetryPolicy.
+	Retry RetryPolicy `mapstructure:"retry" json:"retry"`
+
+	// Incremental enables delta-tracking: posts/pages/media are diffed against the
+	// previous run's state (per internal/state), and a manifest of added/updated/deleted
+	// IDs is written alongside the export.
+	Incremental bool `mapstructure:"incremental" json:"incremental"`
+	// StateFile is where the incremental export's state (last export time and per-ID
+	// content hashes, per endpoint) is persisted as JSON. Defaults to
+	// "<Output>/.wpexport-state.json" when empty and Incremental is set.
+	StateFile string `mapstructure:"state_file" json:"state_file"`
+
+	// After restricts posts/pages/media to content published on or after this date,
+	// in "2006-01-02" form, passed to the WP REST API as ?after=. Empty means no
+	// lower bound.
+	After string `mapstructure:"after" json:"after"`
+	// Before restricts posts/pages/media to content published before this date, in
+	// "2006-01-02" form, passed to the WP REST API as ?before=. Empty means no
+	// upper bound.
+	Before string `mapstructure:"before" json:"before"`
+	// SinceLast restricts posts/pages/media to content modified since the previous
+	// export's state (the per-endpoint modified_after high-water mark persisted in
+	// StateFile), fetching only the delta and merging it into any existing
+	// "<Output>/export.json" instead of re-downloading everything. Requires a
+	// previous run's state file to have something to diff against; the first run
+	// with SinceLast set behaves like a full export and just records the cursor.
+	SinceLast bool `mapstructure:"since_last" json:"since_last"`
+
+	// Resume enables persisting a brute force scan's progress (the highest ID attempted
+	// and the IDs found, per content type) so an interrupted or crashed scan picks up
+	// where it left off on the next run instead of re-probing IDs already tried.
+	Resume bool `mapstructure:"resume" json:"resume"`
+	// ScanCheckpointPath is where the brute force scan's resume checkpoint is persisted as
+	// JSON. Defaults to "<Output>/.wpexport-scan-checkpoint.json" when empty and Resume is
+	// set.
+	ScanCheckpointPath string `mapstructure:"scan_checkpoint_path" json:"scan_checkpoint_path"`
+	// BatchSize is how many IDs a brute force scan requests per `?include=` REST call
+	// instead of one GET per ID (see bruteforce.Scanner's batch discovery). Defaults to
+	// 100, the WP REST API's own per_page ceiling, when <= 0; set to 1 to fall back to
+	// the original one-request-per-ID behavior for servers that reject long query strings.
+	BatchSize int `mapstructure:"batch_size" json:"batch_size"`
+	// ScanStrategy selects how the brute force scan's dense per-ID pass chooses which IDs
+	// to probe, in place of a flat sweep over every ID from 1 to MaxID: "linear" (default)
+	// probes every ID in order, unchanged from historical behavior; "exponential" first
+	// probes at doubling intervals (1, 2, 4, 8, ...) and binary-searches for the true upper
+	// bound before scanning densely, cutting scan time sharply when MaxID overestimates how
+	// much content actually exists; "sparse" scans densely until a rolling hit rate (see
+	// SparseMinHitRate) drops too low, then skips ahead by a growing stride until it finds
+	// another hit before resuming a dense scan.
+	ScanStrategy string `mapstructure:"scan_strategy" json:"scan_strategy"`
+	// SparseMinHitRate is the rolling hit rate (0-1) ScanStrategy "sparse" requires before
+	// it stops skipping ahead and resumes a dense scan. Zero/unset uses a 2% default.
+	SparseMinHitRate float64 `mapstructure:"sparse_min_hit_rate" json:"sparse_min_hit_rate"`
+	// WriteScanStats writes wpexporter_scan_stats.json alongside the export when BruteForce
+	// is enabled: per-content-type probe counts, an HTTP status histogram, latency
+	// percentiles, bytes downloaded, discovered ID gaps, and suspicious slugs (see
+	// bruteforce.Scanner.WriteReport).
+	WriteScanStats bool `mapstructure:"write_scan_stats" json:"write_scan_stats"`
+
+	// Serve starts a local preview server (see internal/server) rooted at Output after
+	// exporting, so the exported site can be browsed without a separate static-file server.
+	Serve bool `mapstructure:"serve" json:"serve"`
+	// ServeAddr is the address the preview server listens on, e.g. ":8080". Defaults to
+	// ":8080" when Serve is set and ServeAddr is empty.
+	ServeAddr string `mapstructure:"serve_addr" json:"serve_addr"`
+	// Watch re-runs the export periodically while serving and pushes a browser reload
+	// over the preview server's SSE endpoint whenever it produces a changed export.
+	Watch bool `mapstructure:"watch" json:"watch"`
+	// ServeOnly skips exporting and serves Output as it already exists on disk, so a user
+	// can iterate on a previously exported site without re-crawling it.
+	ServeOnly bool `mapstructure:"serve_only" json:"serve_only"`
+
+	// Progress controls how internal/progress reports brute force scan and media
+	// download progress: "auto" renders interactive bars on a TTY (unless Verbose) and
+	// falls back to periodic log lines otherwise, "always" forces bars, "never" forces
+	// log lines, and "json" emits NDJSON progress events on stderr. Defaults to "auto".
+	Progress string `mapstructure:"progress" json:"progress"`
+
+	// WriteSitemap writes a sitemap.xml (chunked with a sitemap index past the
+	// sitemaps.org 50,000-URL limit) covering posts, pages, categories, and tags
+	// alongside the primary export, regardless of Format. The export command defaults
+	// this to true for Format "markdown" unless the user passes --write-sitemap=false.
+	WriteSitemap bool `mapstructure:"write_sitemap" json:"write_sitemap"`
+	// WriteLLMsTxt writes an llms.txt alongside the primary export: a plain-text
+	// index of post/page titles, URLs, and one-line excerpts for LLM ingestion.
+	WriteLLMsTxt bool `mapstructure:"write_llms_txt" json:"write_llms_txt"`
+	// WriteStats writes a stats.json alongside the primary export, listing every HTML
+	// tag, class, and ID found across all rendered post/page content so a downstream
+	// CSS purger (PurgeCSS, Tailwind) can scan one small file instead of the whole
+	// generated site.
+	WriteStats bool `mapstructure:"write_stats" json:"write_stats"`
+	// RenderTOC injects a "## Table of Contents" section (linking to each <h2>-<h4>
+	// heading's anchor) at the top of a Format "markdown" post/page body, in addition to
+	// the toc: front matter field generateMarkdownContent always writes when headings are
+	// present.
+	RenderTOC bool `mapstructure:"render_toc" json:"render_toc"`
+
+	// MarkdownFlavor selects the directory layout and content conventions used by
+	// Format "markdown": "plain" (default) is this tool's own category-folder layout,
+	// "hugo" emits a Hugo-compatible content tree with taxonomy stubs, "jekyll" emits a
+	// Jekyll _posts collection, and "bundle" emits one self-contained directory per
+	// post/page with its media colocated in an images/ subdirectory alongside it.
+	MarkdownFlavor string `mapstructure:"markdown_flavor" json:"markdown_flavor"`
+	// FrontMatterFormat selects how MarkdownFlavor "hugo" encodes front matter: "yaml"
+	// (default), "toml", or "json". MarkdownFlavor "jekyll" always uses YAML front
+	// matter regardless of this setting, since that's the only format Jekyll reads.
+	FrontMatterFormat string `mapstructure:"front_matter_format" json:"front_matter_format"`
+	// Highlight selects how convertHTMLToMarkdown handles <pre><code class="language-xxx">
+	// blocks: "none" (default) emits a plain fenced code block with the detected language
+	// tag, "chroma" pre-renders the block to syntax-highlighted HTML with inline styles
+	// (via github.com/alecthomas/chroma/v2), and "html" does the same but emits CSS
+	// classes instead, for a site that ships its own chroma stylesheet. The pre-rendered
+	// options exist for exports that go straight to a static host without a Markdown
+	// renderer's own syntax highlighter.
+	Highlight string `mapstructure:"highlight" json:"highlight"`
+
+	// WriteFeeds writes feed.atom and feed.rss (single-file, unpaginated companions
+	// to the chunked "atom"/"rss" Format outputs) alongside the primary export,
+	// regardless of Format. The export command defaults this to true for Format
+	// "markdown" (so a mirrored/archived site keeps valid syndication endpoints after
+	// its WordPress backend is gone) unless the user passes --feeds=false.
+	WriteFeeds bool `mapstructure:"write_feeds" json:"write_feeds"`
+	// FeedTagAuthority overrides the host component of a feed entry's tag: URI
+	// (RFC 4151), e.g. "example.com". Defaults to the exported site's hostname
+	// when empty.
+	FeedTagAuthority string `mapstructure:"feed_tag_authority" json:"feed_tag_authority"`
+	// FeedTagDate overrides the {start-date} component of a feed's tag: URIs, in
+	// YYYY-MM-DD form. Defaults to the earliest post's date when empty.
+	FeedTagDate string `mapstructure:"feed_tag_date" json:"feed_tag_date"`
+
+	// FileCache configures the content-addressed on-disk cache (see internal/cache) that
+	// lets a re-run of the same export skip re-downloading posts/media that haven't
+	// changed. Caching is off when Dir is empty.
+	FileCache FileCacheConfig `mapstructure:"filecache" json:"filecache"`
+
+	// ArchiveFormat selects the internal/archive.Archiver used to package the export
+	// when CreateZip is set: "zip" (default) or "tar.gz".
+	ArchiveFormat string `mapstructure:"archive_format" json:"archive_format"`
+	// SignKey is the path to a PEM-encoded (PKCS#8) ed25519 private key used to sign the
+	// archive's MANIFEST.json, writing a detached MANIFEST.json.sig alongside it. Signing
+	// is skipped when empty.
+	SignKey string `mapstructure:"sign_key" json:"sign_key"`
+
+	// MediaLayout selects how downloaded media is laid out on disk and linked from
+	// exported content: "legacy" (default) keeps the long-standing `{id}_{name}` flat
+	// path, while "content-addressed" stores each file's real bytes under a
+	// `sha256/<aa>/<hash>` path (see internal/media's MediaManifest) with the legacy path
+	// hard-linked alongside it for backward compatibility.
+	MediaLayout string `mapstructure:"media_layout" json:"media_layout"`
+
+	// MediaStore configures where internal/media.Downloader ultimately persists
+	// downloaded files and what URL exported content links to: on local disk (the
+	// default), or mirrored to S3 or WebDAV so an export can point straight at a CDN.
+	MediaStore MediaStoreConfig `mapstructure:"media_store" json:"media_store"`
+
+	// PlaceholderAssets configures the files internal/media.Downloader substitutes for a
+	// downloaded media file that fails MediaProbe (corrupt, or declared a type it can't
+	// verify), keyed by category. A category with no file configured falls back to a
+	// generated placeholder SVG, same as a permanently failed download.
+	PlaceholderAssets PlaceholderAssetsConfig `mapstructure:"placeholder_assets" json:"placeholder_assets"`
+
+	// TranscodeImages enables internal/media.Downloader's Transcoder step: after a
+	// successful image download, a BlurHash placeholder string is computed and recorded
+	// in the media manifest for static-site exports to render while the real image
+	// loads. Off by default since it decodes every downloaded image a second time.
+	TranscodeImages bool `mapstructure:"transcode_images" json:"transcode_images"`
+
+	// MediaSizes whitelists which of WordPress's registered image sizes (e.g.
+	// "thumbnail", "medium", "large") internal/media.Downloader fetches alongside a
+	// media item's full SourceURL, and which ones UpdateMediaPaths offers in the
+	// srcset it builds for a rewritten <img>. Empty (the default) fetches every size
+	// WordPress reports.
+	MediaSizes []string `mapstructure:"media_sizes" json:"media_sizes"`
+
+	// Deploy configures the "deploy" command's internal/deploy.Target, which pushes an
+	// already-written export output directory to a remote: a git repo, an S3 bucket, or
+	// an rsync destination. Empty (the default) leaves the deploy command unconfigured.
+	Deploy DeployConfig `mapstructure:"deploy" json:"deploy"`
+
+	// IncludeComments fetches every comment on the site (via Client.GetComments) into
+	// ExportData.Comments. Off by default since most exports only care about content.
+	IncludeComments bool `mapstructure:"include_comments" json:"include_comments"`
+	// IncludeRevisions fetches each post/page's revision history (via
+	// Client.GetPostRevisions) into ExportData.Revisions, keyed by post ID. Off by
+	// default: it costs one extra request per post/page.
+	IncludeRevisions bool `mapstructure:"include_revisions" json:"include_revisions"`
+	// IncludeCustomContent discovers non-core post types and taxonomies (via
+	// Client.GetPostTypes and Client.GetTaxonomies) and fetches their items into
+	// ExportData.CustomContent, keyed by rest_base. Off by default: most sites don't
+	// register any, and enumerating every registered type costs two extra requests plus
+	// one per discovered custom type.
+	IncludeCustomContent bool `mapstructure:"include_custom_content" json:"include_custom_content"`
+
+	// explicitKeys records which mapstructure keys were explicitly present in the loaded
+	// config file or a bound environment variable, rather than left at DefaultConfig's
+	// zero value. A bool field loaded from YAML can't otherwise be told apart from its
+	// zero value, so callers that need to know "the file said false" from "the file
+	// didn't mention it" (e.g. main.go's markdown feeds/sitemap default) use WasSet.
+	explicitKeys map[string]bool
+}
+
+// WasSet reports whether key (the mapstructure tag, e.g. "write_feeds") was explicitly
+// present in the config file or environment this Config was loaded from, as opposed to
+// left at its DefaultConfig() zero value. Always false for a Config not built by
+// LoadConfig (e.g. DefaultConfig() itself, or one constructed directly in tests).
+func (c *Config) WasSet(key string) bool {
+	return c.explicitKeys[key]
+}
+
+// DeployConfig selects and configures the internal/deploy.Target the deploy command
+// pushes an export's output directory to.
+type DeployConfig struct {
+	// Type selects the Target implementation: "git", "s3", or "rsync". Required to run
+	// the deploy command.
+	Type string `mapstructure:"type" json:"type"`
+	// Git configures the "git" target. Ignored otherwise.
+	Git GitTargetConfig `mapstructure:"git" json:"git"`
+	// S3 configures the "s3" target. Ignored otherwise.
+	S3 S3TargetConfig `mapstructure:"s3" json:"s3"`
+	// Rsync configures the "rsync" target. Ignored otherwise.
+	Rsync RsyncTargetConfig `mapstructure:"rsync" json:"rsync"`
+}
+
+// GitTargetConfig configures internal/deploy.GitTarget.
+type GitTargetConfig struct {
+	// URL is the repository to clone and push to, e.g.
+	// "git@github.com:example/example.github.io.git". Required when Deploy.Type is
+	// "git".
+	URL string `mapstructure:"url" json:"url"`
+	// Branch is the branch to check out and push. Defaults to "main" when empty.
+	Branch string `mapstructure:"branch" json:"branch"`
+	// CommitMessage is a text/template string rendered with a deploy.CommitMessageData,
+	// used as the commit message for each push. Defaults to a message naming the post,
+	// page, and media counts deployed when empty.
+	CommitMessage string `mapstructure:"commit_message" json:"commit_message"`
+}
+
+// S3TargetConfig configures internal/deploy.S3Target.
+type S3TargetConfig struct {
+	// Bucket is the destination S3 bucket. Required when Deploy.Type is "s3".
+	Bucket string `mapstructure:"bucket" json:"bucket"`
+	// Region is the bucket's AWS region, e.g. "us-east-1".
+	Region string `mapstructure:"region" json:"region"`
+	// Prefix is prepended to every object key, without a leading slash (e.g.
+	// "my-site/").
+	Prefix string `mapstructure:"prefix" json:"prefix"`
+	// Endpoint overrides the default AWS endpoint, for S3-compatible services (MinIO,
+	// R2, Spaces, ...). Empty uses AWS's own endpoint for Region.
+	Endpoint string `mapstructure:"endpoint" json:"endpoint"`
+	// CacheControl sets the Cache-Control header on every uploaded object, e.g.
+	// "public, max-age=3600". Left empty, no Cache-Control header is set.
+	CacheControl string `mapstructure:"cache_control" json:"cache_control"`
+	// CloudFrontDistributionID, when set, invalidates "/*" on this CloudFront
+	// distribution after a successful upload, so cached objects don't outlive the
+	// deploy they were replaced by.
+	CloudFrontDistributionID string `mapstructure:"cloudfront_distribution_id" json:"cloudfront_distribution_id"`
+}
+
+// RsyncTargetConfig configures internal/deploy.RsyncTarget.
+type RsyncTargetConfig struct {
+	// Host is the rsync destination host, e.g. "example.com". Required when Deploy.Type
+	// is "rsync".
+	Host string `mapstructure:"host" json:"host"`
+	// Path is the destination directory on Host, e.g. "/var/www/example.com".
+	// Required when Deploy.Type is "rsync".
+	Path string `mapstructure:"path" json:"path"`
+	// User is the SSH user to connect as. Defaults to the current user when empty.
+	User string `mapstructure:"user" json:"user"`
+	// Port is the SSH port to connect on. Defaults to 22 when zero.
+	Port int `mapstructure:"port" json:"port"`
+}
+
+// PlaceholderAssetsConfig names the on-disk placeholder file internal/media.Downloader
+// substitutes for a media download MediaProbe rejects, keyed by category.
+type PlaceholderAssetsConfig struct {
+	// Unknown is used for a failed image probe, or any category MediaProbe doesn't
+	// otherwise recognize.
+	Unknown string `mapstructure:"unknown" json:"unknown"`
+	// Audio is used for a failed audio probe.
+	Audio string `mapstructure:"audio" json:"audio"`
+	// Video is used for a failed video probe.
+	Video string `mapstructure:"video" json:"video"`
+}
+
+// MediaStoreConfig selects and configures the internal/media.MediaStore a Downloader
+// writes completed downloads to.
+type MediaStoreConfig struct {
+	// Backend selects the MediaStore implementation: "" or "local" (default) keeps
+	// files on disk under GetMediaDir, "s3" mirrors them to an S3 bucket, and "webdav"
+	// mirrors them to a WebDAV server.
+	Backend string `mapstructure:"backend" json:"backend"`
+	// S3 configures the "s3" backend. Ignored otherwise.
+	S3 S3StoreConfig `mapstructure:"s3" json:"s3"`
+	// WebDAV configures the "webdav" backend. Ignored otherwise.
+	WebDAV WebDAVStoreConfig `mapstructure:"webdav" json:"webdav"`
+}
+
+// S3StoreConfig configures internal/media.S3Store.
+type S3StoreConfig struct {
+	// Bucket is the destination S3 bucket. Required when Backend is "s3".
+	Bucket string `mapstructure:"bucket" json:"bucket"`
+	// Prefix is prepended to every object key, without a leading slash (e.g.
+	// "wp-media/").
+	Prefix string `mapstructure:"prefix" json:"prefix"`
+	// Region is the bucket's AWS region, e.g. "us-east-1".
+	Region string `mapstructure:"region" json:"region"`
+	// Endpoint overrides the default AWS endpoint, for S3-compatible services
+	// (MinIO, R2, Spaces, ...). Empty uses AWS's own endpoint for Region.
+	Endpoint string `mapstructure:"endpoint" json:"endpoint"`
+	// PublicURLBase overrides the URL MediaStore.URLFor returns, e.g.
+	// "https://cdn.example.com", for a bucket fronted by a CDN. Defaults to the
+	// bucket's own (virtual-hosted-style) URL when empty.
+	PublicURLBase string `mapstructure:"public_url_base" json:"public_url_base"`
+}
+
+// WebDAVStoreConfig configures internal/media.WebDAVStore.
+type WebDAVStoreConfig struct {
+	// BaseURL is the WebDAV server's root collection, e.g.
+	// "https://dav.example.com/media". Required when Backend is "webdav".
+	BaseURL string `mapstructure:"base_url" json:"base_url"`
+	// Username and Password authenticate via HTTP Basic auth. Left empty, requests are
+	// sent unauthenticated.
+	Username string `mapstructure:"username" json:"username"`
+	Password string `mapstructure:"password" json:"password"`
+	// PublicURLBase overrides the URL MediaStore.URLFor returns, e.g.
+	// "https://cdn.example.com", for a WebDAV collection fronted by a CDN. Defaults to
+	// BaseURL when empty.
+	PublicURLBase string `mapstructure:"public_url_base" json:"public_url_base"`
+}
+
+// FileCacheConfig configures internal/cache.Cache. A zero value disables caching.
+type FileCacheConfig struct {
+	// Dir is the cache's root directory. Caching is disabled entirely when empty.
+	Dir string `mapstructure:"dir" json:"dir"`
+	// TTLSeconds is how long a cache entry is trusted before it's treated as stale and
+	// re-fetched. Zero means entries never expire on their own (they're still replaced
+	// whenever the underlying content changes, and can be reaped manually via
+	// `wpxmlrpc cache gc`).
+	TTLSeconds int `mapstructure:"ttl_seconds" json:"ttl_seconds"`
+	// Overrides sets a different TTL, in seconds, for individual named sub-caches (e.g.
+	// "posts", "media"), overriding TTLSeconds for just that sub-cache.
+	Overrides map[string]int `mapstructure:"overrides" json:"overrides"`
+}
+
+// TTL returns the FileCacheConfig's default entry lifetime as a time.Duration.
+func (f FileCacheConfig) TTL() time.Duration {
+	return time.Duration(f.TTLSeconds) * time.Second
+}
+
+// OverrideTTLs converts Overrides to the map[string]time.Duration internal/cache.Cache.GC expects.
+func (f FileCacheConfig) OverrideTTLs() map[string]time.Duration {
+	if len(f.Overrides) == 0 {
+		return nil
+	}
+	ttls := make(map[string]time.Duration, len(f.Overrides))
+	for sub, seconds := range f.Overrides {
+		ttls[sub] = time.Duration(seconds) * time.Second
+	}
+	return ttls
+}
+
+// RetryPolicy configures how the XML-RPC client retries a failed request: how many
+// attempts to make and the exponential backoff range between them. A permanent
+// failure (401/403, or an XML-RPC auth fault) is never retried regardless of
+// MaxAttempts; a 429 or Retry-After response is honored instead of the computed
+// backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts (including the first), not the
+	// number of retries. Zero falls back to 3.
+	MaxAttempts int `mapstructure:"max_attempts" json:"max_attempts"`
+	// InitialBackoffMS is the base delay before the second attempt. Zero falls
+	// back to 500ms.
+	InitialBackoffMS int `mapstructure:"initial_backoff_ms" json:"initial_backoff_ms"`
+	// MaxBackoffMS caps the exponential backoff. Zero falls back to 30000 (30s).
+	MaxBackoffMS int `mapstructure:"max_backoff_ms" json:"max_backoff_ms"`
+	// DisableJitter turns off full jitter, backing off by the exact computed
+	// delay every time. Off (jitter enabled) by default.
+	DisableJitter bool `mapstructure:"disable_jitter" json:"disable_jitter"`
 }
 
 // DefaultConfig returns a configuration with default values
@@ -39,92 +515,172 @@ func DefaultConfig() *Config {
 		Retries:       3,
 		UserAgent:     "WordPress-Export-JSON/1.0",
 		Verbose:       false,
+		Progress:      "auto",
+
+		MarkdownFlavor:    "plain",
+		FrontMatterFormat: "yaml",
+		ArchiveFormat:     "zip",
+		MediaLayout:       "legacy",
 	}
 }
 
-// LoadConfig loads configuration from file and environment variables
-func LoadConfig(configFile string) (*Config, error) {
-	config := DefaultConfig()
-
-	// Set up viper
-	viper.SetConfigType("yaml")
-	viper.SetConfigName("config")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("$HOME/.wpexportjson")
-	viper.AddConfigPath("/etc/wpexportjson")
+// envBinding maps a Config mapstructure key to the environment variable that overrides it.
+type envBinding struct {
+	key string
+	env string
+}
 
-	// Set environment variable prefix
-	viper.SetEnvPrefix("WPEXPORT")
-	viper.AutomaticEnv()
+// envBindings lists every environment variable LoadConfig honors, keyed by the Config
+// field it overrides.
+var envBindings = []envBinding{
+	{"url", "WPEXPORT_URL"},
+	{"output", "WPEXPORT_OUTPUT"},
+	{"format", "WPEXPORT_FORMAT"},
+	{"brute_force", "WPEXPORT_BRUTE_FORCE"},
+	{"max_id", "WPEXPORT_MAX_ID"},
+	{"download_media", "WPEXPORT_DOWNLOAD_MEDIA"},
+	{"concurrent", "WPEXPORT_CONCURRENT"},
+	{"timeout", "WPEXPORT_TIMEOUT"},
+	{"retries", "WPEXPORT_RETRIES"},
+	{"user_agent", "WPEXPORT_USER_AGENT"},
+	{"verbose", "WPEXPORT_VERBOSE"},
+	{"auth_type", "WPEXPORT_AUTH_TYPE"},
+	{"username", "WPEXPORT_AUTH_USER"},
+	{"app_password", "WPEXPORT_AUTH_PASS"},
+	{"token", "WPEXPORT_AUTH_TOKEN"},
+	{"oauth_client_id", "WPEXPORT_AUTH_CLIENT_ID"},
+	{"oauth_client_secret", "WPEXPORT_AUTH_CLIENT_SECRET"},
+	{"jwt_token", "WPEXPORT_AUTH_JWT_TOKEN"},
+	{"incremental", "WPEXPORT_INCREMENTAL"},
+	{"state_file", "WPEXPORT_STATE_FILE"},
+	{"resume", "WPEXPORT_RESUME"},
+	{"scan_checkpoint_path", "WPEXPORT_SCAN_CHECKPOINT_PATH"},
+	{"batch_size", "WPEXPORT_BATCH_SIZE"},
+	{"serve", "WPEXPORT_SERVE"},
+	{"serve_addr", "WPEXPORT_SERVE_ADDR"},
+	{"watch", "WPEXPORT_WATCH"},
+	{"serve_only", "WPEXPORT_SERVE_ONLY"},
+	{"progress", "WPEXPORT_PROGRESS"},
+	{"write_sitemap", "WPEXPORT_WRITE_SITEMAP"},
+	{"write_llms_txt", "WPEXPORT_WRITE_LLMS_TXT"},
+	{"markdown_flavor", "WPEXPORT_MARKDOWN_FLAVOR"},
+	{"front_matter_format", "WPEXPORT_FRONT_MATTER_FORMAT"},
+	{"write_feeds", "WPEXPORT_WRITE_FEEDS"},
+	{"feed_tag_authority", "WPEXPORT_FEED_TAG_AUTHORITY"},
+	{"feed_tag_date", "WPEXPORT_FEED_TAG_DATE"},
+	{"filecache.dir", "WPEXPORT_CACHE_DIR"},
+	{"filecache.ttl_seconds", "WPEXPORT_CACHE_TTL_SECONDS"},
+	{"rate_limit", "WPEXPORT_RATE_LIMIT"},
+	{"max_qps", "WPEXPORT_MAX_QPS"},
+	{"respect_retry_after", "WPEXPORT_RESPECT_RETRY_AFTER"},
+}
 
-	// Bind environment variables
-	if err := viper.BindEnv("url", "WPEXPORT_URL"); err != nil {
-		return nil, fmt.Errorf("failed to bind url environment variable: %w", err)
-	}
-	if err := viper.BindEnv("output", "WPEXPORT_OUTPUT"); err != nil {
-		return nil, fmt.Errorf("failed to bind output environment variable: %w", err)
-	}
-	if err := viper.BindEnv("format", "WPEXPORT_FORMAT"); err != nil {
-		return nil, fmt.Errorf("failed to bind format environment variable: %w", err)
-	}
-	if err := viper.BindEnv("brute_force", "WPEXPORT_BRUTE_FORCE"); err != nil {
-		return nil, fmt.Errorf("failed to bind brute_force environment variable: %w", err)
-	}
-	if err := viper.BindEnv("max_id", "WPEXPORT_MAX_ID"); err != nil {
-		return nil, fmt.Errorf("failed to bind max_id environment variable: %w", err)
-	}
-	if err := viper.BindEnv("download_media", "WPEXPORT_DOWNLOAD_MEDIA"); err != nil {
-		return nil, fmt.Errorf("failed to bind download_media environment variable: %w", err)
+// configSearchPaths returns the absolute config file paths LoadConfig searches when
+// configFile isn't set explicitly, in priority order: the working directory, then
+// $HOME/.wpexportjson, then /etc/wpexportjson. getenv resolves HOME so the search honors
+// an injected environment rather than the process's real one.
+func configSearchPaths(getenv func(string) string) []string {
+	var paths []string
+	if cwd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(cwd, "config.yaml"), filepath.Join(cwd, "config.yml"))
 	}
-	if err := viper.BindEnv("concurrent", "WPEXPORT_CONCURRENT"); err != nil {
-		return nil, fmt.Errorf("failed to bind concurrent environment variable: %w", err)
+	if home := getenv("HOME"); home != "" {
+		paths = append(paths,
+			filepath.Join(home, ".wpexportjson", "config.yaml"),
+			filepath.Join(home, ".wpexportjson", "config.yml"),
+		)
 	}
-	if err := viper.BindEnv("timeout", "WPEXPORT_TIMEOUT"); err != nil {
-		return nil, fmt.Errorf("failed to bind timeout environment variable: %w", err)
-	}
-	if err := viper.BindEnv("retries", "WPEXPORT_RETRIES"); err != nil {
-		return nil, fmt.Errorf("failed to bind retries environment variable: %w", err)
-	}
-	if err := viper.BindEnv("user_agent", "WPEXPORT_USER_AGENT"); err != nil {
-		return nil, fmt.Errorf("failed to bind user_agent environment variable: %w", err)
+	return append(paths, "/etc/wpexportjson/config.yaml", "/etc/wpexportjson/config.yml")
+}
+
+// readConfigFile loads the first config file found into v: configFile itself when set, or
+// else the first of configSearchPaths to exist. fsys is rooted at "/", so each candidate's
+// leading separator is trimmed before it's opened; production callers pass os.DirFS("/").
+// It's not an error for no config file to exist when configFile wasn't set explicitly.
+func readConfigFile(v *viper.Viper, fsys fs.FS, getenv func(string) string, configFile string) error {
+	candidates := configSearchPaths(getenv)
+	if configFile != "" {
+		candidates = []string{configFile}
+		if abs, err := filepath.Abs(configFile); err == nil {
+			candidates = []string{abs}
+		}
 	}
-	if err := viper.BindEnv("verbose", "WPEXPORT_VERBOSE"); err != nil {
-		return nil, fmt.Errorf("failed to bind verbose environment variable: %w", err)
+
+	for _, path := range candidates {
+		if path == "" {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, strings.TrimPrefix(filepath.ToSlash(path), "/"))
+		if err != nil {
+			if configFile != "" {
+				return fmt.Errorf("error reading config file: %w", err)
+			}
+			continue
+		}
+
+		if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("error reading config file: %w", err)
+		}
+		return nil
 	}
 
-	// Load config file if specified
-	if configFile != "" {
-		viper.SetConfigFile(configFile)
+	return nil
+}
+
+// LoadConfig loads configuration from a config file and environment variables. fsys and
+// getenv are injected rather than read from the real disk/environment (production callers
+// pass os.DirFS("/") and os.Getenv) so callers can load fully isolated configuration under
+// t.Parallel(), and each call gets its own viper instance rather than mutating global state.
+func LoadConfig(configFile string, fsys fs.FS, getenv func(string) string) (*Config, error) {
+	cfg := DefaultConfig()
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	if err := readConfigFile(v, fsys, getenv, configFile); err != nil {
+		return nil, err
 	}
 
-	// Read config file
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("error reading config file: %w", err)
+	for _, b := range envBindings {
+		if val := getenv(b.env); val != "" {
+			v.Set(b.key, val)
 		}
 	}
 
 	// Unmarshal config
-	if err := viper.Unmarshal(config); err != nil {
+	if err := v.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	cfg.explicitKeys = map[string]bool{}
+	for _, key := range v.AllKeys() {
+		cfg.explicitKeys[key] = true
+	}
+
 	// Validate configuration
-	if err := config.Validate(); err != nil {
+	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	return config, nil
+	return cfg, nil
 }
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	if c.URL == "" {
+	// A config file dedicated to the deploy command (see internal/deploy) has no reason
+	// to also name a WordPress URL: it only pushes an already-exported output directory
+	// to Deploy.Type's target, never fetches anything from WordPress itself.
+	if c.URL == "" && c.Deploy.Type == "" {
 		return fmt.Errorf("URL is required")
 	}
 
-	if c.Format != "json" && c.Format != "markdown" {
-		return fmt.Errorf("format must be 'json' or 'markdown'")
+	switch c.Format {
+	case "json", "json-stream", "markdown", "hugo", "atom", "rss", "sitemap", "activitypub", "xml":
+	default:
+		if _, ok, err := plugin.FindByFormat(plugin.DefaultPluginDirs(), c.Format); err == nil && ok {
+			break
+		}
+		return fmt.Errorf("format must be one of 'json', 'json-stream', 'markdown', 'hugo', 'atom', 'rss', 'sitemap', 'activitypub', 'xml', or a format provided by an installed plugin")
 	}
 
 	if c.MaxID <= 0 {
@@ -143,6 +699,97 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("retries must be greater than or equal to 0")
 	}
 
+	switch c.AuthType {
+	case "", "app_password", "oauth2", "jwt", "oauth1", "nonce":
+	default:
+		return fmt.Errorf("auth_type must be one of '', 'app_password', 'oauth2', 'jwt', 'oauth1', or 'nonce'")
+	}
+
+	switch c.Progress {
+	case "", "auto", "always", "never", "json":
+	default:
+		return fmt.Errorf("progress must be one of 'auto', 'always', 'never', or 'json'")
+	}
+
+	switch c.ScanStrategy {
+	case "", "linear", "exponential", "sparse":
+	default:
+		return fmt.Errorf("scan_strategy must be one of 'linear', 'exponential', or 'sparse'")
+	}
+
+	switch c.MarkdownFlavor {
+	case "", "plain", "hugo", "jekyll", "bundle":
+	default:
+		return fmt.Errorf("markdown_flavor must be one of 'plain', 'hugo', 'jekyll', or 'bundle'")
+	}
+
+	switch c.FrontMatterFormat {
+	case "", "yaml", "toml", "json":
+	default:
+		return fmt.Errorf("front_matter_format must be one of 'yaml', 'toml', or 'json'")
+	}
+
+	switch c.Highlight {
+	case "", "none", "chroma", "html":
+	default:
+		return fmt.Errorf("highlight must be one of 'none', 'chroma', or 'html'")
+	}
+
+	switch c.ArchiveFormat {
+	case "", "zip", "tar.gz":
+	default:
+		return fmt.Errorf("archive_format must be one of 'zip' or 'tar.gz'")
+	}
+
+	switch c.MediaLayout {
+	case "", "legacy", "content-addressed":
+	default:
+		return fmt.Errorf("media_layout must be one of 'legacy' or 'content-addressed'")
+	}
+
+	switch c.MediaStore.Backend {
+	case "", "local":
+	case "s3":
+		if c.MediaStore.S3.Bucket == "" {
+			return fmt.Errorf("media_store.s3.bucket is required when media_store.backend is 's3'")
+		}
+	case "webdav":
+		if c.MediaStore.WebDAV.BaseURL == "" {
+			return fmt.Errorf("media_store.webdav.base_url is required when media_store.backend is 'webdav'")
+		}
+	default:
+		return fmt.Errorf("media_store.backend must be one of 'local', 's3', or 'webdav'")
+	}
+
+	switch c.Deploy.Type {
+	case "":
+	case "git":
+		if c.Deploy.Git.URL == "" {
+			return fmt.Errorf("deploy.git.url is required when deploy.type is 'git'")
+		}
+	case "s3":
+		if c.Deploy.S3.Bucket == "" {
+			return fmt.Errorf("deploy.s3.bucket is required when deploy.type is 's3'")
+		}
+	case "rsync":
+		if c.Deploy.Rsync.Host == "" || c.Deploy.Rsync.Path == "" {
+			return fmt.Errorf("deploy.rsync.host and deploy.rsync.path are required when deploy.type is 'rsync'")
+		}
+	default:
+		return fmt.Errorf("deploy.type must be one of 'git', 's3', or 'rsync'")
+	}
+
+	if c.After != "" {
+		if _, err := time.Parse("2006-01-02", c.After); err != nil {
+			return fmt.Errorf("after must be a date in YYYY-MM-DD form: %w", err)
+		}
+	}
+	if c.Before != "" {
+		if _, err := time.Parse("2006-01-02", c.Before); err != nil {
+			return fmt.Errorf("before must be a date in YYYY-MM-DD form: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -154,6 +801,12 @@ func (c *Config) EnsureOutputDir() error {
 		return os.MkdirAll(dir, 0755)
 	}
 
+	if (c.Format == "rss" || c.Format == "xml") && filepath.Ext(c.Output) == ".xml" {
+		// If output is a feed.xml/wordpress.xml-style file, ensure parent directory exists
+		dir := filepath.Dir(c.Output)
+		return os.MkdirAll(dir, 0755)
+	}
+
 	// Otherwise, ensure output directory exists
 	return os.MkdirAll(c.Output, 0755)
 }
@@ -233,6 +886,23 @@ func (c *Config) GetMediaDir() string {
 		return filepath.Join(dir, name+"_media")
 	}
 
+	if (c.Format == "rss" || c.Format == "xml") && filepath.Ext(c.Output) == ".xml" {
+		// If output is a feed.xml/wordpress.xml-style file, create media directory next to it
+		dir := filepath.Dir(c.Output)
+		base := filepath.Base(c.Output)
+		name := base[:len(base)-len(filepath.Ext(base))]
+		return filepath.Join(dir, name+"_media")
+	}
+
 	// Otherwise, create media directory inside output directory
 	return filepath.Join(c.Output, "media")
 }
+
+// HTTPCacheDir returns the directory internal/httpcache uses to store downloaded media
+// bodies and their conditional-GET validators. It's a ".cache" sibling of the media
+// directory, so it survives a later --no-files cleanup of GetMediaDir itself, letting a
+// re-run recognize an unchanged source file and reconstruct it without hitting the
+// network.
+func (c *Config) HTTPCacheDir() string {
+	return filepath.Join(filepath.Dir(c.GetMediaDir()), ".cache")
+}
@@ -5,9 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
-
-	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -84,7 +83,7 @@ func TestConfigValidate(t *testing.T) {
 			name: "Invalid format",
 			cfg: &Config{
 				URL:        "https://example.com",
-				Format:     "xml",
+				Format:     "no-such-format",
 				MaxID:      100,
 				Concurrent: 5,
 				Timeout:    30,
@@ -92,6 +91,18 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid WXR xml format",
+			cfg: &Config{
+				URL:        "https://example.com",
+				Format:     "xml",
+				MaxID:      100,
+				Concurrent: 5,
+				Timeout:    30,
+				Retries:    3,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Zero MaxID",
 			cfg: &Config{
@@ -152,6 +163,140 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Valid after and before dates",
+			cfg: &Config{
+				URL:        "https://example.com",
+				Format:     "json",
+				MaxID:      100,
+				Concurrent: 5,
+				Timeout:    30,
+				Retries:    3,
+				After:      "2024-01-01",
+				Before:     "2024-06-01",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Malformed after date",
+			cfg: &Config{
+				URL:        "https://example.com",
+				Format:     "json",
+				MaxID:      100,
+				Concurrent: 5,
+				Timeout:    30,
+				Retries:    3,
+				After:      "01/01/2024",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Malformed before date",
+			cfg: &Config{
+				URL:        "https://example.com",
+				Format:     "json",
+				MaxID:      100,
+				Concurrent: 5,
+				Timeout:    30,
+				Retries:    3,
+				Before:     "not-a-date",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid tar.gz archive format",
+			cfg: &Config{
+				URL:           "https://example.com",
+				Format:        "json",
+				MaxID:         100,
+				Concurrent:    5,
+				Timeout:       30,
+				Retries:       3,
+				ArchiveFormat: "tar.gz",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid archive format",
+			cfg: &Config{
+				URL:           "https://example.com",
+				Format:        "json",
+				MaxID:         100,
+				Concurrent:    5,
+				Timeout:       30,
+				Retries:       3,
+				ArchiveFormat: "rar",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid content-addressed media layout",
+			cfg: &Config{
+				URL:         "https://example.com",
+				Format:      "json",
+				MaxID:       100,
+				Concurrent:  5,
+				Timeout:     30,
+				Retries:     3,
+				MediaLayout: "content-addressed",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid media layout",
+			cfg: &Config{
+				URL:         "https://example.com",
+				Format:      "json",
+				MaxID:       100,
+				Concurrent:  5,
+				Timeout:     30,
+				Retries:     3,
+				MediaLayout: "flat",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid S3 media store",
+			cfg: &Config{
+				URL:        "https://example.com",
+				Format:     "json",
+				MaxID:      100,
+				Concurrent: 5,
+				Timeout:    30,
+				Retries:    3,
+				MediaStore: MediaStoreConfig{
+					Backend: "s3",
+					S3:      S3StoreConfig{Bucket: "exported-media"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "S3 media store without bucket",
+			cfg: &Config{
+				URL:        "https://example.com",
+				Format:     "json",
+				MaxID:      100,
+				Concurrent: 5,
+				Timeout:    30,
+				Retries:    3,
+				MediaStore: MediaStoreConfig{Backend: "s3"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid media store backend",
+			cfg: &Config{
+				URL:        "https://example.com",
+				Format:     "json",
+				MaxID:      100,
+				Concurrent: 5,
+				Timeout:    30,
+				Retries:    3,
+				MediaStore: MediaStoreConfig{Backend: "ftp"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,6 +309,25 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidateAcceptsFormatProvidedByAnInstalledPlugin(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	pluginDir := filepath.Join(home, ".wpxmlrpc", "plugins", "wpexport-zola")
+	if err := os.MkdirAll(pluginDir, 0750); err != nil {
+		t.Fatalf("failed to create plugin directory: %v", err)
+	}
+	manifest := "name: wpexport-zola\nformat: zola\ncommand: wpexport-zola\n"
+	if err := os.WriteFile(filepath.Join(pluginDir, "plugin.yaml"), []byte(manifest), 0600); err != nil {
+		t.Fatalf("failed to write plugin.yaml: %v", err)
+	}
+
+	cfg := &Config{URL: "https://example.com", Format: "zola", MaxID: 100, Concurrent: 5, Timeout: 30, Retries: 3}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil for a format provided by an installed plugin", err)
+	}
+}
+
 func TestGenerateDefaultOutput(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -273,6 +437,14 @@ func TestEnsureOutputDir(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "WXR XML file output creates parent directory",
+			cfg: &Config{
+				Output: filepath.Join(tempDir, "output", "wordpress.xml"),
+				Format: "xml",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -338,6 +510,14 @@ func TestGetMediaDir(t *testing.T) {
 			},
 			expected: filepath.Join(cwd, "export/directory/media"),
 		},
+		{
+			name: "WXR XML file output creates media directory next to file",
+			cfg: &Config{
+				Output: "export/wordpress.xml",
+				Format: "xml",
+			},
+			expected: filepath.Join(cwd, "export/wordpress_media"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -389,6 +569,8 @@ func TestSanitizeDomainName(t *testing.T) {
 }
 
 func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
 	tempDir := t.TempDir()
 	configFile := filepath.Join(tempDir, "config.yaml")
 
@@ -411,7 +593,7 @@ verbose: true
 	}
 
 	// Test loading config from file
-	cfg, err := LoadConfig(configFile)
+	cfg, err := LoadConfig(configFile, os.DirFS("/"), noEnv)
 	if err != nil {
 		t.Fatalf("LoadConfig() error = %v", err)
 	}
@@ -433,24 +615,21 @@ verbose: true
 	}
 }
 
+// noEnv is a getenv func that reports every environment variable as unset, for LoadConfig
+// tests that don't exercise environment-variable overrides.
+func noEnv(string) string { return "" }
+
 func TestLoadConfigWithEnvVars(t *testing.T) {
-	// Set environment variables
-	err := os.Setenv("WPEXPORT_URL", "https://env.example.com")
-	require.NoError(t, err)
-	err = os.Setenv("WPEXPORT_FORMAT", "markdown")
-	require.NoError(t, err)
-	err = os.Setenv("WPEXPORT_MAX_ID", "2000")
-	require.NoError(t, err)
-	defer func() {
-		err = os.Unsetenv("WPEXPORT_URL")
-		require.NoError(t, err)
-		err = os.Unsetenv("WPEXPORT_FORMAT")
-		require.NoError(t, err)
-		err = os.Unsetenv("WPEXPORT_MAX_ID")
-		require.NoError(t, err)
-	}()
-
-	cfg, err := LoadConfig("")
+	t.Parallel()
+
+	env := map[string]string{
+		"WPEXPORT_URL":    "https://env.example.com",
+		"WPEXPORT_FORMAT": "markdown",
+		"WPEXPORT_MAX_ID": "2000",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	cfg, err := LoadConfig("", fstest.MapFS{}, getenv)
 	if err != nil {
 		t.Fatalf("LoadConfig() error = %v", err)
 	}
@@ -468,6 +647,82 @@ func TestLoadConfigWithEnvVars(t *testing.T) {
 	}
 }
 
+func TestLoadConfigWithFileCacheEnvVars(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"WPEXPORT_URL":               "https://env.example.com",
+		"WPEXPORT_CACHE_DIR":         "/tmp/wpexport-cache",
+		"WPEXPORT_CACHE_TTL_SECONDS": "3600",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	cfg, err := LoadConfig("", fstest.MapFS{}, getenv)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if cfg.FileCache.Dir != "/tmp/wpexport-cache" {
+		t.Errorf("LoadConfig() FileCache.Dir from env = %v, want %v", cfg.FileCache.Dir, "/tmp/wpexport-cache")
+	}
+	if cfg.FileCache.TTLSeconds != 3600 {
+		t.Errorf("LoadConfig() FileCache.TTLSeconds from env = %v, want %v", cfg.FileCache.TTLSeconds, 3600)
+	}
+	if cfg.FileCache.TTL() != time.Hour {
+		t.Errorf("FileCache.TTL() = %v, want %v", cfg.FileCache.TTL(), time.Hour)
+	}
+}
+
+func TestLoadConfigWithRateLimitEnvVars(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"WPEXPORT_URL":                 "https://env.example.com",
+		"WPEXPORT_RATE_LIMIT":          "5",
+		"WPEXPORT_MAX_QPS":             "20",
+		"WPEXPORT_RESPECT_RETRY_AFTER": "true",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	cfg, err := LoadConfig("", fstest.MapFS{}, getenv)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if cfg.RateLimit != 5 {
+		t.Errorf("LoadConfig() RateLimit from env = %v, want %v", cfg.RateLimit, 5)
+	}
+	if cfg.MaxQPS != 20 {
+		t.Errorf("LoadConfig() MaxQPS from env = %v, want %v", cfg.MaxQPS, 20)
+	}
+	if !cfg.RespectRetryAfter {
+		t.Error("LoadConfig() RespectRetryAfter from env = false, want true")
+	}
+}
+
+func TestLoadConfigWithResumeEnvVars(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"WPEXPORT_URL":                  "https://env.example.com",
+		"WPEXPORT_RESUME":               "true",
+		"WPEXPORT_SCAN_CHECKPOINT_PATH": "/tmp/wpexport-scan-checkpoint.json",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	cfg, err := LoadConfig("", fstest.MapFS{}, getenv)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if !cfg.Resume {
+		t.Error("LoadConfig() Resume from env = false, want true")
+	}
+	if cfg.ScanCheckpointPath != "/tmp/wpexport-scan-checkpoint.json" {
+		t.Errorf("LoadConfig() ScanCheckpointPath from env = %v, want %v", cfg.ScanCheckpointPath, "/tmp/wpexport-scan-checkpoint.json")
+	}
+}
+
 func TestGenerateDefaultOutputDateFormat(t *testing.T) {
 	cfg := &Config{
 		URL: "https://example.com",
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ResolveBaseURL probes rawURL with a HEAD request (falling back to GET if the server
+// rejects HEAD) and follows redirects, returning the canonical origin ("scheme://host")
+// the site actually resolves to. This is what NewClient uses, when cfg.ResolveRedirects
+// is set, to absorb the http->https and trailing-slash/www redirects that otherwise
+// surface as a cryptic "301"/"302" error from the REST or XML-RPC clients, which build
+// their endpoints by simple string concatenation on cfg.URL.
+//
+// Redirects beyond cfg.MaxRedirects (default 5) abort resolution with an error. A
+// redirect to a different host is only followed when cfg.AllowCrossHostRedirects is set;
+// otherwise resolution stops at the last same-host response. A warning is printed to
+// stderr whenever the canonical origin differs from the configured one, since the caller
+// will silently use it instead.
+func ResolveBaseURL(cfg *Config, rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	maxRedirects := cfg.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = 5
+	}
+
+	client := &http.Client{
+		Timeout: time.Duration(cfg.Timeout) * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if !cfg.AllowCrossHostRedirects && req.URL.Host != via[0].URL.Host {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+
+	current := parsed.String()
+
+	resp, err := client.Head(current)
+	if err != nil || resp.StatusCode >= 400 {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		resp, err = client.Get(current)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve canonical URL for %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	canonical := strings.TrimSuffix(resp.Request.URL.Scheme+"://"+resp.Request.URL.Host, "/")
+	original := strings.TrimSuffix(parsed.Scheme+"://"+parsed.Host, "/")
+
+	if canonical != original {
+		fmt.Fprintf(os.Stderr, "warning: %s redirects to %s; using the canonical origin\n", original, canonical)
+	}
+
+	return canonical, nil
+}
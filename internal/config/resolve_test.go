@@ -0,0 +1,93 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveBaseURLNoRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &Config{Timeout: 5}
+
+	canonical, err := ResolveBaseURL(cfg, server.URL)
+	if err != nil {
+		t.Fatalf("ResolveBaseURL() error = %v", err)
+	}
+
+	if canonical != server.URL {
+		t.Errorf("ResolveBaseURL() = %q, want %q", canonical, server.URL)
+	}
+}
+
+func TestResolveBaseURLFollowsRedirect(t *testing.T) {
+	finalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer finalServer.Close()
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, finalServer.URL, http.StatusMovedPermanently)
+	}))
+	defer redirectServer.Close()
+
+	cfg := &Config{Timeout: 5, AllowCrossHostRedirects: true}
+
+	canonical, err := ResolveBaseURL(cfg, redirectServer.URL)
+	if err != nil {
+		t.Fatalf("ResolveBaseURL() error = %v", err)
+	}
+
+	if canonical != finalServer.URL {
+		t.Errorf("ResolveBaseURL() = %q, want %q", canonical, finalServer.URL)
+	}
+}
+
+func TestResolveBaseURLStopsAtSameHostWhenCrossHostDisallowed(t *testing.T) {
+	finalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer finalServer.Close()
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, finalServer.URL, http.StatusMovedPermanently)
+	}))
+	defer redirectServer.Close()
+
+	cfg := &Config{Timeout: 5, AllowCrossHostRedirects: false}
+
+	canonical, err := ResolveBaseURL(cfg, redirectServer.URL)
+	if err != nil {
+		t.Fatalf("ResolveBaseURL() error = %v", err)
+	}
+
+	if canonical != redirectServer.URL {
+		t.Errorf("ResolveBaseURL() = %q, want the redirecting server's own origin %q since cross-host redirects are disallowed", canonical, redirectServer.URL)
+	}
+}
+
+func TestResolveBaseURLFallsBackToGetWhenHeadRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &Config{Timeout: 5}
+
+	canonical, err := ResolveBaseURL(cfg, server.URL)
+	if err != nil {
+		t.Fatalf("ResolveBaseURL() error = %v", err)
+	}
+
+	if canonical != server.URL {
+		t.Errorf("ResolveBaseURL() = %q, want %q", canonical, server.URL)
+	}
+}
@@ -0,0 +1,401 @@
+// Package converter renders WordPress's rendered-HTML post/page content to Markdown.
+//
+// It replaces a naive strings.ReplaceAll-based conversion with a real DOM walk (parsed via
+// goquery/golang.org/x/net/html), so nesting, attributes, and block structure survive the
+// conversion: nested lists keep their indentation, <a href>/<img src alt title> keep their
+// targets, and tables become GFM pipe tables instead of being silently dropped.
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Options configures HTMLToMarkdown.
+type Options struct {
+	// Highlight selects how a <pre><code class="language-xxx"> block is rendered: ""/"none"
+	// (default) emits a plain fenced code block, "chroma" pre-renders it to
+	// syntax-highlighted HTML with inline styles, and "html" does the same but with CSS
+	// classes instead (for a site that ships its own chroma stylesheet). See
+	// renderer.renderCodeBlock.
+	Highlight string
+}
+
+// HTMLToMarkdown converts an HTML fragment (as returned by the WordPress REST API's
+// rendered content fields) to Markdown.
+func HTMLToMarkdown(input string, opts Options) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(input))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	bodies := doc.Find("body").Nodes
+	if len(bodies) == 0 {
+		return "", nil
+	}
+
+	r := &renderer{opts: opts}
+	return postProcess(r.render(bodies[0])), nil
+}
+
+var excessBlankLines = regexp.MustCompile(`\n{3,}`)
+
+// postProcess collapses the blank-line runs that naturally occur at the seams between
+// consecutive block elements (e.g. a </p> markdown "\n\n" is immediately followed by a
+// <h2> rendering, which also starts / ends with blank lines) down to the single blank line
+// Markdown actually needs between blocks.
+func postProcess(s string) string {
+	s = excessBlankLines.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+// listContext tracks one level of list nesting: whether it's ordered (and if so, the next
+// item number) so nested <ol>/<ul> pairs each keep their own independent counter.
+type listContext struct {
+	ordered bool
+	index   int
+}
+
+// renderer walks an html.Node tree, converting each node to its Markdown equivalent.
+// Nesting state (list depth/numbering) lives on renderer rather than being threaded through
+// every render call, since only <ul>/<ol>/<li> need it.
+type renderer struct {
+	listStack []listContext
+	opts      Options
+}
+
+func (r *renderer) render(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return collapseWhitespace(n.Data)
+	case html.ElementNode:
+		return r.renderElement(n)
+	default:
+		return r.renderChildren(n)
+	}
+}
+
+func (r *renderer) renderChildren(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(r.render(c))
+	}
+	return b.String()
+}
+
+func (r *renderer) renderElement(n *html.Node) string {
+	switch n.Data {
+	case "script", "style", "head", "noscript":
+		return ""
+
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(n.Data[1] - '0')
+		text := strings.TrimSpace(r.renderChildren(n))
+		return strings.Repeat("#", level) + " " + text + "\n\n"
+
+	case "p":
+		text := strings.TrimSpace(r.renderChildren(n))
+		if text == "" {
+			return ""
+		}
+		return text + "\n\n"
+
+	case "strong", "b":
+		return "**" + strings.TrimSpace(r.renderChildren(n)) + "**"
+
+	case "em", "i":
+		return "*" + strings.TrimSpace(r.renderChildren(n)) + "*"
+
+	case "del", "s", "strike":
+		return "~~" + strings.TrimSpace(r.renderChildren(n)) + "~~"
+
+	case "a":
+		text := strings.TrimSpace(r.renderChildren(n))
+		href := attr(n, "href")
+		if href == "" {
+			return text
+		}
+		return fmt.Sprintf("[%s](%s)", text, href)
+
+	case "img":
+		src := attr(n, "src")
+		alt := attr(n, "alt")
+		title := attr(n, "title")
+		if title != "" {
+			return fmt.Sprintf(`![%s](%s %q)`, alt, src, title)
+		}
+		return fmt.Sprintf("![%s](%s)", alt, src)
+
+	case "br":
+		return "\n"
+
+	case "hr":
+		return "\n---\n\n"
+
+	case "ul":
+		return r.renderList(n, false)
+	case "ol":
+		return r.renderList(n, true)
+	case "li":
+		// Reached only for a stray <li> with no <ul>/<ol> parent - render it as an
+		// unordered item rather than dropping its content.
+		return "- " + strings.TrimSpace(r.renderChildren(n)) + "\n"
+
+	case "blockquote":
+		return r.renderBlockquote(n)
+
+	case "pre":
+		return r.renderCodeBlock(n)
+	case "code":
+		if n.Parent != nil && n.Parent.Data == "pre" {
+			return ""
+		}
+		return "`" + nodeText(n) + "`"
+
+	case "table":
+		return r.renderTable(n) + "\n"
+
+	case "figcaption":
+		caption := strings.TrimSpace(r.renderChildren(n))
+		if caption == "" {
+			return ""
+		}
+		return "\n*" + caption + "*\n"
+
+	case "div", "section", "article", "figure":
+		text := strings.TrimSpace(r.renderChildren(n))
+		if text == "" {
+			return ""
+		}
+		return text + "\n\n"
+
+	default:
+		return r.renderChildren(n)
+	}
+}
+
+// renderList renders a <ul>/<ol>, pushing a fresh listContext so nested lists get their own
+// indentation level and (for <ol>) their own independent item counter.
+func (r *renderer) renderList(n *html.Node, ordered bool) string {
+	r.listStack = append(r.listStack, listContext{ordered: ordered})
+	depth := len(r.listStack)
+
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "li" {
+			b.WriteString(r.renderListItem(c, depth))
+		}
+	}
+
+	r.listStack = r.listStack[:depth-1]
+	if depth == 1 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderListItem renders one <li>, splitting its children into inline content (rendered on
+// the marker's own line) and any nested <ul>/<ol> (rendered as indented blocks beneath it),
+// so a list like "<li>A<ul><li>B</li></ul></li>" becomes "- A\n  - B\n" instead of losing
+// the nesting.
+func (r *renderer) renderListItem(n *html.Node, depth int) string {
+	ctx := &r.listStack[depth-1]
+	var marker string
+	if ctx.ordered {
+		ctx.index++
+		marker = fmt.Sprintf("%d. ", ctx.index)
+	} else {
+		marker = "- "
+	}
+	indent := strings.Repeat("  ", depth-1)
+
+	var inline, nested strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (c.Data == "ul" || c.Data == "ol") {
+			nested.WriteString(r.render(c))
+		} else {
+			inline.WriteString(r.render(c))
+		}
+	}
+
+	text := strings.TrimSpace(collapseWhitespace(inline.String()))
+	line := indent + marker + text + "\n"
+	line += nested.String()
+	return line
+}
+
+// renderBlockquote prefixes every line of its rendered content with "> ", including blank
+// lines (as a bare ">"), matching how Markdown blockquotes keep multi-paragraph content
+// together as a single quoted block.
+func (r *renderer) renderBlockquote(n *html.Node) string {
+	inner := strings.TrimSpace(r.renderChildren(n))
+	if inner == "" {
+		return ""
+	}
+	lines := strings.Split(inner, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
+
+// renderCodeBlock renders a <pre> (optionally wrapping a <code class="language-xxx">) as a
+// fenced code block, detecting the language from that class and using nodeText rather than
+// renderChildren so embedded tags/entities inside the sample don't get Markdown-escaped or
+// whitespace-collapsed. If Options.Highlight is "chroma" or "html", the block is instead
+// pre-rendered to syntax-highlighted HTML (see highlightCode) and embedded as-is - Markdown
+// passes through raw HTML blocks unchanged, so this still lands correctly in the output.
+func (r *renderer) renderCodeBlock(n *html.Node) string {
+	source := n
+	lang := languageFromClass(attr(n, "class"))
+	if code := firstChildElement(n, "code"); code != nil {
+		source = code
+		if lang == "" {
+			lang = languageFromClass(attr(code, "class"))
+		}
+	}
+	code := strings.Trim(nodeText(source), "\n")
+
+	if r.opts.Highlight == "chroma" || r.opts.Highlight == "html" {
+		if rendered, err := highlightCode(lang, code, r.opts.Highlight == "chroma"); err == nil {
+			return rendered + "\n\n"
+		}
+	}
+
+	return "```" + lang + "\n" + code + "\n```\n\n"
+}
+
+// renderTable renders a <table> as a GFM pipe table. The header row is taken from <thead>'s
+// first <tr> if present, falling back to the first <tr> in <tbody>/the table itself -
+// WordPress block-editor tables, and plenty of hand-written HTML, don't always bother with
+// <thead>.
+func (r *renderer) renderTable(n *html.Node) string {
+	var header []string
+	var bodyRows []*html.Node
+
+	if thead := firstChildElement(n, "thead"); thead != nil {
+		if tr := firstChildElement(thead, "tr"); tr != nil {
+			header = r.tableRowCells(tr)
+		}
+	}
+
+	rowSource := n
+	if tbody := firstChildElement(n, "tbody"); tbody != nil {
+		rowSource = tbody
+	}
+	for c := rowSource.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "tr" {
+			bodyRows = append(bodyRows, c)
+		}
+	}
+
+	if header == nil && len(bodyRows) > 0 {
+		header = r.tableRowCells(bodyRows[0])
+		bodyRows = bodyRows[1:]
+	}
+	if header == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	b.WriteString("| " + strings.Join(sep, " | ") + " |\n")
+
+	for _, tr := range bodyRows {
+		row := r.tableRowCells(tr)
+		cells := make([]string, len(header))
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = row[i]
+			}
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+// tableRowCells renders a <tr>'s <td>/<th> children as pipe-table cell text, escaping
+// literal pipes and flattening embedded newlines so they can't break the row out of its
+// single table line.
+func (r *renderer) tableRowCells(tr *html.Node) []string {
+	var cells []string
+	for c := tr.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || (c.Data != "td" && c.Data != "th") {
+			continue
+		}
+		text := strings.TrimSpace(collapseWhitespace(r.renderChildren(c)))
+		text = strings.ReplaceAll(text, "|", `\|`)
+		text = strings.ReplaceAll(text, "\n", " ")
+		cells = append(cells, text)
+	}
+	return cells
+}
+
+var whitespaceRun = regexp.MustCompile(`[ \t\r\n]+`)
+
+// collapseWhitespace folds a run of HTML source whitespace (spaces, tabs, the newlines and
+// indentation between tags) down to a single space, the way a browser would when laying out
+// inline text. It must not be applied inside <pre>/<code> (see nodeText), where whitespace is
+// significant.
+func collapseWhitespace(s string) string {
+	return whitespaceRun.ReplaceAllString(s, " ")
+}
+
+// nodeText concatenates the raw text of n and its descendants, preserving whitespace
+// exactly as parsed - used for <pre>/<code> content, where collapseWhitespace's HTML
+// layout rules don't apply.
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(nodeText(c))
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func firstChildElement(n *html.Node, tag string) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == tag {
+			return c
+		}
+	}
+	return nil
+}
+
+// languageFromClass extracts a code-fence language from a "language-xxx"/"lang-xxx" class
+// token, the convention WordPress's syntax-highlighting blocks and most static-site themes
+// already use for <pre>/<code class="...">.
+func languageFromClass(class string) string {
+	for _, token := range strings.Fields(class) {
+		if lang, ok := strings.CutPrefix(token, "language-"); ok {
+			return lang
+		}
+		if lang, ok := strings.CutPrefix(token, "lang-"); ok {
+			return lang
+		}
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "link with href",
+			html:     `<p>See <a href="https://example.com">our site</a>.</p>`,
+			expected: "See [our site](https://example.com).",
+		},
+		{
+			name:     "image with alt and title",
+			html:     `<img src="/photo.jpg" alt="A photo" title="Cover">`,
+			expected: `![A photo](/photo.jpg "Cover")`,
+		},
+		{
+			name:     "image without title",
+			html:     `<img src="/photo.jpg" alt="A photo">`,
+			expected: "![A photo](/photo.jpg)",
+		},
+		{
+			name:     "ordered list numbering",
+			html:     `<ol><li>First</li><li>Second</li><li>Third</li></ol>`,
+			expected: "1. First\n2. Second\n3. Third",
+		},
+		{
+			name:     "nested unordered list indentation",
+			html:     `<ul><li>Parent<ul><li>Child one</li><li>Child two</li></ul></li></ul>`,
+			expected: "- Parent\n  - Child one\n  - Child two",
+		},
+		{
+			name:     "blockquote prefixes every line",
+			html:     `<blockquote><p>Line one</p><p>Line two</p></blockquote>`,
+			expected: "> Line one\n>\n> Line two",
+		},
+		{
+			name:     "code block with language class",
+			html:     `<pre><code class="language-go">fmt.Println("hi")</code></pre>`,
+			expected: "```go\nfmt.Println(\"hi\")\n```",
+		},
+		{
+			name:     "inline code",
+			html:     `<p>Run <code>go build</code> first.</p>`,
+			expected: "Run `go build` first.",
+		},
+		{
+			name: "gfm table from thead/tbody",
+			html: `<table><thead><tr><th>Name</th><th>Value</th></tr></thead>` +
+				`<tbody><tr><td>a</td><td>1</td></tr><tr><td>b</td><td>2</td></tr></tbody></table>`,
+			expected: "| Name | Value |\n| --- | --- |\n| a | 1 |\n| b | 2 |",
+		},
+		{
+			name:     "bold and italic nesting",
+			html:     `<p><strong>bold <em>and italic</em></strong></p>`,
+			expected: "**bold *and italic***",
+		},
+		{
+			name:     "figure with figcaption",
+			html:     `<figure><img src="/a.jpg" alt="A"><figcaption>Caption text</figcaption></figure>`,
+			expected: "![A](/a.jpg)\n*Caption text*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HTMLToMarkdown(tt.html, Options{})
+			if err != nil {
+				t.Fatalf("HTMLToMarkdown() error = %v", err)
+			}
+			got = strings.TrimSpace(got)
+			if got != tt.expected {
+				t.Errorf("HTMLToMarkdown() =\n%q\nwant:\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHTMLToMarkdownWordPressGalleryBlockKeepsEachImage(t *testing.T) {
+	html := `<div class="wp-block-gallery">` +
+		`<figure class="wp-block-image"><img src="/one.jpg" alt="One"></figure>` +
+		`<figure class="wp-block-image"><img src="/two.jpg" alt="Two"></figure>` +
+		`</div>`
+
+	got, err := HTMLToMarkdown(html, Options{})
+	if err != nil {
+		t.Fatalf("HTMLToMarkdown() error = %v", err)
+	}
+	if !strings.Contains(got, "![One](/one.jpg)") || !strings.Contains(got, "![Two](/two.jpg)") {
+		t.Errorf("HTMLToMarkdown() gallery block dropped an image, got:\n%s", got)
+	}
+}
+
+func TestHTMLToMarkdownGutenbergColumnsFlattenToSequentialParagraphs(t *testing.T) {
+	html := `<div class="wp-block-columns">` +
+		`<div class="wp-block-column"><p>Left column</p></div>` +
+		`<div class="wp-block-column"><p>Right column</p></div>` +
+		`</div>`
+
+	got, err := HTMLToMarkdown(html, Options{})
+	if err != nil {
+		t.Fatalf("HTMLToMarkdown() error = %v", err)
+	}
+	if !strings.Contains(got, "Left column") || !strings.Contains(got, "Right column") {
+		t.Errorf("HTMLToMarkdown() columns block dropped content, got:\n%s", got)
+	}
+}
+
+func TestHTMLToMarkdownEmbedBlockKeepsLinkedURL(t *testing.T) {
+	html := `<figure class="wp-block-embed"><div class="wp-block-embed__wrapper">` +
+		`<a href="https://example.com/video">https://example.com/video</a>` +
+		`</div></figure>`
+
+	got, err := HTMLToMarkdown(html, Options{})
+	if err != nil {
+		t.Fatalf("HTMLToMarkdown() error = %v", err)
+	}
+	if !strings.Contains(got, "[https://example.com/video](https://example.com/video)") {
+		t.Errorf("HTMLToMarkdown() embed block lost its URL, got:\n%s", got)
+	}
+}
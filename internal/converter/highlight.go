@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// highlightCode pre-renders code in lang (falling back to chroma's plain-text lexer when
+// lang is empty or unrecognized) to a syntax-highlighted <pre><code>...</code></pre> block.
+// inlineStyles selects "chroma"-mode output: CSS written directly on each span's style
+// attribute, so the block renders correctly with no separate stylesheet; when false (Options
+// "html"), spans instead carry chroma's default CSS classes, for a site that ships its own
+// stylesheet (e.g. via chroma's own `chroma --html-styles` output).
+func highlightCode(lang, code string, inlineStyles bool) (string, error) {
+	lexer := lexers.Get(lang)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	style := styles.Get("github")
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	formatter := chromahtml.New(chromahtml.WithClasses(!inlineStyles))
+
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := formatter.Format(&b, style, iterator); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLToMarkdownHighlightChromaEmitsInlineStyles(t *testing.T) {
+	input := `<pre><code class="language-go">package main</code></pre>`
+
+	got, err := HTMLToMarkdown(input, Options{Highlight: "chroma"})
+	if err != nil {
+		t.Fatalf("HTMLToMarkdown() error = %v", err)
+	}
+
+	if strings.Contains(got, "```") {
+		t.Errorf("HTMLToMarkdown() with Highlight chroma still emitted a plain fence:\n%s", got)
+	}
+	if !strings.Contains(got, "<pre") || !strings.Contains(got, "style=") {
+		t.Errorf("HTMLToMarkdown() with Highlight chroma = %q, want inline-styled HTML", got)
+	}
+}
+
+func TestHTMLToMarkdownHighlightHTMLEmitsCSSClasses(t *testing.T) {
+	input := `<pre><code class="language-go">package main</code></pre>`
+
+	got, err := HTMLToMarkdown(input, Options{Highlight: "html"})
+	if err != nil {
+		t.Fatalf("HTMLToMarkdown() error = %v", err)
+	}
+
+	if !strings.Contains(got, "class=") {
+		t.Errorf("HTMLToMarkdown() with Highlight html = %q, want CSS classes", got)
+	}
+	if strings.Contains(got, "style=") {
+		t.Errorf("HTMLToMarkdown() with Highlight html unexpectedly emitted inline styles:\n%s", got)
+	}
+}
+
+func TestHTMLToMarkdownHighlightNoneKeepsPlainFence(t *testing.T) {
+	input := `<pre><code class="language-go">package main</code></pre>`
+
+	got, err := HTMLToMarkdown(input, Options{})
+	if err != nil {
+		t.Fatalf("HTMLToMarkdown() error = %v", err)
+	}
+
+	want := "```go\npackage main\n```"
+	if got != want {
+		t.Errorf("HTMLToMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestHighlightCodeFallsBackForUnknownLanguage(t *testing.T) {
+	got, err := highlightCode("not-a-real-language", "hello", true)
+	if err != nil {
+		t.Fatalf("highlightCode() error = %v", err)
+	}
+	if !strings.Contains(got, "hello") {
+		t.Errorf("highlightCode() = %q, want it to contain the source text", got)
+	}
+}
@@ -0,0 +1,83 @@
+// Package deploy pushes an already-written export output directory to a remote target:
+// a git repository, an S3 (or S3-compatible) bucket, or an rsync destination.
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// TargetOptions carries what a Target.Push call needs beyond the local directory itself:
+// the stats of the export being deployed (for a commit message template, or similar) and
+// whether to skip the actual network call.
+type TargetOptions struct {
+	// Stats is the export's summary counts, available to a Target's commit message
+	// template (see CommitMessageData).
+	Stats models.ExportStats
+	// DryRun, when true, has a Target log what it would do without making any network
+	// calls or writing to the remote.
+	DryRun bool
+}
+
+// Target pushes localDir's content to a remote destination.
+type Target interface {
+	// Push uploads localDir's content to the destination Target was configured for,
+	// using opts for any per-deploy metadata (commit message stats, dry-run).
+	Push(ctx context.Context, localDir string, opts TargetOptions) error
+}
+
+// localOnlyFiles are never pushed to a remote: they're bookkeeping sidecars the exporter
+// and deploy targets use to track state between runs, not site content.
+var localOnlyFiles = map[string]bool{
+	".wpexporter-manifest.json":      true,
+	deployManifestFileName:           true,
+	".wpexport-state.json":           true,
+	".wpexport-media-manifest.json":  true,
+	".wpexport-scan-checkpoint.json": true,
+}
+
+// isLocalOnlyFile reports whether relPath (the file's path relative to the export's
+// output directory, forward-slashed) is bookkeeping that should never reach a deploy
+// target. Besides the default sidecar names in localOnlyFiles, any dotfile is treated as
+// local-only too, so a --state-file/--scan-checkpoint-path renamed to a different dotfile
+// name is still excluded; a custom name without a leading dot isn't recognizable as
+// bookkeeping and would need to live outside the output directory to stay unpublished.
+func isLocalOnlyFile(relPath string) bool {
+	if localOnlyFiles[relPath] {
+		return true
+	}
+	return strings.HasPrefix(filepath.Base(relPath), ".")
+}
+
+// rsyncExcludedSidecars returns localOnlyFiles' keys in sorted order, for RsyncTarget to
+// pass as root-anchored --exclude patterns. rsync's own exclusion is directory-based, so
+// (unlike isLocalOnlyFile's dotfile-name fallback) it can't safely generalize to "any
+// dotfile" without also dropping legitimate dot-prefixed content directories.
+func rsyncExcludedSidecars() []string {
+	names := make([]string, 0, len(localOnlyFiles))
+	for name := range localOnlyFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New constructs the Target selected by cfg.Type.
+func New(cfg config.DeployConfig) (Target, error) {
+	switch cfg.Type {
+	case "git":
+		return NewGitTarget(cfg.Git), nil
+	case "s3":
+		return NewS3Target(cfg.S3), nil
+	case "rsync":
+		return NewRsyncTarget(cfg.Rsync), nil
+	default:
+		return nil, fmt.Errorf("unknown deploy type %q: must be one of 'git', 's3', or 'rsync'", cfg.Type)
+	}
+}
@@ -0,0 +1,23 @@
+package deploy
+
+import "testing"
+
+func TestIsLocalOnlyFile(t *testing.T) {
+	tests := []struct {
+		relPath string
+		want    bool
+	}{
+		{".wpexporter-manifest.json", true},
+		{".wpexport-state.json", true},
+		{"posts/uncategorized/hello-world.md", false},
+		{"index.html", false},
+		{".wpexport-state-custom.json", true},
+		{"posts/.hidden-draft.md", true},
+	}
+
+	for _, tt := range tests {
+		if got := isLocalOnlyFile(tt.relPath); got != tt.want {
+			t.Errorf("isLocalOnlyFile(%q) = %v, want %v", tt.relPath, got, tt.want)
+		}
+	}
+}
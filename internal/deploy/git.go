@@ -0,0 +1,176 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// defaultCommitMessageTemplate is used when GitTargetConfig.CommitMessage is empty.
+const defaultCommitMessageTemplate = `Deploy export: {{.Stats.TotalPosts}} posts, {{.Stats.TotalPages}} pages, {{.Stats.TotalMedia}} media ({{.Time.Format "2006-01-02 15:04:05"}})`
+
+// CommitMessageData is what GitTarget renders GitTargetConfig.CommitMessage against.
+type CommitMessageData struct {
+	// Stats is the deployed export's summary counts, as passed in TargetOptions.
+	Stats models.ExportStats
+	// Time is when the deploy ran.
+	Time time.Time
+}
+
+// GitTarget is a Target that clones (or reuses a prior clone of) a git repository, copies
+// localDir's content into it, commits with a configurable message template, and pushes.
+type GitTarget struct {
+	cfg config.GitTargetConfig
+}
+
+// NewGitTarget returns a GitTarget for cfg.
+func NewGitTarget(cfg config.GitTargetConfig) *GitTarget {
+	return &GitTarget{cfg: cfg}
+}
+
+// Push clones cfg.URL into a temporary directory, replaces its tracked content with
+// localDir's, commits (using cfg.CommitMessage rendered against opts.Stats, or
+// defaultCommitMessageTemplate when empty), and pushes cfg.Branch.
+func (t *GitTarget) Push(ctx context.Context, localDir string, opts TargetOptions) error {
+	branch := t.cfg.Branch
+	if branch == "" {
+		branch = "main"
+	}
+
+	msg, err := t.renderCommitMessage(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		fmt.Printf("[dry-run] would clone %s (branch %s), replace its content with %s, commit %q, and push\n", t.cfg.URL, branch, localDir, msg)
+		return nil
+	}
+
+	cloneDir, err := os.MkdirTemp("", "wpexporter-deploy-git-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary clone directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(cloneDir) }()
+
+	if err := t.run(ctx, "", "git", "clone", "--branch", branch, "--single-branch", t.cfg.URL, cloneDir); err != nil {
+		if err := t.run(ctx, "", "git", "clone", t.cfg.URL, cloneDir); err != nil {
+			return fmt.Errorf("failed to clone %s: %w", t.cfg.URL, err)
+		}
+		if err := t.run(ctx, cloneDir, "git", "checkout", "-B", branch); err != nil {
+			return fmt.Errorf("failed to create branch %s: %w", branch, err)
+		}
+	}
+
+	if err := replaceTrackedContent(cloneDir, localDir); err != nil {
+		return err
+	}
+
+	if err := t.run(ctx, cloneDir, "git", "add", "-A"); err != nil {
+		return fmt.Errorf("failed to stage changes: %w", err)
+	}
+
+	if err := t.run(ctx, cloneDir, "git", "commit", "-m", msg); err != nil {
+		// A clean tree (nothing changed since the last deploy) isn't a failure.
+		if err := t.run(ctx, cloneDir, "git", "diff", "--cached", "--quiet"); err == nil {
+			return nil
+		}
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	if err := t.run(ctx, cloneDir, "git", "push", "origin", "HEAD:"+branch); err != nil {
+		return fmt.Errorf("failed to push to %s: %w", t.cfg.URL, err)
+	}
+
+	return nil
+}
+
+// renderCommitMessage renders cfg.CommitMessage (or defaultCommitMessageTemplate when
+// empty) against opts.Stats and the current time.
+func (t *GitTarget) renderCommitMessage(opts TargetOptions) (string, error) {
+	tmplText := t.cfg.CommitMessage
+	if tmplText == "" {
+		tmplText = defaultCommitMessageTemplate
+	}
+
+	tmpl, err := template.New("commit_message").Parse(tmplText)
+	if err != nil {
+		return "", fmt.Errorf("invalid deploy.git.commit_message template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, CommitMessageData{Stats: opts.Stats, Time: time.Now()}); err != nil {
+		return "", fmt.Errorf("failed to render deploy.git.commit_message template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// run executes name with args, optionally in dir (the process's own working directory
+// when dir is empty), surfacing stderr on failure.
+func (t *GitTarget) run(ctx context.Context, dir, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %v: %w: %s", name, args, err, out)
+	}
+	return nil
+}
+
+// replaceTrackedContent removes every entry under cloneDir except .git, then copies
+// localDir's content in - skipping localOnlyFiles, the exporter's own bookkeeping
+// sidecars - so the clone ends up mirroring localDir's site content exactly (deletions in
+// localDir since the last deploy are reflected as deletions in the clone too).
+func replaceTrackedContent(cloneDir, localDir string) error {
+	entries, err := os.ReadDir(cloneDir)
+	if err != nil {
+		return fmt.Errorf("failed to list clone directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == ".git" {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(cloneDir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to clear %s from clone: %w", entry.Name(), err)
+		}
+	}
+
+	return filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		if !info.IsDir() && isLocalOnlyFile(filepath.ToSlash(relPath)) {
+			return nil
+		}
+		dest := filepath.Join(cloneDir, relPath)
+
+		if info.IsDir() {
+			return os.MkdirAll(dest, 0750)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return err
+		}
+		return os.WriteFile(dest, data, 0644)
+	})
+}
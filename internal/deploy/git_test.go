@@ -0,0 +1,95 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestGitTargetRenderCommitMessageDefault(t *testing.T) {
+	target := NewGitTarget(config.GitTargetConfig{})
+
+	msg, err := target.renderCommitMessage(TargetOptions{Stats: models.ExportStats{TotalPosts: 3, TotalPages: 1, TotalMedia: 5}})
+	if err != nil {
+		t.Fatalf("renderCommitMessage() error = %v", err)
+	}
+	if !containsAll(msg, "3 posts", "1 pages", "5 media") {
+		t.Errorf("renderCommitMessage() = %q, want it to mention post/page/media counts", msg)
+	}
+}
+
+func TestGitTargetRenderCommitMessageCustomTemplate(t *testing.T) {
+	target := NewGitTarget(config.GitTargetConfig{CommitMessage: "sync: {{.Stats.TotalPosts}} posts"})
+
+	msg, err := target.renderCommitMessage(TargetOptions{Stats: models.ExportStats{TotalPosts: 7}})
+	if err != nil {
+		t.Fatalf("renderCommitMessage() error = %v", err)
+	}
+	if msg != "sync: 7 posts" {
+		t.Errorf("renderCommitMessage() = %q, want %q", msg, "sync: 7 posts")
+	}
+}
+
+func TestGitTargetRenderCommitMessageInvalidTemplate(t *testing.T) {
+	target := NewGitTarget(config.GitTargetConfig{CommitMessage: "{{.Stats.Nope"})
+
+	if _, err := target.renderCommitMessage(TargetOptions{}); err == nil {
+		t.Error("renderCommitMessage() expected error for invalid template, got nil")
+	}
+}
+
+func TestReplaceTrackedContentMirrorsLocalDirAndRemovesStaleFiles(t *testing.T) {
+	cloneDir := t.TempDir()
+	localDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(cloneDir, ".git"), 0750); err != nil {
+		t.Fatalf("failed to create .git dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cloneDir, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0644); err != nil {
+		t.Fatalf("failed to seed .git/HEAD: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cloneDir, "stale.html"), []byte("old"), 0644); err != nil {
+		t.Fatalf("failed to seed stale file: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(localDir, "index.html"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to seed local file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, ".wpexporter-manifest.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("failed to seed export manifest sidecar: %v", err)
+	}
+
+	if err := replaceTrackedContent(cloneDir, localDir); err != nil {
+		t.Fatalf("replaceTrackedContent() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(cloneDir, "stale.html")); !os.IsNotExist(err) {
+		t.Error("expected stale.html to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(cloneDir, ".git", "HEAD")); err != nil {
+		t.Errorf("expected .git to survive, stat error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(cloneDir, "index.html"))
+	if err != nil {
+		t.Fatalf("expected index.html to be copied in: %v", err)
+	}
+	if string(data) != "new" {
+		t.Errorf("index.html content = %q, want %q", data, "new")
+	}
+	if _, err := os.Stat(filepath.Join(cloneDir, ".wpexporter-manifest.json")); !os.IsNotExist(err) {
+		t.Error("expected .wpexporter-manifest.json to be excluded from the clone")
+	}
+}
+
+func containsAll(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// deployManifestFileName is a sidecar JSON file persisted at the export output root,
+// alongside export.exportManifestFileName, recording the content hash S3Target last
+// uploaded for every relative path so a later deploy can skip re-uploading anything
+// unchanged since.
+const deployManifestFileName = ".wpexporter-deploy-manifest.json"
+
+// deployManifest is S3Target's own record of what it last pushed, keyed by path relative
+// to the export output root (forward-slash separated). It follows the same load/save
+// idiom as export.ExportManifest and internal/state.State: a zero value is returned (not
+// an error) when the file doesn't exist yet.
+type deployManifest struct {
+	Hashes map[string]string `json:"hashes"`
+
+	path string
+}
+
+// loadDeployManifest reads a deployManifest from path, returning an empty, unsaved one if
+// the file doesn't exist yet (the first deploy).
+func loadDeployManifest(path string) (*deployManifest, error) {
+	m := &deployManifest{Hashes: map[string]string{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to read deploy manifest %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse deploy manifest %s: %w", path, err)
+	}
+	m.path = path
+
+	return m, nil
+}
+
+// save writes the manifest to its backing path.
+func (m *deployManifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal deploy manifest: %w", err)
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write deploy manifest %s: %w", m.path, err)
+	}
+	return nil
+}
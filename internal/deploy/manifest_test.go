@@ -0,0 +1,39 @@
+package deploy
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDeployManifestMissingFileReturnsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), deployManifestFileName)
+
+	m, err := loadDeployManifest(path)
+	if err != nil {
+		t.Fatalf("loadDeployManifest() error = %v", err)
+	}
+	if len(m.Hashes) != 0 {
+		t.Errorf("Hashes = %v, want empty", m.Hashes)
+	}
+}
+
+func TestDeployManifestSaveAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), deployManifestFileName)
+
+	m, err := loadDeployManifest(path)
+	if err != nil {
+		t.Fatalf("loadDeployManifest() error = %v", err)
+	}
+	m.Hashes["posts/uncategorized/test-post.md"] = "deadbeef"
+	if err := m.save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	reloaded, err := loadDeployManifest(path)
+	if err != nil {
+		t.Fatalf("reload loadDeployManifest() error = %v", err)
+	}
+	if got := reloaded.Hashes["posts/uncategorized/test-post.md"]; got != "deadbeef" {
+		t.Errorf("reloaded hash = %q, want %q", got, "deadbeef")
+	}
+}
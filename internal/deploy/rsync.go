@@ -0,0 +1,61 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// RsyncTarget is a Target that shells out to rsync to mirror localDir onto a remote host
+// over SSH, deleting anything on the remote no longer present locally.
+type RsyncTarget struct {
+	cfg config.RsyncTargetConfig
+}
+
+// NewRsyncTarget returns an RsyncTarget for cfg.
+func NewRsyncTarget(cfg config.RsyncTargetConfig) *RsyncTarget {
+	return &RsyncTarget{cfg: cfg}
+}
+
+// Push runs `rsync -avz --delete localDir/ <destination>`, using cfg.Port/cfg.User to
+// build the SSH transport rsync connects over.
+func (t *RsyncTarget) Push(ctx context.Context, localDir string, opts TargetOptions) error {
+	destination := t.destination()
+	source := strings.TrimSuffix(localDir, "/") + "/"
+
+	// Exclude localOnlyFiles' bookkeeping sidecars by their exact root-anchored name, the
+	// same ones GitTarget/S3Target skip via isLocalOnlyFile - unlike a blanket ".*"
+	// pattern, this doesn't also drop legitimate dot-prefixed content directories (e.g.
+	// Exporter.exportActivityPub's ".well-known/webfinger/").
+	args := []string{"-avz", "--delete"}
+	for _, name := range rsyncExcludedSidecars() {
+		args = append(args, "--exclude=/"+name)
+	}
+	if opts.DryRun {
+		args = append(args, "--dry-run")
+	}
+	if t.cfg.Port != 0 {
+		args = append(args, "-e", fmt.Sprintf("ssh -p %d", t.cfg.Port))
+	}
+	args = append(args, source, destination)
+
+	cmd := exec.CommandContext(ctx, "rsync", args...)
+	out, err := cmd.CombinedOutput()
+	fmt.Print(string(out))
+	if err != nil {
+		return fmt.Errorf("rsync to %s failed: %w", destination, err)
+	}
+	return nil
+}
+
+// destination returns rsync's remote destination argument, e.g. "user@host:/path".
+func (t *RsyncTarget) destination() string {
+	host := t.cfg.Host
+	if t.cfg.User != "" {
+		host = t.cfg.User + "@" + host
+	}
+	return host + ":" + t.cfg.Path
+}
@@ -0,0 +1,35 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestRsyncTargetDestination(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.RsyncTargetConfig
+		want string
+	}{
+		{
+			name: "host and path only",
+			cfg:  config.RsyncTargetConfig{Host: "example.com", Path: "/var/www/example.com"},
+			want: "example.com:/var/www/example.com",
+		},
+		{
+			name: "with user",
+			cfg:  config.RsyncTargetConfig{Host: "example.com", Path: "/var/www/example.com", User: "deploy"},
+			want: "deploy@example.com:/var/www/example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NewRsyncTarget(tt.cfg)
+			if got := target.destination(); got != tt.want {
+				t.Errorf("destination() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
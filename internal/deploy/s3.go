@@ -0,0 +1,227 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/internal/export"
+)
+
+// S3Target is a Target that walks an export's output directory and uploads it to an S3
+// (or S3-compatible) bucket, skipping any file whose content hasn't changed since the
+// last deploy (see deployManifest) and, for posts/pages, reusing the content hash already
+// recorded by the incremental-export manifest (see export.ExportManifest) instead of
+// re-hashing the file itself. Any object a previous deploy uploaded that this run no
+// longer finds locally (e.g. a post pruned from the export) is deleted from the bucket,
+// so it mirrors localDir the same way GitTarget and RsyncTarget do.
+type S3Target struct {
+	cfg config.S3TargetConfig
+}
+
+// NewS3Target returns an S3Target for cfg.
+func NewS3Target(cfg config.S3TargetConfig) *S3Target {
+	return &S3Target{cfg: cfg}
+}
+
+// Push uploads every changed file under localDir to t.cfg.Bucket, keyed by t.cfg.Prefix
+// joined with its path relative to localDir, then invalidates
+// t.cfg.CloudFrontDistributionID when set and the run uploaded or deleted anything.
+func (t *S3Target) Push(ctx context.Context, localDir string, opts TargetOptions) error {
+	exportManifest, err := export.LoadExportManifest(filepath.Join(localDir, ".wpexporter-manifest.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read export manifest: %w", err)
+	}
+	knownHashes := map[string]string{}
+	for _, entry := range exportManifest.Entries {
+		knownHashes[filepath.ToSlash(entry.OutputPath)] = entry.Hash
+	}
+
+	deployManifestPath := filepath.Join(localDir, deployManifestFileName)
+	prior, err := loadDeployManifest(deployManifestPath)
+	if err != nil {
+		return err
+	}
+
+	var client *s3.Client
+	var uploader *manager.Uploader
+	if !opts.DryRun {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(t.cfg.Region))
+		if err != nil {
+			return fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		client = s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+			if t.cfg.Endpoint != "" {
+				o.BaseEndpoint = aws.String(t.cfg.Endpoint)
+				o.UsePathStyle = true
+			}
+		})
+		uploader = manager.NewUploader(client)
+	}
+
+	uploaded := map[string]string{}
+	err = filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if isLocalOnlyFile(relPath) {
+			return nil
+		}
+
+		hash, ok := knownHashes[relPath]
+		if !ok {
+			hash, err = hashFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to hash %s: %w", relPath, err)
+			}
+		}
+		uploaded[relPath] = hash
+
+		if prior.Hashes[relPath] == hash {
+			return nil
+		}
+
+		if opts.DryRun {
+			fmt.Printf("[dry-run] would upload %s to s3://%s/%s\n", relPath, t.cfg.Bucket, t.key(relPath))
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer func() { _ = f.Close() }()
+
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(t.cfg.Bucket),
+			Key:    aws.String(t.key(relPath)),
+			Body:   f,
+		}
+		if contentType := mime.TypeByExtension(filepath.Ext(path)); contentType != "" {
+			input.ContentType = aws.String(contentType)
+		}
+		if t.cfg.CacheControl != "" {
+			input.CacheControl = aws.String(t.cfg.CacheControl)
+		}
+
+		if _, err := uploader.Upload(ctx, input); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", relPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Delete anything the previous deploy pushed that this run no longer sees locally -
+	// e.g. a post removed from WordPress and pruned from the export (see --prune) - so the
+	// bucket stays a mirror of localDir instead of accumulating orphaned objects forever.
+	for relPath := range prior.Hashes {
+		if _, stillPresent := uploaded[relPath]; stillPresent {
+			continue
+		}
+		if opts.DryRun {
+			fmt.Printf("[dry-run] would delete s3://%s/%s\n", t.cfg.Bucket, t.key(relPath))
+			continue
+		}
+		if _, err := client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(t.cfg.Bucket),
+			Key:    aws.String(t.key(relPath)),
+		}); err != nil {
+			return fmt.Errorf("failed to delete stale object %s: %w", relPath, err)
+		}
+	}
+
+	if opts.DryRun {
+		return nil
+	}
+
+	changed := !reflect.DeepEqual(prior.Hashes, uploaded)
+	prior.Hashes = uploaded
+	if err := prior.save(); err != nil {
+		return err
+	}
+
+	if changed && t.cfg.CloudFrontDistributionID != "" {
+		if err := t.invalidateCloudFront(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// key returns relPath's full S3 object key, with cfg.Prefix prepended.
+func (t *S3Target) key(relPath string) string {
+	prefix := strings.Trim(t.cfg.Prefix, "/")
+	if prefix == "" {
+		return relPath
+	}
+	return prefix + "/" + relPath
+}
+
+// invalidateCloudFront invalidates every path ("/*") on cfg.CloudFrontDistributionID, so
+// viewers don't keep seeing objects this deploy just replaced.
+func (t *S3Target) invalidateCloudFront(ctx context.Context) error {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(t.cfg.Region))
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config for CloudFront invalidation: %w", err)
+	}
+	client := cloudfront.NewFromConfig(awsCfg)
+
+	callerRef := fmt.Sprintf("wpexporter-deploy-%d", time.Now().UnixNano())
+	_, err = client.CreateInvalidation(ctx, &cloudfront.CreateInvalidationInput{
+		DistributionId: aws.String(t.cfg.CloudFrontDistributionID),
+		InvalidationBatch: &cftypes.InvalidationBatch{
+			CallerReference: aws.String(callerRef),
+			Paths: &cftypes.Paths{
+				Quantity: aws.Int32(1),
+				Items:    []string{"/*"},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invalidate CloudFront distribution %s: %w", t.cfg.CloudFrontDistributionID, err)
+	}
+	return nil
+}
+
+// hashFile returns path's content SHA-256 digest, hex-encoded.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,174 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tradik/wpexporter/internal/activitypub"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// activityPubPageSize bounds each OrderedCollectionPage, matching the page size the API
+// client uses when paginating the WordPress REST API.
+const activityPubPageSize = 100
+
+// exportActivityPub renders posts and authors as a static ActivityStreams 2.0 /
+// WebFinger directory tree that can be served read-only behind a reverse proxy.
+func (e *Exporter) exportActivityPub(data *models.ExportData) error {
+	baseURL := e.siteBaseURL(data.Site)
+	host := e.siteHost(data.Site)
+
+	actorsDir := filepath.Join(e.config.Output, "actors")
+	notesDir := filepath.Join(e.config.Output, "notes")
+	outboxDir := filepath.Join(e.config.Output, "outbox")
+	webfingerDir := filepath.Join(e.config.Output, ".well-known", "webfinger")
+
+	for _, dir := range []string{actorsDir, notesDir, outboxDir, webfingerDir} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", dir, err)
+		}
+	}
+
+	tagMap := make(map[int]models.WordPressTag, len(data.Tags))
+	for _, tag := range data.Tags {
+		tagMap[tag.ID] = tag
+	}
+
+	mediaMap := make(map[int]models.WordPressMedia, len(data.Media))
+	for _, m := range data.Media {
+		mediaMap[m.ID] = m
+	}
+
+	actorURLs := make(map[int]string, len(data.Users))
+	postsByAuthor := make(map[int][]models.WordPressPost)
+
+	for _, user := range data.Users {
+		if err := e.exportActivityPubActor(user, baseURL, host, actorsDir, webfingerDir, actorURLs); err != nil {
+			return err
+		}
+	}
+
+	for _, post := range data.Posts {
+		postsByAuthor[post.Author] = append(postsByAuthor[post.Author], post)
+
+		var tags []models.WordPressTag
+		for _, tagID := range post.Tags {
+			if tag, ok := tagMap[tagID]; ok {
+				tags = append(tags, tag)
+			}
+		}
+
+		var featuredMedia *models.WordPressMedia
+		if post.FeaturedMedia > 0 {
+			if m, ok := mediaMap[post.FeaturedMedia]; ok {
+				featuredMedia = &m
+			}
+		}
+
+		obj := activitypub.BuildObject(post, "Note", actorURLs[post.Author], baseURL, tags, featuredMedia)
+		if err := e.writeJSON(filepath.Join(notesDir, fmt.Sprintf("%d.json", post.ID)), obj); err != nil {
+			return err
+		}
+	}
+
+	for _, user := range data.Users {
+		if err := e.writeActorOutbox(outboxDir, baseURL, user, postsByAuthor[user.ID]); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", e.config.Output)
+	return nil
+}
+
+// exportActivityPubActor generates a fresh keypair for user, writes its Actor document,
+// private key, and WebFinger JRD, and records the actor's URL in actorURLs.
+func (e *Exporter) exportActivityPubActor(user models.WordPressUser, baseURL, host, actorsDir, webfingerDir string, actorURLs map[int]string) error {
+	keyPair, err := activitypub.GenerateKeyPair()
+	if err != nil {
+		return fmt.Errorf("failed to generate keypair for %s: %w", user.Slug, err)
+	}
+
+	actor := activitypub.BuildActor(user, baseURL, keyPair.PublicKeyPEM)
+	actorURLs[user.ID] = actor.ID
+
+	if err := e.writeJSON(filepath.Join(actorsDir, user.Slug+".json"), actor); err != nil {
+		return err
+	}
+
+	privateKeyPath := filepath.Join(actorsDir, user.Slug+".private.pem")
+	if err := os.WriteFile(privateKeyPath, []byte(keyPair.PrivateKeyPEM), 0600); err != nil {
+		return fmt.Errorf("failed to write private key for %s: %w", user.Slug, err)
+	}
+
+	webfinger := activitypub.BuildWebFinger(user.Slug, host, actor.ID)
+	webfingerPath := filepath.Join(webfingerDir, fmt.Sprintf("%s@%s.json", user.Slug, host))
+	return e.writeJSON(webfingerPath, webfinger)
+}
+
+// writeActorOutbox writes an actor's OrderedCollection plus its paginated
+// OrderedCollectionPage documents, matching the API client's pagination page size.
+func (e *Exporter) writeActorOutbox(outboxDir, baseURL string, user models.WordPressUser, posts []models.WordPressPost) error {
+	actorOutboxDir := filepath.Join(outboxDir, user.Slug)
+	if err := os.MkdirAll(actorOutboxDir, 0750); err != nil {
+		return fmt.Errorf("failed to create outbox directory for %s: %w", user.Slug, err)
+	}
+
+	outboxURL := fmt.Sprintf("%s/outbox/%s", baseURL, user.Slug)
+
+	collection := activitypub.OrderedCollection{
+		Context:    []string{activitypub.ContextURL},
+		ID:         outboxURL,
+		Type:       "OrderedCollection",
+		TotalItems: len(posts),
+	}
+
+	if len(posts) > 0 {
+		collection.First = outboxURL + "/page-1"
+	}
+
+	if err := e.writeJSON(filepath.Join(actorOutboxDir, "index.json"), collection); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(posts); i += activityPubPageSize {
+		end := i + activityPubPageSize
+		if end > len(posts) {
+			end = len(posts)
+		}
+
+		pageNum := i/activityPubPageSize + 1
+		page := activitypub.OrderedCollectionPage{
+			Context: []string{activitypub.ContextURL},
+			ID:      fmt.Sprintf("%s/page-%d", outboxURL, pageNum),
+			Type:    "OrderedCollectionPage",
+			PartOf:  outboxURL,
+		}
+
+		if end < len(posts) {
+			page.Next = fmt.Sprintf("%s/page-%d", outboxURL, pageNum+1)
+		}
+
+		for _, post := range posts[i:end] {
+			page.OrderedItems = append(page.OrderedItems, fmt.Sprintf("%s/notes/%d", baseURL, post.ID))
+		}
+
+		pagePath := filepath.Join(actorOutboxDir, fmt.Sprintf("page-%d.json", pageNum))
+		if err := e.writeJSON(pagePath, page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeJSON marshals v as indented JSON and writes it to path.
+func (e *Exporter) writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
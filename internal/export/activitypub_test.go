@@ -0,0 +1,59 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestExportActivityPub(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir, Format: "activitypub"}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site:  models.SiteInfo{URL: "https://example.com"},
+		Users: []models.WordPressUser{{ID: 1, Slug: "jdoe", Name: "Jane Doe"}},
+		Posts: []models.WordPressPost{
+			{ID: 1, Author: 1, Content: models.RenderedContent{Rendered: "Hello"}, Link: "https://example.com/hello"},
+		},
+	}
+
+	if err := exporter.exportActivityPub(data); err != nil {
+		t.Fatalf("exportActivityPub() error = %v", err)
+	}
+
+	actorPath := filepath.Join(tmpDir, "actors", "jdoe.json")
+	if _, err := os.Stat(actorPath); err != nil {
+		t.Errorf("expected actor document at %s: %v", actorPath, err)
+	}
+
+	privateKeyPath := filepath.Join(tmpDir, "actors", "jdoe.private.pem")
+	if _, err := os.Stat(privateKeyPath); err != nil {
+		t.Errorf("expected private key at %s: %v", privateKeyPath, err)
+	}
+
+	webfingerPath := filepath.Join(tmpDir, ".well-known", "webfinger", "jdoe@example.com.json")
+	if _, err := os.Stat(webfingerPath); err != nil {
+		t.Errorf("expected webfinger document at %s: %v", webfingerPath, err)
+	}
+
+	notePath := filepath.Join(tmpDir, "notes", "1.json")
+	if _, err := os.Stat(notePath); err != nil {
+		t.Errorf("expected note document at %s: %v", notePath, err)
+	}
+
+	outboxIndexPath := filepath.Join(tmpDir, "outbox", "jdoe", "index.json")
+	if _, err := os.Stat(outboxIndexPath); err != nil {
+		t.Errorf("expected outbox index at %s: %v", outboxIndexPath, err)
+	}
+
+	outboxPagePath := filepath.Join(tmpDir, "outbox", "jdoe", "page-1.json")
+	if _, err := os.Stat(outboxPagePath); err != nil {
+		t.Errorf("expected outbox page at %s: %v", outboxPagePath, err)
+	}
+}
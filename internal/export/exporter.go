@@ -1,23 +1,41 @@
 package export
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/internal/converter"
 	"github.com/tradik/wpexporter/internal/media"
+	"github.com/tradik/wpexporter/internal/progress"
 	"github.com/tradik/wpexporter/pkg/models"
+	"golang.org/x/sync/errgroup"
 )
 
 // Exporter handles data export functionality
 type Exporter struct {
 	config     *config.Config
 	downloader *media.Downloader
+	progress   *progress.Manager
+
+	// dirLocks serializes MkdirAll calls per directory path (see ensureDir) so concurrent
+	// exportPostsWithCategories/exportPostsMarkdown workers writing into the same shared
+	// category folder don't race on its creation or redundantly re-stat/re-create a
+	// directory another worker just made.
+	dirLocks sync.Map
+
+	// htmlStats accumulates tags/classes/IDs seen by convertHTMLToMarkdown when
+	// Config.WriteStats is set, for writeStats to dump as stats.json at the end of Export.
+	htmlStats *htmlStatsCollector
 }
 
 // NewExporter creates a new exporter instance
@@ -25,7 +43,123 @@ func NewExporter(cfg *config.Config) *Exporter {
 	return &Exporter{
 		config:     cfg,
 		downloader: media.NewDownloader(cfg),
+		progress:   progress.New(cfg),
+		htmlStats:  newHTMLStatsCollector(),
+	}
+}
+
+// exportConcurrency returns the worker pool size exportPostsWithCategories and
+// exportPostsMarkdown fan out across: Config.Concurrency if set, otherwise
+// runtime.NumCPU() - unlike BruteForceContent's Concurrency fallback chain (see
+// api.BruteForceContentWithOptions), a markdown export isn't network-bound, so it's worth
+// defaulting to every available core rather than falling back to a single worker.
+func (e *Exporter) exportConcurrency() int {
+	if e.config.Concurrency > 0 {
+		return e.config.Concurrency
 	}
+	return runtime.NumCPU()
+}
+
+// ensureDir creates dir (and any missing parents) the same way os.MkdirAll does, but holds
+// a per-path lock for the duration so concurrent callers targeting the same directory (e.g.
+// two posts in the same category) serialize instead of racing.
+func (e *Exporter) ensureDir(dir string) error {
+	lockIface, _ := e.dirLocks.LoadOrStore(dir, &sync.Mutex{})
+	lock := lockIface.(*sync.Mutex)
+	lock.Lock()
+	defer lock.Unlock()
+	return os.MkdirAll(dir, 0750)
+}
+
+// exportManifestTracker bundles the state exportPostsWithCategories/exportPostsMarkdown's
+// worker pools need around the on-disk ExportManifest for one export run: the manifest
+// itself, the run's ExportStats (to bump Skipped), and which post/page IDs were actually
+// seen this run (so pruneStaleFiles knows what's no longer present).
+type exportManifestTracker struct {
+	manifest *ExportManifest
+	stats    *models.ExportStats
+
+	mu   sync.Mutex
+	seen map[int]bool
+}
+
+// newExportManifestTracker creates a tracker over manifest, recording skip counts into stats.
+func newExportManifestTracker(manifest *ExportManifest, stats *models.ExportStats) *exportManifestTracker {
+	return &exportManifestTracker{manifest: manifest, stats: stats, seen: make(map[int]bool)}
+}
+
+func (t *exportManifestTracker) markSeen(postID int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seen[postID] = true
+}
+
+func (t *exportManifestTracker) incSkipped() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats.Skipped++
+}
+
+// writeMarkdownIfChanged writes content to filePath unless the export manifest shows the
+// post is already there unchanged and the file is actually still present on disk, in which
+// case it records a skip instead (bypassed entirely by Config.Force). If the post was
+// previously written to a different path (e.g. a category rename), the stale file at that
+// old path is removed before writing the new one.
+func (e *Exporter) writeMarkdownIfChanged(tracker *exportManifestTracker, post models.WordPressPost, filePath, content string) error {
+	tracker.markSeen(post.ID)
+
+	relPath, err := filepath.Rel(e.config.Output, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output-relative path for %s: %w", filePath, err)
+	}
+	hash := HashContent(content)
+
+	prev, ok := tracker.manifest.Get(post.ID)
+	if !e.config.Force && ok && prev.Hash == hash && prev.OutputPath == relPath {
+		if _, err := os.Stat(filePath); err == nil {
+			tracker.incSkipped()
+			return nil
+		}
+		// Manifest says this is already written, but the file itself is missing (deleted
+		// out from under us, or a previous run crashed after Set but before the write) -
+		// fall through and write it rather than skipping a post that isn't actually there.
+	}
+
+	if ok && prev.OutputPath != relPath {
+		oldPath := filepath.Join(e.config.Output, prev.OutputPath)
+		if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale file %s: %w", oldPath, err)
+		}
+	}
+
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		return err
+	}
+
+	tracker.manifest.Set(post.ID, ExportManifestEntry{
+		Modified:   post.Modified.Format(time.RFC3339),
+		Hash:       hash,
+		OutputPath: relPath,
+	})
+	return nil
+}
+
+// pruneStaleFiles removes the on-disk file (and manifest entry) for every post/page
+// recorded in tracker's manifest that wasn't seen during this run, i.e. posts that have
+// been deleted or unpublished since the last export. Only called when Config.Prune is set,
+// and only after both worker pools have finished (it isn't safe for concurrent callers).
+func (e *Exporter) pruneStaleFiles(tracker *exportManifestTracker) error {
+	for postID, entry := range tracker.manifest.Entries {
+		if tracker.seen[postID] {
+			continue
+		}
+		path := filepath.Join(e.config.Output, entry.OutputPath)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale file %s: %w", path, err)
+		}
+		tracker.manifest.Delete(postID)
+	}
+	return nil
 }
 
 // Export exports the data in the specified format
@@ -35,27 +169,97 @@ func (e *Exporter) Export(data *models.ExportData) error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Download media files if enabled
-	if e.config.DownloadMedia {
+	// Download media files if enabled. A bundle export downloads each post's media
+	// directly into that post's own directory instead (see exportMarkdownBundle), so the
+	// usual shared-mediaDir pass here would just be wasted, duplicate work.
+	if e.config.DownloadMedia && !e.isBundleExport() {
 		downloaded, err := e.downloader.DownloadMedia(data.Media)
 		if err != nil {
 			return fmt.Errorf("failed to download media: %w", err)
 		}
 		data.Stats.MediaDownloaded = downloaded
+
+		data.MediaFailures = e.downloader.Failures()
+		data.Stats.MediaFailed = len(data.MediaFailures)
 	}
 
-	// Update media paths in content
-	e.updateMediaPaths(data)
+	// Update media paths in content - skipped for a bundle export, which rewrites each
+	// post's content itself against its own per-post download paths.
+	if !e.isBundleExport() {
+		e.updateMediaPaths(data)
+	}
 
 	// Export based on format
+	var err error
 	switch e.config.Format {
 	case "json":
-		return e.exportJSON(data)
+		err = e.exportJSON(data)
+	case "json-stream":
+		err = e.exportJSONStream(data)
 	case "markdown":
-		return e.exportMarkdown(data)
+		err = e.exportMarkdown(data)
+	case "hugo":
+		err = e.exportHugoSite(data)
+	case "atom":
+		err = e.exportAtom(data)
+	case "sitemap":
+		err = e.exportSitemap(data)
+	case "rss":
+		err = e.exportRSS(data)
+	case "activitypub":
+		err = e.exportActivityPub(data)
+	case "xml":
+		err = e.exportWXR(data)
 	default:
-		return fmt.Errorf("unsupported export format: %s", e.config.Format)
+		err = e.exportViaPlugin(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.writeExtras(data)
+}
+
+// outputRoot returns the directory the primary export was written into, even when Output
+// itself names a file (a JSON or RSS export with a file extension rather than a directory).
+func (e *Exporter) outputRoot() string {
+	if filepath.Ext(e.config.Output) != "" {
+		return filepath.Dir(e.config.Output)
 	}
+	return e.config.Output
+}
+
+// writeExtras writes the optional companion files gated by Config.WriteSitemap,
+// Config.WriteLLMsTxt, Config.WriteFeeds, and Config.WriteStats, run after the primary
+// export regardless of Format.
+func (e *Exporter) writeExtras(data *models.ExportData) error {
+	outputRoot := e.outputRoot()
+
+	if e.config.WriteSitemap {
+		if err := e.writeCompanionSitemap(data, outputRoot); err != nil {
+			return fmt.Errorf("failed to write sitemap.xml: %w", err)
+		}
+	}
+
+	if e.config.WriteLLMsTxt {
+		if err := e.writeLLMsTxt(data, outputRoot); err != nil {
+			return fmt.Errorf("failed to write llms.txt: %w", err)
+		}
+	}
+
+	if e.config.WriteFeeds {
+		if err := e.writeCompanionFeeds(data, outputRoot); err != nil {
+			return fmt.Errorf("failed to write companion feeds: %w", err)
+		}
+	}
+
+	if e.config.WriteStats {
+		if err := e.writeStats(outputRoot); err != nil {
+			return fmt.Errorf("failed to write stats.json: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // exportJSON exports data as JSON
@@ -86,8 +290,39 @@ func (e *Exporter) exportJSON(data *models.ExportData) error {
 	return nil
 }
 
-// exportMarkdown exports data as Markdown files
+// exportMarkdown exports data as Markdown files, in the layout selected by
+// Config.MarkdownFlavor: "plain" (default) is this tool's own category-folder layout,
+// "hugo" emits a Hugo-compatible content tree, "jekyll" emits a Jekyll _posts collection,
+// and "bundle" emits one self-contained directory per post/page with its media colocated
+// alongside it.
 func (e *Exporter) exportMarkdown(data *models.ExportData) error {
+	switch e.config.MarkdownFlavor {
+	case "hugo":
+		return e.exportMarkdownHugo(data)
+	case "jekyll":
+		return e.exportMarkdownJekyll(data)
+	case "bundle":
+		return e.exportMarkdownBundle(data)
+	default:
+		return e.exportMarkdownPlain(data)
+	}
+}
+
+// isBundleExport reports whether this export writes per-post media bundles (see
+// exportMarkdownBundle and exportHugoSite) - which download their own media directly into
+// each post's directory - rather than relying on Export's single shared-mediaDir download
+// pass.
+func (e *Exporter) isBundleExport() bool {
+	return (e.config.Format == "markdown" && e.config.MarkdownFlavor == "bundle") || e.config.Format == "hugo"
+}
+
+// exportMarkdownPlain exports data as Markdown files using this tool's own category-folder
+// layout (the long-standing default, predating MarkdownFlavor). Re-running it against a
+// previously-exported Output directory is incremental: exportPostsWithCategories and
+// exportPostsMarkdown consult the on-disk ExportManifest and only rewrite a post/page whose
+// generated content actually changed (bypassed by Config.Force), and --prune removes files
+// for posts no longer present in the current export set.
+func (e *Exporter) exportMarkdownPlain(data *models.ExportData) error {
 	// Create base directory structure
 	pagesDir := filepath.Join(e.config.Output, "pages")
 
@@ -100,16 +335,32 @@ func (e *Exporter) exportMarkdown(data *models.ExportData) error {
 		return fmt.Errorf("failed to export site info: %w", err)
 	}
 
+	manifest, err := LoadExportManifest(filepath.Join(e.config.Output, exportManifestFileName))
+	if err != nil {
+		return fmt.Errorf("failed to load export manifest: %w", err)
+	}
+	tracker := newExportManifestTracker(manifest, &data.Stats)
+
 	// Export posts with category-based folder structure
-	if err := e.exportPostsWithCategories(data.Posts, data.Categories, "post"); err != nil {
+	if err := e.exportPostsWithCategories(data.Posts, data.Categories, "post", tracker); err != nil {
 		return fmt.Errorf("failed to export posts: %w", err)
 	}
 
 	// Export pages
-	if err := e.exportPostsMarkdown(data.Pages, pagesDir, "page"); err != nil {
+	if err := e.exportPostsMarkdown(data.Pages, pagesDir, "page", tracker); err != nil {
 		return fmt.Errorf("failed to export pages: %w", err)
 	}
 
+	if e.config.Prune {
+		if err := e.pruneStaleFiles(tracker); err != nil {
+			return fmt.Errorf("failed to prune stale export files: %w", err)
+		}
+	}
+
+	if err := manifest.Save(); err != nil {
+		return fmt.Errorf("failed to save export manifest: %w", err)
+	}
+
 	// Export metadata
 	if err := e.exportMetadata(data); err != nil {
 		return fmt.Errorf("failed to export metadata: %w", err)
@@ -148,8 +399,13 @@ func (e *Exporter) exportSiteInfo(site models.SiteInfo) error {
 	return os.WriteFile(filePath, []byte(content), 0600)
 }
 
-// exportPostsWithCategories exports posts organized by category folders
-func (e *Exporter) exportPostsWithCategories(posts []models.WordPressPost, categories []models.WordPressCategory, contentType string) error {
+// exportPostsWithCategories exports posts organized by category folders. Posts are
+// processed by a bounded worker pool (see exportConcurrency) rather than sequentially -
+// each worker resolves its own category path, creates that category's directory (via
+// ensureDir, serialized per-path), and writes its file independently (skipping it if
+// tracker's manifest shows it's unchanged - see writeMarkdownIfChanged). errgroup.Group
+// cancels the remaining workers and returns the first error on failure.
+func (e *Exporter) exportPostsWithCategories(posts []models.WordPressPost, categories []models.WordPressCategory, contentType string, tracker *exportManifestTracker) error {
 	// Create category map for quick lookup
 	categoryMap := make(map[int]models.WordPressCategory)
 	for _, cat := range categories {
@@ -159,43 +415,68 @@ func (e *Exporter) exportPostsWithCategories(posts []models.WordPressPost, categ
 	// Create category hierarchy map
 	categoryHierarchy := e.buildCategoryHierarchy(categories)
 
+	bar := e.progress.NewCountBar(fmt.Sprintf("Exporting %ss", contentType), len(posts))
+	defer bar.Finish()
+
+	group, _ := errgroup.WithContext(context.Background())
+	group.SetLimit(e.exportConcurrency())
+
 	for _, post := range posts {
-		// Determine the category path for this post
-		categoryPath := e.getCategoryPath(post, categoryMap, categoryHierarchy)
+		group.Go(func() error {
+			defer bar.Add(1)
 
-		// Create the full directory path
-		postDir := filepath.Join(e.config.Output, "posts", categoryPath)
-		if err := os.MkdirAll(postDir, 0750); err != nil {
-			return fmt.Errorf("failed to create category directory %s: %w", postDir, err)
-		}
+			// Determine the category path for this post
+			categoryPath := e.getCategoryPath(post, categoryMap, categoryHierarchy)
 
-		// Generate filename and content
-		filename := e.generateMarkdownFilename(post)
-		filePath := filepath.Join(postDir, filename)
-		content := e.generateMarkdownContent(post, contentType)
+			// Create the full directory path
+			postDir := filepath.Join(e.config.Output, "posts", categoryPath)
+			if err := e.ensureDir(postDir); err != nil {
+				return fmt.Errorf("failed to create category directory %s: %w", postDir, err)
+			}
 
-		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
-			return fmt.Errorf("failed to write %s file %s: %w", contentType, filename, err)
-		}
+			// Generate filename and content
+			filename := e.generateMarkdownFilename(post)
+			filePath := filepath.Join(postDir, filename)
+			content := e.generateMarkdownContent(post, contentType)
+
+			if err := e.writeMarkdownIfChanged(tracker, post, filePath, content); err != nil {
+				return fmt.Errorf("failed to write %s file %s: %w", contentType, filename, err)
+			}
+			return nil
+		})
 	}
 
-	return nil
+	return group.Wait()
 }
 
-// exportPostsMarkdown exports posts/pages as markdown files
-func (e *Exporter) exportPostsMarkdown(posts []models.WordPressPost, dir, contentType string) error {
+// exportPostsMarkdown exports posts/pages as markdown files via the same bounded worker
+// pool as exportPostsWithCategories; dir already exists (created by the caller) so workers
+// only need to generate content and write their own file (skipping it if tracker's manifest
+// shows it's unchanged - see writeMarkdownIfChanged).
+func (e *Exporter) exportPostsMarkdown(posts []models.WordPressPost, dir, contentType string, tracker *exportManifestTracker) error {
+	bar := e.progress.NewCountBar(fmt.Sprintf("Exporting %ss", contentType), len(posts))
+	defer bar.Finish()
+
+	group, _ := errgroup.WithContext(context.Background())
+	group.SetLimit(e.exportConcurrency())
+
 	for _, post := range posts {
-		filename := e.generateMarkdownFilename(post)
-		filePath := filepath.Join(dir, filename)
+		group.Go(func() error {
+			defer bar.Add(1)
 
-		content := e.generateMarkdownContent(post, contentType)
+			filename := e.generateMarkdownFilename(post)
+			filePath := filepath.Join(dir, filename)
 
-		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
-			return fmt.Errorf("failed to write %s file %s: %w", contentType, filename, err)
-		}
+			content := e.generateMarkdownContent(post, contentType)
+
+			if err := e.writeMarkdownIfChanged(tracker, post, filePath, content); err != nil {
+				return fmt.Errorf("failed to write %s file %s: %w", contentType, filename, err)
+			}
+			return nil
+		})
 	}
 
-	return nil
+	return group.Wait()
 }
 
 // generateMarkdownFilename generates a filename for a markdown file
@@ -299,7 +580,25 @@ func (e *Exporter) getCategoryPath(post models.WordPressPost, categoryMap map[in
 
 // sanitizeDirectoryName sanitizes a string for use as a directory name
 func (e *Exporter) sanitizeDirectoryName(name string) string {
-	// Replace invalid characters with hyphens
+	sanitized := sanitizeName(name, false)
+
+	// Ensure it's not empty
+	if sanitized == "" {
+		sanitized = "category"
+	}
+
+	return sanitized
+}
+
+// sanitizeName replaces filesystem/URL-unsafe characters with hyphens, collapses repeated
+// hyphens, and trims them from both ends. lowercase folds the result to lower case first -
+// sanitizeDirectoryName (directory names, case preserved) and slugifyHeading (heading
+// anchors, conventionally lower case) each need a different answer here.
+func sanitizeName(name string, lowercase bool) string {
+	if lowercase {
+		name = strings.ToLower(name)
+	}
+
 	invalid := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|", " "}
 	sanitized := name
 
@@ -313,14 +612,14 @@ func (e *Exporter) sanitizeDirectoryName(name string) string {
 	}
 
 	// Trim hyphens from start and end
-	sanitized = strings.Trim(sanitized, "-")
-
-	// Ensure it's not empty
-	if sanitized == "" {
-		sanitized = "category"
-	}
+	return strings.Trim(sanitized, "-")
+}
 
-	return sanitized
+// slugifyHeading slugifies heading text into a Markdown/HTML anchor, using the same
+// character-replacement rules as sanitizeDirectoryName but lowercased, the anchor
+// convention static site generators expect.
+func slugifyHeading(title string) string {
+	return sanitizeName(title, true)
 }
 
 // extractCategoriesFromLink extracts category path from WordPress permalink structure
@@ -446,11 +745,34 @@ func (e *Exporter) generateMarkdownContent(post models.WordPressPost, contentTyp
 		}
 	}
 
+	toc := extractTOC(post.Content.Rendered)
+	if len(toc) > 0 {
+		builder.WriteString("toc:\n")
+		for _, entry := range toc {
+			builder.WriteString(fmt.Sprintf("  - level: %d\n    title: \"%s\"\n    anchor: \"%s\"\n",
+				entry.Level, e.escapeYAML(entry.Title), entry.Anchor))
+		}
+	}
+
+	if summary, truncated := splitSummary(post.Content.Rendered); truncated {
+		builder.WriteString(fmt.Sprintf("summary: \"%s\"\n", e.escapeYAML(summary)))
+		builder.WriteString("truncated: true\n")
+	}
+
 	builder.WriteString("---\n\n")
 
 	// Title
 	builder.WriteString(fmt.Sprintf("# %s\n\n", post.Title.Rendered))
 
+	if e.config.RenderTOC && len(toc) > 0 {
+		builder.WriteString("## Table of Contents\n\n")
+		for _, entry := range toc {
+			indent := strings.Repeat("  ", entry.Level-2)
+			builder.WriteString(fmt.Sprintf("%s- [%s](#%s)\n", indent, entry.Title, entry.Anchor))
+		}
+		builder.WriteString("\n")
+	}
+
 	// Excerpt if available
 	if post.Excerpt.Rendered != "" {
 		builder.WriteString("## Excerpt\n\n")
@@ -475,6 +797,18 @@ func (e *Exporter) exportMetadata(data *models.ExportData) error {
 		"stats":       data.Stats,
 		"exported_at": time.Now(),
 	}
+	if len(data.Comments) > 0 {
+		metadata["comments"] = data.Comments
+	}
+	if len(data.Revisions) > 0 {
+		metadata["revisions"] = data.Revisions
+	}
+	if len(data.CustomContent) > 0 {
+		metadata["custom_content"] = data.CustomContent
+	}
+	if len(data.MediaFailures) > 0 {
+		metadata["media_failures"] = data.MediaFailures
+	}
 
 	jsonData, err := json.MarshalIndent(metadata, "", "  ")
 	if err != nil {
@@ -516,63 +850,21 @@ func (e *Exporter) escapeYAML(s string) string {
 	return s
 }
 
-// convertHTMLToMarkdown performs basic HTML to Markdown conversion
-func (e *Exporter) convertHTMLToMarkdown(html string) string {
-	// Basic HTML to Markdown conversion
-	// This is a simplified version - for production use, consider using a proper HTML to Markdown library
-
-	md := html
-
-	// Headers
-	md = strings.ReplaceAll(md, "<h1>", "# ")
-	md = strings.ReplaceAll(md, "</h1>", "\n\n")
-	md = strings.ReplaceAll(md, "<h2>", "## ")
-	md = strings.ReplaceAll(md, "</h2>", "\n\n")
-	md = strings.ReplaceAll(md, "<h3>", "### ")
-	md = strings.ReplaceAll(md, "</h3>", "\n\n")
-	md = strings.ReplaceAll(md, "<h4>", "#### ")
-	md = strings.ReplaceAll(md, "</h4>", "\n\n")
-	md = strings.ReplaceAll(md, "<h5>", "##### ")
-	md = strings.ReplaceAll(md, "</h5>", "\n\n")
-	md = strings.ReplaceAll(md, "<h6>", "###### ")
-	md = strings.ReplaceAll(md, "</h6>", "\n\n")
-
-	// Bold and italic
-	md = strings.ReplaceAll(md, "<strong>", "**")
-	md = strings.ReplaceAll(md, "</strong>", "**")
-	md = strings.ReplaceAll(md, "<b>", "**")
-	md = strings.ReplaceAll(md, "</b>", "**")
-	md = strings.ReplaceAll(md, "<em>", "*")
-	md = strings.ReplaceAll(md, "</em>", "*")
-	md = strings.ReplaceAll(md, "<i>", "*")
-	md = strings.ReplaceAll(md, "</i>", "*")
-
-	// Paragraphs
-	md = strings.ReplaceAll(md, "<p>", "")
-	md = strings.ReplaceAll(md, "</p>", "\n\n")
-
-	// Line breaks
-	md = strings.ReplaceAll(md, "<br>", "\n")
-	md = strings.ReplaceAll(md, "<br/>", "\n")
-	md = strings.ReplaceAll(md, "<br />", "\n")
-
-	// Lists
-	md = strings.ReplaceAll(md, "<ul>", "")
-	md = strings.ReplaceAll(md, "</ul>", "\n")
-	md = strings.ReplaceAll(md, "<ol>", "")
-	md = strings.ReplaceAll(md, "</ol>", "\n")
-	md = strings.ReplaceAll(md, "<li>", "- ")
-	md = strings.ReplaceAll(md, "</li>", "\n")
-
-	// Code
-	md = strings.ReplaceAll(md, "<code>", "`")
-	md = strings.ReplaceAll(md, "</code>", "`")
-	md = strings.ReplaceAll(md, "<pre>", "```\n")
-	md = strings.ReplaceAll(md, "</pre>", "\n```")
-
-	// Clean up extra whitespace
-	md = strings.ReplaceAll(md, "\n\n\n", "\n\n")
-	md = strings.TrimSpace(md)
+// convertHTMLToMarkdown converts HTML to Markdown via internal/converter's DOM-driven
+// renderer. It keeps returning a bare string (rather than surfacing converter.HTMLToMarkdown's
+// error) because every caller threads it straight into a strings.Builder chain; a malformed
+// fragment is logged and passed through as-is rather than aborting the whole export.
+func (e *Exporter) convertHTMLToMarkdown(htmlContent string) string {
+	htmlContent = convertCodeShortcodesToFences(htmlContent)
 
+	if e.config.WriteStats {
+		e.htmlStats.collect(htmlContent)
+	}
+
+	md, err := converter.HTMLToMarkdown(htmlContent, converter.Options{Highlight: e.config.Highlight})
+	if err != nil {
+		log.Printf("convertHTMLToMarkdown: %v", err)
+		return strings.TrimSpace(htmlContent)
+	}
 	return md
 }
@@ -318,6 +318,108 @@ func TestGenerateMarkdownContent(t *testing.T) {
 	}
 }
 
+func TestGenerateMarkdownContentIncludesTOCFrontMatterWhenHeadingsPresent(t *testing.T) {
+	e := NewExporter(&config.Config{})
+
+	post := models.WordPressPost{
+		ID:    1,
+		Slug:  "with-headings",
+		Title: models.RenderedContent{Rendered: "With Headings"},
+		Content: models.RenderedContent{
+			Rendered: "<h2>First Section</h2><p>text</p>",
+		},
+	}
+
+	result := e.generateMarkdownContent(post, "post")
+
+	if !containsString(result, "toc:") {
+		t.Error("generateMarkdownContent() should contain toc front matter when headings are present")
+	}
+	if !containsString(result, `title: "First Section"`) {
+		t.Error("generateMarkdownContent() toc entry should contain heading title")
+	}
+	if !containsString(result, "anchor: \"first-section\"") {
+		t.Error("generateMarkdownContent() toc entry should contain slugified anchor")
+	}
+	if containsString(result, "## Table of Contents") {
+		t.Error("generateMarkdownContent() should not render a TOC body section when RenderTOC is disabled")
+	}
+}
+
+func TestGenerateMarkdownContentOmitsTOCFrontMatterWithoutHeadings(t *testing.T) {
+	e := NewExporter(&config.Config{})
+
+	post := models.WordPressPost{
+		ID:      1,
+		Slug:    "no-headings",
+		Title:   models.RenderedContent{Rendered: "No Headings"},
+		Content: models.RenderedContent{Rendered: "<p>just a paragraph</p>"},
+	}
+
+	result := e.generateMarkdownContent(post, "post")
+
+	if containsString(result, "toc:") {
+		t.Error("generateMarkdownContent() should not contain toc front matter without headings")
+	}
+}
+
+func TestGenerateMarkdownContentRendersTOCBodyWhenEnabled(t *testing.T) {
+	e := NewExporter(&config.Config{RenderTOC: true})
+
+	post := models.WordPressPost{
+		ID:      1,
+		Slug:    "with-headings",
+		Title:   models.RenderedContent{Rendered: "With Headings"},
+		Content: models.RenderedContent{Rendered: "<h2>First Section</h2><p>text</p>"},
+	}
+
+	result := e.generateMarkdownContent(post, "post")
+
+	if !containsString(result, "## Table of Contents") {
+		t.Error("generateMarkdownContent() should render a TOC body section when RenderTOC is enabled")
+	}
+	if !containsString(result, "[First Section](#first-section)") {
+		t.Error("generateMarkdownContent() TOC body should link to the heading anchor")
+	}
+}
+
+func TestGenerateMarkdownContentIncludesSummaryWhenMoreMarkerPresent(t *testing.T) {
+	e := NewExporter(&config.Config{})
+
+	post := models.WordPressPost{
+		ID:      1,
+		Slug:    "split-post",
+		Title:   models.RenderedContent{Rendered: "Split Post"},
+		Content: models.RenderedContent{Rendered: "<p>Intro text.</p><!--more--><p>Rest of the post.</p>"},
+	}
+
+	result := e.generateMarkdownContent(post, "post")
+
+	if !containsString(result, `summary: "Intro text."`) {
+		t.Error("generateMarkdownContent() should contain summary front matter before the more marker")
+	}
+	if !containsString(result, "truncated: true") {
+		t.Error("generateMarkdownContent() should mark the post as truncated")
+	}
+}
+
+func TestGenerateMarkdownContentOmitsSummaryWithoutMoreMarker(t *testing.T) {
+	e := NewExporter(&config.Config{})
+
+	post := models.WordPressPost{
+		ID:      1,
+		Slug:    "no-split-post",
+		Title:   models.RenderedContent{Rendered: "No Split Post"},
+		Content: models.RenderedContent{Rendered: "<p>Just one paragraph.</p>"},
+	}
+
+	result := e.generateMarkdownContent(post, "post")
+
+	if containsString(result, "summary:") || containsString(result, "truncated:") {
+		t.Error("generateMarkdownContent() should not contain summary/truncated front matter without a more marker")
+	}
+}
+
 func TestBuildCategoryHierarchy(t *testing.T) {
 	e := NewExporter(&config.Config{})
 
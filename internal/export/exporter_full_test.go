@@ -2,6 +2,7 @@ package export
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -148,7 +149,7 @@ func TestExportUnsupportedFormat(t *testing.T) {
 
 	cfg := &config.Config{
 		Output:        tmpDir,
-		Format:        "xml", // Unsupported format
+		Format:        "no-such-format",
 		DownloadMedia: false,
 	}
 
@@ -355,7 +356,7 @@ func TestExportPostsWithCategories(t *testing.T) {
 		{ID: 1, Name: "Technology", Slug: "technology", Parent: 0},
 	}
 
-	err = e.exportPostsWithCategories(posts, categories, "post")
+	err = e.exportPostsWithCategories(posts, categories, "post", newTestTracker())
 	if err != nil {
 		t.Fatalf("exportPostsWithCategories() error = %v", err)
 	}
@@ -404,7 +405,7 @@ func TestExportPostsMarkdown(t *testing.T) {
 		t.Fatalf("Failed to create posts dir: %v", err)
 	}
 
-	err = e.exportPostsMarkdown(posts, postsDir, "post")
+	err = e.exportPostsMarkdown(posts, postsDir, "post", newTestTracker())
 	if err != nil {
 		t.Fatalf("exportPostsMarkdown() error = %v", err)
 	}
@@ -642,3 +643,86 @@ func containsStr(s, substr string) bool {
 	}
 	return false
 }
+
+// TestExportPostsWithCategoriesWritesEveryPostAcrossCategories exercises the worker-pool
+// path of exportPostsWithCategories with enough posts spread across enough categories that,
+// on a multi-core machine, several workers are writing into both shared and distinct
+// category directories at once.
+func TestExportPostsWithCategoriesWritesEveryPostAcrossCategories(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown"}
+	e := NewExporter(cfg)
+
+	categories := []models.WordPressCategory{
+		{ID: 1, Name: "Technology", Slug: "technology"},
+		{ID: 2, Name: "Travel", Slug: "travel"},
+	}
+
+	var posts []models.WordPressPost
+	for i := 0; i < 20; i++ {
+		posts = append(posts, models.WordPressPost{
+			ID:         i,
+			Slug:       fmt.Sprintf("post-%d", i),
+			Title:      models.RenderedContent{Rendered: fmt.Sprintf("Post %d", i)},
+			Content:    models.RenderedContent{Rendered: "<p>Content</p>"},
+			Date:       models.WordPressTime{Time: time.Now()},
+			Status:     "publish",
+			Link:       fmt.Sprintf("https://example.com/post-%d", i),
+			Categories: []int{1 + i%2},
+		})
+	}
+
+	if err := e.exportPostsWithCategories(posts, categories, "post", newTestTracker()); err != nil {
+		t.Fatalf("exportPostsWithCategories() error = %v", err)
+	}
+
+	for _, cat := range []string{"technology", "travel"} {
+		entries, err := os.ReadDir(filepath.Join(tmpDir, "posts", cat))
+		if err != nil {
+			t.Fatalf("failed to read %s category directory: %v", cat, err)
+		}
+		if len(entries) != 10 {
+			t.Errorf("category %q has %d files, want 10", cat, len(entries))
+		}
+	}
+}
+
+// TestExportPostsWithCategoriesPropagatesFirstWriteError confirms a single worker's failure
+// (here, an unwritable target directory) surfaces through errgroup.Group rather than being
+// silently dropped by a concurrent worker that happened to succeed.
+func TestExportPostsWithCategoriesPropagatesFirstWriteError(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown"}
+	e := NewExporter(cfg)
+
+	// Occupy "posts/technology" with a regular file so MkdirAll for that category fails.
+	if err := os.MkdirAll(filepath.Join(tmpDir, "posts"), 0750); err != nil {
+		t.Fatalf("failed to create posts directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "posts", "technology"), []byte("blocked"), 0600); err != nil {
+		t.Fatalf("failed to create blocking file: %v", err)
+	}
+
+	categories := []models.WordPressCategory{{ID: 1, Name: "Technology", Slug: "technology"}}
+	posts := []models.WordPressPost{{
+		ID:         1,
+		Slug:       "test-post",
+		Title:      models.RenderedContent{Rendered: "Test Post"},
+		Content:    models.RenderedContent{Rendered: "<p>Content</p>"},
+		Date:       models.WordPressTime{Time: time.Now()},
+		Status:     "publish",
+		Link:       "https://example.com/test-post",
+		Categories: []int{1},
+	}}
+
+	if err := e.exportPostsWithCategories(posts, categories, "post", newTestTracker()); err == nil {
+		t.Error("exportPostsWithCategories() expected an error when a category directory can't be created")
+	}
+}
+
+// newTestTracker returns an exportManifestTracker over an empty, in-memory-only manifest,
+// for tests that call exportPostsWithCategories/exportPostsMarkdown directly rather than
+// through exportMarkdownPlain (which loads/saves the manifest from disk itself).
+func newTestTracker() *exportManifestTracker {
+	return newExportManifestTracker(&ExportManifest{Entries: map[int]ExportManifestEntry{}}, &models.ExportStats{})
+}
@@ -0,0 +1,638 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// sitemapMaxURLsPerFile and sitemapMaxBytesPerFile mirror the limits imposed by the
+// sitemaps.org protocol: a single sitemap file may list at most 50,000 URLs and must
+// not exceed 50MB uncompressed.
+const (
+	sitemapMaxURLsPerFile  = 50000
+	sitemapMaxBytesPerFile = 50 * 1024 * 1024
+)
+
+// feedMaxEntriesPerFile caps how many posts go into a single Atom/RSS feed file before
+// the export splits into paged feed files linked via rel="next".
+const feedMaxEntriesPerFile = 1000
+
+// atomFeed is the root element of an Atom 1.0 feed (RFC 4287).
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	XMLBase string      `xml:"xml:base,attr,omitempty"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    []atomLink  `xml:"link"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title    string         `xml:"title"`
+	ID       string         `xml:"id"`
+	Updated  string         `xml:"updated"`
+	Link     atomLink       `xml:"link"`
+	Author   *atomAuthor    `xml:"author,omitempty"`
+	Category []atomCategory `xml:"category,omitempty"`
+	Summary  string         `xml:"summary,omitempty"`
+	Content  atomContent    `xml:"content"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",cdata"`
+}
+
+// exportAtom exports posts as one or more Atom 1.0 feed files (atom.xml, atom-2.xml, ...),
+// linked together with rel="next" when the export is large enough to split.
+func (e *Exporter) exportAtom(data *models.ExportData) error {
+	if err := os.MkdirAll(e.config.Output, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	userMap := e.buildUserMap(data.Users)
+	categoryMap := e.buildCategoryMap(data.Categories)
+	pages := e.chunkPosts(data.Posts, feedMaxEntriesPerFile)
+	base := e.siteBaseURL(data.Site)
+
+	var outputPath string
+	for i, pagePosts := range pages {
+		feed := e.buildAtomFeed(data.Site, pagePosts, userMap, categoryMap)
+		if i < len(pages)-1 {
+			feed.Link = append(feed.Link, atomLink{Href: e.feedPageHref(base, atomFilename(i+1)), Rel: "next"})
+		}
+
+		xmlData, err := xml.MarshalIndent(feed, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal atom feed: %w", err)
+		}
+
+		outputPath = filepath.Join(e.config.Output, atomFilename(i))
+		content := append([]byte(xml.Header), xmlData...)
+		if err := os.WriteFile(outputPath, content, 0600); err != nil {
+			return fmt.Errorf("failed to write atom feed: %w", err)
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", outputPath)
+	return nil
+}
+
+// atomFilename returns the Atom feed filename for a 0-indexed page: the first page is
+// atom.xml, subsequent pages are atom-2.xml, atom-3.xml, and so on.
+func atomFilename(page int) string {
+	if page == 0 {
+		return "atom.xml"
+	}
+	return fmt.Sprintf("atom-%d.xml", page+1)
+}
+
+// buildAtomFeed assembles an atomFeed from the site info and a page of posts, resolving
+// each entry's author against userMap, its categories against categoryMap, and deriving a
+// stable tag: URI for each entry's ID from the feed-wide start date.
+func (e *Exporter) buildAtomFeed(site models.SiteInfo, posts []models.WordPressPost, userMap map[int]models.WordPressUser, categoryMap map[int]models.WordPressCategory) atomFeed {
+	host := e.siteHost(site)
+	base := e.siteBaseURL(site)
+	startDate := e.feedStartDate(posts)
+
+	feed := atomFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		XMLBase: base,
+		Title:   site.Name,
+		ID:      fmt.Sprintf("tag:%s,%s:/", host, startDate),
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Link: []atomLink{
+			{Href: base, Rel: "alternate"},
+			{Href: e.feedPageHref(base, "atom.xml"), Rel: "self"},
+		},
+	}
+
+	for _, post := range posts {
+		entry := atomEntry{
+			Title:   post.Title.Rendered,
+			ID:      e.atomEntryID(host, startDate, post),
+			Updated: post.Modified.Format(time.RFC3339),
+			Link:    atomLink{Href: post.Link},
+			Summary: post.Excerpt.Rendered,
+			Content: atomContent{Type: "html", Body: post.Content.Rendered},
+		}
+
+		for _, catID := range post.Categories {
+			if cat, ok := categoryMap[catID]; ok {
+				entry.Category = append(entry.Category, atomCategory{Term: cat.Slug})
+			}
+		}
+
+		if user, ok := userMap[post.Author]; ok {
+			entry.Author = &atomAuthor{Name: user.Name}
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+// atomEntryID returns a stable tag: URI (RFC 4151) of the form tag:<host>,<start-date>:post-<id>.
+// Using the feed-wide start date rather than each post's own date keeps an entry's ID from
+// changing if the post is later edited.
+func (e *Exporter) atomEntryID(host, startDate string, post models.WordPressPost) string {
+	return fmt.Sprintf("tag:%s,%s:post-%d", host, startDate, post.ID)
+}
+
+// feedStartDate returns the earliest post date in posts, formatted as YYYY-MM-DD, for use
+// as the {start-date} component of a feed's tag: URIs. Falls back to today when posts is
+// empty, since a tag: URI still needs a date component. Config.FeedTagDate overrides this
+// entirely when set.
+func (e *Exporter) feedStartDate(posts []models.WordPressPost) string {
+	if e.config.FeedTagDate != "" {
+		return e.config.FeedTagDate
+	}
+	if len(posts) == 0 {
+		return time.Now().UTC().Format("2006-01-02")
+	}
+
+	earliest := posts[0].Date.Time
+	for _, post := range posts[1:] {
+		if post.Date.Time.Before(earliest) {
+			earliest = post.Date.Time
+		}
+	}
+	return earliest.Format("2006-01-02")
+}
+
+// feedPageHref joins a site's base URL with a feed page's filename.
+func (e *Exporter) feedPageHref(base, filename string) string {
+	return strings.TrimSuffix(base, "/") + "/" + filename
+}
+
+// buildUserMap indexes users by ID for resolving a post's author.
+func (e *Exporter) buildUserMap(users []models.WordPressUser) map[int]models.WordPressUser {
+	userMap := make(map[int]models.WordPressUser, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+	return userMap
+}
+
+// buildCategoryMap indexes categories by ID for resolving a post's category IDs to names.
+func (e *Exporter) buildCategoryMap(categories []models.WordPressCategory) map[int]models.WordPressCategory {
+	categoryMap := make(map[int]models.WordPressCategory, len(categories))
+	for _, cat := range categories {
+		categoryMap[cat.ID] = cat
+	}
+	return categoryMap
+}
+
+// chunkPosts splits posts into groups of at most maxPerFile, for paginating a feed export
+// across multiple files. Returns a single (possibly empty) chunk when posts fits within
+// maxPerFile.
+func (e *Exporter) chunkPosts(posts []models.WordPressPost, maxPerFile int) [][]models.WordPressPost {
+	if len(posts) == 0 {
+		return [][]models.WordPressPost{nil}
+	}
+
+	var chunks [][]models.WordPressPost
+	for len(posts) > maxPerFile {
+		chunks = append(chunks, posts[:maxPerFile])
+		posts = posts[maxPerFile:]
+	}
+	return append(chunks, posts)
+}
+
+// rssFeed is the root element of an RSS 2.0 feed. The atom namespace is declared so the
+// channel can carry self/next links the way WordPress's own RSS output does, since RSS 2.0
+// has no native equivalent of Atom's <link rel="...">.
+type rssFeed struct {
+	XMLName      xml.Name   `xml:"rss"`
+	Version      string     `xml:"version,attr"`
+	XmlnsAtom    string     `xml:"xmlns:atom,attr"`
+	XmlnsContent string     `xml:"xmlns:content,attr"`
+	Channel      rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	AtomLinks   []rssAtomLink `xml:"atom:link"`
+	Items       []rssItem     `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type rssItem struct {
+	Title       string            `xml:"title"`
+	Link        string            `xml:"link"`
+	GUID        rssGUID           `xml:"guid"`
+	PubDate     string            `xml:"pubDate"`
+	Author      string            `xml:"author,omitempty"`
+	Category    []string          `xml:"category,omitempty"`
+	Description string            `xml:"description,omitempty"`
+	Content     rssContentEncoded `xml:"content:encoded"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssContentEncoded struct {
+	Body string `xml:",cdata"`
+}
+
+// exportRSS exports posts as one or more RSS 2.0 feed files (feed.xml, feed-2.xml, ...),
+// linked together with an atom:link rel="next" when the export is large enough to split.
+func (e *Exporter) exportRSS(data *models.ExportData) error {
+	if err := os.MkdirAll(e.config.Output, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	userMap := e.buildUserMap(data.Users)
+	categoryMap := e.buildCategoryMap(data.Categories)
+	pages := e.chunkPosts(data.Posts, feedMaxEntriesPerFile)
+	base := e.siteBaseURL(data.Site)
+
+	var outputPath string
+	for i, pagePosts := range pages {
+		feed := e.buildRSSFeed(data.Site, pagePosts, userMap, categoryMap)
+		if i < len(pages)-1 {
+			feed.Channel.AtomLinks = append(feed.Channel.AtomLinks, rssAtomLink{
+				Href: e.feedPageHref(base, rssFilename(i+1)), Rel: "next", Type: "application/rss+xml",
+			})
+		}
+
+		xmlData, err := xml.MarshalIndent(feed, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal rss feed: %w", err)
+		}
+
+		outputPath = filepath.Join(e.config.Output, rssFilename(i))
+		content := append([]byte(xml.Header), xmlData...)
+		if err := os.WriteFile(outputPath, content, 0600); err != nil {
+			return fmt.Errorf("failed to write rss feed: %w", err)
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", outputPath)
+	return nil
+}
+
+// rssFilename returns the RSS feed filename for a 0-indexed page: the first page is
+// feed.xml, subsequent pages are feed-2.xml, feed-3.xml, and so on.
+func rssFilename(page int) string {
+	if page == 0 {
+		return "feed.xml"
+	}
+	return fmt.Sprintf("feed-%d.xml", page+1)
+}
+
+// buildRSSFeed assembles an rssFeed from the site info and a page of posts, resolving each
+// item's author against userMap, its categories against categoryMap, and using the same
+// feed-wide tag: URI scheme as Atom for each item's GUID.
+func (e *Exporter) buildRSSFeed(site models.SiteInfo, posts []models.WordPressPost, userMap map[int]models.WordPressUser, categoryMap map[int]models.WordPressCategory) rssFeed {
+	host := e.siteHost(site)
+	base := e.siteBaseURL(site)
+	startDate := e.feedStartDate(posts)
+
+	feed := rssFeed{
+		Version:      "2.0",
+		XmlnsAtom:    "http://www.w3.org/2005/Atom",
+		XmlnsContent: "http://purl.org/rss/1.0/modules/content/",
+		Channel: rssChannel{
+			Title:       site.Name,
+			Link:        base,
+			Description: site.Description,
+			AtomLinks: []rssAtomLink{
+				{Href: e.feedPageHref(base, "feed.xml"), Rel: "self", Type: "application/rss+xml"},
+			},
+		},
+	}
+
+	for _, post := range posts {
+		item := rssItem{
+			Title:       post.Title.Rendered,
+			Link:        post.Link,
+			GUID:        rssGUID{IsPermaLink: false, Value: e.atomEntryID(host, startDate, post)},
+			PubDate:     post.Date.Format(time.RFC1123Z),
+			Description: post.Excerpt.Rendered,
+			Content:     rssContentEncoded{Body: post.Content.Rendered},
+		}
+
+		for _, catID := range post.Categories {
+			if cat, ok := categoryMap[catID]; ok {
+				item.Category = append(item.Category, cat.Name)
+			}
+		}
+
+		if user, ok := userMap[post.Author]; ok {
+			item.Author = user.Name
+		}
+
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	return feed
+}
+
+// siteHost returns the hostname to use in tag: URIs, preferring Config.FeedTagAuthority
+// when set and otherwise falling back to the configured source URL if the exported site
+// info doesn't carry one.
+func (e *Exporter) siteHost(site models.SiteInfo) string {
+	if e.config.FeedTagAuthority != "" {
+		return e.config.FeedTagAuthority
+	}
+	parsed, err := url.Parse(e.siteBaseURL(site))
+	if err != nil || parsed.Hostname() == "" {
+		return "localhost"
+	}
+	return parsed.Hostname()
+}
+
+// feedUpdated returns the newest post modification time across posts, formatted per
+// RFC 3339, for a feed's <updated> element. Falls back to the current time when posts is
+// empty.
+func (e *Exporter) feedUpdated(posts []models.WordPressPost) string {
+	if len(posts) == 0 {
+		return time.Now().UTC().Format(time.RFC3339)
+	}
+
+	latest := posts[0].Modified.Time
+	for _, post := range posts[1:] {
+		if post.Modified.Time.After(latest) {
+			latest = post.Modified.Time
+		}
+	}
+	return latest.Format(time.RFC3339)
+}
+
+// writeCompanionFeeds writes feed.atom and feed.rss into outputRoot: single-file,
+// unpaginated Atom/RSS summaries of every post, gated by Config.WriteFeeds and run
+// alongside whichever Format was exported. Unlike exportAtom/exportRSS (the "atom"/"rss"
+// Format cases, which paginate past feedMaxEntriesPerFile), these companions are not
+// chunked, since they're a secondary artifact rather than the primary export.
+func (e *Exporter) writeCompanionFeeds(data *models.ExportData, outputRoot string) error {
+	userMap := e.buildUserMap(data.Users)
+	categoryMap := e.buildCategoryMap(data.Categories)
+
+	atom := e.buildAtomFeed(data.Site, data.Posts, userMap, categoryMap)
+	atom.Updated = e.feedUpdated(data.Posts)
+	atomData, err := xml.MarshalIndent(atom, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal companion atom feed: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputRoot, "feed.atom"), append([]byte(xml.Header), atomData...), 0600); err != nil {
+		return fmt.Errorf("failed to write feed.atom: %w", err)
+	}
+
+	rss := e.buildRSSFeed(data.Site, data.Posts, userMap, categoryMap)
+	rssData, err := xml.MarshalIndent(rss, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal companion rss feed: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputRoot, "feed.rss"), append([]byte(xml.Header), rssData...), 0600); err != nil {
+		return fmt.Errorf("failed to write feed.rss: %w", err)
+	}
+
+	return nil
+}
+
+// siteBaseURL returns the site's canonical URL, falling back to the configured source
+// URL so xml:base and feed self-links still resolve when site info is incomplete.
+func (e *Exporter) siteBaseURL(site models.SiteInfo) string {
+	if site.URL != "" {
+		return site.URL
+	}
+	return e.config.URL
+}
+
+// sitemapURLSet is a single <urlset> sitemap document per the sitemaps.org schema.
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	Changefreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+// sitemapIndex is a <sitemapindex> document referencing one or more chunked sitemaps.
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	Xmlns    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// exportSitemap exports posts and pages as sitemap.xml, chunking into multiple files
+// plus a sitemap index once the sitemaps.org per-file limits are exceeded.
+func (e *Exporter) exportSitemap(data *models.ExportData) error {
+	if err := os.MkdirAll(e.config.Output, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var urls []sitemapURL
+	for _, post := range data.Posts {
+		urls = append(urls, sitemapURL{Loc: post.Link, LastMod: post.Modified.Format("2006-01-02")})
+	}
+	for _, page := range data.Pages {
+		urls = append(urls, sitemapURL{Loc: page.Link, LastMod: page.Modified.Format("2006-01-02")})
+	}
+
+	chunks := e.chunkSitemapURLs(urls)
+
+	if len(chunks) <= 1 {
+		outputPath := filepath.Join(e.config.Output, "sitemap.xml")
+		if err := e.writeSitemapFile(outputPath, chunks[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Export completed: %s\n", outputPath)
+		return nil
+	}
+
+	index := sitemapIndex{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	today := time.Now().UTC().Format("2006-01-02")
+
+	for i, chunk := range chunks {
+		filename := fmt.Sprintf("sitemap-%d.xml", i+1)
+		if err := e.writeSitemapFile(filepath.Join(e.config.Output, filename), chunk); err != nil {
+			return err
+		}
+
+		loc := filename
+		if base := e.siteBaseURL(data.Site); base != "" {
+			loc = strings.TrimSuffix(base, "/") + "/" + filename
+		}
+		index.Sitemaps = append(index.Sitemaps, sitemapEntry{Loc: loc, LastMod: today})
+	}
+
+	indexPath := filepath.Join(e.config.Output, "sitemap.xml")
+	xmlData, err := xml.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sitemap index: %w", err)
+	}
+	if err := os.WriteFile(indexPath, append([]byte(xml.Header), xmlData...), 0600); err != nil {
+		return fmt.Errorf("failed to write sitemap index: %w", err)
+	}
+
+	fmt.Printf("Export completed: %s\n", indexPath)
+	return nil
+}
+
+// writeSitemapFile marshals a single chunk of URLs into a sitemap document at path.
+func (e *Exporter) writeSitemapFile(path string, urls []sitemapURL) error {
+	urlset := sitemapURLSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: urls}
+
+	xmlData, err := xml.MarshalIndent(urlset, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sitemap: %w", err)
+	}
+
+	return os.WriteFile(path, append([]byte(xml.Header), xmlData...), 0600)
+}
+
+// chunkSitemapURLs splits urls into groups that respect the sitemaps.org per-file
+// limits of 50,000 URLs and ~50MB. Returns a single (possibly empty) chunk when urls
+// fits within both limits.
+func (e *Exporter) chunkSitemapURLs(urls []sitemapURL) [][]sitemapURL {
+	if len(urls) == 0 {
+		return [][]sitemapURL{nil}
+	}
+
+	var chunks [][]sitemapURL
+	var current []sitemapURL
+	var currentBytes int
+
+	for _, u := range urls {
+		// Rough per-entry overhead for the surrounding <url>/<loc>/<lastmod> tags.
+		entrySize := len(u.Loc) + len(u.LastMod) + 64
+
+		if len(current) >= sitemapMaxURLsPerFile || currentBytes+entrySize > sitemapMaxBytesPerFile {
+			chunks = append(chunks, current)
+			current = nil
+			currentBytes = 0
+		}
+
+		current = append(current, u)
+		currentBytes += entrySize
+	}
+
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// sitemapChangefreq infers a sitemap <changefreq> hint from how long ago content was last
+// modified, on the theory that something edited recently is more likely to keep changing
+// than something untouched for a year - a static "weekly" for every post both overstates
+// the freshness of old content and understates it for content edited today.
+func sitemapChangefreq(modified time.Time) string {
+	if modified.IsZero() {
+		return "monthly"
+	}
+	switch age := time.Since(modified); {
+	case age < 7*24*time.Hour:
+		return "daily"
+	case age < 30*24*time.Hour:
+		return "weekly"
+	case age < 365*24*time.Hour:
+		return "monthly"
+	default:
+		return "yearly"
+	}
+}
+
+// writeCompanionSitemap writes sitemap.xml (plus sitemap-N.xml and a sitemap index past the
+// sitemaps.org per-file limits) into outputRoot, gated by Config.WriteSitemap. Unlike
+// exportSitemap (the "sitemap" Format case, which covers only posts and pages), this also
+// lists categories and tags and always runs alongside whichever Format was exported.
+func (e *Exporter) writeCompanionSitemap(data *models.ExportData, outputRoot string) error {
+	var urls []sitemapURL
+	for _, post := range data.Posts {
+		urls = append(urls, sitemapURL{
+			Loc:        post.Link,
+			LastMod:    post.ModifiedGMT.Format("2006-01-02"),
+			Changefreq: sitemapChangefreq(post.ModifiedGMT.Time),
+			Priority:   "0.8",
+		})
+	}
+	for _, page := range data.Pages {
+		urls = append(urls, sitemapURL{
+			Loc:        page.Link,
+			LastMod:    page.ModifiedGMT.Format("2006-01-02"),
+			Changefreq: sitemapChangefreq(page.ModifiedGMT.Time),
+			Priority:   "0.5",
+		})
+	}
+	for _, category := range data.Categories {
+		urls = append(urls, sitemapURL{Loc: category.Link, Changefreq: "weekly", Priority: "0.3"})
+	}
+	for _, tag := range data.Tags {
+		urls = append(urls, sitemapURL{Loc: tag.Link, Changefreq: "weekly", Priority: "0.3"})
+	}
+
+	chunks := e.chunkSitemapURLs(urls)
+
+	if len(chunks) <= 1 {
+		return e.writeSitemapFile(filepath.Join(outputRoot, "sitemap.xml"), chunks[0])
+	}
+
+	index := sitemapIndex{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	today := time.Now().UTC().Format("2006-01-02")
+
+	for i, chunk := range chunks {
+		filename := fmt.Sprintf("sitemap-%d.xml", i+1)
+		if err := e.writeSitemapFile(filepath.Join(outputRoot, filename), chunk); err != nil {
+			return err
+		}
+
+		loc := filename
+		if base := e.siteBaseURL(data.Site); base != "" {
+			loc = strings.TrimSuffix(base, "/") + "/" + filename
+		}
+		index.Sitemaps = append(index.Sitemaps, sitemapEntry{Loc: loc, LastMod: today})
+	}
+
+	xmlData, err := xml.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sitemap index: %w", err)
+	}
+	return os.WriteFile(filepath.Join(outputRoot, "sitemap.xml"), append([]byte(xml.Header), xmlData...), 0600)
+}
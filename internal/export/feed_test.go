@@ -0,0 +1,424 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestAtomEntryID(t *testing.T) {
+	cfg := &config.Config{}
+	exporter := NewExporter(cfg)
+
+	post := models.WordPressPost{ID: 7, Slug: "hello-world"}
+
+	got := exporter.atomEntryID("example.com", "2024-03-15", post)
+	want := "tag:example.com,2024-03-15:post-7"
+
+	if got != want {
+		t.Errorf("atomEntryID() = %q, want %q", got, want)
+	}
+}
+
+func TestAtomEntryIDUsesFeedStartDateNotPostDate(t *testing.T) {
+	cfg := &config.Config{}
+	exporter := NewExporter(cfg)
+
+	date, _ := time.Parse("2006-01-02", "2024-06-01")
+	post := models.WordPressPost{ID: 9, Date: models.WordPressTime{Time: date}}
+
+	got := exporter.atomEntryID("example.com", "2024-01-01", post)
+	want := "tag:example.com,2024-01-01:post-9"
+
+	if got != want {
+		t.Errorf("atomEntryID() = %q, want %q", got, want)
+	}
+}
+
+func TestFeedStartDateReturnsEarliestPostDate(t *testing.T) {
+	cfg := &config.Config{}
+	exporter := NewExporter(cfg)
+
+	early, _ := time.Parse("2006-01-02", "2023-05-01")
+	later, _ := time.Parse("2006-01-02", "2024-03-15")
+	posts := []models.WordPressPost{
+		{ID: 1, Date: models.WordPressTime{Time: later}},
+		{ID: 2, Date: models.WordPressTime{Time: early}},
+	}
+
+	got := exporter.feedStartDate(posts)
+	want := "2023-05-01"
+
+	if got != want {
+		t.Errorf("feedStartDate() = %q, want %q", got, want)
+	}
+}
+
+func TestExportAtom(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir, Format: "atom"}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site: models.SiteInfo{Name: "Example Blog", URL: "https://example.com"},
+		Posts: []models.WordPressPost{
+			{ID: 1, Slug: "first-post", Author: 1, Title: models.RenderedContent{Rendered: "First Post"}, Link: "https://example.com/first-post"},
+		},
+		Users: []models.WordPressUser{{ID: 1, Name: "Jane Doe"}},
+	}
+
+	if err := exporter.exportAtom(data); err != nil {
+		t.Fatalf("exportAtom() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "atom.xml"))
+	if err != nil {
+		t.Fatalf("failed to read atom.xml: %v", err)
+	}
+
+	if !strings.Contains(string(content), "<title>First Post</title>") {
+		t.Errorf("atom.xml missing expected entry title, got:\n%s", content)
+	}
+
+	if !strings.Contains(string(content), "<name>Jane Doe</name>") {
+		t.Errorf("atom.xml missing resolved author name, got:\n%s", content)
+	}
+}
+
+func TestExportAtomPaginatesAcrossFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir, Format: "atom"}
+	exporter := NewExporter(cfg)
+
+	var posts []models.WordPressPost
+	for i := 0; i < feedMaxEntriesPerFile+1; i++ {
+		posts = append(posts, models.WordPressPost{ID: i + 1, Title: models.RenderedContent{Rendered: fmt.Sprintf("Post %d", i+1)}})
+	}
+
+	data := &models.ExportData{
+		Site:  models.SiteInfo{Name: "Example Blog", URL: "https://example.com"},
+		Posts: posts,
+	}
+
+	if err := exporter.exportAtom(data); err != nil {
+		t.Fatalf("exportAtom() error = %v", err)
+	}
+
+	first, err := os.ReadFile(filepath.Join(tmpDir, "atom.xml"))
+	if err != nil {
+		t.Fatalf("failed to read atom.xml: %v", err)
+	}
+	if !strings.Contains(string(first), `rel="next"`) {
+		t.Errorf("atom.xml missing rel=\"next\" link for the second page, got:\n%s", first)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "atom-2.xml")); err != nil {
+		t.Errorf("expected atom-2.xml to exist: %v", err)
+	}
+}
+
+func TestExportRSS(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir, Format: "rss"}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site: models.SiteInfo{Name: "Example Blog", URL: "https://example.com"},
+		Posts: []models.WordPressPost{
+			{
+				ID: 1, Author: 1, Title: models.RenderedContent{Rendered: "First Post"},
+				Link: "https://example.com/first-post", Categories: []int{5},
+			},
+		},
+		Users:      []models.WordPressUser{{ID: 1, Name: "Jane Doe"}},
+		Categories: []models.WordPressCategory{{ID: 5, Name: "News", Slug: "news"}},
+	}
+
+	if err := exporter.exportRSS(data); err != nil {
+		t.Fatalf("exportRSS() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "feed.xml"))
+	if err != nil {
+		t.Fatalf("failed to read feed.xml: %v", err)
+	}
+
+	if !strings.Contains(string(content), "<title>First Post</title>") {
+		t.Errorf("feed.xml missing expected item title, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "<category>News</category>") {
+		t.Errorf("feed.xml missing resolved category name, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "<author>Jane Doe</author>") {
+		t.Errorf("feed.xml missing resolved author name, got:\n%s", content)
+	}
+}
+
+func TestChunkSitemapURLsSingleChunk(t *testing.T) {
+	cfg := &config.Config{}
+	exporter := NewExporter(cfg)
+
+	urls := []sitemapURL{{Loc: "https://example.com/a"}, {Loc: "https://example.com/b"}}
+	chunks := exporter.chunkSitemapURLs(urls)
+
+	if len(chunks) != 1 || len(chunks[0]) != 2 {
+		t.Errorf("chunkSitemapURLs() = %v, want a single chunk of 2 URLs", chunks)
+	}
+}
+
+func TestChunkSitemapURLsRespectsMaxURLsPerFile(t *testing.T) {
+	cfg := &config.Config{}
+	exporter := NewExporter(cfg)
+
+	var urls []sitemapURL
+	for i := 0; i < sitemapMaxURLsPerFile+10; i++ {
+		urls = append(urls, sitemapURL{Loc: "https://example.com/post"})
+	}
+
+	chunks := exporter.chunkSitemapURLs(urls)
+
+	if len(chunks) != 2 {
+		t.Fatalf("chunkSitemapURLs() returned %d chunks, want 2", len(chunks))
+	}
+
+	if len(chunks[0]) != sitemapMaxURLsPerFile {
+		t.Errorf("first chunk has %d URLs, want %d", len(chunks[0]), sitemapMaxURLsPerFile)
+	}
+
+	if len(chunks[1]) != 10 {
+		t.Errorf("second chunk has %d URLs, want 10", len(chunks[1]))
+	}
+}
+
+func TestExportSitemap(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir, Format: "sitemap"}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site:  models.SiteInfo{URL: "https://example.com"},
+		Posts: []models.WordPressPost{{ID: 1, Link: "https://example.com/first-post"}},
+		Pages: []models.WordPressPost{{ID: 2, Link: "https://example.com/about"}},
+	}
+
+	if err := exporter.exportSitemap(data); err != nil {
+		t.Fatalf("exportSitemap() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+
+	if !strings.Contains(string(content), "https://example.com/first-post") {
+		t.Errorf("sitemap.xml missing post URL, got:\n%s", content)
+	}
+
+	if !strings.Contains(string(content), "https://example.com/about") {
+		t.Errorf("sitemap.xml missing page URL, got:\n%s", content)
+	}
+}
+
+func TestWriteCompanionSitemapEscapesURLsAndIncludesTaxonomies(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site:       models.SiteInfo{URL: "https://example.com"},
+		Posts:      []models.WordPressPost{{ID: 1, Link: "https://example.com/a-b?x=1&y=2"}},
+		Categories: []models.WordPressCategory{{ID: 1, Link: "https://example.com/category/news"}},
+		Tags:       []models.WordPressTag{{ID: 1, Link: "https://example.com/tag/go"}},
+	}
+
+	if err := exporter.writeCompanionSitemap(data, tmpDir); err != nil {
+		t.Fatalf("writeCompanionSitemap() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+
+	if strings.Contains(string(content), "x=1&y=2") {
+		t.Errorf("sitemap.xml did not escape '&' in <loc>, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "x=1&amp;y=2") {
+		t.Errorf("sitemap.xml missing escaped post URL, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "https://example.com/category/news") {
+		t.Errorf("sitemap.xml missing category URL, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "https://example.com/tag/go") {
+		t.Errorf("sitemap.xml missing tag URL, got:\n%s", content)
+	}
+}
+
+func TestWriteCompanionSitemapSplitsIndexAtFiftyThousandURLs(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{Site: models.SiteInfo{URL: "https://example.com"}}
+	for i := 0; i < sitemapMaxURLsPerFile+10; i++ {
+		data.Posts = append(data.Posts, models.WordPressPost{
+			ID:   i,
+			Link: fmt.Sprintf("https://example.com/post-%d", i),
+		})
+	}
+
+	if err := exporter.writeCompanionSitemap(data, tmpDir); err != nil {
+		t.Fatalf("writeCompanionSitemap() error = %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(tmpDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+	if !strings.Contains(string(index), "<sitemapindex") {
+		t.Errorf("sitemap.xml should be a sitemap index once the per-file limit is exceeded, got:\n%s", index)
+	}
+	if !strings.Contains(string(index), "https://example.com/sitemap-1.xml") {
+		t.Errorf("sitemap index missing <loc> built against Config.URL, got:\n%s", index)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "sitemap-2.xml")); err != nil {
+		t.Errorf("expected sitemap-2.xml to exist: %v", err)
+	}
+}
+
+func TestWriteCompanionFeedsWritesAtomAndRSS(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site: models.SiteInfo{Name: "Example Blog", URL: "https://example.com"},
+		Posts: []models.WordPressPost{
+			{ID: 1, Author: 1, Title: models.RenderedContent{Rendered: "First Post"}, Link: "https://example.com/first-post"},
+		},
+		Users: []models.WordPressUser{{ID: 1, Name: "Jane Doe"}},
+	}
+
+	if err := exporter.writeCompanionFeeds(data, tmpDir); err != nil {
+		t.Fatalf("writeCompanionFeeds() error = %v", err)
+	}
+
+	atom, err := os.ReadFile(filepath.Join(tmpDir, "feed.atom"))
+	if err != nil {
+		t.Fatalf("failed to read feed.atom: %v", err)
+	}
+	if !strings.Contains(string(atom), "<title>First Post</title>") {
+		t.Errorf("feed.atom missing expected entry title, got:\n%s", atom)
+	}
+
+	rss, err := os.ReadFile(filepath.Join(tmpDir, "feed.rss"))
+	if err != nil {
+		t.Fatalf("failed to read feed.rss: %v", err)
+	}
+	if !strings.Contains(string(rss), "<title>First Post</title>") {
+		t.Errorf("feed.rss missing expected item title, got:\n%s", rss)
+	}
+}
+
+func TestFeedUpdatedUsesNewestPostModifiedTime(t *testing.T) {
+	cfg := &config.Config{}
+	exporter := NewExporter(cfg)
+
+	older, _ := time.Parse("2006-01-02", "2023-01-01")
+	newer, _ := time.Parse("2006-01-02", "2024-06-01")
+	posts := []models.WordPressPost{
+		{ID: 1, Modified: models.WordPressTime{Time: older}},
+		{ID: 2, Modified: models.WordPressTime{Time: newer}},
+	}
+
+	got := exporter.feedUpdated(posts)
+	want := newer.Format(time.RFC3339)
+
+	if got != want {
+		t.Errorf("feedUpdated() = %q, want %q", got, want)
+	}
+}
+
+func TestSiteHostAndFeedStartDatePreferConfigOverrides(t *testing.T) {
+	cfg := &config.Config{URL: "https://example.com", FeedTagAuthority: "cdn.example.org", FeedTagDate: "2019-12-25"}
+	exporter := NewExporter(cfg)
+
+	if got := exporter.siteHost(models.SiteInfo{URL: "https://example.com"}); got != "cdn.example.org" {
+		t.Errorf("siteHost() = %q, want config override %q", got, "cdn.example.org")
+	}
+
+	date, _ := time.Parse("2006-01-02", "2024-03-15")
+	posts := []models.WordPressPost{{ID: 1, Date: models.WordPressTime{Time: date}}}
+	if got := exporter.feedStartDate(posts); got != "2019-12-25" {
+		t.Errorf("feedStartDate() = %q, want config override %q", got, "2019-12-25")
+	}
+}
+
+func TestSitemapChangefreqInfersFromAge(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		modified time.Time
+		want     string
+	}{
+		{"zero value", time.Time{}, "monthly"},
+		{"modified today", now, "daily"},
+		{"modified two weeks ago", now.Add(-14 * 24 * time.Hour), "weekly"},
+		{"modified six months ago", now.Add(-180 * 24 * time.Hour), "monthly"},
+		{"modified two years ago", now.Add(-2 * 365 * 24 * time.Hour), "yearly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sitemapChangefreq(tt.modified); got != tt.want {
+				t.Errorf("sitemapChangefreq(%v) = %q, want %q", tt.modified, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCompanionSitemapUsesAgeBasedChangefreq(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site: models.SiteInfo{URL: "https://example.com"},
+		Posts: []models.WordPressPost{
+			{ID: 1, Link: "https://example.com/fresh-post", ModifiedGMT: models.WordPressTime{Time: time.Now()}},
+			{ID: 2, Link: "https://example.com/old-post", ModifiedGMT: models.WordPressTime{Time: time.Now().Add(-2 * 365 * 24 * time.Hour)}},
+		},
+	}
+
+	if err := exporter.writeCompanionSitemap(data, tmpDir); err != nil {
+		t.Fatalf("writeCompanionSitemap() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+
+	if !strings.Contains(string(content), "<changefreq>daily</changefreq>") {
+		t.Errorf("sitemap.xml missing daily changefreq for recently-modified post, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "<changefreq>yearly</changefreq>") {
+		t.Errorf("sitemap.xml missing yearly changefreq for old post, got:\n%s", content)
+	}
+}
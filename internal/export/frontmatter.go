@@ -0,0 +1,155 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// frontMatter holds the fields rendered into a Hugo/Jekyll front matter block. Not every
+// flavor uses every field: Jekyll's YAML front matter omits Slug/Aliases, which
+// aren't part of its conventions.
+type frontMatter struct {
+	Title         string
+	Date          string
+	LastMod       string
+	Draft         bool
+	Slug          string
+	Aliases       []string
+	Categories    []string
+	Tags          []string
+	Author        string
+	FeaturedImage string
+	Description   string
+}
+
+// renderFrontMatter renders fm as a delimited front matter block in format ("yaml"
+// (default), "toml", or "json").
+func renderFrontMatter(fm frontMatter, format string) string {
+	switch format {
+	case "toml":
+		return renderFrontMatterTOML(fm)
+	case "json":
+		return renderFrontMatterJSON(fm)
+	default:
+		return renderFrontMatterYAML(fm)
+	}
+}
+
+func renderFrontMatterYAML(fm frontMatter) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %q\n", fm.Title)
+	if fm.Date != "" {
+		fmt.Fprintf(&b, "date: %s\n", fm.Date)
+	}
+	if fm.LastMod != "" {
+		fmt.Fprintf(&b, "lastmod: %s\n", fm.LastMod)
+	}
+	fmt.Fprintf(&b, "draft: %t\n", fm.Draft)
+	if fm.Slug != "" {
+		fmt.Fprintf(&b, "slug: %q\n", fm.Slug)
+	}
+	writeYAMLList(&b, "aliases", fm.Aliases)
+	writeYAMLList(&b, "categories", fm.Categories)
+	writeYAMLList(&b, "tags", fm.Tags)
+	if fm.Author != "" {
+		fmt.Fprintf(&b, "author: %q\n", fm.Author)
+	}
+	if fm.FeaturedImage != "" {
+		fmt.Fprintf(&b, "featured_image: %q\n", fm.FeaturedImage)
+	}
+	if fm.Description != "" {
+		fmt.Fprintf(&b, "description: %q\n", fm.Description)
+	}
+	b.WriteString("---\n")
+	return b.String()
+}
+
+func writeYAMLList(b *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", key)
+	for _, v := range values {
+		fmt.Fprintf(b, "  - %q\n", v)
+	}
+}
+
+func renderFrontMatterTOML(fm frontMatter) string {
+	var b strings.Builder
+	b.WriteString("+++\n")
+	fmt.Fprintf(&b, "title = %q\n", fm.Title)
+	if fm.Date != "" {
+		fmt.Fprintf(&b, "date = %q\n", fm.Date)
+	}
+	if fm.LastMod != "" {
+		fmt.Fprintf(&b, "lastmod = %q\n", fm.LastMod)
+	}
+	fmt.Fprintf(&b, "draft = %t\n", fm.Draft)
+	if fm.Slug != "" {
+		fmt.Fprintf(&b, "slug = %q\n", fm.Slug)
+	}
+	writeTOMLList(&b, "aliases", fm.Aliases)
+	writeTOMLList(&b, "categories", fm.Categories)
+	writeTOMLList(&b, "tags", fm.Tags)
+	if fm.Author != "" {
+		fmt.Fprintf(&b, "author = %q\n", fm.Author)
+	}
+	if fm.FeaturedImage != "" {
+		fmt.Fprintf(&b, "featured_image = %q\n", fm.FeaturedImage)
+	}
+	if fm.Description != "" {
+		fmt.Fprintf(&b, "description = %q\n", fm.Description)
+	}
+	b.WriteString("+++\n")
+	return b.String()
+}
+
+func writeTOMLList(b *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	fmt.Fprintf(b, "%s = [%s]\n", key, strings.Join(quoted, ", "))
+}
+
+// renderFrontMatterJSON renders fm as Hugo's JSON front matter: a single JSON object with
+// no extra delimiters, since the object's own braces mark its extent.
+func renderFrontMatterJSON(fm frontMatter) string {
+	type jsonFrontMatter struct {
+		Title         string   `json:"title"`
+		Date          string   `json:"date,omitempty"`
+		LastMod       string   `json:"lastmod,omitempty"`
+		Draft         bool     `json:"draft"`
+		Slug          string   `json:"slug,omitempty"`
+		Aliases       []string `json:"aliases,omitempty"`
+		Categories    []string `json:"categories,omitempty"`
+		Tags          []string `json:"tags,omitempty"`
+		Author        string   `json:"author,omitempty"`
+		FeaturedImage string   `json:"featured_image,omitempty"`
+		Description   string   `json:"description,omitempty"`
+	}
+
+	data, err := json.MarshalIndent(jsonFrontMatter{
+		Title:         fm.Title,
+		Date:          fm.Date,
+		LastMod:       fm.LastMod,
+		Draft:         fm.Draft,
+		Slug:          fm.Slug,
+		Aliases:       fm.Aliases,
+		Categories:    fm.Categories,
+		Tags:          fm.Tags,
+		Author:        fm.Author,
+		FeaturedImage: fm.FeaturedImage,
+		Description:   fm.Description,
+	}, "", "  ")
+	if err != nil {
+		return "{}\n"
+	}
+	return string(data) + "\n"
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderFrontMatterYAML(t *testing.T) {
+	fm := frontMatter{Title: "Hello", Categories: []string{"news"}, Tags: []string{"go"}}
+	got := renderFrontMatter(fm, "yaml")
+
+	if !strings.HasPrefix(got, "---\n") || !strings.HasSuffix(got, "---\n") {
+		t.Errorf("renderFrontMatter(yaml) should be delimited by ---, got:\n%s", got)
+	}
+	if !strings.Contains(got, `title: "Hello"`) {
+		t.Errorf("renderFrontMatter(yaml) missing title, got:\n%s", got)
+	}
+}
+
+func TestRenderFrontMatterTOML(t *testing.T) {
+	fm := frontMatter{Title: "Hello", Aliases: []string{"/old-path"}}
+	got := renderFrontMatter(fm, "toml")
+
+	if !strings.HasPrefix(got, "+++\n") || !strings.HasSuffix(got, "+++\n") {
+		t.Errorf("renderFrontMatter(toml) should be delimited by +++, got:\n%s", got)
+	}
+	if !strings.Contains(got, `aliases = ["/old-path"]`) {
+		t.Errorf("renderFrontMatter(toml) missing aliases, got:\n%s", got)
+	}
+}
+
+func TestRenderFrontMatterJSON(t *testing.T) {
+	fm := frontMatter{Title: "Hello", Draft: true}
+	got := renderFrontMatter(fm, "json")
+
+	if !strings.HasPrefix(strings.TrimSpace(got), "{") {
+		t.Errorf("renderFrontMatter(json) should be a bare JSON object, got:\n%s", got)
+	}
+	if !strings.Contains(got, `"title": "Hello"`) || !strings.Contains(got, `"draft": true`) {
+		t.Errorf("renderFrontMatter(json) missing expected fields, got:\n%s", got)
+	}
+}
@@ -0,0 +1,226 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// exportHugoSite exports posts and pages as a standalone Hugo site, ready for `hugo
+// server`/`hugo build` without further setup: content/posts/<category-path>/<slug>/
+// page bundles, each with its own downloaded media colocated next to index.md (see
+// Downloader.DownloadMediaForPost) rather than the shared mediaDir MarkdownFlavor "hugo"
+// uses, so a bundle can be moved or published on its own without broken image links.
+// Categories and tags additionally get content/categories/<slug>/_index.md and
+// content/tags/<slug>/_index.md section bundles so Hugo's taxonomy list pages render, and
+// the site's full metadata (not just what config.toml needs) is written to
+// data/site.toml, queryable from templates as .Site.Data.site.*. Front matter is always
+// TOML, the format Hugo itself defaults to. This differs from Format "markdown" with
+// MarkdownFlavor "hugo" (see exportMarkdownHugo), which writes a flat content/posts/<slug>/
+// tree against the shared mediaDir and honors Config.FrontMatterFormat.
+func (e *Exporter) exportHugoSite(data *models.ExportData) error {
+	postsDir := filepath.Join(e.config.Output, "content", "posts")
+	pagesDir := filepath.Join(e.config.Output, "content", "pages")
+	categoriesDir := filepath.Join(e.config.Output, "content", "categories")
+	tagsDir := filepath.Join(e.config.Output, "content", "tags")
+
+	for _, dir := range []string{postsDir, pagesDir, categoriesDir, tagsDir} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", dir, err)
+		}
+	}
+
+	categoryMap := make(map[int]models.WordPressCategory, len(data.Categories))
+	for _, cat := range data.Categories {
+		categoryMap[cat.ID] = cat
+	}
+	tagMap := make(map[int]models.WordPressTag, len(data.Tags))
+	for _, tag := range data.Tags {
+		tagMap[tag.ID] = tag
+	}
+	mediaMap := make(map[int]models.WordPressMedia, len(data.Media))
+	for _, m := range data.Media {
+		mediaMap[m.ID] = m
+	}
+	userMap := make(map[int]models.WordPressUser, len(data.Users))
+	for _, u := range data.Users {
+		userMap[u.ID] = u
+	}
+
+	categoryHierarchy := e.buildCategoryHierarchy(data.Categories)
+	baseURL := e.siteBaseURL(data.Site)
+
+	for _, post := range data.Posts {
+		categoryPath := e.getCategoryPath(post, categoryMap, categoryHierarchy)
+		if err := e.writeHugoSiteContentFile(filepath.Join(postsDir, categoryPath), post, categoryMap, tagMap, data.Media, mediaMap, userMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write hugo post %q: %w", post.Slug, err)
+		}
+	}
+	for _, page := range data.Pages {
+		if err := e.writeHugoSiteContentFile(pagesDir, page, categoryMap, tagMap, data.Media, mediaMap, userMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write hugo page %q: %w", page.Slug, err)
+		}
+	}
+
+	if err := e.writeHugoSiteConfig(data.Site); err != nil {
+		return fmt.Errorf("failed to write hugo config.toml: %w", err)
+	}
+	if err := e.writeHugoSiteDataFile(data.Site); err != nil {
+		return fmt.Errorf("failed to write hugo data/site.toml: %w", err)
+	}
+
+	for _, cat := range data.Categories {
+		if err := e.writeHugoSiteTaxonomyStub(categoriesDir, cat.Slug, cat.Name, cat.Description); err != nil {
+			return fmt.Errorf("failed to write hugo category bundle %q: %w", cat.Slug, err)
+		}
+	}
+	for _, tag := range data.Tags {
+		if err := e.writeHugoSiteTaxonomyStub(tagsDir, tag.Slug, tag.Name, tag.Description); err != nil {
+			return fmt.Errorf("failed to write hugo tag bundle %q: %w", tag.Slug, err)
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", e.config.Output)
+	return nil
+}
+
+// writeHugoSiteTaxonomyStub writes dir/<slug>/_index.md, a TOML-front-matter section
+// bundle for one of a standalone Hugo site's category/tag taxonomy list pages - the
+// page-bundle counterpart to writeHugoTaxonomyStub, which honors Config.FrontMatterFormat
+// for MarkdownFlavor "hugo" instead.
+func (e *Exporter) writeHugoSiteTaxonomyStub(dir, slug, name, description string) error {
+	slug = e.sanitizeDirectoryName(slug)
+	termDir := filepath.Join(dir, slug)
+	if err := os.MkdirAll(termDir, 0750); err != nil {
+		return fmt.Errorf("failed to create taxonomy directory %s: %w", termDir, err)
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatterTOML(frontMatter{Title: name, Description: description}))
+	if description != "" {
+		b.WriteString("\n")
+		b.WriteString(description)
+		b.WriteString("\n")
+	}
+
+	return os.WriteFile(filepath.Join(termDir, "_index.md"), []byte(b.String()), 0600)
+}
+
+// writeHugoSiteDataFile writes Output/data/site.toml, exposing the WordPress site's full
+// settings to templates as .Site.Data.site.* - fields config.toml itself has no place for
+// (admin_email, date/time formats, start-of-week) alongside the ones it duplicates, so a
+// theme can use whichever it needs without re-deriving them from config.toml.
+func (e *Exporter) writeHugoSiteDataFile(site models.SiteInfo) error {
+	dataDir := filepath.Join(e.config.Output, "data")
+	if err := os.MkdirAll(dataDir, 0750); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", dataDir, err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "name = %q\n", site.Name)
+	fmt.Fprintf(&b, "description = %q\n", site.Description)
+	fmt.Fprintf(&b, "url = %q\n", site.URL)
+	fmt.Fprintf(&b, "home_url = %q\n", site.HomeURL)
+	fmt.Fprintf(&b, "admin_email = %q\n", site.AdminEmail)
+	fmt.Fprintf(&b, "timezone = %q\n", site.Timezone)
+	fmt.Fprintf(&b, "date_format = %q\n", site.DateFormat)
+	fmt.Fprintf(&b, "time_format = %q\n", site.TimeFormat)
+	fmt.Fprintf(&b, "start_of_week = %d\n", site.StartOfWeek)
+	fmt.Fprintf(&b, "language = %q\n", site.Language)
+
+	return os.WriteFile(filepath.Join(dataDir, "site.toml"), []byte(b.String()), 0600)
+}
+
+// writeHugoSiteContentFile writes a single post/page as baseDir/<slug>/index.md,
+// downloading the media it references into baseDir/<slug>/images/ (see
+// Downloader.DownloadMediaForPost) and running its body through convertHugoContent so
+// WordPress shortcodes/code blocks survive the HTML-to-Markdown conversion the same way
+// MarkdownFlavor "hugo" handles them.
+func (e *Exporter) writeHugoSiteContentFile(
+	baseDir string,
+	post models.WordPressPost,
+	categoryMap map[int]models.WordPressCategory,
+	tagMap map[int]models.WordPressTag,
+	allMedia []models.WordPressMedia,
+	mediaMap map[int]models.WordPressMedia,
+	userMap map[int]models.WordPressUser,
+	baseURL string,
+) error {
+	slug := e.sanitizeDirectoryName(post.Slug)
+	if slug == "" {
+		slug = fmt.Sprintf("post-%d", post.ID)
+	}
+
+	contentDir := filepath.Join(baseDir, slug)
+	if err := os.MkdirAll(contentDir, 0750); err != nil {
+		return fmt.Errorf("failed to create content directory %s: %w", contentDir, err)
+	}
+
+	items := postMediaItems(post, allMedia, mediaMap)
+	mediaPaths, err := e.downloader.DownloadMediaForPost(contentDir, items)
+	if err != nil {
+		return fmt.Errorf("failed to download media for %s: %w", contentDir, err)
+	}
+
+	content := post.Content.Rendered
+	for _, m := range items {
+		relPath, ok := mediaPaths[m.ID]
+		if !ok {
+			continue
+		}
+		content = strings.ReplaceAll(content, m.SourceURL, relPath)
+	}
+
+	var categories []string
+	for _, id := range post.Categories {
+		if cat, ok := categoryMap[id]; ok {
+			categories = append(categories, cat.Name)
+		}
+	}
+	var tags []string
+	for _, id := range post.Tags {
+		if tag, ok := tagMap[id]; ok {
+			tags = append(tags, tag.Name)
+		}
+	}
+
+	var featuredImage string
+	if post.FeaturedMedia > 0 {
+		featuredImage = mediaPaths[post.FeaturedMedia]
+	}
+
+	author := fmt.Sprintf("%d", post.Author)
+	if u, ok := userMap[post.Author]; ok && u.Slug != "" {
+		author = u.Slug
+	}
+
+	lastMod := ""
+	if !post.Modified.Time.IsZero() {
+		lastMod = post.Modified.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	fm := frontMatter{
+		Title:         post.Title.Rendered,
+		Date:          post.Date.Format("2006-01-02T15:04:05Z07:00"),
+		LastMod:       lastMod,
+		Draft:         post.Status != "publish",
+		Slug:          slug,
+		Aliases:       aliasesFromLink(post.Link),
+		Categories:    categories,
+		Tags:          tags,
+		Author:        author,
+		FeaturedImage: featuredImage,
+		Description:   stripHTML(post.Excerpt.Rendered),
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatterTOML(fm))
+	b.WriteString("\n")
+	b.WriteString(e.convertHugoContent(content, baseURL))
+	b.WriteString("\n")
+
+	return os.WriteFile(filepath.Join(contentDir, "index.md"), []byte(b.String()), 0600)
+}
@@ -0,0 +1,132 @@
+package export
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestExportHugoSiteWritesCategoryPathBundleWithTOMLFrontMatter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("fake image data"))
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	data := hugoTestData()
+	data.Posts[0].Content.Rendered = `<p>Hello</p><img src="` + server.URL + `/photo.jpg">`
+	data.Posts[0].Excerpt = models.RenderedContent{Rendered: "<p>A quick summary.</p>"}
+	data.Posts[0].Modified = models.WordPressTime{Time: time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC)}
+	data.Media = []models.WordPressMedia{
+		{ID: 100, SourceURL: server.URL + "/photo.jpg", MimeType: "image/jpeg"},
+	}
+
+	cfg := &config.Config{Output: tmpDir, Format: "hugo", DownloadMedia: true}
+	e := NewExporter(cfg)
+
+	if err := e.Export(data); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	contentDir := filepath.Join(tmpDir, "content", "posts", "news", "hello-world")
+	post, err := os.ReadFile(filepath.Join(contentDir, "index.md"))
+	if err != nil {
+		t.Fatalf("failed to read hugo site post: %v", err)
+	}
+	content := string(post)
+
+	if !strings.HasPrefix(content, "+++\n") {
+		t.Errorf("hugo site post should use TOML front matter delimiters, got:\n%s", content)
+	}
+	if !strings.Contains(content, `title = "Hello World"`) {
+		t.Errorf("hugo site post missing title front matter, got:\n%s", content)
+	}
+	if !strings.Contains(content, `categories = ["News"]`) {
+		t.Errorf("hugo site post should use category names, got:\n%s", content)
+	}
+	if !strings.Contains(content, `tags = ["Go"]`) {
+		t.Errorf("hugo site post should use tag names, got:\n%s", content)
+	}
+	if !strings.Contains(content, `lastmod = "2020-02-03T04:05:06Z"`) {
+		t.Errorf("hugo site post missing lastmod front matter, got:\n%s", content)
+	}
+	if !strings.Contains(content, `description = "A quick summary."`) {
+		t.Errorf("hugo site post missing description front matter, got:\n%s", content)
+	}
+	if strings.Contains(content, server.URL) {
+		t.Errorf("hugo site post should rewrite media URLs to bundle-relative paths, got:\n%s", content)
+	}
+	if !strings.Contains(content, "images/") {
+		t.Errorf("hugo site post should reference downloaded media under images/, got:\n%s", content)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(contentDir, "images"))
+	if err != nil {
+		t.Fatalf("failed to read hugo site images dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("hugo site images dir has %d entries, want 1", len(entries))
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "content", "pages", "about", "index.md")); err != nil {
+		t.Errorf("expected hugo site page to exist: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "config.toml")); err != nil {
+		t.Errorf("expected hugo site config.toml to exist: %v", err)
+	}
+}
+
+func TestExportHugoSiteWritesTaxonomyBundlesAndSiteData(t *testing.T) {
+	tmpDir := t.TempDir()
+	data := hugoTestData()
+	data.Categories[0].Description = "Posts about current events."
+	data.Site.AdminEmail = "admin@example.com"
+	data.Site.Language = "en-US"
+
+	cfg := &config.Config{Output: tmpDir, Format: "hugo"}
+	e := NewExporter(cfg)
+
+	if err := e.Export(data); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	category, err := os.ReadFile(filepath.Join(tmpDir, "content", "categories", "news", "_index.md"))
+	if err != nil {
+		t.Fatalf("failed to read hugo site category bundle: %v", err)
+	}
+	if !strings.HasPrefix(string(category), "+++\n") {
+		t.Errorf("hugo site category bundle should use TOML front matter, got:\n%s", category)
+	}
+	if !strings.Contains(string(category), `title = "News"`) {
+		t.Errorf("hugo site category bundle missing title front matter, got:\n%s", category)
+	}
+	if !strings.Contains(string(category), "Posts about current events.") {
+		t.Errorf("hugo site category bundle missing description, got:\n%s", category)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "content", "tags", "go", "_index.md")); err != nil {
+		t.Errorf("expected hugo site tag bundle to exist: %v", err)
+	}
+
+	siteData, err := os.ReadFile(filepath.Join(tmpDir, "data", "site.toml"))
+	if err != nil {
+		t.Fatalf("failed to read hugo site data/site.toml: %v", err)
+	}
+	if !strings.Contains(string(siteData), `name = "Test Site"`) {
+		t.Errorf("hugo site data/site.toml missing name, got:\n%s", siteData)
+	}
+	if !strings.Contains(string(siteData), `admin_email = "admin@example.com"`) {
+		t.Errorf("hugo site data/site.toml missing admin_email, got:\n%s", siteData)
+	}
+	if !strings.Contains(string(siteData), `language = "en-US"`) {
+		t.Errorf("hugo site data/site.toml missing language, got:\n%s", siteData)
+	}
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// llmsExcerptMaxLen bounds each llms.txt entry's excerpt to keep the index skimmable.
+const llmsExcerptMaxLen = 200
+
+// writeLLMsTxt writes llms.txt into outputRoot, gated by Config.WriteLLMsTxt: a plain-text
+// index of post/page titles, URLs, and one-line excerpts following the llmstxt.org
+// convention, so a site's content can be ingested without crawling it.
+func (e *Exporter) writeLLMsTxt(data *models.ExportData, outputRoot string) error {
+	var b strings.Builder
+
+	title := data.Site.Name
+	if title == "" {
+		title = e.config.URL
+	}
+	fmt.Fprintf(&b, "# %s\n", title)
+	if data.Site.Description != "" {
+		fmt.Fprintf(&b, "\n> %s\n", data.Site.Description)
+	}
+
+	if len(data.Posts) > 0 {
+		fmt.Fprintf(&b, "\n## Posts\n")
+		for _, post := range data.Posts {
+			fmt.Fprintf(&b, "- [%s](%s): %s\n", stripHTML(post.Title.Rendered), post.Link, llmsExcerpt(post.Excerpt.Rendered))
+		}
+	}
+
+	if len(data.Pages) > 0 {
+		fmt.Fprintf(&b, "\n## Pages\n")
+		for _, page := range data.Pages {
+			fmt.Fprintf(&b, "- [%s](%s): %s\n", stripHTML(page.Title.Rendered), page.Link, llmsExcerpt(page.Excerpt.Rendered))
+		}
+	}
+
+	return os.WriteFile(filepath.Join(outputRoot, "llms.txt"), []byte(b.String()), 0600)
+}
+
+// llmsExcerpt collapses excerpt to a single line, truncated to llmsExcerptMaxLen.
+func llmsExcerpt(excerpt string) string {
+	line := strings.Join(strings.Fields(stripHTML(excerpt)), " ")
+	if len(line) > llmsExcerptMaxLen {
+		line = line[:llmsExcerptMaxLen] + "..."
+	}
+	return line
+}
+
+// stripHTML removes tags from s, leaving just their text content.
+func stripHTML(s string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestWriteLLMsTxtListsPostsAndPagesWithExcerpts(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com"}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site: models.SiteInfo{Name: "Example Blog", Description: "A test blog"},
+		Posts: []models.WordPressPost{{
+			ID:      1,
+			Link:    "https://example.com/hello-world",
+			Title:   models.RenderedContent{Rendered: "Hello <em>World</em>"},
+			Excerpt: models.RenderedContent{Rendered: "<p>This is the first post.</p>"},
+		}},
+		Pages: []models.WordPressPost{{
+			ID:      2,
+			Link:    "https://example.com/about",
+			Title:   models.RenderedContent{Rendered: "About"},
+			Excerpt: models.RenderedContent{Rendered: "<p>About this site.</p>"},
+		}},
+	}
+
+	if err := exporter.writeLLMsTxt(data, tmpDir); err != nil {
+		t.Fatalf("writeLLMsTxt() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "llms.txt"))
+	if err != nil {
+		t.Fatalf("failed to read llms.txt: %v", err)
+	}
+
+	want := []string{
+		"# Example Blog",
+		"> A test blog",
+		"## Posts",
+		"- [Hello World](https://example.com/hello-world): This is the first post.",
+		"## Pages",
+		"- [About](https://example.com/about): About this site.",
+	}
+	for _, w := range want {
+		if !strings.Contains(string(content), w) {
+			t.Errorf("llms.txt missing %q, got:\n%s", w, content)
+		}
+	}
+}
+
+func TestLLMsExcerptTruncatesLongText(t *testing.T) {
+	long := strings.Repeat("word ", 100)
+	got := llmsExcerpt(long)
+
+	if len(got) != llmsExcerptMaxLen+len("...") {
+		t.Errorf("llmsExcerpt() length = %d, want %d", len(got), llmsExcerptMaxLen+len("..."))
+	}
+	if !strings.HasSuffix(got, "...") {
+		t.Errorf("llmsExcerpt() = %q, want a '...' suffix", got)
+	}
+}
+
+func TestStripHTMLRemovesTags(t *testing.T) {
+	got := stripHTML("<p>Hello <strong>World</strong></p>")
+	if got != "Hello World" {
+		t.Errorf("stripHTML() = %q, want %q", got, "Hello World")
+	}
+}
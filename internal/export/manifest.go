@@ -0,0 +1,112 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// exportManifestFileName is the sidecar JSON file persisted at the export output root
+// recording, per post/page ID, enough to skip rewriting unchanged content on a later run
+// and to clean up its old file if a later run moves it to a new path (e.g. a category
+// rename) or drops it entirely (see --prune).
+const exportManifestFileName = ".wpexporter-manifest.json"
+
+// ExportManifestEntry is what ExportManifest persists for one exported post/page.
+type ExportManifestEntry struct {
+	// Modified is the post's WordPress modified timestamp (RFC3339) as of this entry,
+	// recorded for visibility only - Hash is what's actually compared.
+	Modified string `json:"modified"`
+	// Hash is the SHA-256 (hex-encoded) of the generated content+front matter, used to
+	// detect whether a re-run would produce byte-identical output without diffing the
+	// file on disk.
+	Hash string `json:"hash"`
+	// OutputPath is the file's path relative to the export output root, as actually
+	// written. Exporter.writeMarkdownIfChanged compares it against the path a post would
+	// be written to this run to detect a rename (e.g. its category changed) and remove the
+	// stale file at the old path.
+	OutputPath string `json:"output_path"`
+}
+
+// ExportManifest is a sidecar JSON file, persisted at the export output root, recording
+// each exported post/page's content hash and output path. It follows the same load/save
+// idiom as internal/state.State and internal/media.MediaManifest: a zero value is returned
+// (not an error) when the file doesn't exist yet, and access is safe for concurrent callers
+// since exportPostsWithCategories/exportPostsMarkdown write through it from a worker pool.
+type ExportManifest struct {
+	Entries map[int]ExportManifestEntry `json:"entries"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// LoadExportManifest reads an ExportManifest from path, returning an empty, unsaved one if
+// the file doesn't exist yet (the first run).
+func LoadExportManifest(path string) (*ExportManifest, error) {
+	m := &ExportManifest{Entries: map[int]ExportManifestEntry{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to read export manifest %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse export manifest %s: %w", path, err)
+	}
+	m.path = path
+
+	return m, nil
+}
+
+// Get returns the entry recorded for postID, and whether one exists.
+func (m *ExportManifest) Get(postID int) (ExportManifestEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.Entries[postID]
+	return entry, ok
+}
+
+// Set records postID's entry. Unlike MediaManifest.Set, it doesn't persist immediately -
+// Exporter saves the manifest once after the whole run (see exportMarkdownPlain), since a
+// worker pool writing thousands of posts would otherwise serialize on disk I/O for every
+// single post.
+func (m *ExportManifest) Set(postID int, entry ExportManifestEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Entries[postID] = entry
+}
+
+// Delete removes postID's recorded entry, used when --prune finds a post no longer present
+// in the current export set.
+func (m *ExportManifest) Delete(postID int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.Entries, postID)
+}
+
+// Save writes the manifest to its backing path.
+func (m *ExportManifest) Save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export manifest: %w", err)
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write export manifest %s: %w", m.path, err)
+	}
+	return nil
+}
+
+// HashContent returns a stable SHA-256 (hex-encoded) of a post's generated content and
+// front matter combined.
+func HashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,240 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func testExportData(posts []models.WordPressPost, categories []models.WordPressCategory) *models.ExportData {
+	return &models.ExportData{
+		Site:       models.SiteInfo{Name: "Test Site"},
+		Posts:      posts,
+		Categories: categories,
+	}
+}
+
+func TestExportMarkdownPlainSkipsUnchangedPostsOnSecondRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown"}
+	e := NewExporter(cfg)
+
+	posts := []models.WordPressPost{{
+		ID:       1,
+		Slug:     "test-post",
+		Title:    models.RenderedContent{Rendered: "Test Post"},
+		Content:  models.RenderedContent{Rendered: "<p>Content</p>"},
+		Date:     models.WordPressTime{Time: time.Now()},
+		Modified: models.WordPressTime{Time: time.Now()},
+		Status:   "publish",
+		Link:     "https://example.com/test-post",
+	}}
+
+	data := testExportData(posts, nil)
+	if err := e.Export(data); err != nil {
+		t.Fatalf("first Export() error = %v", err)
+	}
+	if data.Stats.Skipped != 0 {
+		t.Errorf("first run Skipped = %d, want 0", data.Stats.Skipped)
+	}
+
+	data2 := testExportData(posts, nil)
+	if err := e.Export(data2); err != nil {
+		t.Fatalf("second Export() error = %v", err)
+	}
+	if data2.Stats.Skipped != 1 {
+		t.Errorf("second run Skipped = %d, want 1", data2.Stats.Skipped)
+	}
+}
+
+func TestExportMarkdownPlainRewritesChangedPostContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown"}
+	e := NewExporter(cfg)
+
+	makePost := func(content string) models.WordPressPost {
+		return models.WordPressPost{
+			ID:      1,
+			Slug:    "test-post",
+			Title:   models.RenderedContent{Rendered: "Test Post"},
+			Content: models.RenderedContent{Rendered: content},
+			Date:    models.WordPressTime{Time: time.Now()},
+			Status:  "publish",
+			Link:    "https://example.com/test-post",
+		}
+	}
+
+	if err := e.Export(testExportData([]models.WordPressPost{makePost("<p>Original</p>")}, nil)); err != nil {
+		t.Fatalf("first Export() error = %v", err)
+	}
+
+	postFile := filepath.Join(tmpDir, "posts", "uncategorized", "test-post.md")
+	before, err := os.ReadFile(postFile)
+	if err != nil {
+		t.Fatalf("failed to read exported post: %v", err)
+	}
+
+	data2 := testExportData([]models.WordPressPost{makePost("<p>Updated</p>")}, nil)
+	if err := e.Export(data2); err != nil {
+		t.Fatalf("second Export() error = %v", err)
+	}
+	if data2.Stats.Skipped != 0 {
+		t.Errorf("Skipped = %d after content changed, want 0", data2.Stats.Skipped)
+	}
+
+	after, err := os.ReadFile(postFile)
+	if err != nil {
+		t.Fatalf("failed to read re-exported post: %v", err)
+	}
+	if string(before) == string(after) {
+		t.Error("expected post file content to change after post content changed")
+	}
+}
+
+func TestExportMarkdownPlainForceBypassesManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown"}
+	e := NewExporter(cfg)
+
+	posts := []models.WordPressPost{{
+		ID:      1,
+		Slug:    "test-post",
+		Title:   models.RenderedContent{Rendered: "Test Post"},
+		Content: models.RenderedContent{Rendered: "<p>Content</p>"},
+		Date:    models.WordPressTime{Time: time.Now()},
+		Status:  "publish",
+		Link:    "https://example.com/test-post",
+	}}
+
+	if err := e.Export(testExportData(posts, nil)); err != nil {
+		t.Fatalf("first Export() error = %v", err)
+	}
+
+	cfg.Force = true
+	data2 := testExportData(posts, nil)
+	if err := e.Export(data2); err != nil {
+		t.Fatalf("second Export() error = %v", err)
+	}
+	if data2.Stats.Skipped != 0 {
+		t.Errorf("Skipped = %d with Force set, want 0", data2.Stats.Skipped)
+	}
+}
+
+func TestExportMarkdownPlainPruneRemovesDeletedPosts(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown", Prune: true}
+	e := NewExporter(cfg)
+
+	posts := []models.WordPressPost{{
+		ID:      1,
+		Slug:    "test-post",
+		Title:   models.RenderedContent{Rendered: "Test Post"},
+		Content: models.RenderedContent{Rendered: "<p>Content</p>"},
+		Date:    models.WordPressTime{Time: time.Now()},
+		Status:  "publish",
+		Link:    "https://example.com/test-post",
+	}}
+
+	if err := e.Export(testExportData(posts, nil)); err != nil {
+		t.Fatalf("first Export() error = %v", err)
+	}
+
+	postFile := filepath.Join(tmpDir, "posts", "uncategorized", "test-post.md")
+	if _, err := os.Stat(postFile); err != nil {
+		t.Fatalf("expected post file to exist after first export: %v", err)
+	}
+
+	if err := e.Export(testExportData(nil, nil)); err != nil {
+		t.Fatalf("second Export() error = %v", err)
+	}
+
+	if _, err := os.Stat(postFile); !os.IsNotExist(err) {
+		t.Error("expected post file to be pruned after post no longer present")
+	}
+}
+
+func TestExportMarkdownPlainRewritesWhenManifestFileMissing(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown"}
+	e := NewExporter(cfg)
+
+	posts := []models.WordPressPost{{
+		ID:      1,
+		Slug:    "test-post",
+		Title:   models.RenderedContent{Rendered: "Test Post"},
+		Content: models.RenderedContent{Rendered: "<p>Content</p>"},
+		Date:    models.WordPressTime{Time: time.Now()},
+		Status:  "publish",
+		Link:    "https://example.com/test-post",
+	}}
+
+	if err := e.Export(testExportData(posts, nil)); err != nil {
+		t.Fatalf("first Export() error = %v", err)
+	}
+
+	postFile := filepath.Join(tmpDir, "posts", "uncategorized", "test-post.md")
+	if err := os.Remove(postFile); err != nil {
+		t.Fatalf("failed to remove post file to simulate it going missing: %v", err)
+	}
+
+	data2 := testExportData(posts, nil)
+	if err := e.Export(data2); err != nil {
+		t.Fatalf("second Export() error = %v", err)
+	}
+	if data2.Stats.Skipped != 0 {
+		t.Errorf("Skipped = %d when the previously-written file was missing, want 0", data2.Stats.Skipped)
+	}
+	if _, err := os.Stat(postFile); err != nil {
+		t.Errorf("expected missing post file to be rewritten, stat error: %v", err)
+	}
+}
+
+func TestExportMarkdownPlainRenameDetectionRemovesOldCategoryFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown"}
+	e := NewExporter(cfg)
+
+	categories := []models.WordPressCategory{
+		{ID: 1, Name: "Technology", Slug: "technology"},
+		{ID: 2, Name: "Travel", Slug: "travel"},
+	}
+
+	makePost := func(catID int) models.WordPressPost {
+		return models.WordPressPost{
+			ID:         1,
+			Slug:       "test-post",
+			Title:      models.RenderedContent{Rendered: "Test Post"},
+			Content:    models.RenderedContent{Rendered: "<p>Content</p>"},
+			Date:       models.WordPressTime{Time: time.Now()},
+			Status:     "publish",
+			Link:       "https://example.com/test-post",
+			Categories: []int{catID},
+		}
+	}
+
+	if err := e.Export(testExportData([]models.WordPressPost{makePost(1)}, categories)); err != nil {
+		t.Fatalf("first Export() error = %v", err)
+	}
+
+	oldFile := filepath.Join(tmpDir, "posts", "technology", "test-post.md")
+	if _, err := os.Stat(oldFile); err != nil {
+		t.Fatalf("expected post file under technology category: %v", err)
+	}
+
+	if err := e.Export(testExportData([]models.WordPressPost{makePost(2)}, categories)); err != nil {
+		t.Fatalf("second Export() error = %v", err)
+	}
+
+	if _, err := os.Stat(oldFile); !os.IsNotExist(err) {
+		t.Error("expected stale file under old category to be removed after category change")
+	}
+
+	newFile := filepath.Join(tmpDir, "posts", "travel", "test-post.md")
+	if _, err := os.Stat(newFile); err != nil {
+		t.Fatalf("expected post file under new travel category: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// exportMarkdownBundle exports posts and pages as self-contained "page bundles": each gets
+// its own baseDir/<slug>/ directory holding index.md and an images/ subdirectory colocating
+// only the media that post/page actually references (see Downloader.DownloadMediaForPost),
+// rather than this tool's other Markdown flavors, which all write every downloaded file
+// into one shared media directory regardless of which post references it. This mirrors the
+// per-post colocated layout used by tools like mastodon-markdown-archive, and suits
+// publishing a post as a standalone unit - e.g. committing one directory per post to its
+// own repository, or copying a single bundle elsewhere without the rest of the export.
+func (e *Exporter) exportMarkdownBundle(data *models.ExportData) error {
+	postsDir := filepath.Join(e.config.Output, "posts")
+	pagesDir := filepath.Join(e.config.Output, "pages")
+
+	for _, dir := range []string{postsDir, pagesDir} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", dir, err)
+		}
+	}
+
+	categoryMap := make(map[int]models.WordPressCategory, len(data.Categories))
+	for _, cat := range data.Categories {
+		categoryMap[cat.ID] = cat
+	}
+	tagMap := make(map[int]models.WordPressTag, len(data.Tags))
+	for _, tag := range data.Tags {
+		tagMap[tag.ID] = tag
+	}
+	mediaMap := make(map[int]models.WordPressMedia, len(data.Media))
+	for _, m := range data.Media {
+		mediaMap[m.ID] = m
+	}
+	userMap := make(map[int]models.WordPressUser, len(data.Users))
+	for _, u := range data.Users {
+		userMap[u.ID] = u
+	}
+
+	baseURL := e.siteBaseURL(data.Site)
+
+	for _, post := range data.Posts {
+		if err := e.writeBundleContentFile(postsDir, post, categoryMap, tagMap, data.Media, mediaMap, userMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write bundle post %q: %w", post.Slug, err)
+		}
+	}
+	for _, page := range data.Pages {
+		if err := e.writeBundleContentFile(pagesDir, page, categoryMap, tagMap, data.Media, mediaMap, userMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write bundle page %q: %w", page.Slug, err)
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", e.config.Output)
+	return nil
+}
+
+// writeBundleContentFile writes a single post/page as baseDir/<slug>/index.md, downloading
+// the media it references into baseDir/<slug>/images/ (see Downloader.DownloadMediaForPost)
+// and rewriting its content to reference those files by their bundle-relative path
+// instead of the original WordPress URL.
+func (e *Exporter) writeBundleContentFile(
+	baseDir string,
+	post models.WordPressPost,
+	categoryMap map[int]models.WordPressCategory,
+	tagMap map[int]models.WordPressTag,
+	allMedia []models.WordPressMedia,
+	mediaMap map[int]models.WordPressMedia,
+	userMap map[int]models.WordPressUser,
+	baseURL string,
+) error {
+	slug := e.sanitizeDirectoryName(post.Slug)
+	if slug == "" {
+		slug = fmt.Sprintf("post-%d", post.ID)
+	}
+
+	contentDir := filepath.Join(baseDir, slug)
+	if err := os.MkdirAll(contentDir, 0750); err != nil {
+		return fmt.Errorf("failed to create content directory %s: %w", contentDir, err)
+	}
+
+	items := postMediaItems(post, allMedia, mediaMap)
+	mediaPaths, err := e.downloader.DownloadMediaForPost(contentDir, items)
+	if err != nil {
+		return fmt.Errorf("failed to download media for %s: %w", contentDir, err)
+	}
+
+	content := post.Content.Rendered
+	for _, m := range items {
+		relPath, ok := mediaPaths[m.ID]
+		if !ok {
+			continue
+		}
+		content = strings.ReplaceAll(content, m.SourceURL, relPath)
+	}
+
+	var categories []string
+	for _, id := range post.Categories {
+		if cat, ok := categoryMap[id]; ok {
+			categories = append(categories, cat.Slug)
+		}
+	}
+	var tags []string
+	for _, id := range post.Tags {
+		if tag, ok := tagMap[id]; ok {
+			tags = append(tags, tag.Slug)
+		}
+	}
+
+	var featuredImage string
+	if post.FeaturedMedia > 0 {
+		featuredImage = mediaPaths[post.FeaturedMedia]
+	}
+
+	author := fmt.Sprintf("%d", post.Author)
+	if u, ok := userMap[post.Author]; ok && u.Slug != "" {
+		author = u.Slug
+	}
+
+	fm := frontMatter{
+		Title:         post.Title.Rendered,
+		Date:          post.Date.Format("2006-01-02T15:04:05Z07:00"),
+		Draft:         post.Status != "publish",
+		Slug:          slug,
+		Aliases:       aliasesFromLink(post.Link),
+		Categories:    categories,
+		Tags:          tags,
+		Author:        author,
+		FeaturedImage: featuredImage,
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatter(fm, e.config.FrontMatterFormat))
+	b.WriteString("\n")
+	b.WriteString(e.rewriteSiteLinks(e.convertHTMLToMarkdown(convertImgTagsToHugoMarkdown(content)), baseURL))
+	b.WriteString("\n")
+
+	return os.WriteFile(filepath.Join(contentDir, "index.md"), []byte(b.String()), 0600)
+}
+
+// postMediaItems returns the media items a post/page's content or excerpt actually
+// references - by its SourceURL appearing anywhere in the rendered HTML, the same
+// substring match Downloader.UpdateMediaPaths uses - plus its featured image, if any. This
+// is the set exportMarkdownBundle downloads into a single post's own bundle directory,
+// rather than every item in allMedia regardless of which post (if any) uses it.
+func postMediaItems(post models.WordPressPost, allMedia []models.WordPressMedia, mediaMap map[int]models.WordPressMedia) []models.WordPressMedia {
+	seen := make(map[int]bool)
+	var items []models.WordPressMedia
+
+	add := func(m models.WordPressMedia) {
+		if m.SourceURL == "" || seen[m.ID] {
+			return
+		}
+		seen[m.ID] = true
+		items = append(items, m)
+	}
+
+	if post.FeaturedMedia > 0 {
+		if m, ok := mediaMap[post.FeaturedMedia]; ok {
+			add(m)
+		}
+	}
+	for _, m := range allMedia {
+		if m.SourceURL == "" {
+			continue
+		}
+		if strings.Contains(post.Content.Rendered, m.SourceURL) || strings.Contains(post.Excerpt.Rendered, m.SourceURL) {
+			add(m)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestExportMarkdownBundleWritesPerPostImagesDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("fake image data"))
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	data := hugoTestData()
+	data.Posts[0].Content.Rendered = `<p>Hello</p><img src="` + server.URL + `/photo.jpg">`
+	data.Media = []models.WordPressMedia{
+		{ID: 100, SourceURL: server.URL + "/photo.jpg", MimeType: "image/jpeg"},
+	}
+
+	cfg := &config.Config{Output: tmpDir, Format: "markdown", MarkdownFlavor: "bundle", DownloadMedia: true}
+	e := NewExporter(cfg)
+
+	if err := e.Export(data); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	contentDir := filepath.Join(tmpDir, "posts", "hello-world")
+	post, err := os.ReadFile(filepath.Join(contentDir, "index.md"))
+	if err != nil {
+		t.Fatalf("failed to read bundle post: %v", err)
+	}
+	if !strings.Contains(string(post), `title: "Hello World"`) {
+		t.Errorf("bundle post missing title front matter, got:\n%s", post)
+	}
+	if strings.Contains(string(post), server.URL) {
+		t.Errorf("bundle post should rewrite media URLs to bundle-relative paths, got:\n%s", post)
+	}
+	if !strings.Contains(string(post), "images/") {
+		t.Errorf("bundle post should reference downloaded media under images/, got:\n%s", post)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(contentDir, "images"))
+	if err != nil {
+		t.Fatalf("failed to read bundle images dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("bundle images dir has %d entries, want 1", len(entries))
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "pages", "about", "index.md")); err != nil {
+		t.Errorf("expected bundle page to exist: %v", err)
+	}
+}
+
+func TestPostMediaItemsMatchesContentExcerptAndFeaturedImage(t *testing.T) {
+	featured := models.WordPressMedia{ID: 1, SourceURL: "https://example.com/featured.jpg"}
+	inContent := models.WordPressMedia{ID: 2, SourceURL: "https://example.com/content.jpg"}
+	inExcerpt := models.WordPressMedia{ID: 3, SourceURL: "https://example.com/excerpt.jpg"}
+	unused := models.WordPressMedia{ID: 4, SourceURL: "https://example.com/unused.jpg"}
+
+	post := models.WordPressPost{
+		FeaturedMedia: 1,
+		Content:       models.RenderedContent{Rendered: `<img src="https://example.com/content.jpg">`},
+		Excerpt:       models.RenderedContent{Rendered: `<img src="https://example.com/excerpt.jpg">`},
+	}
+	allMedia := []models.WordPressMedia{featured, inContent, inExcerpt, unused}
+	mediaMap := map[int]models.WordPressMedia{1: featured, 2: inContent, 3: inExcerpt, 4: unused}
+
+	items := postMediaItems(post, allMedia, mediaMap)
+
+	got := make(map[int]bool, len(items))
+	for _, m := range items {
+		got[m.ID] = true
+	}
+	for _, id := range []int{1, 2, 3} {
+		if !got[id] {
+			t.Errorf("postMediaItems() missing media ID %d", id)
+		}
+	}
+	if got[4] {
+		t.Error("postMediaItems() should not include media unreferenced by the post")
+	}
+}
@@ -0,0 +1,242 @@
+package export
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// exportMarkdownHugo exports posts and pages as a Hugo-compatible content tree:
+// content/posts/<slug>/index.md and content/pages/<slug>/index.md, each with a front
+// matter block (rendered per Config.FrontMatterFormat) carrying title, date, draft,
+// slug, aliases (the post's original WordPress permalink path, so Hugo can redirect old
+// URLs), categories, tags, author (resolved to the WordPress user's slug when known), and
+// featured_image. Post/page bodies go through convertHugoContent, which rewrites
+// WordPress-specific shortcodes and fixes up entities WordPress mangled inside code blocks
+// before falling back to the shared HTML-to-Markdown conversion. Categories and tags
+// additionally get content/categories/<slug>/_index.md and content/tags/<slug>/_index.md
+// stubs so Hugo's taxonomy list pages render, and the site itself gets a config.toml
+// populated from SiteInfo.
+func (e *Exporter) exportMarkdownHugo(data *models.ExportData) error {
+	postsDir := filepath.Join(e.config.Output, "content", "posts")
+	pagesDir := filepath.Join(e.config.Output, "content", "pages")
+	categoriesDir := filepath.Join(e.config.Output, "content", "categories")
+	tagsDir := filepath.Join(e.config.Output, "content", "tags")
+
+	for _, dir := range []string{postsDir, pagesDir, categoriesDir, tagsDir} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", dir, err)
+		}
+	}
+
+	categoryMap := make(map[int]models.WordPressCategory, len(data.Categories))
+	for _, cat := range data.Categories {
+		categoryMap[cat.ID] = cat
+	}
+	tagMap := make(map[int]models.WordPressTag, len(data.Tags))
+	for _, tag := range data.Tags {
+		tagMap[tag.ID] = tag
+	}
+	mediaMap := make(map[int]models.WordPressMedia, len(data.Media))
+	for _, m := range data.Media {
+		mediaMap[m.ID] = m
+	}
+	userMap := make(map[int]models.WordPressUser, len(data.Users))
+	for _, u := range data.Users {
+		userMap[u.ID] = u
+	}
+
+	baseURL := e.siteBaseURL(data.Site)
+
+	for _, post := range data.Posts {
+		if err := e.writeHugoContentFile(postsDir, post, categoryMap, tagMap, mediaMap, userMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write hugo post %q: %w", post.Slug, err)
+		}
+	}
+	for _, page := range data.Pages {
+		if err := e.writeHugoContentFile(pagesDir, page, categoryMap, tagMap, mediaMap, userMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write hugo page %q: %w", page.Slug, err)
+		}
+	}
+
+	if err := e.writeHugoSiteConfig(data.Site); err != nil {
+		return fmt.Errorf("failed to write hugo config.toml: %w", err)
+	}
+
+	for _, cat := range data.Categories {
+		if err := e.writeHugoTaxonomyStub(categoriesDir, cat.Slug, cat.Name, cat.Description); err != nil {
+			return fmt.Errorf("failed to write hugo category stub %q: %w", cat.Slug, err)
+		}
+	}
+	for _, tag := range data.Tags {
+		if err := e.writeHugoTaxonomyStub(tagsDir, tag.Slug, tag.Name, tag.Description); err != nil {
+			return fmt.Errorf("failed to write hugo tag stub %q: %w", tag.Slug, err)
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", e.config.Output)
+	return nil
+}
+
+// writeHugoContentFile writes a single post/page as baseDir/<slug>/index.md.
+func (e *Exporter) writeHugoContentFile(
+	baseDir string,
+	post models.WordPressPost,
+	categoryMap map[int]models.WordPressCategory,
+	tagMap map[int]models.WordPressTag,
+	mediaMap map[int]models.WordPressMedia,
+	userMap map[int]models.WordPressUser,
+	baseURL string,
+) error {
+	slug := e.sanitizeDirectoryName(post.Slug)
+	if slug == "" {
+		slug = fmt.Sprintf("post-%d", post.ID)
+	}
+
+	contentDir := filepath.Join(baseDir, slug)
+	if err := os.MkdirAll(contentDir, 0750); err != nil {
+		return fmt.Errorf("failed to create content directory %s: %w", contentDir, err)
+	}
+
+	var categories []string
+	for _, id := range post.Categories {
+		if cat, ok := categoryMap[id]; ok {
+			categories = append(categories, cat.Slug)
+		}
+	}
+	var tags []string
+	for _, id := range post.Tags {
+		if tag, ok := tagMap[id]; ok {
+			tags = append(tags, tag.Slug)
+		}
+	}
+
+	var featuredImage string
+	if post.FeaturedMedia > 0 {
+		if m, ok := mediaMap[post.FeaturedMedia]; ok {
+			featuredImage = e.mediaPath(m)
+		}
+	}
+
+	author := fmt.Sprintf("%d", post.Author)
+	if u, ok := userMap[post.Author]; ok && u.Slug != "" {
+		author = u.Slug
+	}
+
+	fm := frontMatter{
+		Title:         post.Title.Rendered,
+		Date:          post.Date.Format("2006-01-02T15:04:05Z07:00"),
+		Draft:         post.Status != "publish",
+		Slug:          slug,
+		Aliases:       aliasesFromLink(post.Link),
+		Categories:    categories,
+		Tags:          tags,
+		Author:        author,
+		FeaturedImage: featuredImage,
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatter(fm, e.config.FrontMatterFormat))
+	b.WriteString("\n")
+	b.WriteString(e.convertHugoContent(post.Content.Rendered, baseURL))
+	b.WriteString("\n")
+
+	return os.WriteFile(filepath.Join(contentDir, "index.md"), []byte(b.String()), 0600)
+}
+
+// convertHugoContent runs a WordPress post/page body through the full Hugo conversion
+// pipeline: unescape entities WordPress mangled inside code blocks, rewrite known
+// shortcodes to their Hugo equivalents, convert remaining <img> tags to Markdown image
+// syntax, convert the rest of the HTML to Markdown, then rewrite any links still pointing
+// at the original site to root-relative paths.
+func (e *Exporter) convertHugoContent(content, baseURL string) string {
+	content = unescapeCodeEntities(content)
+	content = convertShortcodesToHugo(content)
+	content = convertImgTagsToHugoMarkdown(content)
+	content = e.convertHTMLToMarkdown(content)
+	return e.rewriteSiteLinks(content, baseURL)
+}
+
+// writeHugoSiteConfig writes Output/config.toml, a minimal Hugo site config populated from
+// the WordPress site's own settings - enough for `hugo server` to build something
+// recognizable without the user hand-writing it first.
+func (e *Exporter) writeHugoSiteConfig(site models.SiteInfo) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "baseURL = %q\n", e.siteBaseURL(site))
+	fmt.Fprintf(&b, "title = %q\n", site.Name)
+	if site.Language != "" {
+		fmt.Fprintf(&b, "languageCode = %q\n", site.Language)
+	}
+	if site.Timezone != "" {
+		fmt.Fprintf(&b, "timeZone = %q\n", site.Timezone)
+	}
+	b.WriteString("\n[params]\n")
+	fmt.Fprintf(&b, "  description = %q\n", site.Description)
+
+	return os.WriteFile(filepath.Join(e.config.Output, "config.toml"), []byte(b.String()), 0600)
+}
+
+// writeHugoTaxonomyStub writes dir/<slug>/_index.md, a section listing page for Hugo's
+// taxonomy terms.
+func (e *Exporter) writeHugoTaxonomyStub(dir, slug, name, description string) error {
+	slug = e.sanitizeDirectoryName(slug)
+	termDir := filepath.Join(dir, slug)
+	if err := os.MkdirAll(termDir, 0750); err != nil {
+		return fmt.Errorf("failed to create taxonomy directory %s: %w", termDir, err)
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatter(frontMatter{Title: name}, e.config.FrontMatterFormat))
+	if description != "" {
+		b.WriteString("\n")
+		b.WriteString(description)
+		b.WriteString("\n")
+	}
+
+	return os.WriteFile(filepath.Join(termDir, "_index.md"), []byte(b.String()), 0600)
+}
+
+// mediaPath returns media's downloaded local path (relative to Output, e.g.
+// "media/123_photo.jpg") when Config.DownloadMedia is set, falling back to its original
+// SourceURL otherwise.
+func (e *Exporter) mediaPath(media models.WordPressMedia) string {
+	if media.SourceURL == "" {
+		return ""
+	}
+	if !e.config.DownloadMedia {
+		return media.SourceURL
+	}
+	return e.downloader.UpdateMediaPaths(media.SourceURL, []models.WordPressMedia{media})
+}
+
+// aliasesFromLink returns post's original WordPress permalink path (e.g.
+// "/2020/01/old-slug/") as a Hugo alias, so a visitor following a pre-migration bookmark
+// or search result still lands on the migrated page. Returns nil when link can't be
+// parsed or carries no path worth aliasing.
+func aliasesFromLink(link string) []string {
+	if link == "" {
+		return nil
+	}
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Path == "" || parsed.Path == "/" {
+		return nil
+	}
+	return []string{parsed.Path}
+}
+
+// rewriteSiteLinks rewrites absolute links/images pointing back at the original site
+// (content referencing other posts/pages by their old WordPress URL) to root-relative
+// paths, following wp2hugo's convention of assuming the migrated site keeps each page's
+// URL path as a Hugo alias. Links to other hosts are left untouched.
+func (e *Exporter) rewriteSiteLinks(content, baseURL string) string {
+	if baseURL == "" {
+		return content
+	}
+	trimmed := strings.TrimSuffix(baseURL, "/")
+	content = strings.ReplaceAll(content, trimmed+"/", "/")
+	return strings.ReplaceAll(content, trimmed, "/")
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func hugoTestData() *models.ExportData {
+	return &models.ExportData{
+		Site: models.SiteInfo{Name: "Test Site", URL: "https://example.com"},
+		Posts: []models.WordPressPost{{
+			ID:         1,
+			Slug:       "hello-world",
+			Title:      models.RenderedContent{Rendered: "Hello World"},
+			Content:    models.RenderedContent{Rendered: "<p>Hello</p>"},
+			Date:       models.WordPressTime{Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+			Status:     "publish",
+			Link:       "https://example.com/2020/01/hello-world",
+			Categories: []int{1},
+			Tags:       []int{2},
+		}},
+		Pages: []models.WordPressPost{{
+			ID:     10,
+			Slug:   "about",
+			Title:  models.RenderedContent{Rendered: "About"},
+			Status: "publish",
+			Link:   "https://example.com/about",
+		}},
+		Categories: []models.WordPressCategory{{ID: 1, Name: "News", Slug: "news"}},
+		Tags:       []models.WordPressTag{{ID: 2, Name: "Go", Slug: "go"}},
+	}
+}
+
+func TestExportMarkdownHugoWritesContentTreeAndTaxonomyStubs(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown", MarkdownFlavor: "hugo", DownloadMedia: false}
+	e := NewExporter(cfg)
+
+	if err := e.Export(hugoTestData()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	post, err := os.ReadFile(filepath.Join(tmpDir, "content", "posts", "hello-world", "index.md"))
+	if err != nil {
+		t.Fatalf("failed to read hugo post: %v", err)
+	}
+	if !strings.Contains(string(post), `title: "Hello World"`) {
+		t.Errorf("hugo post missing title front matter, got:\n%s", post)
+	}
+	if !strings.Contains(string(post), "aliases:") || !strings.Contains(string(post), "/2020/01/hello-world") {
+		t.Errorf("hugo post missing alias from original permalink, got:\n%s", post)
+	}
+	if !strings.Contains(string(post), "news") || !strings.Contains(string(post), "go") {
+		t.Errorf("hugo post missing category/tag slugs, got:\n%s", post)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "content", "pages", "about", "index.md")); err != nil {
+		t.Errorf("expected hugo page to exist: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "content", "categories", "news", "_index.md")); err != nil {
+		t.Errorf("expected category taxonomy stub to exist: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "content", "tags", "go", "_index.md")); err != nil {
+		t.Errorf("expected tag taxonomy stub to exist: %v", err)
+	}
+}
+
+func TestExportMarkdownHugoTOMLFrontMatter(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		Output: tmpDir, Format: "markdown", MarkdownFlavor: "hugo",
+		FrontMatterFormat: "toml", DownloadMedia: false,
+	}
+	e := NewExporter(cfg)
+
+	if err := e.Export(hugoTestData()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	post, err := os.ReadFile(filepath.Join(tmpDir, "content", "posts", "hello-world", "index.md"))
+	if err != nil {
+		t.Fatalf("failed to read hugo post: %v", err)
+	}
+	if !strings.HasPrefix(string(post), "+++\n") {
+		t.Errorf("hugo post should start with TOML +++ delimiter, got:\n%s", post)
+	}
+	if !strings.Contains(string(post), `title = "Hello World"`) {
+		t.Errorf("hugo post missing TOML title, got:\n%s", post)
+	}
+}
+
+func TestRewriteSiteLinksMakesSiteURLsRootRelative(t *testing.T) {
+	cfg := &config.Config{}
+	e := NewExporter(cfg)
+
+	content := `<a href="https://example.com/other-post">link</a> and <a href="https://other.com/x">external</a>`
+	got := e.rewriteSiteLinks(content, "https://example.com")
+
+	if !strings.Contains(got, `href="/other-post"`) {
+		t.Errorf("rewriteSiteLinks() should make same-site links root-relative, got: %s", got)
+	}
+	if !strings.Contains(got, "https://other.com/x") {
+		t.Errorf("rewriteSiteLinks() should leave other hosts untouched, got: %s", got)
+	}
+}
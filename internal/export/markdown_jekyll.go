@@ -0,0 +1,136 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// exportMarkdownJekyll exports posts as Jekyll's _posts/YYYY-MM-DD-slug.md collection
+// (YAML front matter, per Jekyll's own convention regardless of Config.FrontMatterFormat)
+// and pages as plain <slug>.md files at the site root, Jekyll's convention for pages
+// outside any collection.
+func (e *Exporter) exportMarkdownJekyll(data *models.ExportData) error {
+	postsDir := filepath.Join(e.config.Output, "_posts")
+	if err := os.MkdirAll(postsDir, 0750); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", postsDir, err)
+	}
+	if err := os.MkdirAll(e.config.Output, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	categoryMap := make(map[int]models.WordPressCategory, len(data.Categories))
+	for _, cat := range data.Categories {
+		categoryMap[cat.ID] = cat
+	}
+	tagMap := make(map[int]models.WordPressTag, len(data.Tags))
+	for _, tag := range data.Tags {
+		tagMap[tag.ID] = tag
+	}
+	mediaMap := make(map[int]models.WordPressMedia, len(data.Media))
+	for _, m := range data.Media {
+		mediaMap[m.ID] = m
+	}
+
+	baseURL := e.siteBaseURL(data.Site)
+
+	for _, post := range data.Posts {
+		if err := e.writeJekyllPost(postsDir, post, categoryMap, tagMap, mediaMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write jekyll post %q: %w", post.Slug, err)
+		}
+	}
+	for _, page := range data.Pages {
+		if err := e.writeJekyllPage(e.config.Output, page, mediaMap, baseURL); err != nil {
+			return fmt.Errorf("failed to write jekyll page %q: %w", page.Slug, err)
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", e.config.Output)
+	return nil
+}
+
+// writeJekyllPost writes postsDir/YYYY-MM-DD-slug.md.
+func (e *Exporter) writeJekyllPost(
+	postsDir string,
+	post models.WordPressPost,
+	categoryMap map[int]models.WordPressCategory,
+	tagMap map[int]models.WordPressTag,
+	mediaMap map[int]models.WordPressMedia,
+	baseURL string,
+) error {
+	slug := e.sanitizeDirectoryName(post.Slug)
+	if slug == "" {
+		slug = fmt.Sprintf("post-%d", post.ID)
+	}
+
+	var categories []string
+	for _, id := range post.Categories {
+		if cat, ok := categoryMap[id]; ok {
+			categories = append(categories, cat.Slug)
+		}
+	}
+	var tags []string
+	for _, id := range post.Tags {
+		if tag, ok := tagMap[id]; ok {
+			tags = append(tags, tag.Slug)
+		}
+	}
+
+	var featuredImage string
+	if post.FeaturedMedia > 0 {
+		if m, ok := mediaMap[post.FeaturedMedia]; ok {
+			featuredImage = e.mediaPath(m)
+		}
+	}
+
+	fm := frontMatter{
+		Title:         post.Title.Rendered,
+		Date:          post.Date.Format("2006-01-02 15:04:05 -0700"),
+		Draft:         post.Status != "publish",
+		Categories:    categories,
+		Tags:          tags,
+		Author:        fmt.Sprintf("%d", post.Author),
+		FeaturedImage: featuredImage,
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatterYAML(fm))
+	b.WriteString("\n")
+	b.WriteString(e.rewriteSiteLinks(e.convertHTMLToMarkdown(post.Content.Rendered), baseURL))
+	b.WriteString("\n")
+
+	filename := fmt.Sprintf("%s-%s.md", post.Date.Format("2006-01-02"), slug)
+	return os.WriteFile(filepath.Join(postsDir, filename), []byte(b.String()), 0600)
+}
+
+// writeJekyllPage writes dir/<slug>.md, a plain Jekyll page outside any collection.
+func (e *Exporter) writeJekyllPage(dir string, page models.WordPressPost, mediaMap map[int]models.WordPressMedia, baseURL string) error {
+	slug := e.sanitizeDirectoryName(page.Slug)
+	if slug == "" {
+		slug = fmt.Sprintf("page-%d", page.ID)
+	}
+
+	var featuredImage string
+	if page.FeaturedMedia > 0 {
+		if m, ok := mediaMap[page.FeaturedMedia]; ok {
+			featuredImage = e.mediaPath(m)
+		}
+	}
+
+	fm := frontMatter{
+		Title:         page.Title.Rendered,
+		Draft:         page.Status != "publish",
+		FeaturedImage: featuredImage,
+	}
+
+	var b strings.Builder
+	b.WriteString(renderFrontMatterYAML(fm))
+	b.WriteString("\n")
+	b.WriteString(e.rewriteSiteLinks(e.convertHTMLToMarkdown(page.Content.Rendered), baseURL))
+	b.WriteString("\n")
+
+	return os.WriteFile(filepath.Join(dir, slug+".md"), []byte(b.String()), 0600)
+}
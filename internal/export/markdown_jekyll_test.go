@@ -0,0 +1,35 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestExportMarkdownJekyllWritesPostsAndPages(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown", MarkdownFlavor: "jekyll", DownloadMedia: false}
+	e := NewExporter(cfg)
+
+	if err := e.Export(hugoTestData()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	post, err := os.ReadFile(filepath.Join(tmpDir, "_posts", "2020-01-02-hello-world.md"))
+	if err != nil {
+		t.Fatalf("failed to read jekyll post: %v", err)
+	}
+	if !strings.HasPrefix(string(post), "---\n") {
+		t.Errorf("jekyll post should always use YAML front matter, got:\n%s", post)
+	}
+	if !strings.Contains(string(post), `title: "Hello World"`) {
+		t.Errorf("jekyll post missing title front matter, got:\n%s", post)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "about.md")); err != nil {
+		t.Errorf("expected jekyll page about.md to exist: %v", err)
+	}
+}
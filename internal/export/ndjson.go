@@ -0,0 +1,63 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// exportJSONStream writes data as NDJSON (one JSON object per line) across separate
+// posts.ndjson, pages.ndjson, media.ndjson, categories.ndjson, tags.ndjson, and
+// users.ndjson files, instead of exportJSON's single buffered export.json. Each file is
+// written with a streaming json.Encoder rather than json.MarshalIndent-ing the whole
+// slice at once, so write-side memory use stays flat regardless of site size. Format
+// "json-stream" is otherwise exported the same way "json" is: Export still gathers the
+// full ExportData before calling this, so the fetch side doesn't yet stream incrementally
+// the way a channel-fed runExport would.
+func (e *Exporter) exportJSONStream(data *models.ExportData) error {
+	outputDir := e.outputRoot()
+
+	files := []struct {
+		name string
+		fn   func(string) error
+	}{
+		{"posts.ndjson", func(p string) error { return writeNDJSON(p, data.Posts) }},
+		{"pages.ndjson", func(p string) error { return writeNDJSON(p, data.Pages) }},
+		{"media.ndjson", func(p string) error { return writeNDJSON(p, data.Media) }},
+		{"categories.ndjson", func(p string) error { return writeNDJSON(p, data.Categories) }},
+		{"tags.ndjson", func(p string) error { return writeNDJSON(p, data.Tags) }},
+		{"users.ndjson", func(p string) error { return writeNDJSON(p, data.Users) }},
+	}
+
+	for _, f := range files {
+		if err := f.fn(filepath.Join(outputDir, f.name)); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Export completed: %s\n", outputDir)
+	return nil
+}
+
+// writeNDJSON writes one JSON-encoded element of items per line to path, via a streaming
+// json.Encoder rather than marshaling the whole slice into memory at once.
+func writeNDJSON[T any](path string, items []T) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	enc := json.NewEncoder(f)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestExportJSONStream(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{
+		Output:        tmpDir,
+		Format:        "json-stream",
+		DownloadMedia: false,
+	}
+	e := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site: models.SiteInfo{Name: "Test Site", URL: "https://example.com"},
+		Posts: []models.WordPressPost{
+			{ID: 1, Slug: "post-one"},
+			{ID: 2, Slug: "post-two"},
+		},
+		Pages: []models.WordPressPost{
+			{ID: 10, Slug: "about"},
+		},
+		Media: []models.WordPressMedia{
+			{ID: 100, SourceURL: "https://example.com/image.jpg"},
+		},
+	}
+
+	if err := e.Export(data); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	postIDs := readNDJSONIDs(t, filepath.Join(tmpDir, "posts.ndjson"))
+	if len(postIDs) != 2 || postIDs[0] != 1 || postIDs[1] != 2 {
+		t.Errorf("posts.ndjson IDs = %v, want [1 2]", postIDs)
+	}
+
+	pageIDs := readNDJSONIDs(t, filepath.Join(tmpDir, "pages.ndjson"))
+	if len(pageIDs) != 1 || pageIDs[0] != 10 {
+		t.Errorf("pages.ndjson IDs = %v, want [10]", pageIDs)
+	}
+
+	mediaIDs := readNDJSONIDs(t, filepath.Join(tmpDir, "media.ndjson"))
+	if len(mediaIDs) != 1 || mediaIDs[0] != 100 {
+		t.Errorf("media.ndjson IDs = %v, want [100]", mediaIDs)
+	}
+
+	for _, name := range []string{"categories.ndjson", "tags.ndjson", "users.ndjson"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("expected %s to be written even when empty: %v", name, err)
+		}
+	}
+}
+
+// readNDJSONIDs reads path as NDJSON, one {"id": N, ...} object per line, and returns the
+// IDs in file order.
+func readNDJSONIDs(t *testing.T, path string) []int {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var ids []int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var row struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(line, &row); err != nil {
+			t.Fatalf("failed to parse line %q in %s: %v", line, path, err)
+		}
+		ids = append(ids, row.ID)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to scan %s: %v", path, err)
+	}
+	return ids
+}
@@ -0,0 +1,30 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/tradik/wpexporter/internal/plugin"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// exportViaPlugin handles a Format that isn't one of the built-ins by dispatching to a
+// matching external plugin discovered via plugin.FindByFormat: the export payload is sent
+// to the plugin's executable over stdin as JSON, and the tar stream it writes to stdout is
+// extracted into Output. Returns an error if no plugin advertises this Format, mirroring
+// Config.Validate's own plugin lookup for unknown formats.
+func (e *Exporter) exportViaPlugin(data *models.ExportData) error {
+	p, ok, err := plugin.FindByFormat(plugin.DefaultPluginDirs(), e.config.Format)
+	if err != nil {
+		return fmt.Errorf("failed to search for a plugin handling format %q: %w", e.config.Format, err)
+	}
+	if !ok {
+		return fmt.Errorf("unsupported export format: %s", e.config.Format)
+	}
+
+	if err := p.Run(data, e.config.Output); err != nil {
+		return fmt.Errorf("plugin export failed: %w", err)
+	}
+
+	fmt.Printf("Export completed: %s\n", e.config.Output)
+	return nil
+}
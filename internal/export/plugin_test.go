@@ -0,0 +1,20 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestExportViaPluginErrorsWhenNoPluginMatchesFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir, Format: "zola"}
+	exporter := NewExporter(cfg)
+
+	err := exporter.exportViaPlugin(&models.ExportData{})
+	if err == nil || !strings.Contains(err.Error(), "unsupported export format") {
+		t.Errorf("exportViaPlugin() error = %v, want an unsupported format error", err)
+	}
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// sourcecodeShortcodePattern matches the SyntaxHighlighter Evolved plugin's
+// [sourcecode language="xxx"]...[/sourcecode] shortcode.
+var sourcecodeShortcodePattern = regexp.MustCompile(`(?s)\[sourcecode(?:\s+(?:lang|language)="?([a-zA-Z0-9_+-]*)"?)?[^\]]*\](.*?)\[/sourcecode\]`)
+
+// convertSourceCodeShortcode rewrites [sourcecode language="python"]...[/sourcecode] into a
+// fenced code block with the same language tag, mirroring convertCodeShortcode.
+func convertSourceCodeShortcode(match string) string {
+	m := sourcecodeShortcodePattern.FindStringSubmatch(match)
+	language, body := m[1], html.UnescapeString(m[2])
+	return fmt.Sprintf("```%s\n%s\n```", language, strings.Trim(body, "\n"))
+}
+
+// convertCodeShortcodesToFences rewrites WordPress's two common code shortcodes -
+// [code lang="xxx"]...[/code] and [sourcecode language="xxx"]...[/sourcecode] - into fenced
+// Markdown code blocks carrying the same language tag. Unlike convertShortcodesToHugo (which
+// also translates [caption]/[gallery]/[googlemaps] into Hugo-specific shortcodes),
+// this is format-agnostic: a fenced code block reads the same in plain, Jekyll, and bundle
+// Markdown, so convertHTMLToMarkdown runs it as a pre-pass regardless of MarkdownFlavor.
+func convertCodeShortcodesToFences(content string) string {
+	content = codeShortcodePattern.ReplaceAllStringFunc(content, convertCodeShortcode)
+	content = sourcecodeShortcodePattern.ReplaceAllStringFunc(content, convertSourceCodeShortcode)
+	return content
+}
@@ -0,0 +1,27 @@
+package export
+
+import "testing"
+
+func TestConvertCodeShortcodesToFencesCodeWithLangAttr(t *testing.T) {
+	got := convertCodeShortcodesToFences(`[code lang=go]fmt.Println(&quot;hi&quot;)[/code]`)
+	want := "```go\nfmt.Println(\"hi\")\n```"
+	if got != want {
+		t.Errorf("convertCodeShortcodesToFences() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertCodeShortcodesToFencesSourceCode(t *testing.T) {
+	got := convertCodeShortcodesToFences(`[sourcecode language="python"]print("hi")[/sourcecode]`)
+	want := "```python\nprint(\"hi\")\n```"
+	if got != want {
+		t.Errorf("convertCodeShortcodesToFences() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertCodeShortcodesToFencesLeavesOtherShortcodesAlone(t *testing.T) {
+	input := `[gallery ids="1,2,3"]`
+	got := convertCodeShortcodesToFences(input)
+	if got != input {
+		t.Errorf("convertCodeShortcodesToFences() = %q, want unchanged %q", got, input)
+	}
+}
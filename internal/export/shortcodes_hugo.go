@@ -0,0 +1,136 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// codeBlockPattern matches <pre>/<code> blocks so their contents can be entity-unescaped
+// before convertHTMLToMarkdown strips the surrounding tags - WordPress double-escapes
+// characters like &amp;/&gt; inside code samples, which would otherwise survive the
+// conversion as literal "&amp;" in the Markdown output instead of "&".
+var codeBlockPattern = regexp.MustCompile(`(?s)(<(?:pre|code)[^>]*>)(.*?)(</(?:pre|code)>)`)
+
+// unescapeCodeEntities unescapes HTML entities inside <pre>/<code> blocks, leaving the rest
+// of content untouched.
+func unescapeCodeEntities(content string) string {
+	return codeBlockPattern.ReplaceAllStringFunc(content, func(block string) string {
+		m := codeBlockPattern.FindStringSubmatch(block)
+		return m[1] + html.UnescapeString(m[2]) + m[3]
+	})
+}
+
+var (
+	captionShortcodePattern    = regexp.MustCompile(`(?s)\[caption[^\]]*\](.*?)\[/caption\]`)
+	captionImgPattern          = regexp.MustCompile(`<img[^>]*src="([^"]+)"[^>]*>`)
+	captionTextPattern         = regexp.MustCompile(`(?s)<img[^>]*>\s*(.*)$`)
+	galleryShortcodePattern    = regexp.MustCompile(`\[gallery([^\]]*)\]`)
+	galleryIDsPattern          = regexp.MustCompile(`ids="([^"]+)"`)
+	codeShortcodePattern       = regexp.MustCompile(`(?s)\[code(?:\s+(?:lang|language)="?([a-zA-Z0-9_+-]*)"?)?\](.*?)\[/code\]`)
+	latexShortcodePattern      = regexp.MustCompile(`\$latex\s+(.*?)\$`)
+	googleMapsShortcodePattern = regexp.MustCompile(`\[googlemaps([^\]]*)\]`)
+	googleMapsAttrPattern      = regexp.MustCompile(`([a-zA-Z0-9_-]+)="([^"]*)"`)
+)
+
+// convertShortcodesToHugo rewrites WordPress shortcodes commonly found in post content into
+// their Hugo shortcode equivalents, in the spirit of wp2hugo: [caption]/[gallery] become
+// {{< figure >}}/{{< gallery >}}, [code] becomes a fenced code block, $latex ... $ becomes
+// MathJax-friendly inline math, and [googlemaps] becomes {{< googlemaps >}}. Shortcodes this
+// function doesn't recognize are left as-is, to be handled (or ignored) by the target site.
+func convertShortcodesToHugo(content string) string {
+	content = captionShortcodePattern.ReplaceAllStringFunc(content, convertCaptionShortcode)
+	content = galleryShortcodePattern.ReplaceAllStringFunc(content, convertGalleryShortcode)
+	content = convertCodeShortcodesToFences(content)
+	content = latexShortcodePattern.ReplaceAllString(content, `\($1\)`)
+	content = googleMapsShortcodePattern.ReplaceAllStringFunc(content, convertGoogleMapsShortcode)
+	return content
+}
+
+// convertCaptionShortcode rewrites [caption]<img src="...">Some caption text[/caption] into
+// Hugo's {{< figure src="..." caption="..." >}}.
+func convertCaptionShortcode(match string) string {
+	inner := captionShortcodePattern.FindStringSubmatch(match)[1]
+
+	var src string
+	if m := captionImgPattern.FindStringSubmatch(inner); m != nil {
+		src = m[1]
+	}
+
+	caption := ""
+	if m := captionTextPattern.FindStringSubmatch(inner); m != nil {
+		caption = strings.TrimSpace(m[1])
+	}
+
+	if src == "" {
+		return match
+	}
+	if caption == "" {
+		return fmt.Sprintf(`{{< figure src=%q >}}`, src)
+	}
+	return fmt.Sprintf(`{{< figure src=%q caption=%q >}}`, src, caption)
+}
+
+// convertGalleryShortcode rewrites [gallery ids="1,2,3"] into Hugo's {{< gallery >}} with
+// the same comma-separated ids attribute, for a gallery shortcode theme/partial to consume.
+func convertGalleryShortcode(match string) string {
+	attrs := galleryShortcodePattern.FindStringSubmatch(match)[1]
+	if m := galleryIDsPattern.FindStringSubmatch(attrs); m != nil {
+		return fmt.Sprintf(`{{< gallery ids=%q >}}`, m[1])
+	}
+	return `{{< gallery >}}`
+}
+
+// convertCodeShortcode rewrites [code language="go"]...[/code] into a fenced code block with
+// the same language tag, so Hugo's syntax highlighter picks it up like any other Markdown
+// code fence.
+func convertCodeShortcode(match string) string {
+	m := codeShortcodePattern.FindStringSubmatch(match)
+	language, body := m[1], html.UnescapeString(m[2])
+	return fmt.Sprintf("```%s\n%s\n```", language, strings.Trim(body, "\n"))
+}
+
+// convertGoogleMapsShortcode rewrites [googlemaps lat="..." lng="..." ...] into Hugo's
+// {{< googlemaps >}}, passing every attribute through unchanged.
+func convertGoogleMapsShortcode(match string) string {
+	attrs := googleMapsShortcodePattern.FindStringSubmatch(match)[1]
+	pairs := googleMapsAttrPattern.FindAllStringSubmatch(attrs, -1)
+	if len(pairs) == 0 {
+		return `{{< googlemaps >}}`
+	}
+
+	var b strings.Builder
+	b.WriteString("{{< googlemaps")
+	for _, pair := range pairs {
+		fmt.Fprintf(&b, " %s=%q", pair[1], pair[2])
+	}
+	b.WriteString(" >}}")
+	return b.String()
+}
+
+var (
+	imgTagPattern = regexp.MustCompile(`<img[^>]*>`)
+	imgSrcPattern = regexp.MustCompile(`src="([^"]*)"`)
+	imgAltPattern = regexp.MustCompile(`alt="([^"]*)"`)
+)
+
+// convertImgTagsToHugoMarkdown rewrites bare <img> tags (those not already absorbed into a
+// [caption] shortcode's {{< figure >}}) into Markdown image syntax, so the resulting content
+// reads as Markdown rather than Markdown-with-embedded-HTML. src is left untouched here: it
+// is whatever the caller already made it - a downloaded file's local path where the caller
+// rewrote it (Exporter.updateMediaPaths, or a bundle/Hugo-site export's own images/ rewrite),
+// otherwise the original WordPress URL.
+func convertImgTagsToHugoMarkdown(content string) string {
+	return imgTagPattern.ReplaceAllStringFunc(content, func(tag string) string {
+		src := imgSrcPattern.FindStringSubmatch(tag)
+		if src == nil {
+			return tag
+		}
+		alt := ""
+		if m := imgAltPattern.FindStringSubmatch(tag); m != nil {
+			alt = m[1]
+		}
+		return fmt.Sprintf("![%s](%s)", alt, src[1])
+	})
+}
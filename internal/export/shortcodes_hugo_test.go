@@ -0,0 +1,106 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestUnescapeCodeEntitiesOnlyInsideCodeBlocks(t *testing.T) {
+	content := `<p>a &amp; b</p><pre><code>if x &gt; 0 &amp;&amp; y &lt; 1 {}</code></pre>`
+	got := unescapeCodeEntities(content)
+
+	if !strings.Contains(got, "<p>a &amp; b</p>") {
+		t.Errorf("unescapeCodeEntities() should leave prose entities alone, got: %s", got)
+	}
+	if !strings.Contains(got, "if x > 0 && y < 1 {}") {
+		t.Errorf("unescapeCodeEntities() should unescape entities inside <pre><code>, got: %s", got)
+	}
+}
+
+func TestConvertShortcodesToHugoCaption(t *testing.T) {
+	content := `[caption id="attachment_1" align="aligncenter"]<img src="/media/1_photo.jpg" alt="A photo">A nice photo[/caption]`
+	got := convertShortcodesToHugo(content)
+
+	want := `{{< figure src="/media/1_photo.jpg" caption="A nice photo" >}}`
+	if got != want {
+		t.Errorf("convertShortcodesToHugo() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertShortcodesToHugoGallery(t *testing.T) {
+	got := convertShortcodesToHugo(`[gallery ids="1,2,3" columns="2"]`)
+	want := `{{< gallery ids="1,2,3" >}}`
+	if got != want {
+		t.Errorf("convertShortcodesToHugo() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertShortcodesToHugoCode(t *testing.T) {
+	got := convertShortcodesToHugo(`[code language="go"]fmt.Println(&quot;hi&quot;)[/code]`)
+	want := "```go\nfmt.Println(\"hi\")\n```"
+	if got != want {
+		t.Errorf("convertShortcodesToHugo() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertShortcodesToHugoLatex(t *testing.T) {
+	got := convertShortcodesToHugo(`The formula is $latex E = mc^2$ for mass-energy equivalence.`)
+	want := `The formula is \(E = mc^2\) for mass-energy equivalence.`
+	if got != want {
+		t.Errorf("convertShortcodesToHugo() = %q, want %q", got, want)
+	}
+}
+
+func TestConvertShortcodesToHugoGoogleMaps(t *testing.T) {
+	got := convertShortcodesToHugo(`[googlemaps lat="40.7" lng="-74.0"]`)
+	if !strings.HasPrefix(got, "{{< googlemaps") || !strings.Contains(got, `lat="40.7"`) || !strings.Contains(got, `lng="-74.0"`) {
+		t.Errorf("convertShortcodesToHugo() = %q, want a {{< googlemaps >}} shortcode carrying lat/lng", got)
+	}
+}
+
+func TestConvertImgTagsToHugoMarkdown(t *testing.T) {
+	got := convertImgTagsToHugoMarkdown(`<p>Before</p><img src="/media/2_cat.jpg" alt="A cat"><p>After</p>`)
+	if !strings.Contains(got, `![A cat](/media/2_cat.jpg)`) {
+		t.Errorf("convertImgTagsToHugoMarkdown() = %q, want a Markdown image", got)
+	}
+}
+
+func TestExportMarkdownHugoWritesConfigTOMLAndResolvesAuthor(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Output: tmpDir, Format: "markdown", MarkdownFlavor: "hugo", DownloadMedia: false}
+	e := NewExporter(cfg)
+
+	data := hugoTestData()
+	data.Site.Language = "en-us"
+	data.Site.Description = "A test site"
+	data.Posts[0].Author = 5
+	data.Users = []models.WordPressUser{{ID: 5, Slug: "jane"}}
+
+	if err := e.Export(data); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	configToml, err := os.ReadFile(filepath.Join(tmpDir, "config.toml"))
+	if err != nil {
+		t.Fatalf("expected config.toml to be written: %v", err)
+	}
+	if !strings.Contains(string(configToml), `title = "Test Site"`) {
+		t.Errorf("config.toml missing site title, got:\n%s", configToml)
+	}
+	if !strings.Contains(string(configToml), `languageCode = "en-us"`) {
+		t.Errorf("config.toml missing languageCode, got:\n%s", configToml)
+	}
+
+	post, err := os.ReadFile(filepath.Join(tmpDir, "content", "posts", "hello-world", "index.md"))
+	if err != nil {
+		t.Fatalf("failed to read hugo post: %v", err)
+	}
+	if !strings.Contains(string(post), `author: "jane"`) {
+		t.Errorf("hugo post should resolve author to the user's slug, got:\n%s", post)
+	}
+}
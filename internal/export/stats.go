@@ -0,0 +1,104 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// htmlStatsCollector accumulates the distinct HTML tags, classes, and IDs seen across every
+// convertHTMLToMarkdown call during an export, for writeStats to dump as stats.json.
+// Exported posts/pages are converted concurrently (see exportConcurrency), so collect locks
+// around its maps rather than requiring callers to serialize.
+type htmlStatsCollector struct {
+	mu      sync.Mutex
+	tags    map[string]struct{}
+	classes map[string]struct{}
+	ids     map[string]struct{}
+}
+
+func newHTMLStatsCollector() *htmlStatsCollector {
+	return &htmlStatsCollector{
+		tags:    make(map[string]struct{}),
+		classes: make(map[string]struct{}),
+		ids:     make(map[string]struct{}),
+	}
+}
+
+// collect tokenizes htmlContent and records every tag name and class/id attribute value it
+// finds. It's a tolerant, best-effort pre-pass - a malformed fragment just yields whatever
+// tokens html.NewTokenizer managed to produce before erroring, the same way
+// convertHTMLToMarkdown degrades rather than aborting the export.
+func (c *htmlStatsCollector) collect(htmlContent string) {
+	z := html.NewTokenizer(strings.NewReader(htmlContent))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		token := z.Token()
+		c.tags[token.Data] = struct{}{}
+
+		for _, attr := range token.Attr {
+			switch attr.Key {
+			case "class":
+				for _, class := range strings.Fields(attr.Val) {
+					c.classes[class] = struct{}{}
+				}
+			case "id":
+				if attr.Val != "" {
+					c.ids[attr.Val] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+// sortedKeys returns m's keys, deduplicated (by construction) and sorted.
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// statsFile is stats.json's top-level shape.
+type statsFile struct {
+	HTMLElements struct {
+		Tags    []string `json:"tags"`
+		Classes []string `json:"classes"`
+		IDs     []string `json:"ids"`
+	} `json:"htmlElements"`
+}
+
+// writeStats writes stats.json into outputRoot, gated by Config.WriteStats: the distinct
+// HTML tags, classes, and IDs collected from every exported post/page, so a downstream CSS
+// purger (PurgeCSS, Tailwind) can scan one small file instead of crawling the generated site.
+func (e *Exporter) writeStats(outputRoot string) error {
+	var stats statsFile
+	stats.HTMLElements.Tags = sortedKeys(e.htmlStats.tags)
+	stats.HTMLElements.Classes = sortedKeys(e.htmlStats.classes)
+	stats.HTMLElements.IDs = sortedKeys(e.htmlStats.ids)
+
+	jsonData, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outputRoot, "stats.json"), jsonData, 0600)
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestHTMLStatsCollectorCollectsTagsClassesAndIDs(t *testing.T) {
+	c := newHTMLStatsCollector()
+
+	c.collect(`<div class="hero intro" id="top"><p class="hero">Hi</p><img src="a.jpg" class="intro"/></div>`)
+	c.collect(`<div class="footer">Bye</div>`)
+
+	if got, want := sortedKeys(c.tags), []string{"div", "img", "p"}; !equalStrings(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+	if got, want := sortedKeys(c.classes), []string{"footer", "hero", "intro"}; !equalStrings(got, want) {
+		t.Errorf("classes = %v, want %v", got, want)
+	}
+	if got, want := sortedKeys(c.ids), []string{"top"}; !equalStrings(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func TestConvertHTMLToMarkdownCollectsStatsWhenEnabled(t *testing.T) {
+	cfg := &config.Config{URL: "https://example.com", WriteStats: true}
+	exporter := NewExporter(cfg)
+
+	exporter.convertHTMLToMarkdown(`<p class="lead" id="first">Hello</p>`)
+
+	if _, ok := exporter.htmlStats.tags["p"]; !ok {
+		t.Errorf("expected tag %q to be collected, tags = %v", "p", sortedKeys(exporter.htmlStats.tags))
+	}
+	if _, ok := exporter.htmlStats.classes["lead"]; !ok {
+		t.Errorf("expected class %q to be collected, classes = %v", "lead", sortedKeys(exporter.htmlStats.classes))
+	}
+	if _, ok := exporter.htmlStats.ids["first"]; !ok {
+		t.Errorf("expected id %q to be collected, ids = %v", "first", sortedKeys(exporter.htmlStats.ids))
+	}
+}
+
+func TestConvertHTMLToMarkdownSkipsStatsWhenDisabled(t *testing.T) {
+	cfg := &config.Config{URL: "https://example.com"}
+	exporter := NewExporter(cfg)
+
+	exporter.convertHTMLToMarkdown(`<p class="lead">Hello</p>`)
+
+	if len(exporter.htmlStats.tags) != 0 {
+		t.Errorf("expected no tags collected when WriteStats is false, got %v", sortedKeys(exporter.htmlStats.tags))
+	}
+}
+
+func TestWriteStatsWritesDeduplicatedSortedJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", WriteStats: true}
+	exporter := NewExporter(cfg)
+
+	exporter.convertHTMLToMarkdown(`<div class="b"><p class="a" id="x">One</p></div>`)
+	exporter.convertHTMLToMarkdown(`<div class="a"><p class="a" id="y">Two</p></div>`)
+
+	if err := exporter.writeStats(tmpDir); err != nil {
+		t.Fatalf("writeStats() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "stats.json"))
+	if err != nil {
+		t.Fatalf("failed to read stats.json: %v", err)
+	}
+
+	var got statsFile
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("failed to unmarshal stats.json: %v", err)
+	}
+
+	if want := []string{"div", "p"}; !equalStrings(got.HTMLElements.Tags, want) {
+		t.Errorf("Tags = %v, want %v", got.HTMLElements.Tags, want)
+	}
+	if want := []string{"a", "b"}; !equalStrings(got.HTMLElements.Classes, want) {
+		t.Errorf("Classes = %v, want %v", got.HTMLElements.Classes, want)
+	}
+	if want := []string{"x", "y"}; !equalStrings(got.HTMLElements.IDs, want) {
+		t.Errorf("IDs = %v, want %v", got.HTMLElements.IDs, want)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
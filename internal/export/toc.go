@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// moreMarker is the literal HTML comment WordPress inserts at a "Read more" split point.
+const moreMarker = "<!--more-->"
+
+// tocEntry is one heading captured by extractTOC.
+type tocEntry struct {
+	Level  int
+	Title  string
+	Anchor string
+}
+
+var tocHeadingLevels = map[string]int{"h2": 2, "h3": 3, "h4": 4}
+
+// extractTOC walks htmlContent for <h2>-<h4> headings, returning one tocEntry per heading in
+// document order. Anchor is the heading's text slugified with the same rules as
+// sanitizeDirectoryName (lowercased - see sanitizeName), with a "-2", "-3", ... suffix
+// appended on repeat so two identically-titled headings don't collide, matching how static
+// site generators disambiguate heading anchors.
+func extractTOC(htmlContent string) []tocEntry {
+	var entries []tocEntry
+	seen := make(map[string]int)
+
+	z := html.NewTokenizer(strings.NewReader(htmlContent))
+	var current *strings.Builder
+	var level int
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return entries
+		}
+
+		switch tt {
+		case html.StartTagToken:
+			if l, ok := tocHeadingLevels[z.Token().Data]; ok {
+				current = &strings.Builder{}
+				level = l
+			}
+		case html.TextToken:
+			if current != nil {
+				current.WriteString(z.Token().Data)
+			}
+		case html.EndTagToken:
+			if _, ok := tocHeadingLevels[z.Token().Data]; ok && current != nil {
+				title := strings.TrimSpace(strings.Join(strings.Fields(current.String()), " "))
+				current = nil
+				if title == "" {
+					continue
+				}
+
+				anchor := slugifyHeading(title)
+				seen[anchor]++
+				if n := seen[anchor]; n > 1 {
+					anchor = fmt.Sprintf("%s-%d", anchor, n)
+				}
+				entries = append(entries, tocEntry{Level: level, Title: title, Anchor: anchor})
+			}
+		}
+	}
+}
+
+// splitSummary looks for WordPress's <!--more--> marker in htmlContent. When present, it
+// returns the plain-text content before the marker (for front matter's summary field) and
+// truncated=true; otherwise summary is empty and truncated is false.
+func splitSummary(htmlContent string) (summary string, truncated bool) {
+	idx := strings.Index(htmlContent, moreMarker)
+	if idx < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(strings.Join(strings.Fields(stripHTML(htmlContent[:idx])), " ")), true
+}
@@ -0,0 +1,72 @@
+package export
+
+import "testing"
+
+func TestExtractTOCCollectsH2ThroughH4InOrder(t *testing.T) {
+	html := `<h2>Intro</h2><p>text</p><h3>Details</h3><h4>Sub Detail</h4><h5>Ignored</h5>`
+
+	got := extractTOC(html)
+	if len(got) != 3 {
+		t.Fatalf("extractTOC() returned %d entries, want 3: %+v", len(got), got)
+	}
+
+	want := []tocEntry{
+		{Level: 2, Title: "Intro", Anchor: "intro"},
+		{Level: 3, Title: "Details", Anchor: "details"},
+		{Level: 4, Title: "Sub Detail", Anchor: "sub-detail"},
+	}
+	for i, entry := range got {
+		if entry != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entry, want[i])
+		}
+	}
+}
+
+func TestExtractTOCDisambiguatesDuplicateAnchors(t *testing.T) {
+	got := extractTOC(`<h2>Overview</h2><h2>Overview</h2>`)
+
+	if len(got) != 2 {
+		t.Fatalf("extractTOC() returned %d entries, want 2", len(got))
+	}
+	if got[0].Anchor != "overview" {
+		t.Errorf("first anchor = %q, want %q", got[0].Anchor, "overview")
+	}
+	if got[1].Anchor != "overview-2" {
+		t.Errorf("second anchor = %q, want %q", got[1].Anchor, "overview-2")
+	}
+}
+
+func TestExtractTOCIgnoresHeadingsWithNoContent(t *testing.T) {
+	got := extractTOC(`<h2></h2><h3>   </h3><p>no headings here</p>`)
+	if len(got) != 0 {
+		t.Errorf("extractTOC() = %+v, want no entries", got)
+	}
+}
+
+func TestSlugifyHeadingLowercasesAndReplacesInvalidChars(t *testing.T) {
+	got := slugifyHeading("Hello World: A Guide")
+	want := "hello-world-a-guide"
+	if got != want {
+		t.Errorf("slugifyHeading() = %q, want %q", got, want)
+	}
+}
+
+func TestSplitSummarySplitsOnMoreMarker(t *testing.T) {
+	summary, truncated := splitSummary(`<p>Short intro.</p><!--more--><p>Rest of the post.</p>`)
+	if !truncated {
+		t.Fatal("splitSummary() truncated = false, want true")
+	}
+	if summary != "Short intro." {
+		t.Errorf("summary = %q, want %q", summary, "Short intro.")
+	}
+}
+
+func TestSplitSummaryNoMarkerReturnsNotTruncated(t *testing.T) {
+	summary, truncated := splitSummary(`<p>No split marker here.</p>`)
+	if truncated {
+		t.Error("splitSummary() truncated = true, want false")
+	}
+	if summary != "" {
+		t.Errorf("summary = %q, want empty", summary)
+	}
+}
@@ -0,0 +1,277 @@
+package export
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// wxrCDATA renders a field as a CDATA-wrapped character data block, for the raw-HTML
+// content/excerpt fields a WXR reader expects untouched rather than entity-escaped.
+type wxrCDATA struct {
+	Body string `xml:",cdata"`
+}
+
+// wxrAuthor is one <wp:author> block, built from a WordPressUser.
+type wxrAuthor struct {
+	XMLName     xml.Name `xml:"wp:author"`
+	ID          int      `xml:"wp:author_id"`
+	Login       string   `xml:"wp:author_login"`
+	Email       string   `xml:"wp:author_email"`
+	DisplayName string   `xml:"wp:author_display_name"`
+}
+
+// wxrCategoryTerm is one <wp:category> block, built from a WordPressCategory.
+type wxrCategoryTerm struct {
+	XMLName        xml.Name `xml:"wp:category"`
+	TermID         int      `xml:"wp:term_id"`
+	Nicename       string   `xml:"wp:category_nicename"`
+	ParentNicename string   `xml:"wp:category_parent"`
+	CatName        string   `xml:"wp:cat_name"`
+}
+
+// wxrTagTerm is one <wp:tag> block, built from a WordPressTag.
+type wxrTagTerm struct {
+	XMLName xml.Name `xml:"wp:tag"`
+	TermID  int      `xml:"wp:term_id"`
+	Slug    string   `xml:"wp:tag_slug"`
+	TagName string   `xml:"wp:tag_name"`
+}
+
+// wxrItemCategory is one <category> element attached to an <item>, covering both
+// domain="category" and domain="post_tag" entries the way WordPress's own WXR does.
+type wxrItemCategory struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+// wxrItem is one <item> block: a single post or page.
+type wxrItem struct {
+	XMLName       xml.Name          `xml:"item"`
+	Title         string            `xml:"title"`
+	Link          string            `xml:"link"`
+	PubDate       string            `xml:"pubDate"`
+	Creator       string            `xml:"dc:creator"`
+	GUID          string            `xml:"guid"`
+	Content       wxrCDATA          `xml:"content:encoded"`
+	Excerpt       wxrCDATA          `xml:"excerpt:encoded"`
+	PostID        int               `xml:"wp:post_id"`
+	PostDate      string            `xml:"wp:post_date"`
+	PostDateGMT   string            `xml:"wp:post_date_gmt"`
+	CommentStatus string            `xml:"wp:comment_status"`
+	PostName      string            `xml:"wp:post_name"`
+	Status        string            `xml:"wp:status"`
+	PostParent    int               `xml:"wp:post_parent"`
+	PostType      string            `xml:"wp:post_type"`
+	Categories    []wxrItemCategory `xml:"category"`
+}
+
+// exportWXR exports posts and pages as a single wordpress.xml WXR (WordPress eXtended
+// RSS) file, the native WordPress import/export format - letting a user round-trip an
+// export back into another WordPress instance, or feed it into converters like wp2hugo
+// that understand WXR. Unlike exportAtom/exportRSS, which build the whole feed as one
+// in-memory struct before marshaling it, exportWXR streams the channel header, one
+// <wp:author>/<wp:category>/<wp:tag> block, and one <item> per post/page directly to the
+// output file via a single xml.Encoder, so memory use stays flat regardless of site size.
+func (e *Exporter) exportWXR(data *models.ExportData) error {
+	outputPath := e.config.Output
+	if filepath.Ext(outputPath) != ".xml" {
+		if err := os.MkdirAll(outputPath, 0750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		outputPath = filepath.Join(outputPath, "wordpress.xml")
+	} else {
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	w := bufio.NewWriter(f)
+	if err := e.writeWXR(w, data); err != nil {
+		return fmt.Errorf("failed to write WXR export: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush WXR export: %w", err)
+	}
+
+	fmt.Printf("Export completed: %s\n", outputPath)
+	return nil
+}
+
+// writeWXR streams data to w as a complete WXR document: the <rss>/<channel> envelope and
+// header fields, author/category/tag term blocks, then one <item> per post and page.
+func (e *Exporter) writeWXR(w *bufio.Writer, data *models.ExportData) error {
+	if _, err := w.WriteString(xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	rssStart := xml.StartElement{
+		Name: xml.Name{Local: "rss"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "version"}, Value: "2.0"},
+			{Name: xml.Name{Local: "xmlns:wp"}, Value: "http://wordpress.org/export/1.2/"},
+			{Name: xml.Name{Local: "xmlns:content"}, Value: "http://purl.org/rss/1.0/modules/content/"},
+			{Name: xml.Name{Local: "xmlns:dc"}, Value: "http://purl.org/dc/elements/1.1/"},
+			{Name: xml.Name{Local: "xmlns:excerpt"}, Value: "http://wordpress.org/export/1.2/excerpt/"},
+		},
+	}
+	if err := enc.EncodeToken(rssStart); err != nil {
+		return err
+	}
+
+	channelStart := xml.StartElement{Name: xml.Name{Local: "channel"}}
+	if err := enc.EncodeToken(channelStart); err != nil {
+		return err
+	}
+
+	base := e.siteBaseURL(data.Site)
+	header := struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		PubDate     string `xml:"pubDate"`
+		Language    string `xml:"language,omitempty"`
+		WXRVersion  string `xml:"wp:wxr_version"`
+		BaseSiteURL string `xml:"wp:base_site_url"`
+		BaseBlogURL string `xml:"wp:base_blog_url"`
+	}{
+		Title:       data.Site.Name,
+		Link:        base,
+		Description: data.Site.Description,
+		PubDate:     time.Now().UTC().Format(time.RFC1123Z),
+		Language:    data.Site.Language,
+		WXRVersion:  "1.2",
+		BaseSiteURL: data.Site.HomeURL,
+		BaseBlogURL: base,
+	}
+	hv := reflect.ValueOf(header)
+	for i := 0; i < hv.NumField(); i++ {
+		name, opts, _ := strings.Cut(hv.Type().Field(i).Tag.Get("xml"), ",")
+		if value := hv.Field(i).String(); value != "" || opts != "omitempty" {
+			if err := enc.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, user := range data.Users {
+		author := wxrAuthor{
+			ID:          user.ID,
+			Login:       user.Slug,
+			Email:       user.Email,
+			DisplayName: user.Name,
+		}
+		if err := enc.Encode(author); err != nil {
+			return err
+		}
+	}
+
+	categoryMap := e.buildCategoryMap(data.Categories)
+	for _, cat := range data.Categories {
+		parentNicename := ""
+		if parent, ok := categoryMap[cat.Parent]; ok {
+			parentNicename = parent.Slug
+		}
+		term := wxrCategoryTerm{
+			TermID:         cat.ID,
+			Nicename:       cat.Slug,
+			ParentNicename: parentNicename,
+			CatName:        cat.Name,
+		}
+		if err := enc.Encode(term); err != nil {
+			return err
+		}
+	}
+
+	tagMap := make(map[int]models.WordPressTag, len(data.Tags))
+	for _, tag := range data.Tags {
+		tagMap[tag.ID] = tag
+		term := wxrTagTerm{TermID: tag.ID, Slug: tag.Slug, TagName: tag.Name}
+		if err := enc.Encode(term); err != nil {
+			return err
+		}
+	}
+
+	userMap := e.buildUserMap(data.Users)
+	for _, post := range data.Posts {
+		if err := e.encodeWXRItem(enc, post, "post", categoryMap, tagMap, userMap); err != nil {
+			return err
+		}
+	}
+	for _, page := range data.Pages {
+		if err := e.encodeWXRItem(enc, page, "page", categoryMap, tagMap, userMap); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeToken(channelStart.End()); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(rssStart.End()); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// encodeWXRItem builds and streams a single post/page's <item> block to enc.
+func (e *Exporter) encodeWXRItem(
+	enc *xml.Encoder,
+	post models.WordPressPost,
+	postType string,
+	categoryMap map[int]models.WordPressCategory,
+	tagMap map[int]models.WordPressTag,
+	userMap map[int]models.WordPressUser,
+) error {
+	creator := fmt.Sprintf("%d", post.Author)
+	if user, ok := userMap[post.Author]; ok && user.Slug != "" {
+		creator = user.Slug
+	}
+
+	item := wxrItem{
+		Title:         post.Title.Rendered,
+		Link:          post.Link,
+		PubDate:       post.Date.Format(time.RFC1123Z),
+		Creator:       creator,
+		GUID:          post.GUID.Rendered,
+		Content:       wxrCDATA{Body: post.Content.Rendered},
+		Excerpt:       wxrCDATA{Body: post.Excerpt.Rendered},
+		PostID:        post.ID,
+		PostDate:      post.Date.Format("2006-01-02 15:04:05"),
+		PostDateGMT:   post.DateGMT.Format("2006-01-02 15:04:05"),
+		CommentStatus: post.CommentStatus,
+		PostName:      post.Slug,
+		Status:        post.Status,
+		PostParent:    post.Parent,
+		PostType:      postType,
+	}
+
+	for _, id := range post.Categories {
+		if cat, ok := categoryMap[id]; ok {
+			item.Categories = append(item.Categories, wxrItemCategory{Domain: "category", Nicename: cat.Slug, Name: cat.Name})
+		}
+	}
+	for _, id := range post.Tags {
+		if tag, ok := tagMap[id]; ok {
+			item.Categories = append(item.Categories, wxrItemCategory{Domain: "post_tag", Nicename: tag.Slug, Name: tag.Name})
+		}
+	}
+
+	return enc.Encode(item)
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func TestExportWXR(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{URL: "https://example.com", Output: tmpDir, Format: "xml"}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{
+		Site: models.SiteInfo{Name: "Example Blog", URL: "https://example.com", HomeURL: "https://example.com", Language: "en-US"},
+		Posts: []models.WordPressPost{
+			{
+				ID: 1, Author: 1, Slug: "first-post", Status: "publish",
+				Title:      models.RenderedContent{Rendered: "First Post"},
+				Content:    models.RenderedContent{Rendered: "<p>Hi there</p>"},
+				Excerpt:    models.RenderedContent{Rendered: "Hi"},
+				Link:       "https://example.com/first-post",
+				Categories: []int{5},
+				Tags:       []int{9},
+			},
+		},
+		Pages: []models.WordPressPost{
+			{ID: 2, Slug: "about", Status: "publish", Title: models.RenderedContent{Rendered: "About"}},
+		},
+		Users:      []models.WordPressUser{{ID: 1, Slug: "admin", Name: "Admin", Email: "admin@example.com"}},
+		Categories: []models.WordPressCategory{{ID: 5, Name: "News", Slug: "news"}},
+		Tags:       []models.WordPressTag{{ID: 9, Name: "Golang", Slug: "golang"}},
+	}
+
+	if err := exporter.exportWXR(data); err != nil {
+		t.Fatalf("exportWXR() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "wordpress.xml"))
+	if err != nil {
+		t.Fatalf("failed to read wordpress.xml: %v", err)
+	}
+	body := string(content)
+
+	for _, want := range []string{
+		`<rss version="2.0"`,
+		`xmlns:wp="http://wordpress.org/export/1.2/"`,
+		`xmlns:content="http://purl.org/rss/1.0/modules/content/"`,
+		`<title>Example Blog</title>`,
+		`<wp:author_login>admin</wp:author_login>`,
+		`<wp:category_nicename>news</wp:category_nicename>`,
+		`<wp:tag_slug>golang</wp:tag_slug>`,
+		`<title>First Post</title>`,
+		`<dc:creator>admin</dc:creator>`,
+		`<![CDATA[<p>Hi there</p>]]>`,
+		`<wp:post_id>1</wp:post_id>`,
+		`<wp:post_type>post</wp:post_type>`,
+		`<category domain="category" nicename="news">News</category>`,
+		`<category domain="post_tag" nicename="golang">Golang</category>`,
+		`<title>About</title>`,
+		`<wp:post_type>page</wp:post_type>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("wordpress.xml missing %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestExportWXRWritesToExplicitXMLFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "export", "site.xml")
+
+	cfg := &config.Config{URL: "https://example.com", Output: outputPath, Format: "xml"}
+	exporter := NewExporter(cfg)
+
+	data := &models.ExportData{Site: models.SiteInfo{Name: "Example Blog", URL: "https://example.com"}}
+
+	if err := exporter.exportWXR(data); err != nil {
+		t.Fatalf("exportWXR() error = %v", err)
+	}
+
+	if _, err := os.Stat(outputPath); err != nil {
+		t.Errorf("expected %s to exist: %v", outputPath, err)
+	}
+}
@@ -0,0 +1,73 @@
+// Package httpcache persists conditional-GET validators and response bodies for
+// downloaded URLs, keyed by URL, under a root directory (typically config.Config's
+// HTTPCacheDir, a ".cache" sibling of the media directory). It exists alongside
+// internal/media's own MediaManifest: the manifest lives inside the media directory and
+// is lost along with it (e.g. after a --no-files cleanup), while httpcache's root
+// survives that cleanup, letting a later run recognize an unchanged source file via a
+// 304 and reconstruct it from the cached body instead of re-downloading it.
+package httpcache
+
+import (
+	"encoding/json"
+
+	"github.com/tradik/wpexporter/internal/cache"
+)
+
+// validatorsSub and bodiesSub are the internal/cache.Cache sub-caches Cache stores
+// entries under.
+const (
+	validatorsSub = "validators"
+	bodiesSub     = "bodies"
+)
+
+// Validators are the conditional-GET headers a later request sends as
+// If-None-Match/If-Modified-Since to avoid re-downloading a URL whose content hasn't
+// changed.
+type Validators struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+}
+
+// Cache is an on-disk, content-addressed cache of URL -> (Validators, body), built on
+// internal/cache.Cache. A zero TTL (the default New uses) means entries never expire on
+// their own.
+type Cache struct {
+	cache *cache.Cache
+}
+
+// New returns a Cache rooted at root. root is created on first write, not by New.
+func New(root string) *Cache {
+	return &Cache{cache: cache.New(root, 0)}
+}
+
+// Lookup returns the Validators and body previously stored for url, and whether an
+// entry was found at all. body is nil (ok still true) if only validators were stored.
+func (c *Cache) Lookup(url string) (Validators, []byte, bool) {
+	key := cache.Key(url)
+
+	data, ok, err := c.cache.Get(validatorsSub, key, 0)
+	if err != nil || !ok {
+		return Validators{}, nil, false
+	}
+	var validators Validators
+	if err := json.Unmarshal(data, &validators); err != nil {
+		return Validators{}, nil, false
+	}
+
+	body, ok, err := c.cache.Get(bodiesSub, key, 0)
+	if err != nil || !ok {
+		return validators, nil, true
+	}
+	return validators, body, true
+}
+
+// Store persists validators and body for url, best-effort: a failure here only costs a
+// future re-download, never correctness.
+func (c *Cache) Store(url string, validators Validators, body []byte) {
+	key := cache.Key(url)
+
+	if data, err := json.Marshal(validators); err == nil {
+		_ = c.cache.Set(validatorsSub, key, data)
+	}
+	_ = c.cache.Set(bodiesSub, key, body)
+}
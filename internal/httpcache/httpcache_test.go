@@ -0,0 +1,48 @@
+package httpcache
+
+import "testing"
+
+func TestCacheLookupMiss(t *testing.T) {
+	c := New(t.TempDir())
+
+	if _, _, ok := c.Lookup("https://example.com/photo.jpg"); ok {
+		t.Error("Lookup() ok = true for a URL that was never stored")
+	}
+}
+
+func TestCacheStoreAndLookup(t *testing.T) {
+	c := New(t.TempDir())
+
+	url := "https://example.com/photo.jpg"
+	want := Validators{ETag: `"abc123"`, LastModified: "Wed, 21 Oct 2015 07:28:00 GMT"}
+	body := []byte("fake-image-bytes")
+
+	c.Store(url, want, body)
+
+	got, gotBody, ok := c.Lookup(url)
+	if !ok {
+		t.Fatal("Lookup() ok = false, want true after Store()")
+	}
+	if got != want {
+		t.Errorf("Lookup() validators = %+v, want %+v", got, want)
+	}
+	if string(gotBody) != string(body) {
+		t.Errorf("Lookup() body = %q, want %q", gotBody, body)
+	}
+}
+
+func TestCacheStoreOverwrites(t *testing.T) {
+	c := New(t.TempDir())
+	url := "https://example.com/photo.jpg"
+
+	c.Store(url, Validators{ETag: "old"}, []byte("old body"))
+	c.Store(url, Validators{ETag: "new"}, []byte("new body"))
+
+	got, gotBody, ok := c.Lookup(url)
+	if !ok {
+		t.Fatal("Lookup() ok = false, want true")
+	}
+	if got.ETag != "new" || string(gotBody) != "new body" {
+		t.Errorf("Lookup() = (%+v, %q), want overwritten entry", got, gotBody)
+	}
+}
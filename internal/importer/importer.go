@@ -0,0 +1,21 @@
+// Package importer normalizes content from other platforms' export archives into the
+// same models.ExportData shape internal/export.Exporter already knows how to write, so
+// a WXR dump or a Mastodon archive can be run through this tool's existing Format
+// writers (json, markdown, atom, rss, sitemap, ...) instead of needing a separate
+// conversion step.
+package importer
+
+import "github.com/tradik/wpexporter/pkg/models"
+
+// Adapter parses a source archive at path into the same ExportData shape this tool's
+// own export command produces. Fields the source platform has no equivalent for are
+// left at their zero value.
+type Adapter interface {
+	Import(path string) (*models.ExportData, error)
+}
+
+// Adapters maps the import command's --from flag to the Adapter implementing it.
+var Adapters = map[string]Adapter{
+	"wxr":      WXRAdapter{},
+	"mastodon": MastodonAdapter{},
+}
@@ -0,0 +1,176 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// MastodonAdapter parses a Mastodon "outbox.json" archive export (an ActivityStreams
+// OrderedCollection of Create activities wrapping Notes) into an ExportData, so a
+// Mastodon archive can be run through any of this tool's own Format writers. Mastodon
+// has no taxonomy of its own, so hashtags found in each Note's Tag[] array are
+// synthesized into WordPressTag entries, numbered in first-seen order.
+type MastodonAdapter struct{}
+
+// mastodonOutbox is the top-level OrderedCollection an outbox.json archive contains.
+type mastodonOutbox struct {
+	OrderedItems []mastodonActivity `json:"orderedItems"`
+}
+
+type mastodonActivity struct {
+	Type   string       `json:"type"`
+	Object mastodonNote `json:"object"`
+}
+
+type mastodonNote struct {
+	ID         string               `json:"id"`
+	Type       string               `json:"type"`
+	Content    string               `json:"content"`
+	Published  string               `json:"published"`
+	URL        string               `json:"url"`
+	Tag        []mastodonTag        `json:"tag"`
+	Attachment []mastodonAttachment `json:"attachment"`
+}
+
+type mastodonTag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+type mastodonAttachment struct {
+	Type      string `json:"type"`
+	MediaType string `json:"mediaType"`
+	URL       string `json:"url"`
+}
+
+func (MastodonAdapter) Import(archivePath string) (*models.ExportData, error) {
+	raw, err := os.ReadFile(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Mastodon outbox %s: %w", archivePath, err)
+	}
+
+	var outbox mastodonOutbox
+	if err := json.Unmarshal(raw, &outbox); err != nil {
+		return nil, fmt.Errorf("failed to parse Mastodon outbox JSON in %s: %w", archivePath, err)
+	}
+
+	data := &models.ExportData{}
+	tagIDs := make(map[string]int)
+	mediaID := 1
+
+	for _, activity := range outbox.OrderedItems {
+		if activity.Type != "Create" {
+			continue
+		}
+		note := activity.Object
+		if note.Type != "Note" && note.Type != "Article" {
+			continue
+		}
+
+		post := models.WordPressPost{
+			ID:      mastodonStatusID(note.ID),
+			Date:    parseMastodonTime(note.Published),
+			DateGMT: parseMastodonTime(note.Published),
+			Slug:    mastodonSlug(note.ID),
+			Status:  "publish",
+			Type:    "post",
+			Link:    note.URL,
+			Content: models.RenderedContent{Rendered: note.Content},
+		}
+
+		for _, t := range note.Tag {
+			if t.Type != "Hashtag" {
+				continue
+			}
+			name := strings.TrimPrefix(t.Name, "#")
+			id, ok := tagIDs[name]
+			if !ok {
+				id = len(tagIDs) + 1
+				tagIDs[name] = id
+				data.Tags = append(data.Tags, models.WordPressTag{
+					ID:       id,
+					Name:     name,
+					Slug:     name,
+					Taxonomy: "post_tag",
+				})
+			}
+			post.Tags = append(post.Tags, id)
+		}
+
+		for _, a := range note.Attachment {
+			data.Media = append(data.Media, models.WordPressMedia{
+				ID:        mediaID,
+				Post:      post.ID,
+				Status:    "inherit",
+				Type:      "attachment",
+				MediaType: mastodonMediaType(a.MediaType),
+				MimeType:  a.MediaType,
+				SourceURL: a.URL,
+			})
+			post.FeaturedMedia = mediaID
+			mediaID++
+		}
+
+		data.Posts = append(data.Posts, post)
+	}
+
+	data.Stats = models.ExportStats{
+		TotalPosts: len(data.Posts),
+		TotalMedia: len(data.Media),
+		TotalTags:  len(data.Tags),
+	}
+
+	return data, nil
+}
+
+// mastodonStatusID extracts the trailing numeric status ID from a Note's ActivityPub
+// ID URL (".../statuses/12345"), falling back to 0 if it isn't numeric.
+func mastodonStatusID(id string) int {
+	n, err := strconv.Atoi(path.Base(id))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// mastodonSlug derives a post slug from a Note's ActivityPub ID, reusing its trailing
+// path segment the way mastodonStatusID does for the numeric ID.
+func mastodonSlug(id string) string {
+	return path.Base(id)
+}
+
+// mastodonMediaType maps an attachment's ActivityStreams mediaType (a MIME type) to
+// the coarse media_type WordPress's own REST API reports ("image", "video", "audio", "file").
+func mastodonMediaType(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return "image"
+	case strings.HasPrefix(mimeType, "video/"):
+		return "video"
+	case strings.HasPrefix(mimeType, "audio/"):
+		return "audio"
+	default:
+		return "file"
+	}
+}
+
+// parseMastodonTime parses an ActivityStreams "published" timestamp (RFC3339) into a
+// models.WordPressTime, leaving it at the zero value if s is empty or unparseable.
+func parseMastodonTime(s string) models.WordPressTime {
+	if s == "" {
+		return models.WordPressTime{}
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return models.WordPressTime{}
+	}
+	return models.WordPressTime{Time: t}
+}
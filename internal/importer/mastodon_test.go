@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleOutbox = `{
+	"type": "OrderedCollection",
+	"orderedItems": [
+		{
+			"type": "Create",
+			"object": {
+				"id": "https://mastodon.example/users/alice/statuses/123456",
+				"type": "Note",
+				"content": "<p>Hello fediverse <a href=\"#\">#golang</a></p>",
+				"published": "2024-03-15T08:30:00Z",
+				"url": "https://mastodon.example/@alice/123456",
+				"tag": [
+					{"type": "Hashtag", "name": "#golang", "href": "https://mastodon.example/tags/golang"}
+				],
+				"attachment": [
+					{"type": "Document", "mediaType": "image/png", "url": "https://mastodon.example/media/1.png"}
+				]
+			}
+		},
+		{
+			"type": "Announce",
+			"object": {"id": "https://mastodon.example/statuses/999", "type": "Note"}
+		}
+	]
+}`
+
+func TestMastodonAdapterImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	if err := os.WriteFile(path, []byte(sampleOutbox), 0644); err != nil {
+		t.Fatalf("failed to write sample outbox: %v", err)
+	}
+
+	data, err := MastodonAdapter{}.Import(path)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if len(data.Posts) != 1 {
+		t.Fatalf("Posts = %+v, want exactly 1 (the Announce activity should be skipped)", data.Posts)
+	}
+	post := data.Posts[0]
+
+	if post.ID != 123456 {
+		t.Errorf("post.ID = %d, want 123456", post.ID)
+	}
+	if post.Slug != "123456" {
+		t.Errorf("post.Slug = %q, want 123456", post.Slug)
+	}
+	if post.Content.Rendered != `<p>Hello fediverse <a href="#">#golang</a></p>` {
+		t.Errorf("post.Content.Rendered = %q", post.Content.Rendered)
+	}
+	if post.Date.IsZero() || post.Date.Year() != 2024 {
+		t.Errorf("post.Date = %v, want year 2024", post.Date)
+	}
+
+	if len(data.Tags) != 1 || data.Tags[0].Name != "golang" {
+		t.Fatalf("Tags = %+v, want one tag named golang", data.Tags)
+	}
+	if len(post.Tags) != 1 || post.Tags[0] != data.Tags[0].ID {
+		t.Errorf("post.Tags = %v, want [%d]", post.Tags, data.Tags[0].ID)
+	}
+
+	if len(data.Media) != 1 || data.Media[0].SourceURL != "https://mastodon.example/media/1.png" {
+		t.Fatalf("Media = %+v, want one PNG attachment", data.Media)
+	}
+	if data.Media[0].MediaType != "image" {
+		t.Errorf("Media[0].MediaType = %q, want image", data.Media[0].MediaType)
+	}
+	if post.FeaturedMedia != data.Media[0].ID {
+		t.Errorf("post.FeaturedMedia = %d, want %d", post.FeaturedMedia, data.Media[0].ID)
+	}
+}
@@ -0,0 +1,119 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleWXR = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:wp="http://wordpress.org/export/1.2/"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/">
+<channel>
+	<title>Example Blog</title>
+	<link>https://example.com</link>
+	<wp:base_site_url>https://example.com</wp:base_site_url>
+	<language>en-US</language>
+	<wp:author>
+		<wp:author_id>1</wp:author_id>
+		<wp:author_login>admin</wp:author_login>
+		<wp:author_email>admin@example.com</wp:author_email>
+		<wp:author_display_name>Admin</wp:author_display_name>
+	</wp:author>
+	<wp:category>
+		<wp:term_id>5</wp:term_id>
+		<wp:category_nicename>news</wp:category_nicename>
+		<wp:cat_name>News</wp:cat_name>
+	</wp:category>
+	<wp:tag>
+		<wp:term_id>9</wp:term_id>
+		<wp:tag_slug>golang</wp:tag_slug>
+		<wp:tag_name>Golang</wp:tag_name>
+	</wp:tag>
+	<item>
+		<title>Hello World</title>
+		<link>https://example.com/hello-world</link>
+		<content:encoded><![CDATA[<p>Hi there</p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Hi]]></excerpt:encoded>
+		<wp:post_id>42</wp:post_id>
+		<wp:post_date>2024-01-02 10:00:00</wp:post_date>
+		<wp:post_date_gmt>2024-01-02 10:00:00</wp:post_date_gmt>
+		<wp:post_name>hello-world</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+		<category domain="category" nicename="news">News</category>
+		<category domain="post_tag" nicename="golang">Golang</category>
+	</item>
+	<item>
+		<title>About</title>
+		<link>https://example.com/about</link>
+		<content:encoded><![CDATA[<p>About us</p>]]></content:encoded>
+		<wp:post_id>7</wp:post_id>
+		<wp:post_name>about</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>photo.jpg</title>
+		<link>https://example.com/photo</link>
+		<wp:post_id>99</wp:post_id>
+		<wp:post_name>photo</wp:post_name>
+		<wp:status>inherit</wp:status>
+		<wp:post_type>attachment</wp:post_type>
+		<wp:attachment_url>https://example.com/wp-content/uploads/photo.jpg</wp:attachment_url>
+	</item>
+</channel>
+</rss>`
+
+func TestWXRAdapterImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.xml")
+	if err := os.WriteFile(path, []byte(sampleWXR), 0644); err != nil {
+		t.Fatalf("failed to write sample WXR: %v", err)
+	}
+
+	data, err := WXRAdapter{}.Import(path)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if data.Site.Name != "Example Blog" || data.Site.URL != "https://example.com" {
+		t.Errorf("Site = %+v, want Name=Example Blog URL=https://example.com", data.Site)
+	}
+
+	if len(data.Posts) != 1 || data.Posts[0].ID != 42 {
+		t.Fatalf("Posts = %+v, want one post with ID 42", data.Posts)
+	}
+	post := data.Posts[0]
+	if post.Content.Rendered != "<p>Hi there</p>" {
+		t.Errorf("post.Content.Rendered = %q, want <p>Hi there</p>", post.Content.Rendered)
+	}
+	if post.Excerpt.Rendered != "Hi" {
+		t.Errorf("post.Excerpt.Rendered = %q, want Hi", post.Excerpt.Rendered)
+	}
+	if len(post.Categories) != 1 || post.Categories[0] != 5 {
+		t.Errorf("post.Categories = %v, want [5]", post.Categories)
+	}
+	if len(post.Tags) != 1 || post.Tags[0] != 9 {
+		t.Errorf("post.Tags = %v, want [9]", post.Tags)
+	}
+
+	if len(data.Pages) != 1 || data.Pages[0].ID != 7 {
+		t.Fatalf("Pages = %+v, want one page with ID 7", data.Pages)
+	}
+
+	if len(data.Media) != 1 || data.Media[0].SourceURL != "https://example.com/wp-content/uploads/photo.jpg" {
+		t.Fatalf("Media = %+v, want one item with the attachment_url as SourceURL", data.Media)
+	}
+
+	if len(data.Categories) != 1 || data.Categories[0].Name != "News" {
+		t.Errorf("Categories = %+v, want one category named News", data.Categories)
+	}
+	if len(data.Tags) != 1 || data.Tags[0].Name != "Golang" {
+		t.Errorf("Tags = %+v, want one tag named Golang", data.Tags)
+	}
+	if len(data.Users) != 1 || data.Users[0].Slug != "admin" {
+		t.Errorf("Users = %+v, want one user with slug admin", data.Users)
+	}
+}
@@ -0,0 +1,161 @@
+// Package log provides a small leveled, structured logger for the CLI commands. It renders
+// either as plain text or as one JSON record per line (--log-format), filtered by a minimum
+// severity (--log-level), and exposes a Phase helper for timing labeled sections of work.
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Level is a log record's severity. Levels are ordered low to high; a Logger discards any
+// record below its configured minimum.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// ParseLevel parses one of "debug", "info", "warn", or "error" (case-sensitive), defaulting
+// to LevelInfo for anything else.
+func ParseLevel(s string) Level {
+	switch s {
+	case "debug":
+		return LevelDebug
+	case "warn":
+		return LevelWarn
+	case "error":
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Format selects how a Logger renders its records.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+// Field is one piece of structured context attached to a log record, e.g. a post count or
+// an export phase name.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F builds a Field, for call sites like log.Info("fetched", log.F("count", 42)).
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Logger emits leveled, optionally structured log records to an io.Writer (stderr by
+// default), in either plain text or one-JSON-record-per-line form.
+type Logger struct {
+	out    io.Writer
+	format Format
+	level  Level
+}
+
+// New returns a Logger rendering in format, discarding records below level, writing to stderr.
+func New(format Format, level Level) *Logger {
+	return &Logger{out: os.Stderr, format: format, level: level}
+}
+
+// record is the shape of one JSON log line.
+type record struct {
+	Timestamp time.Time      `json:"ts"`
+	Level     string         `json:"level"`
+	Phase     string         `json:"phase,omitempty"`
+	Message   string         `json:"msg"`
+	Fields    map[string]any `json:"-"`
+}
+
+func (l *Logger) log(level Level, phase, msg string, fields []Field) {
+	if level < l.level {
+		return
+	}
+
+	if l.format == FormatJSON {
+		m := make(map[string]any, len(fields)+4)
+		m["ts"] = time.Now().Format(time.RFC3339Nano)
+		m["level"] = level.String()
+		if phase != "" {
+			m["phase"] = phase
+		}
+		m["msg"] = msg
+		for _, f := range fields {
+			m[f.Key] = f.Value
+		}
+		data, err := json.Marshal(m)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(l.out, string(data))
+		return
+	}
+
+	line := fmt.Sprintf("[%s]", level.String())
+	if phase != "" {
+		line += fmt.Sprintf(" [%s]", phase)
+	}
+	line += " " + msg
+	for _, f := range fields {
+		line += fmt.Sprintf(" %s=%v", f.Key, f.Value)
+	}
+	fmt.Fprintln(l.out, line)
+}
+
+// Debug logs a debug-level record.
+func (l *Logger) Debug(msg string, fields ...Field) { l.log(LevelDebug, "", msg, fields) }
+
+// Info logs an info-level record.
+func (l *Logger) Info(msg string, fields ...Field) { l.log(LevelInfo, "", msg, fields) }
+
+// Warn logs a warn-level record.
+func (l *Logger) Warn(msg string, fields ...Field) { l.log(LevelWarn, "", msg, fields) }
+
+// Error logs an error-level record.
+func (l *Logger) Error(msg string, fields ...Field) { l.log(LevelError, "", msg, fields) }
+
+// Fatal logs an error-level record, then exits the process with status 1.
+func (l *Logger) Fatal(msg string, fields ...Field) {
+	l.log(LevelError, "", msg, fields)
+	os.Exit(1)
+}
+
+// Summary logs an info-level "export_summary" record, used for the one-line structured
+// totals emitted at the end of an export in JSON mode.
+func (l *Logger) Summary(fields ...Field) { l.log(LevelInfo, "", "export_summary", fields) }
+
+// Phase logs a "phase_start" record for name, then returns a function that logs its
+// matching "phase_end" record with the elapsed duration; call the returned function (e.g.
+// via defer) when the phase completes.
+func (l *Logger) Phase(name string) func() {
+	start := time.Now()
+	l.log(LevelInfo, name, "phase_start", nil)
+	return func() {
+		l.log(LevelInfo, name, "phase_end", []Field{F("duration_ms", time.Since(start).Milliseconds())})
+	}
+}
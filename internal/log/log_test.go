@@ -0,0 +1,98 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func newTestLogger(format Format, level Level) (*Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return &Logger{out: buf, format: format, level: level}, buf
+}
+
+func TestLoggerFiltersBelowConfiguredLevel(t *testing.T) {
+	l, buf := newTestLogger(FormatText, LevelWarn)
+
+	l.Debug("debug message")
+	l.Info("info message")
+	l.Warn("warn message")
+
+	out := buf.String()
+	if strings.Contains(out, "debug message") || strings.Contains(out, "info message") {
+		t.Errorf("log output = %q, want debug/info suppressed below LevelWarn", out)
+	}
+	if !strings.Contains(out, "warn message") {
+		t.Errorf("log output = %q, want warn message present", out)
+	}
+}
+
+func TestLoggerJSONFormatEmitsOneRecordPerLine(t *testing.T) {
+	l, buf := newTestLogger(FormatJSON, LevelDebug)
+
+	l.Info("fetched posts", F("count", 42))
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v, output = %q", err, buf.String())
+	}
+	if rec["msg"] != "fetched posts" {
+		t.Errorf("msg = %v, want %q", rec["msg"], "fetched posts")
+	}
+	if rec["level"] != "info" {
+		t.Errorf("level = %v, want %q", rec["level"], "info")
+	}
+	count, ok := rec["count"].(float64)
+	if !ok || count != 42 {
+		t.Errorf("count = %v, want 42", rec["count"])
+	}
+}
+
+func TestPhaseEmitsStartAndEndRecords(t *testing.T) {
+	l, buf := newTestLogger(FormatJSON, LevelDebug)
+
+	done := l.Phase("fetch_posts")
+	done()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2 (phase_start, phase_end)", len(lines))
+	}
+
+	var start, end map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &start); err != nil {
+		t.Fatalf("failed to parse phase_start record: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &end); err != nil {
+		t.Fatalf("failed to parse phase_end record: %v", err)
+	}
+
+	if start["msg"] != "phase_start" || start["phase"] != "fetch_posts" {
+		t.Errorf("phase_start record = %v, want msg=phase_start phase=fetch_posts", start)
+	}
+	if end["msg"] != "phase_end" || end["phase"] != "fetch_posts" {
+		t.Errorf("phase_end record = %v, want msg=phase_end phase=fetch_posts", end)
+	}
+	if _, ok := end["duration_ms"]; !ok {
+		t.Errorf("phase_end record = %v, want a duration_ms field", end)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Level
+	}{
+		{"debug", LevelDebug},
+		{"info", LevelInfo},
+		{"warn", LevelWarn},
+		{"error", LevelError},
+		{"bogus", LevelInfo},
+	}
+	for _, tt := range tests {
+		if got := ParseLevel(tt.in); got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package media
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+// blurHashChars is the 83-character alphabet BlurHash packs its numeric components into.
+const blurHashChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// encodeBlurHash computes img's BlurHash (https://blurha.sh), a short string a static-site
+// export can render as a placeholder while the real image loads. componentsX and
+// componentsY (each 1-9) control the detail captured, same as the reference
+// implementation's default of 4x3.
+func encodeBlurHash(img image.Image, componentsX, componentsY int) (string, error) {
+	if componentsX < 1 || componentsX > 9 || componentsY < 1 || componentsY > 9 {
+		return "", fmt.Errorf("blurhash components must be between 1 and 9, got %dx%d", componentsX, componentsY)
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return "", fmt.Errorf("blurhash: image has no pixels")
+	}
+
+	factors := make([][3]float64, componentsX*componentsY)
+	for j := 0; j < componentsY; j++ {
+		for i := 0; i < componentsX; i++ {
+			factors[j*componentsX+i] = blurHashBasisFunction(img, bounds, width, height, i, j)
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encodeBase83(int64((componentsX-1)+(componentsY-1)*9), 1))
+
+	ac := factors[1:]
+	maximumValue := 1.0
+	quantisedMaximumValue := 0
+	if len(ac) > 0 {
+		actualMaximum := 0.0
+		for _, f := range ac {
+			actualMaximum = math.Max(actualMaximum, math.Abs(f[0]))
+			actualMaximum = math.Max(actualMaximum, math.Abs(f[1]))
+			actualMaximum = math.Max(actualMaximum, math.Abs(f[2]))
+		}
+		quantisedMaximumValue = int(math.Max(0, math.Min(82, math.Floor(actualMaximum*166-0.5))))
+		maximumValue = float64(quantisedMaximumValue+1) / 166
+	}
+	hash.WriteString(encodeBase83(int64(quantisedMaximumValue), 1))
+	hash.WriteString(encodeBase83(int64(encodeBlurHashDC(factors[0])), 4))
+	for _, f := range ac {
+		hash.WriteString(encodeBase83(int64(encodeBlurHashAC(f, maximumValue)), 2))
+	}
+
+	return hash.String(), nil
+}
+
+// blurHashBasisFunction computes one DCT-II component of img's linear-light pixels.
+func blurHashBasisFunction(img image.Image, bounds image.Rectangle, width, height, i, j int) [3]float64 {
+	normalisation := 2.0
+	if i == 0 && j == 0 {
+		normalisation = 1.0
+	}
+
+	var r, g, b float64
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			basis := normalisation *
+				math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+				math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+
+			cr, cg, cb, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			r += basis * srgbToLinear(int(cr>>8))
+			g += basis * srgbToLinear(int(cg>>8))
+			b += basis * srgbToLinear(int(cb>>8))
+		}
+	}
+
+	scale := 1.0 / float64(width*height)
+	return [3]float64{r * scale, g * scale, b * scale}
+}
+
+// encodeBlurHashDC packs a BlurHash hash's DC (average color) component into an int,
+// suitable for encodeBase83 with length 4.
+func encodeBlurHashDC(rgb [3]float64) int {
+	r := linearToSRGB(rgb[0])
+	g := linearToSRGB(rgb[1])
+	b := linearToSRGB(rgb[2])
+	return (r << 16) + (g << 8) + b
+}
+
+// encodeBlurHashAC packs one AC component into an int, suitable for encodeBase83 with
+// length 2.
+func encodeBlurHashAC(rgb [3]float64, maximumValue float64) int {
+	quantize := func(v float64) int {
+		q := math.Floor(signPow(v/maximumValue, 0.5)*9 + 9.5)
+		return int(math.Max(0, math.Min(18, q)))
+	}
+	return quantize(rgb[0])*19*19 + quantize(rgb[1])*19 + quantize(rgb[2])
+}
+
+// srgbToLinear converts an 8-bit sRGB channel value to linear light, in [0, 1].
+func srgbToLinear(value int) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts a linear-light channel value back to an 8-bit sRGB one.
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(math.Round(v * 12.92 * 255))
+	}
+	return int(math.Round((1.055*math.Pow(v, 1.0/2.4) - 0.055) * 255))
+}
+
+// signPow is math.Pow that preserves val's sign, needed because BlurHash's AC components
+// can be negative.
+func signPow(val, exp float64) float64 {
+	sign := 1.0
+	if val < 0 {
+		sign = -1.0
+	}
+	return sign * math.Pow(math.Abs(val), exp)
+}
+
+// encodeBase83 encodes value as a fixed-width, length-digit base83 string using
+// blurHashChars, the packing BlurHash strings use throughout.
+func encodeBase83(value int64, length int) string {
+	var buf strings.Builder
+	for i := 1; i <= length; i++ {
+		digit := (value / int64(math.Pow(83, float64(length-i)))) % 83
+		buf.WriteByte(blurHashChars[digit])
+	}
+	return buf.String()
+}
@@ -0,0 +1,43 @@
+package media
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestEncodeBlurHashLength(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 16), B: 128, A: 255})
+		}
+	}
+
+	hash, err := encodeBlurHash(img, 4, 3)
+	if err != nil {
+		t.Fatalf("encodeBlurHash() error = %v", err)
+	}
+	// 1 size-flag char + 1 quantised-max char + 4 DC chars + 2 chars per remaining AC component.
+	want := 1 + 1 + 4 + (4*3-1)*2
+	if len(hash) != want {
+		t.Errorf("len(hash) = %d, want %d", len(hash), want)
+	}
+}
+
+func TestEncodeBlurHashRejectsInvalidComponents(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	if _, err := encodeBlurHash(img, 0, 3); err == nil {
+		t.Error("encodeBlurHash() error = nil, want an error for componentsX < 1")
+	}
+	if _, err := encodeBlurHash(img, 4, 10); err == nil {
+		t.Error("encodeBlurHash() error = nil, want an error for componentsY > 9")
+	}
+}
+
+func TestEncodeBlurHashRejectsEmptyImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 0, 0))
+	if _, err := encodeBlurHash(img, 4, 3); err == nil {
+		t.Error("encodeBlurHash() error = nil, want an error for an image with no pixels")
+	}
+}
@@ -1,41 +1,125 @@
 package media
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/schollz/progressbar/v3"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tradik/wpexporter/internal/api"
 	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/internal/httpcache"
+	"github.com/tradik/wpexporter/internal/progress"
 	"github.com/tradik/wpexporter/pkg/models"
 )
 
+// placeholderWidth and placeholderHeight size a generated placeholder (see
+// downloadPlaceholder) when the media item's own MediaDetails don't report dimensions.
+const (
+	placeholderWidth  = 200
+	placeholderHeight = 200
+)
+
 // Downloader handles media file downloads
 type Downloader struct {
 	config     *config.Config
 	httpClient *http.Client
 	mediaDir   string
-	progress   *progressbar.ProgressBar
+	progress   *progress.Manager
+	bar        progress.Bar
+	httpCache  *httpcache.Cache
+	burst      int
+	limiters   map[string]*api.RateLimiter
+	limitersMu sync.Mutex
+	manifest   *MediaManifest
+	store      MediaStore
+	transcoder Transcoder
+
+	failures   []models.MediaFailure
+	failuresMu sync.Mutex
 }
 
-// NewDownloader creates a new media downloader
+// NewDownloader creates a new media downloader. Every download goes through an
+// internal/httpcache rooted at cfg.HTTPCacheDir, so an unchanged source file is recognized
+// via a conditional GET (and, if the local copy itself is gone, reconstructed from the
+// cached body) instead of re-fetched from scratch, and through a per-destination-host
+// internal/api.RateLimiter (see limiterFor) that backs off on 429/5xx responses without
+// throttling requests to other hosts sharing the same export run.
 func NewDownloader(cfg *config.Config) *Downloader {
+	burst := cfg.Concurrent
+	if burst <= 0 {
+		burst = 1
+	}
+
+	mediaDir := cfg.GetMediaDir()
+
 	return &Downloader{
 		config: cfg,
 		httpClient: &http.Client{
 			Timeout: time.Duration(cfg.Timeout) * time.Second,
 		},
-		mediaDir: cfg.GetMediaDir(),
+		mediaDir:   mediaDir,
+		progress:   progress.New(cfg),
+		httpCache:  httpcache.New(cfg.HTTPCacheDir()),
+		burst:      burst,
+		limiters:   map[string]*api.RateLimiter{},
+		store:      newMediaStore(cfg, mediaDir),
+		transcoder: newTranscoder(cfg),
+	}
+}
+
+// limiterFor returns the api.RateLimiter throttling requests to rawURL's host, creating
+// one the first time that host is seen. Media URLs commonly span several CDN/origin hosts
+// within a single export (the site itself, an attached CDN, embedded third-party media);
+// rate-limiting per host instead of sharing one global limiter means a slow or
+// rate-limited host doesn't also throttle unrelated, healthy ones.
+func (d *Downloader) limiterFor(rawURL string) *api.RateLimiter {
+	host := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+
+	d.limitersMu.Lock()
+	defer d.limitersMu.Unlock()
+
+	limiter, ok := d.limiters[host]
+	if !ok {
+		limiter = api.NewRateLimiter(d.config.RateLimit, d.config.MaxQPS, d.burst)
+		d.limiters[host] = limiter
 	}
+	return limiter
 }
 
-// DownloadMedia downloads all media files from the provided media items
+// DownloadMedia downloads all media files from the provided media items. See
+// DownloadMediaContext for cancellation.
 func (d *Downloader) DownloadMedia(mediaItems []models.WordPressMedia) (int, error) {
+	return d.DownloadMediaContext(context.Background(), mediaItems)
+}
+
+// DownloadMediaContext is DownloadMedia's context-aware counterpart: canceling ctx (e.g. a
+// CLI handling Ctrl-C, or an overall export deadline) stops launching new downloads and
+// causes every in-flight one to abandon as soon as its current rate-limiter wait or HTTP
+// round trip observes it, rather than running every item in mediaItems to completion
+// regardless. A per-item failure (a permanently failed download, which falls back to a
+// placeholder) never itself cancels ctx or the rest of the batch.
+func (d *Downloader) DownloadMediaContext(ctx context.Context, mediaItems []models.WordPressMedia) (int, error) {
 	if !d.config.DownloadMedia || len(mediaItems) == 0 {
 		return 0, nil
 	}
@@ -50,63 +134,191 @@ func (d *Downloader) DownloadMedia(mediaItems []models.WordPressMedia) (int, err
 		return 0, fmt.Errorf("media directory path must be absolute")
 	}
 
+	manifest, err := LoadMediaManifest(filepath.Join(d.mediaDir, manifestFileName))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load media manifest: %w", err)
+	}
+	d.manifest = manifest
+
 	// Create progress bar
-	d.progress = progressbar.NewOptions(len(mediaItems),
-		progressbar.OptionSetDescription("Downloading media"),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-		progressbar.OptionShowIts(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "=",
-			SaucerHead:    ">",
-			SaucerPadding: " ",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-	)
-
-	// Create worker pool for concurrent downloads
-	jobs := make(chan models.WordPressMedia, len(mediaItems))
-	results := make(chan bool, len(mediaItems))
-
-	// Start workers
-	for i := 0; i < d.config.Concurrent; i++ {
-		go d.worker(jobs, results)
-	}
-
-	// Send jobs
-	for _, media := range mediaItems {
-		jobs <- media
+	d.bar = d.progress.NewCountBar("Downloading media", len(mediaItems))
+
+	limit := d.config.Concurrent
+	if limit <= 0 {
+		limit = 1
 	}
-	close(jobs)
 
-	// Collect results
-	downloaded := 0
-	for i := 0; i < len(mediaItems); i++ {
-		if <-results {
-			downloaded++
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(limit)
+
+	var downloaded int64
+	for _, media := range mediaItems {
+		group.Go(func() error {
+			if d.downloadMediaItemContext(groupCtx, media) {
+				atomic.AddInt64(&downloaded, 1)
+			}
+			d.bar.Add(1)
+			return nil
+		})
+		if len(media.MediaDetails.Sizes) > 0 {
+			group.Go(func() error {
+				d.downloadMediaSizesContext(groupCtx, media)
+				return nil
+			})
 		}
-		if err := d.progress.Add(1); err != nil {
-			return downloaded, err
+	}
+	_ = group.Wait() // every goroutine above already reports its own success via downloaded/d.bar and never returns an error
+
+	d.bar.Finish()
+	return int(downloaded), nil
+}
+
+// Manifest returns the media manifest recording each downloaded URL's content hash,
+// conditional-GET validators, and on-disk path. It's populated by DownloadMedia and is nil
+// until then.
+func (d *Downloader) Manifest() *MediaManifest {
+	return d.manifest
+}
+
+// Failures returns every media size variant that exhausted its retries during the most
+// recent DownloadMedia/DownloadMediaContext call (see downloadMediaSizesContext), so a
+// caller can surface the shortfall via ExportData.MediaFailures instead of it passing
+// silently. Empty until a call has been made.
+func (d *Downloader) Failures() []models.MediaFailure {
+	d.failuresMu.Lock()
+	defer d.failuresMu.Unlock()
+	return append([]models.MediaFailure(nil), d.failures...)
+}
+
+// recordFailure appends to d.failures, guarded against concurrent size downloads across
+// different media items recording failures at the same time.
+func (d *Downloader) recordFailure(failure models.MediaFailure) {
+	d.failuresMu.Lock()
+	d.failures = append(d.failures, failure)
+	d.failuresMu.Unlock()
+}
+
+// DownloadMediaForPost downloads mediaItems into destDir's "images" subdirectory,
+// concurrently and rate-limited the same way as DownloadMedia, for an exporter mode that
+// colocates a post's media next to its own content file (see internal/export's
+// "bundle" MarkdownFlavor) instead of this package's usual single shared mediaDir.
+// Returns each successfully downloaded item's path relative to destDir (e.g.
+// "images/photo.jpg"), keyed by WordPress media ID; an item missing from the map failed
+// every retry and was skipped rather than substituted with a placeholder, since a bundle
+// is meant to be a self-contained directory a caller can simply not reference.
+//
+// Unlike DownloadMedia, these downloads are never recorded in d.Manifest(): the same
+// source URL can be scoped into more than one post's bundle (a featured image reused
+// across posts), which the manifest's one-entry-per-URL model can't express.
+func (d *Downloader) DownloadMediaForPost(destDir string, mediaItems []models.WordPressMedia) (map[int]string, error) {
+	if len(mediaItems) == 0 {
+		return map[int]string{}, nil
+	}
+
+	imagesDir := filepath.Join(destDir, "images")
+	if err := os.MkdirAll(imagesDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create %s directory: %w", imagesDir, err)
+	}
+
+	paths := make(map[int]string, len(mediaItems))
+	var mu sync.Mutex
+
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(d.burst)
+
+	for _, item := range mediaItems {
+		group.Go(func() error {
+			filename, ok := d.downloadMediaItemToDir(ctx, item, imagesDir)
+			if !ok {
+				return nil
+			}
+			mu.Lock()
+			paths[item.ID] = filepath.Join("images", filename)
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = group.Wait() // every goroutine above reports failure by simply omitting its entry from paths
+
+	return paths, nil
+}
+
+// downloadMediaItemToDir downloads media into destDir under its generated filename,
+// retrying like downloadMediaItemContext, and returns that filename (not a full path) on
+// success. An already-downloaded file is trusted as-is without a conditional GET, since
+// destDir - a per-post bundle directory - has no httpcache/manifest tracking of its own to
+// revalidate against.
+func (d *Downloader) downloadMediaItemToDir(ctx context.Context, media models.WordPressMedia, destDir string) (string, bool) {
+	if media.SourceURL == "" {
+		return "", false
+	}
+
+	parsedURL, err := url.Parse(media.SourceURL)
+	if err != nil {
+		if d.config.Verbose {
+			fmt.Printf("Invalid media URL: %s\n", media.SourceURL)
 		}
+		return "", false
 	}
 
-	if err := d.progress.Finish(); err != nil {
-		return downloaded, err
+	filename := d.generateFilename(media, parsedURL)
+	filePath := filepath.Join(destDir, filename)
+	if !filepath.IsAbs(filePath) {
+		return "", false
+	}
+
+	if _, err := os.Stat(filePath); err == nil {
+		return filepath.Base(filePath), true
+	}
+
+	expectedSize := expectedFilesize(media.MediaDetails.Filesize)
+
+	for attempt := 0; attempt <= d.config.Retries; attempt++ {
+		if ctx.Err() != nil {
+			return "", false
+		}
+		if d.downloadFileContext(ctx, media.SourceURL, filePath, expectedSize, media.MimeType, destDir) {
+			return filepath.Base(resolveDownloadedPath(filePath)), true
+		}
+		if attempt < d.config.Retries {
+			time.Sleep(time.Duration(attempt+1) * time.Second)
+		}
 	}
-	return downloaded, nil
+
+	return "", false
 }
 
-// worker processes media download jobs
-func (d *Downloader) worker(jobs <-chan models.WordPressMedia, results chan<- bool) {
-	for media := range jobs {
-		success := d.downloadMediaItem(media)
-		results <- success
+// resolveDownloadedPath returns the path finalizeDownload's sniffAndFixExtension actually
+// left on disk for filePath - usually filePath itself, or, when the generated filename's
+// extension was ".bin" and the real content sniffed as something else, filePath with its
+// ".bin" suffix replaced by the corrected one instead.
+func resolveDownloadedPath(filePath string) string {
+	if _, err := os.Stat(filePath); err == nil {
+		return filePath
+	}
+	if filepath.Ext(filePath) != ".bin" {
+		return filePath
 	}
+	matches, err := filepath.Glob(strings.TrimSuffix(filePath, ".bin") + ".*")
+	if err != nil || len(matches) == 0 {
+		return filePath
+	}
+	return matches[0]
 }
 
-// downloadMediaItem downloads a single media item
+// downloadMediaItem downloads a single media item (see downloadMediaItemContext).
 func (d *Downloader) downloadMediaItem(media models.WordPressMedia) bool {
+	return d.downloadMediaItemContext(context.Background(), media)
+}
+
+// downloadMediaItemContext downloads a single media item, revalidating an
+// already-downloaded file with a conditional GET (see downloadFileContext) rather than
+// trusting it fresh forever, so a changed source file is re-downloaded and one lost to
+// e.g. a --no-files cleanup is recovered from the HTTP cache without re-fetching bytes the
+// origin confirms haven't changed. ctx cancellation is honored by the rate-limiter wait and
+// HTTP round trip inside downloadFileContext, but never aborts the retry loop itself - the
+// next attempt's downloadFileContext call will simply fail fast once ctx is done.
+func (d *Downloader) downloadMediaItemContext(ctx context.Context, media models.WordPressMedia) bool {
 	if media.SourceURL == "" {
 		return false
 	}
@@ -129,14 +341,26 @@ func (d *Downloader) downloadMediaItem(media models.WordPressMedia) bool {
 		return false
 	}
 
-	// Check if file already exists
-	if _, err := os.Stat(filePath); err == nil {
-		return true // File already exists
+	// An existing file whose URL has no httpcache entry predates this mechanism (or was
+	// placed there some other way): trust it without hitting the network, the long-standing
+	// behavior. Once a URL has gone through downloadFileContext at least once, its cache
+	// entry drives revalidation - and, if the file itself later goes missing, recovery - on
+	// every later run instead.
+	_, _, cacheHit := d.httpCache.Lookup(media.SourceURL)
+	if !cacheHit {
+		if _, err := os.Stat(filePath); err == nil {
+			return true
+		}
 	}
 
+	expectedSize := expectedFilesize(media.MediaDetails.Filesize)
+
 	// Download file with retries
 	for attempt := 0; attempt <= d.config.Retries; attempt++ {
-		if d.downloadFile(media.SourceURL, filePath) {
+		if ctx.Err() != nil {
+			return false
+		}
+		if d.downloadFileContext(ctx, media.SourceURL, filePath, expectedSize, media.MimeType, d.mediaDir) {
 			return true
 		}
 
@@ -145,25 +369,330 @@ func (d *Downloader) downloadMediaItem(media models.WordPressMedia) bool {
 		}
 	}
 
+	// Every retry failed permanently: fall back to a generated placeholder so exported
+	// content still references a renderable file instead of a dead link.
+	return d.downloadPlaceholder(media.SourceURL, media, filePath)
+}
+
+// sizeRetryDelays are the bounded exponential backoff delays downloadMediaSizeWithRetry
+// waits between a sub-size variant's retryable failures, mirroring WP core's own sub-size
+// regeneration behavior (retried up to three times on 500/timeout).
+var sizeRetryDelays = []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+
+// downloadMediaSizesContext downloads every registered, whitelisted MediaDetails.Sizes
+// variant for media (see downloadMediaSizeWithRetry), one goroutine per variant. If any
+// variant is permanently failed, every sub-size file already written for this media ID is
+// removed - WP core never leaves a half-complete set of sub-sizes behind either - and the
+// failure is recorded via recordFailure instead of silently dropping the variant. Unlike
+// downloadMediaItemContext, there's no placeholder fallback: UpdateMediaPaths' srcset just
+// omits a missing width rather than offering a placeholder image as one of several
+// "equivalent" candidates a browser might silently prefer over the real full-size download.
+func (d *Downloader) downloadMediaSizesContext(ctx context.Context, media models.WordPressMedia) {
+	type sizeJob struct {
+		name string
+		size models.MediaSize
+	}
+	var jobs []sizeJob
+	for name, size := range media.MediaDetails.Sizes {
+		if size.SourceURL == "" || !d.sizeWhitelisted(name) {
+			continue
+		}
+		jobs = append(jobs, sizeJob{name, size})
+	}
+	if len(jobs) == 0 {
+		return
+	}
+
+	type sizeResult struct {
+		job      sizeJob
+		filePath string
+		attempts int
+		err      error
+	}
+	results := make([]sizeResult, len(jobs))
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job sizeJob) {
+			defer wg.Done()
+			filePath, attempts, err := d.downloadMediaSizeWithRetry(ctx, media, job.size)
+			results[i] = sizeResult{job: job, filePath: filePath, attempts: attempts, err: err}
+		}(i, job)
+	}
+	wg.Wait()
+
+	var anyFailed bool
+	for _, r := range results {
+		if r.err == nil {
+			continue
+		}
+		anyFailed = true
+		d.recordFailure(models.MediaFailure{
+			ID:        media.ID,
+			URL:       r.job.size.SourceURL,
+			SizeName:  r.job.name,
+			Attempts:  r.attempts,
+			LastError: r.err.Error(),
+		})
+	}
+
+	if !anyFailed {
+		return
+	}
+
+	for _, r := range results {
+		if r.err == nil && r.filePath != "" {
+			_ = os.Remove(r.filePath)
+		}
+	}
+}
+
+// downloadMediaSizeWithRetry downloads a single MediaDetails.Sizes variant (see
+// attemptSizeDownload), retrying a retryable failure (see isRetryableSizeErr) with bounded
+// exponential backoff (sizeRetryDelays) for up to len(sizeRetryDelays) extra attempts. An
+// already-downloaded file is trusted as-is the same way downloadMediaItemContext does,
+// unless its httpCache entry says otherwise. Returns the path the variant was (or would be)
+// written to, how many attempts were made, and the last error on permanent failure (nil on
+// success).
+func (d *Downloader) downloadMediaSizeWithRetry(ctx context.Context, media models.WordPressMedia, size models.MediaSize) (string, int, error) {
+	originalURL, err := url.Parse(media.SourceURL)
+	if err != nil {
+		originalURL = &url.URL{}
+	}
+
+	filename := d.generateSizeFilename(media, size, originalURL)
+	filePath := filepath.Join(d.mediaDir, filename)
+	if !filepath.IsAbs(filePath) {
+		return filePath, 1, fmt.Errorf("media size path %q escapes media directory", filePath)
+	}
+
+	_, _, cacheHit := d.httpCache.Lookup(size.SourceURL)
+	if !cacheHit {
+		if _, err := os.Stat(filePath); err == nil {
+			return filePath, 0, nil
+		}
+	}
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return filePath, attempt, ctx.Err()
+		}
+
+		lastErr = d.attemptSizeDownload(ctx, size.SourceURL, filePath, size.MimeType)
+		if lastErr == nil {
+			return filePath, attempt, nil
+		}
+		if !isRetryableSizeErr(lastErr) || attempt > len(sizeRetryDelays) {
+			return filePath, attempt, lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return filePath, attempt, ctx.Err()
+		case <-time.After(sizeRetryDelays[attempt-1]):
+		}
+	}
+}
+
+// attemptSizeDownload performs a single HTTP GET of rawURL into filePath, rate-limited per
+// destination host the same way downloadFileContext is (see limiterFor). Unlike
+// downloadFileContext, it deliberately skips the resumable ".part"/manifest/content-hash
+// machinery: a sub-size variant that fails partway is always either retried from scratch by
+// downloadMediaSizeWithRetry or discarded by downloadMediaSizesContext's cleanup, never
+// resumed across runs.
+func (d *Downloader) attemptSizeDownload(ctx context.Context, rawURL, filePath, declaredMimeType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return &nonRetryableSizeError{err}
+	}
+	req.Header.Set("User-Agent", d.config.UserAgent)
+
+	limiter := d.limiterFor(rawURL)
+	if err := limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 500 {
+		return &serverStatusError{statusCode: resp.StatusCode}
+	}
+	limiter.Recover()
+
+	if resp.StatusCode != http.StatusOK {
+		return &nonRetryableSizeError{fmt.Errorf("server returned status %d", resp.StatusCode)}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+		return &nonRetryableSizeError{err}
+	}
+
+	tmpPath := filePath + ".part"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return &nonRetryableSizeError{err}
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return &nonRetryableSizeError{err}
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return &nonRetryableSizeError{err}
+	}
+	return nil
+}
+
+// serverStatusError marks a 5xx response from attemptSizeDownload as retryable (see
+// isRetryableSizeErr).
+type serverStatusError struct {
+	statusCode int
+}
+
+func (e *serverStatusError) Error() string {
+	return fmt.Sprintf("server returned status %d", e.statusCode)
+}
+
+// nonRetryableSizeError wraps an attemptSizeDownload failure that isRetryableSizeErr should
+// not retry (e.g. a non-5xx status, or a local filesystem error).
+type nonRetryableSizeError struct {
+	err error
+}
+
+func (e *nonRetryableSizeError) Error() string { return e.err.Error() }
+func (e *nonRetryableSizeError) Unwrap() error { return e.err }
+
+// isRetryableSizeErr reports whether err from attemptSizeDownload is one of the three
+// categories downloadMediaSizeWithRetry retries: a 5xx response, a reset connection, or
+// ctx's deadline being exceeded (including the HTTP client's own request timeout, which Go
+// also surfaces as context.DeadlineExceeded). Anything else - a non-5xx status, a DNS
+// failure, connection refused - is treated as permanent and fails on the first attempt.
+func isRetryableSizeErr(err error) bool {
+	var nonRetryable *nonRetryableSizeError
+	if errors.As(err, &nonRetryable) {
+		return false
+	}
+	var serverErr *serverStatusError
+	if errors.As(err, &serverErr) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return strings.Contains(err.Error(), "connection reset")
+}
+
+// sizeWhitelisted reports whether a WordPress-registered size name (e.g. "thumbnail",
+// "medium") should be downloaded and offered in a srcset, per cfg.MediaSizes. An empty
+// whitelist - the default - allows every size WordPress reports.
+func (d *Downloader) sizeWhitelisted(name string) bool {
+	if len(d.config.MediaSizes) == 0 {
+		return true
+	}
+	for _, allowed := range d.config.MediaSizes {
+		if allowed == name {
+			return true
+		}
+	}
 	return false
 }
 
-// downloadFile downloads a file from URL to local path
-func (d *Downloader) downloadFile(url, filePath string) bool {
+// downloadFile downloads a file from URL to local path (see downloadFileContext).
+func (d *Downloader) downloadFile(url, filePath string, expectedSize int64) bool {
+	return d.downloadFileContext(context.Background(), url, filePath, expectedSize, "", d.mediaDir)
+}
+
+// downloadFileContext downloads a file from URL to local path, rate-limited per
+// destination host (see limiterFor) by an api.RateLimiter that backs off with exponential
+// jitter on a 429/5xx response, and conditional on its internal/httpcache entry: a
+// previously recorded ETag/Last-Modified is sent as If-None-Match/If-Modified-Since, and a
+// 304 response leaves an existing file untouched or, if the file itself is missing (e.g. a
+// --no-files cleanup), reconstructs it from the cache's copy of the body instead of
+// re-downloading bytes the origin confirms haven't changed.
+//
+// The file is written to a ".part" sibling first and renamed into place only once complete
+// and its size matches expectedSize (when known), so a process that dies mid-download - or
+// a response truncated by a flaky link - never leaves a corrupt file at filePath. If a
+// ".part" file from an earlier, interrupted attempt is still on disk, it's resumed with a
+// Range request instead of restarted from scratch - this is what lets a large video/PDF
+// survive a retry (or a later run) without redownloading bytes it already has. A server
+// that doesn't honor the Range header (no Accept-Ranges: bytes support) falls back
+// gracefully: it answers with a full 200 response, which is detected below and just
+// restarts the .part file from zero.
+//
+// ctx cancellation is observed while waiting on the rate limiter and for the duration of
+// the HTTP round trip; either one returning early because of it is just reported as a
+// failed attempt, same as a network error.
+//
+// declaredMimeType is the WordPress-reported MimeType for this download, if any, passed
+// through to finalizeDownload so MediaProbe can flag a mismatch against what's actually
+// in the file.
+//
+// baseDir is the directory filePath must resolve within (see validateFilePath) - d.mediaDir
+// for the usual shared-directory downloads, or a per-post bundle directory for
+// downloadMediaItemToDir.
+func (d *Downloader) downloadFileContext(ctx context.Context, url, filePath string, expectedSize int64, declaredMimeType, baseDir string) bool {
 	// Validate file path to prevent directory traversal
-	if err := d.validateFilePath(filePath); err != nil {
+	if err := d.validateFilePath(filePath, baseDir); err != nil {
 		if d.config.Verbose {
 			fmt.Printf("Invalid file path %s: %v\n", filePath, err)
 		}
 		return false
 	}
 
+	// Clean and validate file path before creation to prevent directory traversal
+	cleanFilePath := filepath.Clean(filePath)
+	partPath := cleanFilePath + ".part"
+
+	var resumeFrom int64
+	if info, err := os.Stat(partPath); err == nil {
+		resumeFrom = info.Size()
+	}
+
 	// Create request
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return false
 	}
 	req.Header.Set("User-Agent", d.config.UserAgent)
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	validators, cachedBody, cacheHit := d.httpCache.Lookup(url)
+	if cacheHit {
+		if validators.ETag != "" {
+			req.Header.Set("If-None-Match", validators.ETag)
+		}
+		if validators.LastModified != "" {
+			req.Header.Set("If-Modified-Since", validators.LastModified)
+		}
+	}
+	if d.manifest != nil {
+		if entry, ok := d.manifest.Get(url); ok {
+			if req.Header.Get("If-None-Match") == "" && entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if req.Header.Get("If-Modified-Since") == "" && entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
+	limiter := d.limiterFor(url)
+	if err := limiter.Wait(ctx); err != nil {
+		return false
+	}
 
 	// Make request
 	resp, err := d.httpClient.Do(req)
@@ -174,15 +703,56 @@ func (d *Downloader) downloadFile(url, filePath string) bool {
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		var retryAfter time.Duration
+		if d.config.RespectRetryAfter {
+			retryAfter = retryAfterDuration(resp.Header.Get("Retry-After"))
+		}
+		time.Sleep(limiter.Throttle(retryAfter))
 		return false
 	}
+	limiter.Recover()
 
-	// Clean and validate file path before creation to prevent directory traversal
-	cleanFilePath := filepath.Clean(filePath)
+	if resp.StatusCode == http.StatusNotModified {
+		if _, err := os.Stat(cleanFilePath); err == nil {
+			return true // unchanged since the last run; the existing file is still current
+		}
+		if cachedBody == nil {
+			return false // nothing cached to reconstruct the missing file from
+		}
+		if err := os.WriteFile(cleanFilePath, cachedBody, 0644); err != nil {
+			return false
+		}
+		sum := sha256.Sum256(cachedBody)
+		return d.finalizeDownload(url, cleanFilePath, int64(len(cachedBody)), hex.EncodeToString(sum[:]), nil, declaredMimeType)
+	}
+
+	switch {
+	case resumeFrom > 0 && resp.StatusCode == http.StatusPartialContent:
+		// Server honored the Range request; make sure it actually resumed from where we
+		// asked before trusting the .part file's existing bytes.
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", resumeFrom)) {
+			resumeFrom = 0
+		}
+	case resumeFrom > 0 && resp.StatusCode == http.StatusOK:
+		// No Accept-Ranges support: the server sent the full file from the start, so fall
+		// back to a plain restart rather than appending a second copy onto the .part file.
+		resumeFrom = 0
+	case resumeFrom > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The .part file already has everything the server can offer (e.g. a previous
+		// attempt finished writing but crashed before the rename); treat it as complete.
+		return d.finishDownload(url, cleanFilePath, partPath, resumeFrom, expectedSize, declaredMimeType)
+	case resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent:
+		return false
+	}
 
-	// Create file
-	file, err := os.Create(cleanFilePath)
+	flags := os.O_CREATE | os.O_WRONLY
+	if resumeFrom > 0 {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(partPath, flags, 0644)
 	if err != nil {
 		return false
 	}
@@ -190,13 +760,530 @@ func (d *Downloader) downloadFile(url, filePath string) bool {
 		_ = file.Close()
 	}()
 
-	// Copy data
-	_, err = io.Copy(file, resp.Body)
-	return err == nil
+	// Copy data, reporting bytes read against Content-Length when the server sent one, and
+	// streaming it through a SHA-256 hash so the manifest can dedup identical files (common
+	// with resized media variants) without a second read pass. When resuming, the bytes
+	// already on disk are hashed first so the final hash still covers the whole file.
+	hasher := sha256.New()
+	if resumeFrom > 0 {
+		if existing, err := os.Open(partPath); err == nil {
+			_, _ = io.CopyN(hasher, existing, resumeFrom)
+			_ = existing.Close()
+		}
+	}
+
+	var body io.Reader = resp.Body
+	if resp.ContentLength > 0 {
+		byteBar := d.progress.NewByteBar(filepath.Base(cleanFilePath), resumeFrom+resp.ContentLength)
+		byteBar.Add(int(resumeFrom))
+		body = progress.NewCountingReader(resp.Body, byteBar)
+		defer byteBar.Finish()
+	}
+
+	copied, err := io.Copy(io.MultiWriter(file, hasher), body)
+	if err != nil {
+		return false
+	}
+	_ = file.Close()
+
+	return d.commitDownload(url, cleanFilePath, partPath, resumeFrom+copied, expectedSize, hex.EncodeToString(hasher.Sum(nil)), resp, declaredMimeType)
 }
 
-// validateFilePath validates that the file path is safe and within the media directory
-func (d *Downloader) validateFilePath(filePath string) error {
+// finishDownload handles the 416 Range Not Satisfiable case: the .part file already holds
+// the complete download from an earlier attempt, so it's renamed into place without
+// re-fetching anything.
+func (d *Downloader) finishDownload(url, cleanFilePath, partPath string, size, expectedSize int64, declaredMimeType string) bool {
+	hasher := sha256.New()
+	existing, err := os.Open(partPath)
+	if err != nil {
+		return false
+	}
+	_, copyErr := io.Copy(hasher, existing)
+	_ = existing.Close()
+	if copyErr != nil {
+		return false
+	}
+	return d.commitDownload(url, cleanFilePath, partPath, size, expectedSize, hex.EncodeToString(hasher.Sum(nil)), nil, declaredMimeType)
+}
+
+// commitDownload renames a completed ".part" file into place once its size matches
+// expectedSize (the item's own MediaDetails.Filesize, when WordPress reported one) and
+// records its manifest entry. A mismatch is treated as a failed attempt: the ".part" file
+// is left on disk for the caller's next retry instead of being committed as a corrupt
+// download. resp is nil when the download was completed from an already-finished .part
+// file or an httpcache-reconstructed body (no fresh response to read validators from).
+func (d *Downloader) commitDownload(url, cleanFilePath, partPath string, size, expectedSize int64, hash string, resp *http.Response, declaredMimeType string) bool {
+	if expectedSize > 0 && size != expectedSize {
+		if d.config.Verbose {
+			fmt.Printf("downloaded size %d for %s does not match reported filesize %d\n", size, url, expectedSize)
+		}
+		return false
+	}
+
+	if err := os.Rename(partPath, cleanFilePath); err != nil {
+		return false
+	}
+
+	return d.finalizeDownload(url, cleanFilePath, size, hash, resp, declaredMimeType)
+}
+
+// finalizeDownload records a completed download's manifest entry, refreshes the HTTP
+// cache's copy of the URL so a later run can recognize it unchanged (or reconstruct it, if
+// the file itself goes missing) without a fresh fetch, and, when cfg.MediaStore.Backend
+// names a remote backend, mirrors the finished file to it. cleanFilePath must already
+// contain the final bytes described by size and hash. resp is nil when there's no fresh
+// response to read validators or a body from (see commitDownload).
+//
+// Under the default "legacy" cfg.MediaLayout, identical content is deduplicated by
+// hard-linking to an earlier download with the same hash (see deduplicate). Under
+// "content-addressed", cleanFilePath's bytes are instead relocated to a sha256/<aa>/<hash>
+// path (see storeContentAddressed), which gives the same deduplication for free and lets
+// UpdateMediaPaths link directly to content rather than to an arbitrary WP attachment ID.
+func (d *Downloader) finalizeDownload(url, cleanFilePath string, size int64, hash string, resp *http.Response, declaredMimeType string) bool {
+	cleanFilePath = d.sniffAndFixExtension(cleanFilePath)
+
+	if resp != nil {
+		if body, err := os.ReadFile(cleanFilePath); err == nil {
+			d.httpCache.Store(url, httpcache.Validators{
+				ETag:         resp.Header.Get("ETag"),
+				LastModified: resp.Header.Get("Last-Modified"),
+			}, body)
+		}
+	}
+
+	if d.manifest == nil {
+		return true
+	}
+
+	entry := MediaManifestEntry{
+		Hash: hash,
+		Size: size,
+	}
+	if resp != nil {
+		entry.ETag = resp.Header.Get("ETag")
+		entry.LastModified = resp.Header.Get("Last-Modified")
+		entry.ContentType = resp.Header.Get("Content-Type")
+	}
+
+	cleanFilePath = d.probeAndFinalize(cleanFilePath, declaredMimeType, &entry)
+
+	if d.config.MediaLayout == "content-addressed" {
+		entry.Path = d.storeContentAddressed(cleanFilePath, entry.Hash)
+	} else {
+		d.deduplicate(url, cleanFilePath, entry.Hash)
+		entry.Path = filepath.Base(cleanFilePath)
+	}
+
+	d.mirrorToStore(cleanFilePath, entry)
+
+	if err := d.manifest.Set(url, entry); err != nil && d.config.Verbose {
+		fmt.Printf("failed to persist media manifest entry for %s: %v\n", url, err)
+	}
+
+	return true
+}
+
+// probeAndFinalize runs MediaProbe (see probeFile) against cleanFilePath and records what
+// it finds on entry. A probe failure - a corrupt image, or a video/audio file ffprobe
+// itself rejects - substitutes cleanFilePath with a category placeholder (see
+// writeProbeFailurePlaceholder) and marks entry.Placeholder, the same signal
+// downloadPlaceholder uses for a permanently failed download; entry.Hash and entry.Size are
+// recomputed for the substituted file since the manifest must describe what's actually on
+// disk. A successfully probed image is additionally handed to d.transcoder, when
+// configured, to populate entry.BlurHash. Returns the (possibly substituted) file's path.
+func (d *Downloader) probeAndFinalize(cleanFilePath, declaredMimeType string, entry *MediaManifestEntry) string {
+	result, ok := probeFile(cleanFilePath, declaredMimeType)
+	entry.MimeMismatch = result.MimeMismatch
+
+	if !ok {
+		placeholderPath, err := d.writeProbeFailurePlaceholder(cleanFilePath, placeholderKind(result.Category))
+		if err != nil {
+			if d.config.Verbose {
+				fmt.Printf("failed to write probe-failure placeholder for %s: %v\n", cleanFilePath, err)
+			}
+			return cleanFilePath
+		}
+
+		entry.Placeholder = true
+		if body, err := os.ReadFile(placeholderPath); err == nil {
+			sum := sha256.Sum256(body)
+			entry.Hash = hex.EncodeToString(sum[:])
+			entry.Size = int64(len(body))
+		}
+		return placeholderPath
+	}
+
+	entry.Width = result.Width
+	entry.Height = result.Height
+	entry.Duration = result.Duration
+	entry.Codec = result.Codec
+
+	if result.Category == "image" && d.transcoder != nil {
+		if transcoded, err := d.transcoder.Transcode(context.Background(), cleanFilePath); err == nil {
+			entry.BlurHash = transcoded.BlurHash
+		} else if d.config.Verbose {
+			fmt.Printf("failed to transcode %s: %v\n", cleanFilePath, err)
+		}
+	}
+
+	return cleanFilePath
+}
+
+// casRelPath returns filename's content-addressed path relative to the media directory:
+// sha256/<first two hex characters of hash>/<hash>/<filename>.
+func casRelPath(hash, filename string) string {
+	return filepath.Join("sha256", hash[:2], hash, filename)
+}
+
+// mirrorToStore uploads cleanFilePath's content to d.store under entry.Path, the same key
+// UpdateMediaPaths/MediaStore.URLFor will later reference. It's a no-op for the default
+// *LocalStore, which already owns cleanFilePath on disk - only a remote backend
+// (S3Store/WebDAVStore) needs its own copy pushed up. A failed mirror is logged (when
+// verbose) rather than failing the download: the file is still safely on local disk. The
+// upload is not retried by a later run while the source is unchanged: a 304 revalidation
+// keeps the existing local file and returns before finalizeDownload, so this never runs.
+func (d *Downloader) mirrorToStore(cleanFilePath string, entry MediaManifestEntry) {
+	if _, ok := d.store.(*LocalStore); ok || d.store == nil {
+		return
+	}
+
+	f, err := os.Open(cleanFilePath)
+	if err != nil {
+		if d.config.Verbose {
+			fmt.Printf("failed to open %s for media store upload: %v\n", cleanFilePath, err)
+		}
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	meta := StoreMeta{ContentType: entry.ContentType}
+	if err := d.store.Put(context.Background(), entry.Path, f, meta); err != nil && d.config.Verbose {
+		fmt.Printf("failed to upload %s to media store: %v\n", entry.Path, err)
+	}
+}
+
+// storeContentAddressed relocates cleanFilePath's just-written bytes to their
+// content-addressed path under d.mediaDir (see casRelPath), hard-linking the legacy
+// `{id}_{name}` path back to it so links written before MediaLayout was switched to
+// "content-addressed" - or any other tooling expecting that path - still resolve. If
+// another media item already downloaded identical content, the existing content-addressed
+// file is reused and cleanFilePath becomes a second hard link to it instead of a duplicate
+// copy. Returns the content-addressed path, relative to d.mediaDir, to record in the
+// manifest - falling back to cleanFilePath's own legacy name on any filesystem error, so a
+// failure here never loses the download.
+func (d *Downloader) storeContentAddressed(cleanFilePath, hash string) string {
+	legacyName := filepath.Base(cleanFilePath)
+	relPath := casRelPath(hash, legacyName)
+	casFilePath := filepath.Join(d.mediaDir, relPath)
+
+	if _, err := os.Stat(casFilePath); err != nil {
+		// Identical content stored earlier under another item's name is reused, not copied.
+		if entries, readErr := os.ReadDir(filepath.Dir(casFilePath)); readErr == nil && len(entries) > 0 {
+			relPath = casRelPath(hash, entries[0].Name())
+			casFilePath = filepath.Join(d.mediaDir, relPath)
+		}
+	}
+
+	if _, err := os.Stat(casFilePath); err == nil {
+		if err := os.Remove(cleanFilePath); err != nil {
+			return legacyName
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(casFilePath), 0750); err != nil {
+			return legacyName
+		}
+		if err := os.Rename(cleanFilePath, casFilePath); err != nil {
+			return legacyName
+		}
+	}
+
+	if err := os.Link(casFilePath, cleanFilePath); err != nil {
+		// Cross-device or otherwise unsupported: fall back to a plain copy so the legacy
+		// path still ends up with the right content.
+		if d.config.Verbose {
+			fmt.Printf("failed to hard-link legacy media path %s -> %s: %v\n", cleanFilePath, casFilePath, err)
+		}
+		if src, openErr := os.Open(casFilePath); openErr == nil {
+			defer func() { _ = src.Close() }()
+			if dst, createErr := os.Create(cleanFilePath); createErr == nil {
+				defer func() { _ = dst.Close() }()
+				_, _ = io.Copy(dst, src)
+			}
+		}
+	}
+
+	return relPath
+}
+
+// sniffAndFixExtension corrects filePath's extension when getExtensionFromMimeType had
+// fallen back to ".bin" for an unknown MIME type, by sniffing the downloaded bytes
+// themselves via http.DetectContentType. Returns filePath unchanged if its extension
+// isn't ".bin", or if sniffing doesn't turn up anything more specific than ".bin" too.
+func (d *Downloader) sniffAndFixExtension(filePath string) string {
+	if filepath.Ext(filePath) != ".bin" {
+		return filePath
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return filePath
+	}
+	buf := make([]byte, 512)
+	n, _ := file.Read(buf)
+	_ = file.Close()
+
+	sniffed := http.DetectContentType(buf[:n])
+	if semi := strings.Index(sniffed, ";"); semi != -1 {
+		sniffed = sniffed[:semi]
+	}
+
+	ext := d.getExtensionFromMimeType(sniffed)
+	if ext == ".bin" {
+		return filePath
+	}
+
+	corrected := strings.TrimSuffix(filePath, ".bin") + ext
+	if err := os.Rename(filePath, corrected); err != nil {
+		return filePath
+	}
+	return corrected
+}
+
+// downloadPlaceholder writes a small generated SVG standing in for media whose source
+// couldn't be downloaded after every retry in downloadMediaItem, sized to the item's own
+// MediaDetails.Width/Height (or placeholderWidth/placeholderHeight when unknown) with its
+// original filename overlaid, so exported content still references a renderable image
+// instead of a dead link. The substitution is recorded in the manifest (MediaManifestEntry.
+// Placeholder) so downstream tooling - including UpdateMediaPaths, see isPlaceholder - can
+// tell it apart from a real downloaded asset.
+func (d *Downloader) downloadPlaceholder(url string, media models.WordPressMedia, filePath string) bool {
+	placeholderPath := strings.TrimSuffix(filePath, filepath.Ext(filePath)) + ".svg"
+
+	if err := d.writePlaceholderSVG(placeholderPath, media); err != nil {
+		if d.config.Verbose {
+			fmt.Printf("failed to generate placeholder for %s: %v\n", url, err)
+		}
+		return false
+	}
+
+	if d.manifest != nil {
+		entry := MediaManifestEntry{
+			Path:        filepath.Base(placeholderPath),
+			Placeholder: true,
+		}
+		if err := d.manifest.Set(url, entry); err != nil && d.config.Verbose {
+			fmt.Printf("failed to persist media manifest entry for %s: %v\n", url, err)
+		}
+	}
+
+	return true
+}
+
+// writePlaceholderSVG renders a flat-colored SVG of media's known (or default) pixel
+// dimensions with its original filename overlaid as text, and writes it to path.
+func (d *Downloader) writePlaceholderSVG(path string, media models.WordPressMedia) error {
+	width := dimensionOrDefault(media.MediaDetails.Width, placeholderWidth)
+	height := dimensionOrDefault(media.MediaDetails.Height, placeholderHeight)
+
+	name := filepath.Base(path)
+	if original := filepath.Base(media.SourceURL); original != "" && original != "." && original != "/" {
+		name = original
+	}
+	var label bytes.Buffer
+	if err := xml.EscapeText(&label, []byte(name)); err != nil {
+		return err
+	}
+
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">
+  <rect width="100%%" height="100%%" fill="#d9d9d9"/>
+  <text x="50%%" y="50%%" dominant-baseline="middle" text-anchor="middle" font-family="sans-serif" font-size="14" fill="#555555">%s</text>
+</svg>
+`, width, height, width, height, label.String())
+
+	return os.WriteFile(path, []byte(svg), 0644)
+}
+
+// writeProbeFailurePlaceholder substitutes cleanFilePath - a download MediaProbe (see
+// probeFile) rejected - with either the cfg.PlaceholderAssets file configured for kind, or
+// a generated SVG sized like downloadPlaceholder's fallback, and removes the rejected file.
+// Returns the replacement's path.
+func (d *Downloader) writeProbeFailurePlaceholder(cleanFilePath, kind string) (string, error) {
+	stem := strings.TrimSuffix(cleanFilePath, filepath.Ext(cleanFilePath))
+
+	var dest string
+	if asset := d.placeholderAsset(kind); asset != "" {
+		dest = stem + filepath.Ext(asset)
+		if err := copyFile(asset, dest); err != nil {
+			return "", fmt.Errorf("copy placeholder asset %s: %w", asset, err)
+		}
+	} else {
+		dest = stem + ".svg"
+		svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">
+  <rect width="100%%" height="100%%" fill="#d9d9d9"/>
+  <text x="50%%" y="50%%" dominant-baseline="middle" text-anchor="middle" font-family="sans-serif" font-size="14" fill="#555555">%s</text>
+</svg>
+`, placeholderWidth, placeholderHeight, placeholderWidth, placeholderHeight, kind)
+		if err := os.WriteFile(dest, []byte(svg), 0644); err != nil {
+			return "", err
+		}
+	}
+
+	if dest != cleanFilePath {
+		_ = os.Remove(cleanFilePath)
+	}
+	return dest, nil
+}
+
+// placeholderAsset returns the cfg.PlaceholderAssets file configured for kind ("audio",
+// "video", or "unknown"), or "" if none is configured.
+func (d *Downloader) placeholderAsset(kind string) string {
+	switch kind {
+	case "audio":
+		return d.config.PlaceholderAssets.Audio
+	case "video":
+		return d.config.PlaceholderAssets.Video
+	default:
+		return d.config.PlaceholderAssets.Unknown
+	}
+}
+
+// copyFile copies src's contents to dst, overwriting it if already present.
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
+
+// placeholderKind maps a ProbeResult.Category to the PlaceholderAssetsConfig field
+// writeProbeFailurePlaceholder substitutes, defaulting anything else (including an empty
+// category) to "unknown".
+func placeholderKind(category string) string {
+	switch category {
+	case "audio", "video":
+		return category
+	default:
+		return "unknown"
+	}
+}
+
+// dimensionOrDefault converts a WordPress media dimension (decoded from JSON as a
+// float64, occasionally a string) to a positive int, falling back to def when it's
+// missing, zero, or unparsable.
+func dimensionOrDefault(v interface{}, def int) int {
+	switch n := v.(type) {
+	case float64:
+		if n > 0 {
+			return int(n)
+		}
+	case int:
+		if n > 0 {
+			return n
+		}
+	case string:
+		if parsed, err := strconv.Atoi(n); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return def
+}
+
+// expectedFilesize converts a WordPress media item's reported filesize (decoded from JSON
+// as a float64, occasionally a string) to a positive int64, or zero if it's missing, zero,
+// or unparsable - in which case commitDownload skips the final size check entirely.
+func expectedFilesize(v interface{}) int64 {
+	switch n := v.(type) {
+	case float64:
+		if n > 0 {
+			return int64(n)
+		}
+	case int64:
+		if n > 0 {
+			return n
+		}
+	case int:
+		if n > 0 {
+			return int64(n)
+		}
+	case string:
+		if parsed, err := strconv.ParseInt(n, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return 0
+}
+
+// retryAfterDuration parses a Retry-After response header in either of its two valid
+// forms (an integer number of seconds, or an HTTP-date), returning zero if v is empty or
+// doesn't parse as either. Mirrors internal/api's unexported parseRetryAfter.
+func retryAfterDuration(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// isPlaceholder reports whether sourceURL's manifest entry is a generated placeholder
+// (see downloadPlaceholder) rather than the real downloaded asset.
+func (d *Downloader) isPlaceholder(sourceURL string) bool {
+	if d.manifest == nil {
+		return false
+	}
+	entry, ok := d.manifest.Get(sourceURL)
+	return ok && entry.Placeholder
+}
+
+// deduplicate replaces the just-downloaded file at filePath with a hard link to an earlier
+// download sharing the same hash, if one still exists on disk, so multiple WP media IDs (or
+// resized variants) pointing at identical bytes only cost disk space once. Best-effort: if no
+// duplicate is found, or the hard link fails (e.g. across filesystems), the freshly
+// downloaded file is left in place.
+func (d *Downloader) deduplicate(sourceURL, filePath, hash string) {
+	existingName, ok := d.manifest.PathForHash(hash, sourceURL)
+	if !ok {
+		return
+	}
+
+	existingPath := filepath.Join(d.mediaDir, existingName)
+	if _, err := os.Stat(existingPath); err != nil {
+		return
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		return
+	}
+	if err := os.Link(existingPath, filePath); err != nil {
+		// Cross-device or otherwise unsupported: fall back to a plain copy so filePath
+		// still ends up with the right content.
+		if d.config.Verbose {
+			fmt.Printf("failed to hard-link duplicate media %s -> %s: %v\n", filePath, existingPath, err)
+		}
+		if src, openErr := os.Open(existingPath); openErr == nil {
+			defer func() { _ = src.Close() }()
+			if dst, createErr := os.Create(filePath); createErr == nil {
+				defer func() { _ = dst.Close() }()
+				_, _ = io.Copy(dst, src)
+			}
+		}
+	}
+}
+
+// validateFilePath validates that filePath is safe and within baseDir. Callers outside
+// this package's usual shared mediaDir - e.g. downloadMediaItemToDir's per-post bundle
+// directories - pass their own baseDir rather than d.mediaDir.
+func (d *Downloader) validateFilePath(filePath, baseDir string) error {
 	// Clean the path to resolve any .. or . components
 	cleanPath := filepath.Clean(filePath)
 
@@ -206,21 +1293,21 @@ func (d *Downloader) validateFilePath(filePath string) error {
 		return fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	// Get absolute media directory path
-	absMediaDir, err := filepath.Abs(d.mediaDir)
+	// Get absolute base directory path
+	absBaseDir, err := filepath.Abs(baseDir)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute media directory: %w", err)
+		return fmt.Errorf("failed to get absolute base directory: %w", err)
 	}
 
-	// Check if the file path is within the media directory
-	relPath, err := filepath.Rel(absMediaDir, absPath)
+	// Check if the file path is within the base directory
+	relPath, err := filepath.Rel(absBaseDir, absPath)
 	if err != nil {
 		return fmt.Errorf("failed to get relative path: %w", err)
 	}
 
-	// Ensure the relative path doesn't start with .. (which would indicate it's outside the media dir)
+	// Ensure the relative path doesn't start with .. (which would indicate it's outside the base dir)
 	if strings.HasPrefix(relPath, "..") || strings.HasPrefix(relPath, "/") {
-		return fmt.Errorf("file path is outside media directory")
+		return fmt.Errorf("file path is outside base directory")
 	}
 
 	return nil
@@ -300,7 +1387,10 @@ func (d *Downloader) getExtensionFromMimeType(mimeType string) string {
 	return ".bin" // Default extension
 }
 
-// UpdateMediaPaths updates media URLs in content to point to local files
+// UpdateMediaPaths updates media URLs in content to point to local files. When the manifest
+// (see Manifest) has an entry for a URL, its recorded on-disk path is used instead of
+// re-deriving a filename, so the rewritten link matches the actual file - including one
+// that deduplicate hard-linked under a different name than generateFilename would produce.
 func (d *Downloader) UpdateMediaPaths(content string, mediaItems []models.WordPressMedia) string {
 	if !d.config.DownloadMedia {
 		return content
@@ -319,25 +1409,148 @@ func (d *Downloader) UpdateMediaPaths(content string, mediaItems []models.WordPr
 			continue
 		}
 
-		filename := d.generateFilename(media, parsedURL)
-		localPath := filepath.Join("media", filename)
+		filename := d.resolveFilename(media.SourceURL, media, parsedURL)
+		localPath := d.store.URLFor(filename)
+		if d.isPlaceholder(media.SourceURL) {
+			// Flag the substitution so downstream tooling can tell this apart from the
+			// real asset without a second manifest lookup.
+			localPath += "?placeholder=1"
+		}
 
-		// Replace absolute URLs with relative paths
+		// Replace absolute URLs with the store's URL for the downloaded file - a
+		// relative "media/..." path for the default LocalStore, or an absolute
+		// CDN/bucket URL when cfg.MediaStore.Backend names a remote one.
 		updated = strings.ReplaceAll(updated, media.SourceURL, localPath)
 
 		// Also check for different size variants
-		if media.MediaDetails.Sizes != nil {
-			for _, size := range media.MediaDetails.Sizes {
-				if size.SourceURL != "" {
-					sizeFilename := d.generateSizeFilename(media, size, parsedURL)
-					sizePath := filepath.Join("media", sizeFilename)
-					updated = strings.ReplaceAll(updated, size.SourceURL, sizePath)
-				}
+		for name, size := range media.MediaDetails.Sizes {
+			if size.SourceURL == "" || !d.sizeWhitelisted(name) {
+				continue
+			}
+			sizeFilename := d.resolveSizeFilename(size.SourceURL, media, size, parsedURL)
+			sizePath := d.store.URLFor(sizeFilename)
+			if d.isPlaceholder(size.SourceURL) {
+				sizePath += "?placeholder=1"
 			}
+			updated = strings.ReplaceAll(updated, size.SourceURL, sizePath)
+		}
+	}
+
+	return d.rewriteResponsiveImgTags(updated, mediaItems)
+}
+
+var (
+	wpImageTagPattern   = regexp.MustCompile(`<img[^>]*>`)
+	wpImageClassPattern = regexp.MustCompile(`wp-image-(\d+)`)
+	srcsetAttrPattern   = regexp.MustCompile(`\s*srcset="[^"]*"`)
+	sizesAttrPattern    = regexp.MustCompile(`\s*sizes="[^"]*"`)
+)
+
+// rewriteResponsiveImgTags finds <img> tags carrying WordPress's own wp-image-NNN class
+// marker and replaces any srcset/sizes attributes with ones built from that media item's
+// downloaded MediaDetails.Sizes variants (see buildSrcset). WordPress's original
+// srcset/sizes still point at the source host - the plain SourceURL substitution above only
+// rewrites strings byte-for-byte, so without this pass a responsive <img> would keep
+// offering the browser dead upstream URLs alongside the one rewritten src.
+func (d *Downloader) rewriteResponsiveImgTags(content string, mediaItems []models.WordPressMedia) string {
+	mediaByID := make(map[int]models.WordPressMedia, len(mediaItems))
+	for _, m := range mediaItems {
+		mediaByID[m.ID] = m
+	}
+
+	return wpImageTagPattern.ReplaceAllStringFunc(content, func(tag string) string {
+		idMatch := wpImageClassPattern.FindStringSubmatch(tag)
+		if idMatch == nil {
+			return tag
+		}
+		id, err := strconv.Atoi(idMatch[1])
+		if err != nil {
+			return tag
+		}
+		media, ok := mediaByID[id]
+		if !ok {
+			return tag
+		}
+
+		srcset := d.buildSrcset(media)
+		if srcset == "" {
+			return tag
+		}
+
+		tag = srcsetAttrPattern.ReplaceAllString(tag, "")
+		tag = sizesAttrPattern.ReplaceAllString(tag, "")
+
+		width := dimensionOrDefault(media.MediaDetails.Width, 0)
+		sizesAttr := "100vw"
+		if width > 0 {
+			sizesAttr = fmt.Sprintf("(max-width: %dpx) 100vw, %dpx", width, width)
 		}
+
+		return strings.Replace(tag, "<img", fmt.Sprintf(`<img srcset="%s" sizes="%s"`, srcset, sizesAttr), 1)
+	})
+}
+
+// buildSrcset returns a srcset attribute value listing media's downloaded size variants
+// (widest last, the usual srcset convention), skipping any size whose width is unknown or
+// that cfg.MediaSizes doesn't whitelist.
+func (d *Downloader) buildSrcset(media models.WordPressMedia) string {
+	if len(media.MediaDetails.Sizes) == 0 {
+		return ""
+	}
+
+	originalURL, err := url.Parse(media.SourceURL)
+	if err != nil {
+		originalURL = &url.URL{}
 	}
 
-	return updated
+	type candidate struct {
+		url   string
+		width int
+	}
+	var candidates []candidate
+	for name, size := range media.MediaDetails.Sizes {
+		if size.SourceURL == "" || !d.sizeWhitelisted(name) {
+			continue
+		}
+		width := dimensionOrDefault(size.Width, 0)
+		if width <= 0 {
+			continue
+		}
+		filename := d.resolveSizeFilename(size.SourceURL, media, size, originalURL)
+		candidates = append(candidates, candidate{url: d.store.URLFor(filename), width: width})
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].width < candidates[j].width })
+
+	parts := make([]string, len(candidates))
+	for i, c := range candidates {
+		parts[i] = fmt.Sprintf("%s %dw", c.url, c.width)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resolveFilename returns the manifest's recorded on-disk path for sourceURL, falling back
+// to generateFilename when there's no manifest entry yet (e.g. DownloadMedia never ran).
+func (d *Downloader) resolveFilename(sourceURL string, media models.WordPressMedia, parsedURL *url.URL) string {
+	if d.manifest != nil {
+		if entry, ok := d.manifest.Get(sourceURL); ok && entry.Path != "" {
+			return entry.Path
+		}
+	}
+	return d.generateFilename(media, parsedURL)
+}
+
+// resolveSizeFilename is resolveFilename's counterpart for media size variants.
+func (d *Downloader) resolveSizeFilename(sourceURL string, media models.WordPressMedia, size models.MediaSize, originalURL *url.URL) string {
+	if d.manifest != nil {
+		if entry, ok := d.manifest.Get(sourceURL); ok && entry.Path != "" {
+			return entry.Path
+		}
+	}
+	return d.generateSizeFilename(media, size, originalURL)
 }
 
 // generateSizeFilename generates filename for media size variants
@@ -1,15 +1,26 @@
 package media
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/png"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/tradik/wpexporter/internal/api"
 	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/internal/httpcache"
 	"github.com/tradik/wpexporter/pkg/models"
 )
 
@@ -269,6 +280,896 @@ func TestDownloadMediaFileExists(t *testing.T) {
 	}
 }
 
+func TestDownloadMediaRevalidatesWhenHTTPCacheHasEntry(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	if err := os.MkdirAll(mediaDir, 0750); err != nil {
+		t.Fatalf("Failed to create media directory: %v", err)
+	}
+
+	existingFile := filepath.Join(mediaDir, "1_image.jpg")
+	if err := os.WriteFile(existingFile, []byte("stale data"), 0644); err != nil {
+		t.Fatalf("Failed to create existing file: %v", err)
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != `"v0"` {
+			t.Errorf("If-None-Match = %q, want the cached ETag", ifNoneMatch)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("fresh data"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.jpg", MimeType: "image/jpeg"},
+	}
+
+	// Seed an httpcache entry for the URL, simulating a real download that happened on an
+	// earlier run, so this run revalidates instead of trusting the manually-staged stale
+	// file forever.
+	downloader.httpCache.Store(mediaItems[0].SourceURL, httpcache.Validators{ETag: `"v0"`}, []byte("stale data"))
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 1 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 1", downloaded)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 HTTP request, got %d", requests)
+	}
+
+	data, err := os.ReadFile(existingFile)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "fresh data" {
+		t.Errorf("file content = %q, want %q (stale file should be revalidated and replaced)", data, "fresh data")
+	}
+}
+
+func TestDownloadMediaReconstructsMissingFileFromHTTPCache(t *testing.T) {
+	tempDir := t.TempDir()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != `"v1"` {
+			t.Errorf("If-None-Match = %q, want the cached ETag", ifNoneMatch)
+		}
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.jpg", MimeType: "image/jpeg"},
+	}
+
+	// The media directory (and any file in it) is missing, as if a previous run's
+	// --no-files cleanup removed it, but the HTTP cache - stored separately under
+	// cfg.HTTPCacheDir - still has the body from that earlier download.
+	downloader.httpCache.Store(mediaItems[0].SourceURL, httpcache.Validators{ETag: `"v1"`}, []byte("cached bytes"))
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 1 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 1", downloaded)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 HTTP request, got %d", requests)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cfg.GetMediaDir(), "1_image.jpg"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "cached bytes" {
+		t.Errorf("file content = %q, want %q (reconstructed from the HTTP cache)", data, "cached bytes")
+	}
+}
+
+func TestDownloadMediaRecordsManifestHash(t *testing.T) {
+	tempDir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("same bytes"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.jpg", MimeType: "image/jpeg"},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	entry, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for the downloaded URL")
+	}
+	wantHash := sha256.Sum256([]byte("same bytes"))
+	if entry.Hash != hex.EncodeToString(wantHash[:]) {
+		t.Errorf("entry.Hash = %q, want %q", entry.Hash, hex.EncodeToString(wantHash[:]))
+	}
+	if entry.ETag != `"v1"` {
+		t.Errorf("entry.ETag = %q, want %q", entry.ETag, `"v1"`)
+	}
+	if entry.Size != int64(len("same bytes")) {
+		t.Errorf("entry.Size = %d, want %d", entry.Size, len("same bytes"))
+	}
+}
+
+func TestDownloadMediaDeduplicatesIdenticalContent(t *testing.T) {
+	tempDir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("duplicate bytes"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image1.jpg", MimeType: "image/jpeg"},
+		{ID: 2, SourceURL: server.URL + "/image2.jpg", MimeType: "image/jpeg"},
+	}
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 2 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 2", downloaded)
+	}
+
+	mediaDir := cfg.GetMediaDir()
+
+	entry1, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for image1")
+	}
+	entry2, ok := downloader.Manifest().Get(mediaItems[1].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for image2")
+	}
+	if entry1.Hash != entry2.Hash {
+		t.Fatalf("entries should share a hash for identical content, got %q and %q", entry1.Hash, entry2.Hash)
+	}
+
+	info1, err := os.Stat(filepath.Join(mediaDir, entry1.Path))
+	if err != nil {
+		t.Fatalf("Stat() first file error = %v", err)
+	}
+	info2, err := os.Stat(filepath.Join(mediaDir, entry2.Path))
+	if err != nil {
+		t.Fatalf("Stat() second file error = %v", err)
+	}
+	if !os.SameFile(info1, info2) {
+		t.Error("the second download's file should be hard-linked to the first's, sharing the same content")
+	}
+}
+
+func TestDownloadMediaContentAddressedLayout(t *testing.T) {
+	tempDir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("cas bytes"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+		MediaLayout:   "content-addressed",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.jpg", MimeType: "image/jpeg"},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	entry, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for the downloaded URL")
+	}
+	wantHash := sha256.Sum256([]byte("cas bytes"))
+	wantHashHex := hex.EncodeToString(wantHash[:])
+	wantPath := filepath.Join("sha256", wantHashHex[:2], wantHashHex, filepath.Base(entry.Path))
+	if entry.Path != wantPath {
+		t.Errorf("entry.Path = %q, want %q", entry.Path, wantPath)
+	}
+	if entry.ContentType != "image/jpeg" {
+		t.Errorf("entry.ContentType = %q, want %q", entry.ContentType, "image/jpeg")
+	}
+
+	mediaDir := cfg.GetMediaDir()
+	if _, err := os.Stat(filepath.Join(mediaDir, entry.Path)); err != nil {
+		t.Fatalf("Stat() content-addressed path error = %v", err)
+	}
+
+	legacyPath := filepath.Join(mediaDir, filepath.Base(entry.Path))
+	legacyInfo, err := os.Stat(legacyPath)
+	if err != nil {
+		t.Fatalf("Stat() legacy path error = %v", err)
+	}
+	casInfo, err := os.Stat(filepath.Join(mediaDir, entry.Path))
+	if err != nil {
+		t.Fatalf("Stat() content-addressed path error = %v", err)
+	}
+	if !os.SameFile(legacyInfo, casInfo) {
+		t.Error("the legacy path should be hard-linked to the content-addressed path")
+	}
+}
+
+func TestDownloadMediaContentAddressedDeduplicatesAcrossIDs(t *testing.T) {
+	tempDir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("shared cas bytes"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+		MediaLayout:   "content-addressed",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image1.jpg", MimeType: "image/jpeg"},
+		{ID: 2, SourceURL: server.URL + "/image2.jpg", MimeType: "image/jpeg"},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	entry1, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for image1")
+	}
+	entry2, ok := downloader.Manifest().Get(mediaItems[1].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for image2")
+	}
+
+	casDir1 := filepath.Dir(entry1.Path)
+	casDir2 := filepath.Dir(entry2.Path)
+	if casDir1 != casDir2 {
+		t.Errorf("both downloads should land under the same content-addressed directory, got %q and %q", casDir1, casDir2)
+	}
+}
+
+func TestDownloadMediaResumesPartialDownload(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	if err := os.MkdirAll(mediaDir, 0750); err != nil {
+		t.Fatalf("Failed to create media directory: %v", err)
+	}
+
+	const full = "Hello, World!"
+	const already = "Hello, "
+	if err := os.WriteFile(filepath.Join(mediaDir, "1_image.jpg.part"), []byte(already), 0644); err != nil {
+		t.Fatalf("Failed to create .part file: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rangeHeader := r.Header.Get("Range")
+		if rangeHeader != fmt.Sprintf("bytes=%d-", len(already)) {
+			t.Errorf("Range header = %q, want %q", rangeHeader, fmt.Sprintf("bytes=%d-", len(already)))
+		}
+		rest := full[len(already):]
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", len(already), len(full)-1, len(full)))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte(rest))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.jpg", MimeType: "image/jpeg"},
+	}
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 1 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 1", downloaded)
+	}
+
+	finalPath := filepath.Join(mediaDir, "1_image.jpg")
+	data, err := os.ReadFile(finalPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != full {
+		t.Errorf("file content = %q, want %q (resumed download should append, not overwrite)", data, full)
+	}
+	if _, err := os.Stat(finalPath + ".part"); !os.IsNotExist(err) {
+		t.Errorf(".part file should be removed once the download completes, stat err = %v", err)
+	}
+}
+
+func TestDownloadMediaFallsBackWhenRangeNotSupported(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	if err := os.MkdirAll(mediaDir, 0750); err != nil {
+		t.Fatalf("Failed to create media directory: %v", err)
+	}
+
+	const full = "complete file contents"
+	if err := os.WriteFile(filepath.Join(mediaDir, "1_image.jpg.part"), []byte("stale partial"), 0644); err != nil {
+		t.Fatalf("Failed to create .part file: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Ignores Range entirely, as a server without Accept-Ranges support would.
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(full))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.jpg", MimeType: "image/jpeg"},
+	}
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 1 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 1", downloaded)
+	}
+
+	data, err := os.ReadFile(filepath.Join(mediaDir, "1_image.jpg"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != full {
+		t.Errorf("file content = %q, want %q (should fall back to a full restart)", data, full)
+	}
+}
+
+func TestDownloadMediaWritesPlaceholderOnPermanentFailure(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{
+			ID:           1,
+			SourceURL:    server.URL + "/missing.jpg",
+			MimeType:     "image/jpeg",
+			MediaDetails: models.MediaDetails{Width: float64(320), Height: float64(240)},
+		},
+	}
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 1 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 1 (a placeholder still counts as handled)", downloaded)
+	}
+
+	data, err := os.ReadFile(filepath.Join(mediaDir, "1_missing.svg"))
+	if err != nil {
+		t.Fatalf("ReadFile() placeholder error = %v", err)
+	}
+	if !strings.Contains(string(data), `width="320"`) || !strings.Contains(string(data), `height="240"`) {
+		t.Errorf("placeholder SVG = %s, want it sized 320x240", data)
+	}
+	if !strings.Contains(string(data), "missing.jpg") {
+		t.Errorf("placeholder SVG = %s, want the original filename overlaid", data)
+	}
+
+	entry, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for the placeholder")
+	}
+	if !entry.Placeholder {
+		t.Error("entry.Placeholder should be true for a generated placeholder")
+	}
+
+	content := downloader.UpdateMediaPaths(`<img src="`+mediaItems[0].SourceURL+`">`, mediaItems)
+	if !strings.Contains(content, "1_missing.svg?placeholder=1") {
+		t.Errorf("UpdateMediaPaths() = %s, want the placeholder marker in the rewritten path", content)
+	}
+}
+
+func TestDownloadMediaSniffsExtensionForUnknownMimeType(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	var pngBytes bytes.Buffer
+	if err := png.Encode(&pngBytes, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(pngBytes.Bytes())
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		// No filename in the URL and an unrecognized MIME type: generateFilename falls
+		// back to "media_<id>.bin".
+		{ID: 1, SourceURL: server.URL + "/", MimeType: "application/octet-stream"},
+	}
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 1 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 1", downloaded)
+	}
+
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_media_1.png")); err != nil {
+		t.Errorf("expected the sniffed content type to rename the file to .png: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_media_1.bin")); !os.IsNotExist(err) {
+		t.Errorf("the original .bin-extension file should no longer exist, stat err = %v", err)
+	}
+}
+
+func TestDownloadMediaRecordsProbeMetadataForImage(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var pngBytes bytes.Buffer
+	if err := png.Encode(&pngBytes, image.NewRGBA(image.Rect(0, 0, 10, 6))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(pngBytes.Bytes())
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.png", MimeType: "image/png"},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	entry, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for the downloaded URL")
+	}
+	if entry.Width != 10 || entry.Height != 6 {
+		t.Errorf("entry dimensions = %dx%d, want 10x6", entry.Width, entry.Height)
+	}
+	if entry.Placeholder {
+		t.Error("entry.Placeholder should be false for a valid image that passes MediaProbe")
+	}
+	if entry.MimeMismatch {
+		t.Error("entry.MimeMismatch should be false when the declared and sniffed types agree")
+	}
+}
+
+func TestDownloadMediaSwapsPlaceholderOnProbeFailure(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A real JPEG signature followed by garbage: sniffs as "image/jpeg" but isn't a
+		// decodable image, so MediaProbe should reject it.
+		_, _ = w.Write([]byte("\xff\xd8\xff\xe0" + strings.Repeat("x", 64)))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    1,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/broken.jpg", MimeType: "image/jpeg"},
+	}
+
+	downloaded, err := downloader.DownloadMedia(mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+	if downloaded != 1 {
+		t.Fatalf("DownloadMedia() downloaded = %d, want 1 (a placeholder still counts as handled)", downloaded)
+	}
+
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_broken.svg")); err != nil {
+		t.Errorf("expected MediaProbe's rejection to substitute a generated SVG placeholder: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_broken.jpg")); !os.IsNotExist(err) {
+		t.Errorf("the rejected download should no longer exist, stat err = %v", err)
+	}
+
+	entry, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for the placeholder")
+	}
+	if !entry.Placeholder {
+		t.Error("entry.Placeholder should be true when MediaProbe rejects the download")
+	}
+}
+
+func TestDownloadMediaUsesConfiguredPlaceholderAssetOnProbeFailure(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	assetPath := filepath.Join(tempDir, "fallback.png")
+	if err := os.WriteFile(assetPath, []byte("not a real png but that's fine, it's just copied"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("\xff\xd8\xff\xe0" + strings.Repeat("x", 64)))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia:     true,
+		Timeout:           10,
+		Concurrent:        1,
+		Retries:           0,
+		UserAgent:         "test-agent",
+		Output:            filepath.Join(tempDir, "output.json"),
+		Format:            "json",
+		PlaceholderAssets: config.PlaceholderAssetsConfig{Unknown: assetPath},
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/broken.jpg", MimeType: "image/jpeg"},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(mediaDir, "1_broken.png"))
+	if err != nil {
+		t.Fatalf("expected the configured placeholder asset to be copied in: %v", err)
+	}
+	want, err := os.ReadFile(assetPath)
+	if err != nil {
+		t.Fatalf("ReadFile(assetPath) error = %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("copied placeholder asset contents don't match the configured source file")
+	}
+}
+
+func TestDownloadMediaPopulatesBlurHashWhenTranscodeEnabled(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var pngBytes bytes.Buffer
+	if err := png.Encode(&pngBytes, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(pngBytes.Bytes())
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia:   true,
+		Timeout:         10,
+		Concurrent:      1,
+		Retries:         0,
+		UserAgent:       "test-agent",
+		Output:          filepath.Join(tempDir, "output.json"),
+		Format:          "json",
+		TranscodeImages: true,
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image.png", MimeType: "image/png"},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	entry, ok := downloader.Manifest().Get(mediaItems[0].SourceURL)
+	if !ok {
+		t.Fatal("Manifest() should record an entry for the downloaded URL")
+	}
+	if entry.BlurHash == "" {
+		t.Error("entry.BlurHash should be populated when cfg.TranscodeImages is set")
+	}
+}
+
+func TestDownloadMediaForPostWritesIntoDestDirImagesSubdir(t *testing.T) {
+	tempDir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/image1.jpg" {
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("fake image data 1"))
+			return
+		}
+		if r.URL.Path == "/image2.png" {
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("fake image data 2"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    2,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image1.jpg", MimeType: "image/jpeg"},
+		{ID: 2, SourceURL: server.URL + "/image2.png", MimeType: "image/png"},
+	}
+
+	destDir := filepath.Join(tempDir, "posts", "hello-world")
+	paths, err := downloader.DownloadMediaForPost(destDir, mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMediaForPost() error = %v, want nil", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("DownloadMediaForPost() returned %d paths, want 2: %v", len(paths), paths)
+	}
+
+	for id, relPath := range paths {
+		if !strings.HasPrefix(relPath, "images"+string(filepath.Separator)) {
+			t.Errorf("paths[%d] = %s, want it under images/", id, relPath)
+		}
+		if _, err := os.Stat(filepath.Join(destDir, relPath)); err != nil {
+			t.Errorf("paths[%d] = %s, file does not exist: %v", id, relPath, err)
+		}
+	}
+
+	if downloader.Manifest() != nil {
+		t.Error("DownloadMediaForPost() should not touch/create the shared manifest")
+	}
+}
+
+func TestDownloadMediaForPostOmitsPermanentlyFailedItems(t *testing.T) {
+	tempDir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok.jpg" {
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("fake image data"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    2,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/ok.jpg", MimeType: "image/jpeg"},
+		{ID: 2, SourceURL: server.URL + "/missing.jpg", MimeType: "image/jpeg"},
+	}
+
+	destDir := filepath.Join(tempDir, "posts", "hello-world")
+	paths, err := downloader.DownloadMediaForPost(destDir, mediaItems)
+	if err != nil {
+		t.Fatalf("DownloadMediaForPost() error = %v, want nil", err)
+	}
+	if _, ok := paths[1]; !ok {
+		t.Error("paths should contain the successfully downloaded item")
+	}
+	if _, ok := paths[2]; ok {
+		t.Error("paths should omit a permanently failed item rather than placeholder-substituting it")
+	}
+}
+
+func TestDownloadMediaForPostEmptyListReturnsEmptyMap(t *testing.T) {
+	downloader := NewDownloader(&config.Config{DownloadMedia: true})
+
+	paths, err := downloader.DownloadMediaForPost("/tmp/unused", nil)
+	if err != nil {
+		t.Fatalf("DownloadMediaForPost() error = %v, want nil", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("DownloadMediaForPost() = %v, want empty map", paths)
+	}
+}
+
+func TestResolveDownloadedPathFollowsBinExtensionCorrection(t *testing.T) {
+	tempDir := t.TempDir()
+
+	binPath := filepath.Join(tempDir, "media_1.bin")
+	pngPath := filepath.Join(tempDir, "media_1.png")
+	if err := os.WriteFile(pngPath, []byte("data"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got := resolveDownloadedPath(binPath)
+	if got != pngPath {
+		t.Errorf("resolveDownloadedPath() = %s, want %s", got, pngPath)
+	}
+}
+
+func TestResolveDownloadedPathReturnsInputWhenFileExists(t *testing.T) {
+	tempDir := t.TempDir()
+
+	path := filepath.Join(tempDir, "media_1.jpg")
+	if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got := resolveDownloadedPath(path)
+	if got != path {
+		t.Errorf("resolveDownloadedPath() = %s, want %s", got, path)
+	}
+}
+
 func TestGenerateFilename(t *testing.T) {
 	downloader := &Downloader{}
 
@@ -445,7 +1346,7 @@ func TestValidateFilePath(t *testing.T) {
 				tt.setup()
 			}
 
-			err := downloader.validateFilePath(tt.filePath)
+			err := downloader.validateFilePath(tt.filePath, downloader.mediaDir)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validateFilePath() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -458,6 +1359,7 @@ func TestUpdateMediaPaths(t *testing.T) {
 		config: &config.Config{
 			DownloadMedia: true,
 		},
+		store: NewLocalStore(t.TempDir()),
 	}
 
 	mediaItems := []models.WordPressMedia{
@@ -533,6 +1435,169 @@ func TestGenerateSizeFilename(t *testing.T) {
 	}
 }
 
+func TestDownloadMediaDownloadsRegisteredSizesAlongsideFullImage(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("fake image data for " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    2,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{
+			ID:        1,
+			SourceURL: server.URL + "/image-1024x768.jpg",
+			MimeType:  "image/jpeg",
+			MediaDetails: models.MediaDetails{
+				Sizes: map[string]models.MediaSize{
+					"thumbnail": {SourceURL: server.URL + "/image-150x150.jpg", Width: float64(150), MimeType: "image/jpeg"},
+					"medium":    {SourceURL: server.URL + "/image-300x225.jpg", Width: float64(300), MimeType: "image/jpeg"},
+				},
+			},
+		},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	for _, name := range []string{"1_image-1024x768.jpg", "1_image-150x150.jpg", "1_image-300x225.jpg"} {
+		if _, err := os.Stat(filepath.Join(mediaDir, name)); err != nil {
+			t.Errorf("expected %s to be downloaded: %v", name, err)
+		}
+	}
+}
+
+func TestDownloadMediaRespectsMediaSizesWhitelist(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("fake image data for " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    2,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+		MediaSizes:    []string{"thumbnail"},
+	}
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{
+			ID:        1,
+			SourceURL: server.URL + "/image-1024x768.jpg",
+			MimeType:  "image/jpeg",
+			MediaDetails: models.MediaDetails{
+				Sizes: map[string]models.MediaSize{
+					"thumbnail": {SourceURL: server.URL + "/image-150x150.jpg", Width: float64(150), MimeType: "image/jpeg"},
+					"medium":    {SourceURL: server.URL + "/image-300x225.jpg", Width: float64(300), MimeType: "image/jpeg"},
+				},
+			},
+		},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_image-150x150.jpg")); err != nil {
+		t.Errorf("expected whitelisted thumbnail size to be downloaded: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_image-300x225.jpg")); !os.IsNotExist(err) {
+		t.Errorf("expected non-whitelisted medium size to be skipped, stat err = %v", err)
+	}
+}
+
+func TestUpdateMediaPathsBuildsSrcsetForWPImageClass(t *testing.T) {
+	downloader := &Downloader{
+		config: &config.Config{DownloadMedia: true},
+		store:  NewLocalStore(t.TempDir()),
+	}
+
+	mediaItems := []models.WordPressMedia{
+		{
+			ID:        5,
+			SourceURL: "https://example.com/wp-content/uploads/2024/01/image-1024x768.jpg",
+			MimeType:  "image/jpeg",
+			MediaDetails: models.MediaDetails{
+				Width: float64(1024),
+				Sizes: map[string]models.MediaSize{
+					"thumbnail": {SourceURL: "https://example.com/wp-content/uploads/2024/01/image-150x150.jpg", Width: float64(150)},
+					"medium":    {SourceURL: "https://example.com/wp-content/uploads/2024/01/image-300x225.jpg", Width: float64(300)},
+				},
+			},
+		},
+	}
+
+	content := `<img src="https://example.com/wp-content/uploads/2024/01/image-1024x768.jpg" class="wp-image-5" alt="">`
+
+	updated := downloader.UpdateMediaPaths(content, mediaItems)
+
+	if !strings.Contains(updated, `srcset="media/5_image-150x150.jpg 150w, media/5_image-300x225.jpg 300w"`) {
+		t.Errorf("UpdateMediaPaths() = %s, want a srcset built from its size variants", updated)
+	}
+	if !strings.Contains(updated, `sizes="(max-width: 1024px) 100vw, 1024px"`) {
+		t.Errorf("UpdateMediaPaths() = %s, want a sizes attribute sized to the full image width", updated)
+	}
+	if !strings.Contains(updated, `src="media/5_image-1024x768.jpg"`) {
+		t.Errorf("UpdateMediaPaths() = %s, want the src rewritten to the downloaded full image", updated)
+	}
+}
+
+func TestUpdateMediaPathsSrcsetRespectsMediaSizesWhitelist(t *testing.T) {
+	downloader := &Downloader{
+		config: &config.Config{DownloadMedia: true, MediaSizes: []string{"thumbnail"}},
+		store:  NewLocalStore(t.TempDir()),
+	}
+
+	mediaItems := []models.WordPressMedia{
+		{
+			ID:        5,
+			SourceURL: "https://example.com/wp-content/uploads/2024/01/image-1024x768.jpg",
+			MimeType:  "image/jpeg",
+			MediaDetails: models.MediaDetails{
+				Width: float64(1024),
+				Sizes: map[string]models.MediaSize{
+					"thumbnail": {SourceURL: "https://example.com/wp-content/uploads/2024/01/image-150x150.jpg", Width: float64(150)},
+					"medium":    {SourceURL: "https://example.com/wp-content/uploads/2024/01/image-300x225.jpg", Width: float64(300)},
+				},
+			},
+		},
+	}
+
+	content := `<img src="https://example.com/wp-content/uploads/2024/01/image-1024x768.jpg" class="wp-image-5" alt="">`
+
+	updated := downloader.UpdateMediaPaths(content, mediaItems)
+
+	if !strings.Contains(updated, `srcset="media/5_image-150x150.jpg 150w"`) {
+		t.Errorf("UpdateMediaPaths() = %s, want only the whitelisted size in srcset", updated)
+	}
+	if strings.Contains(updated, "300w") {
+		t.Errorf("UpdateMediaPaths() = %s, should not include the non-whitelisted medium size", updated)
+	}
+}
+
 func TestDownloadFileInvalidURL(t *testing.T) {
 	downloader := &Downloader{
 		config: &config.Config{
@@ -541,9 +1606,12 @@ func TestDownloadFileInvalidURL(t *testing.T) {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		httpCache: httpcache.New(t.TempDir()),
+		burst:     1,
+		limiters:  map[string]*api.RateLimiter{},
 	}
 
-	success := downloader.downloadFile("invalid-url", "/tmp/test.jpg")
+	success := downloader.downloadFile("invalid-url", "/tmp/test.jpg", 0)
 
 	if success {
 		t.Error("downloadFile() should return false for invalid URL")
@@ -563,9 +1631,12 @@ func TestDownloadFileHTTPError(t *testing.T) {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		httpCache: httpcache.New(t.TempDir()),
+		burst:     1,
+		limiters:  map[string]*api.RateLimiter{},
 	}
 
-	success := downloader.downloadFile(server.URL+"/test.jpg", "/tmp/test.jpg")
+	success := downloader.downloadFile(server.URL+"/test.jpg", "/tmp/test.jpg", 0)
 
 	if success {
 		t.Error("downloadFile() should return false for HTTP error")
@@ -601,3 +1672,181 @@ func TestDownloadMediaItemInvalidURL(t *testing.T) {
 		t.Error("downloadMediaItem() should return false for invalid URL")
 	}
 }
+
+func TestDownloadMediaContextCancellationStopsEarly(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		// Block long enough that a canceled ctx is observed well before any response
+		// would otherwise complete.
+		time.Sleep(2 * time.Second)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("fake image data"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    2,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{ID: 1, SourceURL: server.URL + "/image1.jpg", MimeType: "image/jpeg"},
+		{ID: 2, SourceURL: server.URL + "/image2.jpg", MimeType: "image/jpeg"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	downloaded, err := downloader.DownloadMediaContext(ctx, mediaItems)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Errorf("DownloadMediaContext() error = %v, want nil", err)
+	}
+	if downloaded != 0 {
+		t.Errorf("DownloadMediaContext() downloaded = %d, want 0 for an already-canceled context", downloaded)
+	}
+	if elapsed >= 2*time.Second {
+		t.Errorf("DownloadMediaContext() took %v, want it to return well before the server's response delay", elapsed)
+	}
+}
+
+func TestLimiterForIsolatesHosts(t *testing.T) {
+	cfg := &config.Config{RateLimit: 5, MaxQPS: 10, Concurrent: 1}
+	downloader := NewDownloader(cfg)
+
+	a1 := downloader.limiterFor("https://host-a.example.com/image1.jpg")
+	a2 := downloader.limiterFor("https://host-a.example.com/image2.jpg")
+	b1 := downloader.limiterFor("https://host-b.example.com/image1.jpg")
+
+	if a1 != a2 {
+		t.Error("limiterFor() should return the same limiter for the same host")
+	}
+	if a1 == b1 {
+		t.Error("limiterFor() should return distinct limiters for distinct hosts")
+	}
+
+	a1.Throttle(0)
+	b2 := downloader.limiterFor("https://host-b.example.com/image2.jpg")
+	if b2 != b1 {
+		t.Error("limiterFor() should keep returning host-b's own limiter, unaffected by host-a's throttle")
+	}
+}
+
+func TestDownloadMediaSizeWithRetryRecoversFromServerError(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("fake image data"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    2,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{
+			ID:        1,
+			SourceURL: server.URL + "/image-1024x768.jpg",
+			MimeType:  "image/jpeg",
+			MediaDetails: models.MediaDetails{
+				Sizes: map[string]models.MediaSize{
+					"thumbnail": {SourceURL: server.URL + "/image-150x150.jpg", MimeType: "image/jpeg"},
+				},
+			},
+		},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_image-150x150.jpg")); err != nil {
+		t.Errorf("expected thumbnail to be downloaded after a single transient failure: %v", err)
+	}
+	if failures := downloader.Failures(); len(failures) != 0 {
+		t.Errorf("Failures() = %v, want none after a successful retry", failures)
+	}
+}
+
+func TestDownloadMediaSizesCleansUpOnPermanentFailure(t *testing.T) {
+	tempDir := t.TempDir()
+	mediaDir := filepath.Join(tempDir, "output_media")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("fake image data"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		DownloadMedia: true,
+		Timeout:       10,
+		Concurrent:    2,
+		Retries:       0,
+		UserAgent:     "test-agent",
+		Output:        filepath.Join(tempDir, "output.json"),
+		Format:        "json",
+	}
+	downloader := NewDownloader(cfg)
+
+	mediaItems := []models.WordPressMedia{
+		{
+			ID:        1,
+			SourceURL: server.URL + "/image-1024x768.jpg",
+			MimeType:  "image/jpeg",
+			MediaDetails: models.MediaDetails{
+				Sizes: map[string]models.MediaSize{
+					"thumbnail": {SourceURL: server.URL + "/image-150x150.jpg", MimeType: "image/jpeg"},
+					"medium":    {SourceURL: server.URL + "/image-missing.jpg", MimeType: "image/jpeg"},
+				},
+			},
+		},
+	}
+
+	if _, err := downloader.DownloadMedia(mediaItems); err != nil {
+		t.Fatalf("DownloadMedia() error = %v, want nil", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(mediaDir, "1_image-150x150.jpg")); !os.IsNotExist(err) {
+		t.Errorf("expected the successfully downloaded sibling size to be cleaned up, stat err = %v", err)
+	}
+
+	failures := downloader.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("Failures() = %v, want exactly 1", failures)
+	}
+	if failures[0].ID != 1 || failures[0].SizeName != "medium" {
+		t.Errorf("Failures()[0] = %+v, want ID=1 SizeName=medium", failures[0])
+	}
+}
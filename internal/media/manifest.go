@@ -0,0 +1,120 @@
+package media
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// manifestFileName is the sidecar JSON file persisted in the media directory recording,
+// per source URL, enough to skip or dedup a re-download on a later run.
+const manifestFileName = ".wpexport-media-manifest.json"
+
+// MediaManifestEntry is what MediaManifest persists for one downloaded media URL.
+type MediaManifestEntry struct {
+	// Hash is the SHA-256 of the downloaded file's contents, hex-encoded.
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+	// ETag and LastModified are the conditional-GET validators from the response that
+	// produced Hash, sent as If-None-Match/If-Modified-Since on the next run.
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+	// ContentType is the response's Content-Type header from the download that produced
+	// Hash, if any.
+	ContentType string `json:"content_type,omitempty"`
+	// Path is the file's path within the media directory - either the legacy `{id}_{name}`
+	// name, or, under cfg.MediaLayout "content-addressed", a `sha256/<aa>/<hash>/{name}`
+	// path - as actually written to disk.
+	Path string `json:"path"`
+	// Placeholder is true when Path is a generated stand-in (see Downloader's
+	// downloadPlaceholder, or writeProbeFailurePlaceholder) rather than the real
+	// downloaded asset, because every download attempt for this URL failed permanently,
+	// or MediaProbe rejected what was downloaded.
+	Placeholder bool `json:"placeholder,omitempty"`
+	// Width and Height are MediaProbe's measured pixel dimensions, populated for an
+	// image download; zero otherwise.
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+	// Duration (seconds) and Codec are MediaProbe's ffprobe-derived metadata for an
+	// audio/video download; left zero/empty when ffprobe isn't installed.
+	Duration float64 `json:"duration,omitempty"`
+	Codec    string  `json:"codec,omitempty"`
+	// MimeMismatch is true when MediaProbe's sniffed content type disagrees with the
+	// WordPress-reported MimeType - e.g. a ".jpg" media item whose bytes are actually a
+	// PNG.
+	MimeMismatch bool `json:"mime_mismatch,omitempty"`
+	// BlurHash is a short placeholder string (see internal/media's Transcoder) computed
+	// when cfg.TranscodeImages is set, for a static-site export to render while the real
+	// image loads.
+	BlurHash string `json:"blur_hash,omitempty"`
+}
+
+// MediaManifest is a sidecar JSON file, persisted in the media directory, recording each
+// downloaded URL's content hash and conditional-GET validators. It follows the same
+// load/save idiom as internal/state.State and internal/api.Checkpoint: a zero value is
+// returned (not an error) when the file doesn't exist yet, and Set auto-persists.
+type MediaManifest struct {
+	Entries map[string]MediaManifestEntry `json:"entries"`
+
+	path string
+}
+
+// LoadMediaManifest reads a MediaManifest from path, returning an empty, unsaved one if the
+// file doesn't exist yet (the first download run).
+func LoadMediaManifest(path string) (*MediaManifest, error) {
+	m := &MediaManifest{Entries: map[string]MediaManifestEntry{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to read media manifest %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse media manifest %s: %w", path, err)
+	}
+	m.path = path
+
+	return m, nil
+}
+
+// Get returns the entry recorded for sourceURL, and whether one exists.
+func (m *MediaManifest) Get(sourceURL string) (MediaManifestEntry, bool) {
+	entry, ok := m.Entries[sourceURL]
+	return entry, ok
+}
+
+// Set records sourceURL's entry and persists the manifest to its backing file.
+func (m *MediaManifest) Set(sourceURL string, entry MediaManifestEntry) error {
+	m.Entries[sourceURL] = entry
+	return m.save()
+}
+
+// PathForHash returns the on-disk path of an existing entry whose Hash matches hash, other
+// than excludeURL itself, so a caller can hard-link a newly downloaded duplicate to it
+// instead of keeping a second copy of the same content.
+func (m *MediaManifest) PathForHash(hash, excludeURL string) (string, bool) {
+	for sourceURL, entry := range m.Entries {
+		if sourceURL == excludeURL {
+			continue
+		}
+		if entry.Hash == hash && entry.Path != "" {
+			return entry.Path, true
+		}
+	}
+	return "", false
+}
+
+// save writes the manifest to its backing path.
+func (m *MediaManifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal media manifest: %w", err)
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write media manifest %s: %w", m.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package media
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMediaManifestMissingFileReturnsEmpty(t *testing.T) {
+	m, err := LoadMediaManifest(filepath.Join(t.TempDir(), "manifest.json"))
+	if err != nil {
+		t.Fatalf("LoadMediaManifest() error = %v", err)
+	}
+	if _, ok := m.Get("https://example.com/image.jpg"); ok {
+		t.Error("Get() on a fresh manifest should report no entry")
+	}
+	if _, ok := m.PathForHash("deadbeef", ""); ok {
+		t.Error("PathForHash() on a fresh manifest should report no match")
+	}
+}
+
+func TestMediaManifestSetAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	m, err := LoadMediaManifest(path)
+	if err != nil {
+		t.Fatalf("LoadMediaManifest() error = %v", err)
+	}
+
+	entry := MediaManifestEntry{
+		Hash:         "abc123",
+		Size:         17,
+		ETag:         `"etag-1"`,
+		LastModified: "Mon, 02 Jan 2006 15:04:05 GMT",
+		Path:         "image1.jpg",
+	}
+	if err := m.Set("https://example.com/image1.jpg", entry); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	reloaded, err := LoadMediaManifest(path)
+	if err != nil {
+		t.Fatalf("LoadMediaManifest() reload error = %v", err)
+	}
+
+	got, ok := reloaded.Get("https://example.com/image1.jpg")
+	if !ok {
+		t.Fatal("Get() after reload should find the persisted entry")
+	}
+	if got != entry {
+		t.Errorf("Get() = %+v, want %+v", got, entry)
+	}
+
+	// An unrelated URL should still report no entry.
+	if _, ok := reloaded.Get("https://example.com/image2.jpg"); ok {
+		t.Error("Get() for an unrecorded URL should report no entry")
+	}
+}
+
+func TestMediaManifestPathForHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	m, err := LoadMediaManifest(path)
+	if err != nil {
+		t.Fatalf("LoadMediaManifest() error = %v", err)
+	}
+
+	if err := m.Set("https://example.com/image1.jpg", MediaManifestEntry{Hash: "abc123", Path: "image1.jpg"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := m.PathForHash("abc123", "https://example.com/image2.jpg")
+	if !ok {
+		t.Fatal("PathForHash() should find the matching entry")
+	}
+	if got != "image1.jpg" {
+		t.Errorf("PathForHash() = %q, want %q", got, "image1.jpg")
+	}
+
+	// excludeURL should exclude the matching entry when it's the same URL.
+	if _, ok := m.PathForHash("abc123", "https://example.com/image1.jpg"); ok {
+		t.Error("PathForHash() should not match excludeURL's own entry")
+	}
+
+	if _, ok := m.PathForHash("nonexistent", ""); ok {
+		t.Error("PathForHash() should report no match for an unknown hash")
+	}
+}
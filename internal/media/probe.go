@@ -0,0 +1,161 @@
+package media
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoding for probeFile's image.DecodeConfig
+	_ "image/jpeg" // register JPEG decoding for probeFile's image.DecodeConfig
+	_ "image/png"  // register PNG decoding for probeFile's image.DecodeConfig
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ProbeResult is what probeFile reports about a downloaded media file: its real
+// dimensions or, for audio/video, duration and codec when ffprobe is available.
+type ProbeResult struct {
+	// Category is "image", "audio", or "video", derived from the file's sniffed bytes
+	// alone; the declared MIME type is never used as a fallback.
+	// Empty for any other kind of attachment (PDFs, documents, ...), which MediaProbe
+	// doesn't inspect further.
+	Category string
+	// DetectedMimeType is what http.DetectContentType found in the file's own bytes,
+	// independent of whatever WordPress declared for it.
+	DetectedMimeType string
+	// MimeMismatch is true when declaredMimeType's category disagrees with the
+	// sniffed one (e.g. WordPress says "image/jpeg" but the bytes are actually audio).
+	MimeMismatch bool
+	// Width and Height are populated for Category "image".
+	Width, Height int
+	// Duration (seconds) and Codec are populated for Category "audio"/"video" when
+	// ffprobe is installed; left zero/empty otherwise, since that isn't itself a probe
+	// failure - it just means no finer-grained metadata is available.
+	Duration float64
+	Codec    string
+}
+
+// errFFProbeUnavailable signals that ffprobe isn't installed, as opposed to it having run
+// and rejected the file - probeFile treats these two cases very differently.
+var errFFProbeUnavailable = errors.New("ffprobe not found on PATH")
+
+// probeFile inspects path's actual bytes (sniffed via http.DetectContentType, the same
+// mechanism sniffAndFixExtension already uses) and, for an image, audio, or video
+// category, verifies the file decodes. Category is decided from the sniffed bytes alone,
+// never from declaredMimeType - the WordPress-reported MimeType - so a download whose
+// content merely isn't recognized as any particular format (most non-media attachments)
+// is never mistaken for a failed probe; declaredMimeType is only compared against the
+// sniffed category to set MimeMismatch.
+//
+// ok is false only when a category was identified but the content failed to decode (a
+// corrupt image, or a video/audio file ffprobe - when installed - rejects); a category
+// MediaProbe doesn't recognize, or audio/video inspected without ffprobe on PATH, is
+// reported as ok with whatever was learned from sniffing alone.
+func probeFile(path, declaredMimeType string) (ProbeResult, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ProbeResult{}, false
+	}
+	defer func() { _ = f.Close() }()
+
+	head := make([]byte, 512)
+	n, _ := f.Read(head)
+	sniffed := http.DetectContentType(head[:n])
+	if semi := strings.Index(sniffed, ";"); semi != -1 {
+		sniffed = sniffed[:semi]
+	}
+
+	category := mediaCategory(sniffed)
+
+	result := ProbeResult{
+		Category:         category,
+		DetectedMimeType: sniffed,
+	}
+	if declaredCategory := mediaCategory(declaredMimeType); declaredCategory != "" && category != "" && declaredCategory != category {
+		result.MimeMismatch = true
+	}
+
+	switch category {
+	case "image":
+		if _, err := f.Seek(0, 0); err != nil {
+			return result, false
+		}
+		cfg, _, err := image.DecodeConfig(f)
+		if err != nil {
+			return result, false
+		}
+		result.Width = cfg.Width
+		result.Height = cfg.Height
+		return result, true
+
+	case "audio", "video":
+		duration, codec, err := probeWithFFProbe(path)
+		if errors.Is(err, errFFProbeUnavailable) {
+			return result, true
+		}
+		if err != nil {
+			return result, false
+		}
+		result.Duration = duration
+		result.Codec = codec
+		return result, true
+
+	default:
+		return result, true
+	}
+}
+
+// mediaCategory maps a MIME type's top-level type to "image", "audio", or "video",
+// returning "" for anything else (or an empty mimeType).
+func mediaCategory(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return "image"
+	case strings.HasPrefix(mimeType, "audio/"):
+		return "audio"
+	case strings.HasPrefix(mimeType, "video/"):
+		return "video"
+	default:
+		return ""
+	}
+}
+
+// probeWithFFProbe shells out to ffprobe (when installed) to read path's duration and
+// primary stream codec. Returns errFFProbeUnavailable when the ffprobe binary itself
+// can't be found, which probeFile treats as "can't tell" rather than a failed probe.
+func probeWithFFProbe(path string) (float64, string, error) {
+	ffprobePath, err := exec.LookPath("ffprobe")
+	if err != nil {
+		return 0, "", errFFProbeUnavailable
+	}
+
+	cmd := exec.Command(ffprobePath, "-v", "error", "-print_format", "json",
+		"-show_entries", "format=duration:stream=codec_name", path)
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, "", fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+
+	var parsed struct {
+		Format struct {
+			Duration string `json:"duration"`
+		} `json:"format"`
+		Streams []struct {
+			CodecName string `json:"codec_name"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(out), &parsed); err != nil {
+		return 0, "", fmt.Errorf("parse ffprobe output for %s: %w", path, err)
+	}
+
+	duration, _ := strconv.ParseFloat(parsed.Format.Duration, 64)
+	var codec string
+	if len(parsed.Streams) > 0 {
+		codec = parsed.Streams[0].CodecName
+	}
+	return duration, codec, nil
+}
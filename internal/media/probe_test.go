@@ -0,0 +1,108 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestPNG writes a width x height PNG to path, for probeFile tests that need a real,
+// decodable image rather than sniffable-but-fake bytes.
+func writeTestPNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestProbeFileImageDimensions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "photo.png")
+	writeTestPNG(t, path, 12, 8)
+
+	result, ok := probeFile(path, "image/png")
+	if !ok {
+		t.Fatal("probeFile() ok = false, want true for a valid PNG")
+	}
+	if result.Category != "image" {
+		t.Errorf("result.Category = %q, want %q", result.Category, "image")
+	}
+	if result.Width != 12 || result.Height != 8 {
+		t.Errorf("result dimensions = %dx%d, want 12x8", result.Width, result.Height)
+	}
+	if result.MimeMismatch {
+		t.Error("result.MimeMismatch should be false when declared and sniffed types agree")
+	}
+}
+
+func TestProbeFileRejectsCorruptImage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.png")
+	if err := os.WriteFile(path, []byte("\x89PNG\r\n\x1a\nnot actually a png"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if _, ok := probeFile(path, "image/png"); ok {
+		t.Error("probeFile() ok = true, want false for a file sniffed as an image that fails to decode")
+	}
+}
+
+func TestProbeFileFlagsMimeMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "photo.png")
+	writeTestPNG(t, path, 4, 4)
+
+	result, ok := probeFile(path, "audio/mpeg")
+	if !ok {
+		t.Fatal("probeFile() ok = false, want true")
+	}
+	if !result.MimeMismatch {
+		t.Error("result.MimeMismatch should be true when the declared and sniffed categories disagree")
+	}
+}
+
+func TestProbeFilePassesThroughUnrecognizedCategory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4\n..."), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	result, ok := probeFile(path, "application/pdf")
+	if !ok {
+		t.Error("probeFile() ok = false, want true for a category MediaProbe doesn't inspect")
+	}
+	if result.Category != "" {
+		t.Errorf("result.Category = %q, want empty for a PDF", result.Category)
+	}
+}
+
+func TestMediaCategory(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		want     string
+	}{
+		{"image/png", "image"},
+		{"audio/mpeg", "audio"},
+		{"video/mp4", "video"},
+		{"application/pdf", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := mediaCategory(tt.mimeType); got != tt.want {
+			t.Errorf("mediaCategory(%q) = %q, want %q", tt.mimeType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// StoreMeta carries the metadata MediaStore.Put records alongside a file's bytes, for
+// backends that support it (S3Store writes it as object metadata, WebDAVStore sends
+// ContentType as the PUT's Content-Type header). LocalStore ignores it.
+type StoreMeta struct {
+	ContentType string
+}
+
+// MediaStore is where Downloader's downloaded media bytes ultimately live, and what
+// UpdateMediaPaths asks for the URL exported content should reference. LocalStore (the
+// default) keeps files on disk under the media directory, exactly as Downloader did before
+// this interface existed. S3Store and WebDAVStore instead mirror a completed download to
+// object storage, so an export can link straight at a CDN without a separate upload step.
+type MediaStore interface {
+	// Put uploads relpath's content, read from r, to the store, overwriting any existing
+	// object at relpath. relpath is the same manifest-relative path recorded in
+	// MediaManifestEntry.Path.
+	Put(ctx context.Context, relpath string, r io.Reader, meta StoreMeta) error
+	// URLFor returns the URL exported content should use to reference relpath.
+	URLFor(relpath string) string
+}
+
+// newMediaStore constructs the MediaStore selected by cfg.MediaStore.Backend, rooting
+// LocalStore at mediaDir when no remote backend is configured.
+func newMediaStore(cfg *config.Config, mediaDir string) MediaStore {
+	switch cfg.MediaStore.Backend {
+	case "s3":
+		return newS3Store(cfg.MediaStore.S3)
+	case "webdav":
+		return newWebDAVStore(cfg.MediaStore.WebDAV)
+	default:
+		return NewLocalStore(mediaDir)
+	}
+}
+
+// LocalStore is the default MediaStore: a thin wrapper over the media directory,
+// reproducing the behavior Downloader had before this interface existed.
+type LocalStore struct {
+	root string
+}
+
+// NewLocalStore returns a LocalStore rooted at root.
+func NewLocalStore(root string) *LocalStore {
+	return &LocalStore{root: root}
+}
+
+// Put writes relpath's content under the store's root, creating any parent directories it
+// needs. When r is a local *os.File not already at the destination, Put renames it into
+// place instead of copying, and falls back to a copy (e.g. across filesystems, or when r
+// isn't a plain file) otherwise. Downloader itself never calls Put on a LocalStore (see
+// mirrorToStore): its downloads are already written under the media directory.
+func (s *LocalStore) Put(ctx context.Context, relpath string, r io.Reader, meta StoreMeta) error {
+	dest := filepath.Join(s.root, filepath.FromSlash(relpath))
+	if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+		return fmt.Errorf("failed to create media directory for %s: %w", relpath, err)
+	}
+
+	if f, ok := r.(*os.File); ok {
+		if same, err := sameFile(f.Name(), dest); err == nil && same {
+			return nil
+		}
+		if err := os.Rename(f.Name(), dest); err == nil {
+			return nil
+		}
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", relpath, err)
+	}
+	defer func() { _ = out.Close() }()
+
+	if _, err := io.Copy(out, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", relpath, err)
+	}
+	return nil
+}
+
+// URLFor returns relpath as a "media/..." path relative to the export root, the
+// long-standing link form UpdateMediaPaths has always produced.
+func (s *LocalStore) URLFor(relpath string) string {
+	return path.Join("media", filepath.ToSlash(relpath))
+}
+
+// sameFile reports whether a and b, once resolved to absolute paths, are identical.
+func sameFile(a, b string) (bool, error) {
+	absA, err := filepath.Abs(a)
+	if err != nil {
+		return false, err
+	}
+	absB, err := filepath.Abs(b)
+	if err != nil {
+		return false, err
+	}
+	return absA == absB, nil
+}
@@ -0,0 +1,97 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// S3Store is a MediaStore that uploads completed downloads to an S3 (or S3-compatible)
+// bucket, keyed by cfg.Prefix joined with the manifest-relative path.
+type S3Store struct {
+	uploader *manager.Uploader
+	cfg      config.S3StoreConfig
+}
+
+// newS3Store returns an S3Store for cfg, resolving credentials and region the same way the
+// AWS CLI/SDK do (environment, shared config file, EC2/ECS instance role, ...).
+func newS3Store(cfg config.S3StoreConfig) *S3Store {
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+		awsconfig.WithRegion(cfg.Region),
+	)
+	if err != nil {
+		// Deferred to the first Put call, which will fail with a clearer error once an
+		// actual upload is attempted; newS3Store itself has no error return since it
+		// mirrors NewLocalStore/newWebDAVStore's unconditional construction.
+		awsCfg = aws.Config{Region: cfg.Region}
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &S3Store{
+		uploader: manager.NewUploader(client),
+		cfg:      cfg,
+	}
+}
+
+// key returns relpath's full S3 object key, with cfg.Prefix prepended.
+func (s *S3Store) key(relpath string) string {
+	prefix := strings.Trim(s.cfg.Prefix, "/")
+	if prefix == "" {
+		return relpath
+	}
+	return prefix + "/" + relpath
+}
+
+// Put uploads relpath's content to the bucket, using the manager.Uploader's multipart
+// upload for anything past its part-size threshold so a large video/PDF doesn't need to
+// fit in memory.
+func (s *S3Store) Put(ctx context.Context, relpath string, r io.Reader, meta StoreMeta) error {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.cfg.Bucket),
+		Key:    aws.String(s.key(relpath)),
+		Body:   r,
+	}
+	if meta.ContentType != "" {
+		input.ContentType = aws.String(meta.ContentType)
+	}
+
+	if _, err := s.uploader.Upload(ctx, input); err != nil {
+		return fmt.Errorf("s3 upload %s: %w", relpath, err)
+	}
+	return nil
+}
+
+// URLFor returns cfg.PublicURLBase (e.g. a CDN) joined with relpath when set, otherwise
+// the bucket's own virtual-hosted-style URL (or, against an Endpoint override, a
+// path-style URL matching the UsePathStyle client option set in newS3Store).
+func (s *S3Store) URLFor(relpath string) string {
+	key := s.key(relpath)
+
+	if base := strings.TrimSuffix(s.cfg.PublicURLBase, "/"); base != "" {
+		return base + "/" + key
+	}
+
+	if s.cfg.Endpoint != "" {
+		return strings.TrimSuffix(s.cfg.Endpoint, "/") + "/" + s.cfg.Bucket + "/" + key
+	}
+
+	region := s.cfg.Region
+	if region == "" || region == "us-east-1" {
+		return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", s.cfg.Bucket, key)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.cfg.Bucket, region, key)
+}
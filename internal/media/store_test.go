@@ -0,0 +1,145 @@
+package media
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestLocalStorePutRenamesLocalFile(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStore(root)
+
+	stagingDir := t.TempDir()
+	stagingPath := filepath.Join(stagingDir, "staged.jpg")
+	if err := os.WriteFile(stagingPath, []byte("staged bytes"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	f, err := os.Open(stagingPath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := store.Put(context.Background(), "sha256/ab/abcd/image.jpg", f, StoreMeta{}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "sha256", "ab", "abcd", "image.jpg"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "staged bytes" {
+		t.Errorf("Put() wrote %q, want %q", data, "staged bytes")
+	}
+	if _, err := os.Stat(stagingPath); !os.IsNotExist(err) {
+		t.Error("Put() should rename a local *os.File into place rather than copy it, leaving the staged path gone")
+	}
+}
+
+func TestLocalStorePutCopiesNonFileReader(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStore(root)
+
+	r := strings.NewReader("streamed bytes")
+	if err := store.Put(context.Background(), "image.jpg", r, StoreMeta{}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "image.jpg"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "streamed bytes" {
+		t.Errorf("Put() wrote %q, want %q", data, "streamed bytes")
+	}
+}
+
+func TestLocalStoreURLFor(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	got := store.URLFor("1_image.jpg")
+	want := "media/1_image.jpg"
+	if got != want {
+		t.Errorf("URLFor() = %q, want %q", got, want)
+	}
+}
+
+func TestS3StoreURLFor(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.S3StoreConfig
+		want string
+	}{
+		{
+			name: "default us-east-1",
+			cfg:  config.S3StoreConfig{Bucket: "exported-media"},
+			want: "https://exported-media.s3.amazonaws.com/1_image.jpg",
+		},
+		{
+			name: "explicit region",
+			cfg:  config.S3StoreConfig{Bucket: "exported-media", Region: "eu-west-1"},
+			want: "https://exported-media.s3.eu-west-1.amazonaws.com/1_image.jpg",
+		},
+		{
+			name: "prefix",
+			cfg:  config.S3StoreConfig{Bucket: "exported-media", Prefix: "wp-media/"},
+			want: "https://exported-media.s3.amazonaws.com/wp-media/1_image.jpg",
+		},
+		{
+			name: "endpoint override",
+			cfg:  config.S3StoreConfig{Bucket: "exported-media", Endpoint: "https://minio.internal:9000"},
+			want: "https://minio.internal:9000/exported-media/1_image.jpg",
+		},
+		{
+			name: "public URL base",
+			cfg:  config.S3StoreConfig{Bucket: "exported-media", PublicURLBase: "https://cdn.example.com"},
+			want: "https://cdn.example.com/1_image.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newS3Store(tt.cfg)
+			if got := store.URLFor("1_image.jpg"); got != tt.want {
+				t.Errorf("URLFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebDAVStoreURLFor(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.WebDAVStoreConfig
+		want string
+	}{
+		{
+			name: "base URL",
+			cfg:  config.WebDAVStoreConfig{BaseURL: "https://dav.example.com/media"},
+			want: "https://dav.example.com/media/1_image.jpg",
+		},
+		{
+			name: "public URL base overrides",
+			cfg: config.WebDAVStoreConfig{
+				BaseURL:       "https://dav.example.com/media",
+				PublicURLBase: "https://cdn.example.com",
+			},
+			want: "https://cdn.example.com/1_image.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newWebDAVStore(tt.cfg)
+			if got := store.URLFor("1_image.jpg"); got != tt.want {
+				t.Errorf("URLFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
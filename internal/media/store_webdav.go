@@ -0,0 +1,103 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// WebDAVStore is a MediaStore that PUTs completed downloads to a WebDAV server, creating
+// any intermediate collections (directories) the relpath needs via MKCOL, mirroring how a
+// WebDAV client like cadaver or rclone would upload a nested path.
+type WebDAVStore struct {
+	client  *resty.Client
+	baseURL string
+	cfg     config.WebDAVStoreConfig
+}
+
+// newWebDAVStore returns a WebDAVStore for cfg.
+func newWebDAVStore(cfg config.WebDAVStoreConfig) *WebDAVStore {
+	client := resty.New()
+	if cfg.Username != "" || cfg.Password != "" {
+		client.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	return &WebDAVStore{
+		client:  client,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		cfg:     cfg,
+	}
+}
+
+// Put MKCOLs relpath's parent collections (ignoring the "already exists" case, the common
+// one past the first upload) and PUTs its content.
+func (s *WebDAVStore) Put(ctx context.Context, relpath string, r io.Reader, meta StoreMeta) error {
+	if err := s.mkcolAll(ctx, relpath); err != nil {
+		return err
+	}
+
+	req := s.client.R().SetContext(ctx).SetBody(r)
+	if meta.ContentType != "" {
+		req.SetHeader("Content-Type", meta.ContentType)
+	}
+
+	resp, err := req.Put(s.baseURL + "/" + strings.TrimPrefix(relpath, "/"))
+	if err != nil {
+		return fmt.Errorf("webdav PUT %s: %w", relpath, err)
+	}
+	if resp.IsError() {
+		return fmt.Errorf("webdav PUT %s: unexpected status %s", relpath, resp.Status())
+	}
+	return nil
+}
+
+// mkcolAll issues MKCOL for every collection in relpath's directory, shallowest first, so a
+// nested path (e.g. "sha256/ab/abcdef.../file.jpg") lands in a server that requires each
+// intermediate collection to exist before a deeper one can be created. A 405 Method Not
+// Allowed (the collection already exists) is expected past the first upload into a given
+// directory and isn't an error.
+func (s *WebDAVStore) mkcolAll(ctx context.Context, relpath string) error {
+	dir := strings.TrimSuffix(relpath, "/"+pathBase(relpath))
+	if dir == relpath || dir == "" {
+		return nil
+	}
+
+	var built strings.Builder
+	for _, segment := range strings.Split(dir, "/") {
+		if segment == "" {
+			continue
+		}
+		built.WriteString("/")
+		built.WriteString(segment)
+
+		resp, err := s.client.R().SetContext(ctx).Execute("MKCOL", s.baseURL+built.String())
+		if err != nil {
+			return fmt.Errorf("webdav MKCOL %s: %w", built.String(), err)
+		}
+		if resp.IsError() && resp.StatusCode() != 405 {
+			return fmt.Errorf("webdav MKCOL %s: unexpected status %s", built.String(), resp.Status())
+		}
+	}
+	return nil
+}
+
+// URLFor returns cfg.PublicURLBase (or BaseURL, when that's unset) joined with relpath.
+func (s *WebDAVStore) URLFor(relpath string) string {
+	base := strings.TrimSuffix(s.cfg.PublicURLBase, "/")
+	if base == "" {
+		base = s.baseURL
+	}
+	return base + "/" + strings.TrimPrefix(relpath, "/")
+}
+
+// pathBase returns relpath's final "/"-separated segment.
+func pathBase(relpath string) string {
+	if i := strings.LastIndex(relpath, "/"); i >= 0 {
+		return relpath[i+1:]
+	}
+	return relpath
+}
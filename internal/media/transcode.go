@@ -0,0 +1,62 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// TranscodeResult is what a Transcoder reports about a downloaded image.
+type TranscodeResult struct {
+	// BlurHash is a short placeholder string (see encodeBlurHash) a static-site export
+	// can render while the real image loads.
+	BlurHash string
+}
+
+// Transcoder generates derived metadata - and, in principle, alternate-format variants -
+// for a successfully downloaded image. The only implementation below produces a
+// BlurHash: real WebP/AVIF re-encoding needs an external encoder (cwebp, avifenc, ...)
+// this repo doesn't bundle or assume is installed, so it's left as a later integration
+// point rather than faked here.
+type Transcoder interface {
+	Transcode(ctx context.Context, path string) (TranscodeResult, error)
+}
+
+// newTranscoder returns the Transcoder Downloader.finalizeDownload should run against
+// every successfully downloaded image, or nil when cfg.TranscodeImages is off.
+func newTranscoder(cfg *config.Config) Transcoder {
+	if !cfg.TranscodeImages {
+		return nil
+	}
+	return &blurHashTranscoder{}
+}
+
+// blurHashTranscoder computes a BlurHash from the decoded image; see Transcoder's doc
+// comment for why it stops there.
+type blurHashTranscoder struct{}
+
+// Transcode decodes path and encodes its BlurHash. ctx is accepted for interface
+// consistency with the rest of this package's *Context methods; decoding a local file
+// already on disk isn't itself cancellable.
+func (t *blurHashTranscoder) Transcode(ctx context.Context, path string) (TranscodeResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return TranscodeResult{}, fmt.Errorf("open %s for transcoding: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return TranscodeResult{}, fmt.Errorf("decode %s for transcoding: %w", path, err)
+	}
+
+	hash, err := encodeBlurHash(img, 4, 3)
+	if err != nil {
+		return TranscodeResult{}, fmt.Errorf("encode blurhash for %s: %w", path, err)
+	}
+
+	return TranscodeResult{BlurHash: hash}, nil
+}
@@ -0,0 +1,61 @@
+package media
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestNewTranscoderDisabledByDefault(t *testing.T) {
+	cfg := &config.Config{}
+	if newTranscoder(cfg) != nil {
+		t.Error("newTranscoder() should return nil when TranscodeImages is off")
+	}
+}
+
+func TestNewTranscoderEnabled(t *testing.T) {
+	cfg := &config.Config{TranscodeImages: true}
+	if newTranscoder(cfg) == nil {
+		t.Error("newTranscoder() should return a Transcoder when TranscodeImages is on")
+	}
+}
+
+func TestBlurHashTranscoderTranscode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "photo.png")
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 16), B: 128, A: 255})
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create() error = %v", err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	_ = f.Close()
+
+	transcoder := &blurHashTranscoder{}
+	result, err := transcoder.Transcode(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Transcode() error = %v", err)
+	}
+	if result.BlurHash == "" {
+		t.Error("result.BlurHash should be non-empty for a valid image")
+	}
+}
+
+func TestBlurHashTranscoderRejectsMissingFile(t *testing.T) {
+	transcoder := &blurHashTranscoder{}
+	if _, err := transcoder.Transcode(context.Background(), "/nonexistent/path.png"); err == nil {
+		t.Error("Transcode() error = nil, want an error for a missing file")
+	}
+}
@@ -0,0 +1,83 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Plugin is a discovered export plugin: its parsed manifest plus the directory it was
+// found in, so Run can resolve Command relative to it.
+type Plugin struct {
+	Manifest Manifest
+	Dir      string
+}
+
+// DefaultPluginDirs returns the directories FindPlugins scans when the caller doesn't
+// supply its own list, in Helm's own search order: the working directory's "./plugins",
+// the user's "$HOME/.wpxmlrpc/plugins", then the system-wide "/etc/wpxmlrpc/plugins".
+func DefaultPluginDirs() []string {
+	dirs := []string{"./plugins"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".wpxmlrpc", "plugins"))
+	}
+	return append(dirs, "/etc/wpxmlrpc/plugins")
+}
+
+// FindPlugins scans dirs for plugin subdirectories containing a plugin.yaml manifest,
+// following Helm's layout of one plugin per immediate subdirectory (dir/<plugin-name>/
+// plugin.yaml). Missing directories are skipped rather than treated as errors, since not
+// every search path is expected to exist. A manifest that fails to parse is skipped with
+// its error silently absorbed, so one broken plugin doesn't prevent the rest from loading.
+func FindPlugins(dirs []string) ([]Plugin, error) {
+	var plugins []Plugin
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+
+			pluginDir := filepath.Join(dir, entry.Name())
+			manifestPath := filepath.Join(pluginDir, "plugin.yaml")
+
+			data, err := os.ReadFile(manifestPath)
+			if err != nil {
+				continue
+			}
+
+			manifest, err := parseManifest(data)
+			if err != nil {
+				continue
+			}
+
+			plugins = append(plugins, Plugin{Manifest: manifest, Dir: pluginDir})
+		}
+	}
+
+	return plugins, nil
+}
+
+// FindByFormat scans dirs for a plugin whose manifest declares it handles format, returning
+// the first match. ok is false when no plugin supports format.
+func FindByFormat(dirs []string, format string) (Plugin, bool, error) {
+	plugins, err := FindPlugins(dirs)
+	if err != nil {
+		return Plugin{}, false, err
+	}
+
+	for _, p := range plugins {
+		if p.Manifest.Format == format {
+			return p, true, nil
+		}
+	}
+
+	return Plugin{}, false, nil
+}
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeManifest(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	pluginDir := filepath.Join(dir, name)
+	if err := os.MkdirAll(pluginDir, 0750); err != nil {
+		t.Fatalf("failed to create plugin directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, "plugin.yaml"), []byte(contents), 0600); err != nil {
+		t.Fatalf("failed to write plugin.yaml: %v", err)
+	}
+}
+
+func TestFindPluginsDiscoversManifestsAcrossDirs(t *testing.T) {
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+
+	writeManifest(t, dirA, "wpexport-zola", "name: wpexport-zola\nformat: zola\ncommand: wpexport-zola\n")
+	writeManifest(t, dirB, "wpexport-ghost", "name: wpexport-ghost\nformat: ghost-json\ncommand: wpexport-ghost\n")
+
+	plugins, err := FindPlugins([]string{dirA, dirB})
+	if err != nil {
+		t.Fatalf("FindPlugins() error = %v", err)
+	}
+	if len(plugins) != 2 {
+		t.Fatalf("FindPlugins() returned %d plugins, want 2", len(plugins))
+	}
+}
+
+func TestFindPluginsSkipsMissingDirectories(t *testing.T) {
+	plugins, err := FindPlugins([]string{"/nonexistent/plugins/dir"})
+	if err != nil {
+		t.Fatalf("FindPlugins() error = %v, want nil for a missing directory", err)
+	}
+	if plugins != nil {
+		t.Errorf("FindPlugins() = %v, want nil for a missing directory", plugins)
+	}
+}
+
+func TestFindPluginsSkipsSubdirectoriesWithoutAManifest(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "not-a-plugin"), 0750); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	plugins, err := FindPlugins([]string{dir})
+	if err != nil {
+		t.Fatalf("FindPlugins() error = %v", err)
+	}
+	if len(plugins) != 0 {
+		t.Errorf("FindPlugins() = %v, want none", plugins)
+	}
+}
+
+func TestFindByFormatReturnsMatchingPlugin(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "wpexport-zola", "name: wpexport-zola\nformat: zola\ncommand: wpexport-zola\n")
+
+	p, ok, err := FindByFormat([]string{dir}, "zola")
+	if err != nil {
+		t.Fatalf("FindByFormat() error = %v", err)
+	}
+	if !ok || p.Manifest.Name != "wpexport-zola" {
+		t.Errorf("FindByFormat() = %+v, %v, want the wpexport-zola plugin", p, ok)
+	}
+
+	_, ok, err = FindByFormat([]string{dir}, "nope")
+	if err != nil {
+		t.Fatalf("FindByFormat() error = %v", err)
+	}
+	if ok {
+		t.Error("FindByFormat() ok = true for an unsupported format, want false")
+	}
+}
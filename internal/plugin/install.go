@@ -0,0 +1,60 @@
+package plugin
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Install downloads a plugin's tar.gz archive from url and extracts it into
+// pluginsDir/<name>, where <name> is read from the archive's own plugin.yaml. Returns the
+// installed plugin's manifest.
+func Install(url, pluginsDir string) (Manifest, error) {
+	resp, err := http.Get(url) //nolint:gosec // installing a plugin from a user-supplied URL is the feature
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to download plugin from %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Manifest{}, fmt.Errorf("failed to download plugin from %s: server returned %s", url, resp.Status)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("plugin archive at %s is not a valid gzip stream: %w", url, err)
+	}
+	defer gz.Close()
+
+	stagingDir, err := os.MkdirTemp(pluginsDir, ".install-*")
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := extractTar(gz, stagingDir); err != nil {
+		return Manifest{}, fmt.Errorf("failed to extract plugin archive: %w", err)
+	}
+
+	manifestPath := filepath.Join(stagingDir, "plugin.yaml")
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("plugin archive is missing a top-level plugin.yaml: %w", err)
+	}
+	manifest, err := parseManifest(data)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("invalid plugin.yaml: %w", err)
+	}
+
+	installDir := filepath.Join(pluginsDir, manifest.Name)
+	if err := os.RemoveAll(installDir); err != nil {
+		return Manifest{}, fmt.Errorf("failed to remove existing plugin directory %s: %w", installDir, err)
+	}
+	if err := os.Rename(stagingDir, installDir); err != nil {
+		return Manifest{}, fmt.Errorf("failed to install plugin to %s: %w", installDir, err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,84 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Manifest describes an external export plugin, parsed from a plugin.yaml manifest file
+// modeled on Helm's plugin.yaml: enough metadata to list the plugin and to invoke its
+// executable for a given export format.
+type Manifest struct {
+	// Name is the plugin's identifier, e.g. "wpexport-zola".
+	Name string
+	// Description is a one-line summary shown by `plugin list`.
+	Description string
+	// Format is the export format this plugin handles, selected via --format/Config.Format
+	// (e.g. "zola", "ghost-json", "wxr").
+	Format string
+	// Command is the executable to run, resolved relative to the plugin's directory when
+	// it doesn't contain a path separator.
+	Command string
+	// Args are additional arguments passed to Command before the plugin contract's own
+	// positional arguments.
+	Args []string
+}
+
+// parseManifest parses a plugin.yaml's contents. Only the small, flat subset of YAML the
+// manifest actually needs is supported: "key: value" scalars and an "args:" block of
+// "  - value" list items, consistent with this repo's other hand-written format parsers
+// (see internal/export/frontmatter.go) rather than taking on an unvendored YAML dependency.
+func parseManifest(data []byte) (Manifest, error) {
+	var m Manifest
+	lines := strings.Split(string(data), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch key {
+		case "name":
+			m.Name = value
+		case "description":
+			m.Description = value
+		case "format":
+			m.Format = value
+		case "command":
+			m.Command = value
+		case "args":
+			if value != "" {
+				continue // inline "args: [a, b]" form isn't supported, only the block form below
+			}
+			for i+1 < len(lines) {
+				item := strings.TrimSpace(lines[i+1])
+				if !strings.HasPrefix(item, "- ") {
+					break
+				}
+				m.Args = append(m.Args, strings.TrimSpace(strings.TrimPrefix(item, "-")))
+				i++
+			}
+		}
+	}
+
+	if m.Name == "" {
+		return Manifest{}, fmt.Errorf("plugin.yaml missing required \"name\" field")
+	}
+	if m.Format == "" {
+		return Manifest{}, fmt.Errorf("plugin.yaml missing required \"format\" field")
+	}
+	if m.Command == "" {
+		return Manifest{}, fmt.Errorf("plugin.yaml missing required \"command\" field")
+	}
+
+	return m, nil
+}
@@ -0,0 +1,52 @@
+package plugin
+
+import "testing"
+
+func TestParseManifestParsesScalarsAndArgsBlock(t *testing.T) {
+	data := []byte(`
+name: wpexport-zola
+description: Export to a Zola content tree
+format: zola
+command: ./bin/wpexport-zola
+args:
+  - --verbose
+  - --theme=minimal
+`)
+
+	m, err := parseManifest(data)
+	if err != nil {
+		t.Fatalf("parseManifest() error = %v", err)
+	}
+
+	if m.Name != "wpexport-zola" || m.Format != "zola" || m.Command != "./bin/wpexport-zola" {
+		t.Errorf("parseManifest() = %+v, missing expected scalar fields", m)
+	}
+	if len(m.Args) != 2 || m.Args[0] != "--verbose" || m.Args[1] != "--theme=minimal" {
+		t.Errorf("parseManifest() Args = %v, want [--verbose --theme=minimal]", m.Args)
+	}
+}
+
+func TestParseManifestRejectsMissingRequiredFields(t *testing.T) {
+	_, err := parseManifest([]byte("description: missing everything else\n"))
+	if err == nil {
+		t.Error("parseManifest() expected an error for a manifest missing name/format/command")
+	}
+}
+
+func TestParseManifestIgnoresCommentsAndBlankLines(t *testing.T) {
+	data := []byte(`
+# this is a plugin manifest
+name: wpexport-ghost
+
+format: ghost-json
+command: wpexport-ghost
+`)
+
+	m, err := parseManifest(data)
+	if err != nil {
+		t.Fatalf("parseManifest() error = %v", err)
+	}
+	if m.Name != "wpexport-ghost" || m.Format != "ghost-json" {
+		t.Errorf("parseManifest() = %+v, unexpected result", m)
+	}
+}
@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// Run invokes the plugin's Command with Args, feeding it the export payload as JSON on
+// stdin and reading a tar stream of output files from stdout, which it extracts into
+// outputDir. Command is resolved relative to the plugin's directory unless it already
+// contains a path separator (so a manifest can also give an explicit path); a bare name is
+// never looked up on PATH.
+func (p Plugin) Run(data *models.ExportData, outputDir string) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export data for plugin %s: %w", p.Manifest.Name, err)
+	}
+
+	command := p.Manifest.Command
+	if !strings.ContainsRune(command, filepath.Separator) {
+		command = filepath.Join(p.Dir, command)
+	}
+
+	cmd := exec.Command(command, p.Manifest.Args...)
+	cmd.Dir = p.Dir
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "WPEXPORT_FORMAT="+p.Manifest.Format, "WPEXPORT_OUTPUT="+outputDir)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("plugin %s failed: %w (stderr: %s)", p.Manifest.Name, err, stderr.String())
+	}
+
+	if err := os.MkdirAll(outputDir, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return extractTar(&stdout, outputDir)
+}
+
+// extractTar writes a tar stream's regular files into dir, creating parent directories as
+// needed. Entry names are cleaned and rejected if they'd escape dir, since the stream
+// comes from an external plugin process.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read plugin output tar stream: %w", err)
+		}
+
+		name := filepath.Clean(header.Name)
+		if name == ".." || strings.HasPrefix(name, "../") || filepath.IsAbs(name) {
+			return fmt.Errorf("plugin output tar entry %q escapes output directory", header.Name)
+		}
+		targetPath := filepath.Join(dir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(targetPath, 0750); err != nil {
+				return fmt.Errorf("failed to create directory %s from plugin output: %w", targetPath, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(targetPath), 0750); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", targetPath, err)
+			}
+			file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			if err != nil {
+				return fmt.Errorf("failed to create file %s from plugin output: %w", targetPath, err)
+			}
+			if _, err := io.CopyN(file, tr, header.Size); err != nil && err != io.EOF {
+				file.Close()
+				return fmt.Errorf("failed to write file %s from plugin output: %w", targetPath, err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to close file %s: %w", targetPath, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package progress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestResolveModeHonorsExplicitSetting(t *testing.T) {
+	for _, mode := range []string{"always", "never", "json"} {
+		cfg := &config.Config{Progress: mode}
+		if got := resolveMode(cfg); got != Mode(mode) {
+			t.Errorf("resolveMode(Progress=%q) = %q, want %q", mode, got, mode)
+		}
+	}
+}
+
+func TestResolveModeAutoFallsBackWhenNotATTY(t *testing.T) {
+	cfg := &config.Config{Progress: "auto"}
+	if got := resolveMode(cfg); got != modeNever {
+		t.Errorf("resolveMode(Progress=auto) in a non-TTY test run = %q, want %q", got, modeNever)
+	}
+}
+
+func TestNewCountBarNeverModeDoesNotPanic(t *testing.T) {
+	m := &Manager{mode: modeNever}
+	bar := m.NewCountBar("Scanning posts", 10)
+
+	for i := 0; i < 10; i++ {
+		bar.Add(1)
+	}
+	bar.Finish()
+}
+
+func TestNewCountBarNeverModeHonorsDescribe(t *testing.T) {
+	m := &Manager{mode: modeNever}
+	bar := m.NewCountBar("Scanning posts", 10)
+
+	// Describe should not panic and should be reflected by the next log line; logBar has
+	// no exported way to read its label back, so this just exercises the call.
+	bar.Describe("Scanning posts (5.0 req/s)")
+	bar.Add(1)
+	bar.Finish()
+}
+
+func TestCountingReaderReportsBytesRead(t *testing.T) {
+	var got int
+	bar := &fakeBar{onAdd: func(n int) { got += n }}
+
+	r := NewCountingReader(strings.NewReader("hello world"), bar)
+	buf := make([]byte, 5)
+	for {
+		n, err := r.Read(buf)
+		if n == 0 && err != nil {
+			break
+		}
+	}
+
+	if got != len("hello world") {
+		t.Errorf("CountingReader reported %d bytes, want %d", got, len("hello world"))
+	}
+}
+
+type fakeBar struct {
+	onAdd func(n int)
+}
+
+func (b *fakeBar) Add(n int)       { b.onAdd(n) }
+func (b *fakeBar) Describe(string) {}
+func (b *fakeBar) Finish()         {}
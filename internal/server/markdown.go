@@ -0,0 +1,71 @@
+package server
+
+import (
+	"html"
+	"strings"
+)
+
+// markdownToHTML performs a basic Markdown to HTML conversion for the preview server's
+// on-the-fly rendering of .md exports. It's the inverse of
+// internal/export.Exporter.convertHTMLToMarkdown and just as simplified - for anything
+// beyond headings, bold/italic, and paragraphs, render the full export to a format with
+// proper HTML output instead.
+func markdownToHTML(md string) string {
+	var out strings.Builder
+
+	for _, line := range strings.Split(strings.TrimRight(md, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "###### "):
+			out.WriteString("<h6>" + inlineHTML(trimmed[7:]) + "</h6>\n")
+		case strings.HasPrefix(trimmed, "##### "):
+			out.WriteString("<h5>" + inlineHTML(trimmed[6:]) + "</h5>\n")
+		case strings.HasPrefix(trimmed, "#### "):
+			out.WriteString("<h4>" + inlineHTML(trimmed[5:]) + "</h4>\n")
+		case strings.HasPrefix(trimmed, "### "):
+			out.WriteString("<h3>" + inlineHTML(trimmed[4:]) + "</h3>\n")
+		case strings.HasPrefix(trimmed, "## "):
+			out.WriteString("<h2>" + inlineHTML(trimmed[3:]) + "</h2>\n")
+		case strings.HasPrefix(trimmed, "# "):
+			out.WriteString("<h1>" + inlineHTML(trimmed[2:]) + "</h1>\n")
+		case trimmed == "":
+			out.WriteString("\n")
+		default:
+			out.WriteString("<p>" + inlineHTML(trimmed) + "</p>\n")
+		}
+	}
+
+	return out.String()
+}
+
+// inlineHTML escapes text then applies **bold** and *italic* Markdown inline.
+func inlineHTML(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = replacePairs(escaped, "**", "<strong>", "</strong>")
+	escaped = replacePairs(escaped, "*", "<em>", "</em>")
+	return escaped
+}
+
+// replacePairs replaces alternating occurrences of marker with open then close, so
+// "a **b** c **d**" becomes "a <strong>b</strong> c <strong>d</strong>".
+func replacePairs(s, marker, open, closeTag string) string {
+	parts := strings.Split(s, marker)
+	if len(parts) < 3 {
+		return s
+	}
+
+	var out strings.Builder
+	for i, part := range parts {
+		out.WriteString(part)
+		if i == len(parts)-1 {
+			continue
+		}
+		if i%2 == 0 {
+			out.WriteString(open)
+		} else {
+			out.WriteString(closeTag)
+		}
+	}
+	return out.String()
+}
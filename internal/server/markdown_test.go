@@ -0,0 +1,23 @@
+package server
+
+import "testing"
+
+func TestMarkdownToHTMLHeadingsAndInline(t *testing.T) {
+	md := "# Title\n\nSome **bold** and *italic* text.\n"
+
+	got := markdownToHTML(md)
+
+	want := "<h1>Title</h1>\n\n<p>Some <strong>bold</strong> and <em>italic</em> text.</p>\n"
+	if got != want {
+		t.Errorf("markdownToHTML() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownToHTMLEscapesHTML(t *testing.T) {
+	got := markdownToHTML("<script>alert(1)</script>")
+	want := "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"
+
+	if got != want {
+		t.Errorf("markdownToHTML() = %q, want %q", got, want)
+	}
+}
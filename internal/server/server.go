@@ -0,0 +1,195 @@
+// Package server implements a small preview server for a directory previously written by
+// internal/export: it serves files as-is, rendering Markdown files to HTML on the fly, and
+// exposes an SSE endpoint that pushes a reload event to connected browsers whenever the
+// export is re-run (see Server.Reload).
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Server serves Root over HTTP at Addr, converting .md files to HTML on the fly and
+// pushing browser reload events to anyone connected to /__wpexport/events.
+type Server struct {
+	Addr string
+	Root string
+
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+// New returns a Server serving root at addr. It does not start listening until
+// ListenAndServe is called.
+func New(addr, root string) *Server {
+	return &Server{Addr: addr, Root: root, clients: map[chan struct{}]struct{}{}}
+}
+
+// Reload notifies every connected browser to refresh. Safe to call concurrently with
+// ListenAndServe, e.g. from a --watch re-export loop.
+func (s *Server) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Handler builds the server's http.Handler: the SSE reload endpoint plus a file handler
+// rooted at s.Root.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/__wpexport/events", s.handleEvents)
+	mux.HandleFunc("/", s.handleFile)
+	return mux
+}
+
+// ListenAndServe starts the server and blocks until ctx is canceled, at which point it
+// shuts down gracefully, waiting up to 5s for in-flight requests to finish.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	httpServer := &http.Server{Addr: s.Addr, Handler: s.Handler()}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("preview server failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shut down preview server: %w", err)
+		}
+		return nil
+	}
+}
+
+// handleEvents implements the SSE endpoint the injected reload script connects to: it
+// holds the connection open and writes a "data: reload" event each time Reload is called.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			if _, err := fmt.Fprint(w, "data: reload\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// handleFile serves a file from s.Root, rendering Markdown to an HTML page (with the
+// reload script injected) rather than returning it as plain text.
+func (s *Server) handleFile(w http.ResponseWriter, r *http.Request) {
+	reqPath := r.URL.Path
+	if reqPath == "/" {
+		reqPath = "/index.md"
+	}
+
+	fullPath := filepath.Join(s.Root, filepath.Clean(reqPath))
+	if filepath.Ext(fullPath) != ".md" {
+		http.ServeFile(w, r, fullPath)
+		return
+	}
+
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprint(w, "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"></head>\n<body>\n")
+	_, _ = fmt.Fprint(w, markdownToHTML(string(data)))
+	_, _ = fmt.Fprint(w, reloadScript)
+	_, _ = fmt.Fprint(w, "\n</body>\n</html>\n")
+}
+
+// WatchStateFile polls path's modification time every interval until ctx is canceled,
+// calling onChange whenever it changes. It's the --watch fallback for trees without
+// fsnotify: path is typically the incremental export's state file written by
+// internal/state, whose mtime changes exactly when a re-export produced new content.
+func WatchStateFile(ctx context.Context, path string, interval time.Duration, onChange func()) {
+	var lastMod time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if info.ModTime().After(lastMod) {
+				if !lastMod.IsZero() {
+					onChange()
+				}
+				lastMod = info.ModTime()
+			}
+		}
+	}
+}
+
+// reloadScript is injected into every rendered Markdown page. It connects to the SSE
+// reload endpoint and reconnects with exponential backoff if the connection drops, e.g.
+// while the preview server restarts mid re-export.
+const reloadScript = `
+<script>
+(function() {
+  var backoff = 500;
+  function connect() {
+    var es = new EventSource("/__wpexport/events");
+    es.onmessage = function() { location.reload(); };
+    es.onerror = function() {
+      es.close();
+      setTimeout(connect, backoff);
+      backoff = Math.min(backoff * 2, 10000);
+    };
+    es.onopen = function() { backoff = 500; };
+  }
+  connect();
+})();
+</script>
+`
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHandleFileRendersMarkdownAsHTML(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Hello\n"), 0644); err != nil {
+		t.Fatalf("failed to write index.md: %v", err)
+	}
+
+	srv := New(":0", dir)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/")
+	if err != nil {
+		t.Fatalf("GET / error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/html", resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestHandleFileServesNonMarkdownAsIs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{"ok":true}`), 0644); err != nil {
+		t.Fatalf("failed to write data.json: %v", err)
+	}
+
+	srv := New(":0", dir)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/data.json")
+	if err != nil {
+		t.Fatalf("GET /data.json error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	buf := make([]byte, 32)
+	n, _ := resp.Body.Read(buf)
+	if !strings.Contains(string(buf[:n]), `"ok":true`) {
+		t.Errorf("body = %q, want it to contain the raw JSON", buf[:n])
+	}
+}
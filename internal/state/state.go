@@ -0,0 +1,129 @@
+// Package state persists the bookkeeping an incremental export needs between runs: when
+// the export last ran, and a content hash per item per endpoint so a later run can tell
+// which items were added, updated, or deleted without re-fetching everything in full.
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// EndpointState tracks one endpoint's (posts, pages, media) incremental progress: the
+// modified_after cursor to pass on the next run, and the last-seen content hash per ID.
+type EndpointState struct {
+	ModifiedAfter string         `json:"modified_after"`
+	Hashes        map[int]string `json:"hashes"`
+}
+
+// State is a small sidecar JSON file recording when an export last ran and, per endpoint,
+// enough of its prior content to reconcile what changed on the next run. It mirrors
+// internal/api.Checkpoint's load/save idiom.
+type State struct {
+	LastExportedAt time.Time                `json:"last_exported_at"`
+	Endpoints      map[string]EndpointState `json:"endpoints"`
+
+	path string
+}
+
+// Manifest lists the IDs that changed since the previous export, as computed by Reconcile.
+type Manifest struct {
+	Added   []int `json:"added"`
+	Updated []int `json:"updated"`
+	Deleted []int `json:"deleted"`
+}
+
+// Load reads a State from path, returning an empty, unsaved State if the file doesn't
+// exist yet (the first run of an incremental export).
+func Load(path string) (*State, error) {
+	s := &State{Endpoints: map[string]EndpointState{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read state %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse state %s: %w", path, err)
+	}
+	s.path = path
+
+	return s, nil
+}
+
+// Save persists the state to its backing path.
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Reconcile diffs current (the full set of IDs present in endpoint right now, each mapped
+// to its content hash) against the hashes stored from the previous run, returning which
+// IDs were added, updated, or deleted, and replaces the stored hashes with current.
+//
+// current must cover every ID the endpoint presently has, not just the ones an
+// incremental modified_after fetch returned, or deletions can't be detected: a deleted
+// post simply won't appear in a modified_after-filtered fetch, the same as one that was
+// never modified. Callers that want to avoid re-fetching full content for unchanged items
+// should pair a cheap full ID+modified_gmt listing (to build current) with a
+// modified_after-filtered fetch (to get full content bodies only for what changed).
+func (s *State) Reconcile(endpoint string, current map[int]string) Manifest {
+	prev := s.Endpoints[endpoint]
+
+	var manifest Manifest
+	for id, hash := range current {
+		if oldHash, ok := prev.Hashes[id]; !ok {
+			manifest.Added = append(manifest.Added, id)
+		} else if oldHash != hash {
+			manifest.Updated = append(manifest.Updated, id)
+		}
+	}
+	for id := range prev.Hashes {
+		if _, ok := current[id]; !ok {
+			manifest.Deleted = append(manifest.Deleted, id)
+		}
+	}
+
+	sort.Ints(manifest.Added)
+	sort.Ints(manifest.Updated)
+	sort.Ints(manifest.Deleted)
+
+	hashes := make(map[int]string, len(current))
+	for id, hash := range current {
+		hashes[id] = hash
+	}
+	s.Endpoints[endpoint] = EndpointState{ModifiedAfter: prev.ModifiedAfter, Hashes: hashes}
+
+	return manifest
+}
+
+// SetModifiedAfter records the modified_after cursor to use for endpoint's next run.
+func (s *State) SetModifiedAfter(endpoint, modifiedAfter string) {
+	ep := s.Endpoints[endpoint]
+	ep.ModifiedAfter = modifiedAfter
+	s.Endpoints[endpoint] = ep
+}
+
+// ContentHash returns a stable hash of an item's content fields, suitable for detecting
+// whether an item changed between two runs without comparing full bodies.
+func ContentHash(fields ...string) string {
+	h := sha256.New()
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReconcileDetectsAddedUpdatedAndDeleted(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	first := map[int]string{1: ContentHash("Hello", "v1"), 2: ContentHash("World", "v1")}
+	manifest := s.Reconcile("posts", first)
+	if len(manifest.Added) != 2 || len(manifest.Updated) != 0 || len(manifest.Deleted) != 0 {
+		t.Fatalf("first Reconcile() = %+v, want 2 added and nothing else", manifest)
+	}
+
+	second := map[int]string{1: ContentHash("Hello", "v1"), 2: ContentHash("World", "v2"), 3: ContentHash("New", "v1")}
+	manifest = s.Reconcile("posts", second)
+
+	if len(manifest.Added) != 1 || manifest.Added[0] != 3 {
+		t.Errorf("Added = %v, want [3]", manifest.Added)
+	}
+	if len(manifest.Updated) != 1 || manifest.Updated[0] != 2 {
+		t.Errorf("Updated = %v, want [2]", manifest.Updated)
+	}
+	if len(manifest.Deleted) != 0 {
+		t.Errorf("Deleted = %v, want none", manifest.Deleted)
+	}
+
+	third := map[int]string{1: ContentHash("Hello", "v1")}
+	manifest = s.Reconcile("posts", third)
+
+	if len(manifest.Deleted) != 2 || manifest.Deleted[0] != 2 || manifest.Deleted[1] != 3 {
+		t.Errorf("Deleted = %v, want [2 3]", manifest.Deleted)
+	}
+}
+
+func TestStateSaveAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	s.Reconcile("posts", map[int]string{1: "abc"})
+	s.SetModifiedAfter("posts", "2024-05-01T00:00:00Z")
+
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() reload error = %v", err)
+	}
+
+	ep, ok := reloaded.Endpoints["posts"]
+	if !ok {
+		t.Fatal("Endpoints[posts] missing after reload")
+	}
+	if ep.ModifiedAfter != "2024-05-01T00:00:00Z" {
+		t.Errorf("ModifiedAfter = %q, want %q", ep.ModifiedAfter, "2024-05-01T00:00:00Z")
+	}
+	if ep.Hashes[1] != "abc" {
+		t.Errorf("Hashes[1] = %q, want %q", ep.Hashes[1], "abc")
+	}
+}
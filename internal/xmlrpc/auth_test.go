@@ -0,0 +1,175 @@
+package xmlrpc
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// decodeRequestParams reads and parses an incoming XML-RPC request body into its Params,
+// for assertions against what an Authenticator did to the body.
+func decodeRequestParams(t *testing.T, r *http.Request) []Param {
+	t.Helper()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+
+	var req XMLRPCRequest
+	if err := xml.Unmarshal(body, &req); err != nil {
+		t.Fatalf("failed to unmarshal request body: %v", err)
+	}
+
+	return req.Params
+}
+
+func emptyMediaResponse(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`)
+}
+
+func TestBasicAuthSendsCredentialsInBody(t *testing.T) {
+	var gotAuthHeader string
+	var params []Param
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		params = decodeRequestParams(t, r)
+		emptyMediaResponse(w)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "wpuser", "wppass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetMedia(); err != nil {
+		t.Fatalf("GetMedia() error = %v", err)
+	}
+
+	if gotAuthHeader != "" {
+		t.Errorf("Authorization header = %q, want empty for BasicAuth", gotAuthHeader)
+	}
+	if len(params) < 3 {
+		t.Fatalf("request has %d params, want at least 3", len(params))
+	}
+	if got, ok := params[1].Value.Decode().(string); !ok || got != "wpuser" {
+		t.Errorf("username param = %v, want %q", params[1].Value.Decode(), "wpuser")
+	}
+	if got, ok := params[2].Value.Decode().(string); !ok || got != "wppass" {
+		t.Errorf("password param = %v, want %q", params[2].Value.Decode(), "wppass")
+	}
+}
+
+func TestAppPasswordSetsBasicAuthHeaderAndBlanksBody(t *testing.T) {
+	var gotUser, gotPass string
+	var gotOK bool
+	var params []Param
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotPass, gotOK = r.BasicAuth()
+		params = decodeRequestParams(t, r)
+		emptyMediaResponse(w)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "wpuser-body", "wppass-body",
+		WithAuth(&AppPassword{Username: "wpuser", Password: "app-specific-password"}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetMedia(); err != nil {
+		t.Fatalf("GetMedia() error = %v", err)
+	}
+
+	if !gotOK || gotUser != "wpuser" || gotPass != "app-specific-password" {
+		t.Errorf("BasicAuth() = (%q, %q, %v), want (wpuser, app-specific-password, true)", gotUser, gotPass, gotOK)
+	}
+	if len(params) < 3 {
+		t.Fatalf("request has %d params, want at least 3", len(params))
+	}
+	if got, _ := params[1].Value.Decode().(string); got != "" {
+		t.Errorf("username param = %q, want blank", got)
+	}
+	if got, _ := params[2].Value.Decode().(string); got != "" {
+		t.Errorf("password param = %q, want blank", got)
+	}
+}
+
+func TestBearerTokenSetsAuthorizationHeaderAndBlanksBody(t *testing.T) {
+	var gotAuthHeader string
+	var params []Param
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		params = decodeRequestParams(t, r)
+		emptyMediaResponse(w)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "wpuser-body", "wppass-body",
+		WithAuth(&BearerToken{Token: "abc123"}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetMedia(); err != nil {
+		t.Fatalf("GetMedia() error = %v", err)
+	}
+
+	if gotAuthHeader != "Bearer abc123" {
+		t.Errorf("Authorization header = %q, want %q", gotAuthHeader, "Bearer abc123")
+	}
+	if got, _ := params[1].Value.Decode().(string); got != "" {
+		t.Errorf("username param = %q, want blank", got)
+	}
+}
+
+func TestOAuth1SetsSignedAuthorizationHeaderAndBlanksBody(t *testing.T) {
+	var gotAuthHeader string
+	var params []Param
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		params = decodeRequestParams(t, r)
+		emptyMediaResponse(w)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "wpuser-body", "wppass-body",
+		WithAuth(&OAuth1{
+			ConsumerKey:    "consumer-key",
+			ConsumerSecret: "consumer-secret",
+			Token:          "access-token",
+			TokenSecret:    "access-secret",
+		}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetMedia(); err != nil {
+		t.Fatalf("GetMedia() error = %v", err)
+	}
+
+	if !strings.HasPrefix(gotAuthHeader, "OAuth ") {
+		t.Fatalf("Authorization header = %q, want an OAuth-prefixed header", gotAuthHeader)
+	}
+	if !strings.Contains(gotAuthHeader, `oauth_consumer_key="consumer-key"`) {
+		t.Errorf("Authorization header %q missing oauth_consumer_key", gotAuthHeader)
+	}
+	if !strings.Contains(gotAuthHeader, "oauth_signature=") {
+		t.Errorf("Authorization header %q missing oauth_signature", gotAuthHeader)
+	}
+	if got, _ := params[2].Value.Decode().(string); got != "" {
+		t.Errorf("password param = %q, want blank", got)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -16,31 +15,74 @@ import (
 
 // Client represents a WordPress XML-RPC client
 type Client struct {
-	config   *config.Config
-	username string
-	password string
-	endpoint string
-	blogID   int
+	config     *config.Config
+	username   string
+	password   string
+	endpoint   string
+	blogID     int
+	httpClient *http.Client
+	auth       Authenticator
+
+	// OnAttempt, when set, is called after every attempt makeRequest makes (including
+	// the final, successful or not) with the 1-indexed attempt number and that
+	// attempt's error (nil on success). It lets callers observe retries for logging
+	// or progress reporting without makeRequest itself taking on that responsibility.
+	OnAttempt func(attempt int, err error)
 }
 
-// NewClient creates a new WordPress XML-RPC client
-func NewClient(cfg *config.Config, username, password string) (*Client, error) {
+// ClientOption customizes a Client beyond NewClient's required arguments.
+type ClientOption func(*Client)
+
+// WithAuth overrides the BasicAuth NewClient builds from username/password with a
+// different Authenticator (AppPassword, BearerToken, or OAuth1), for WordPress
+// deployments that have disabled XML-RPC's plain username/password authentication.
+func WithAuth(auth Authenticator) ClientOption {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// NewClient creates a new WordPress XML-RPC client, authenticating with username and
+// password as plain XML-RPC basic auth unless overridden via WithAuth.
+func NewClient(cfg *config.Config, username, password string, opts ...ClientOption) (*Client, error) {
 	// Parse and validate URL
 	parsedURL, err := url.Parse(cfg.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid URL: %w", err)
 	}
 
+	origin := strings.TrimSuffix(parsedURL.String(), "/")
+	if cfg.ResolveRedirects {
+		canonical, err := config.ResolveBaseURL(cfg, cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve canonical URL: %w", err)
+		}
+		origin = canonical
+	}
+
 	// Construct XML-RPC endpoint
-	endpoint := strings.TrimSuffix(parsedURL.String(), "/") + "/xmlrpc.php"
+	endpoint := origin + "/xmlrpc.php"
+
+	httpClient, err := buildHTTPClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP transport: %w", err)
+	}
+
+	client := &Client{
+		config:     cfg,
+		username:   username,
+		password:   password,
+		endpoint:   endpoint,
+		blogID:     1, // Default blog ID
+		httpClient: httpClient,
+		auth:       &BasicAuth{Username: username, Password: password},
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
 
-	return &Client{
-		config:   cfg,
-		username: username,
-		password: password,
-		endpoint: endpoint,
-		blogID:   1, // Default blog ID
-	}, nil
+	return client, nil
 }
 
 // XMLRPCRequest represents an XML-RPC request
@@ -55,14 +97,6 @@ type Param struct {
 	Value Value `xml:"value"`
 }
 
-// Value represents an XML-RPC value
-type Value struct {
-	String *string `xml:"string,omitempty"`
-	Int    *int    `xml:"int,omitempty"`
-	Struct *Struct `xml:"struct,omitempty"`
-	Array  *Array  `xml:"array,omitempty"`
-}
-
 // Struct represents an XML-RPC struct
 type Struct struct {
 	Members []Member `xml:"member"`
@@ -138,8 +172,18 @@ func (c *Client) GetSiteInfo() (*models.SiteInfo, error) {
 	return siteInfo, nil
 }
 
+// progressEvery is how many items pass between onItem callbacks in the *WithProgress
+// variants below.
+const progressEvery = 10
+
 // GetPosts retrieves all posts
 func (c *Client) GetPosts() ([]models.WordPressPost, error) {
+	return c.GetPostsWithProgress(nil)
+}
+
+// GetPostsWithProgress retrieves all posts, invoking onItem with the cumulative count
+// every few items so a caller can stream progress instead of waiting for the final total.
+func (c *Client) GetPostsWithProgress(onItem func(int)) ([]models.WordPressPost, error) {
 	var allPosts []models.WordPressPost
 	offset := 0
 	limit := 100
@@ -167,12 +211,15 @@ func (c *Client) GetPosts() ([]models.WordPressPost, error) {
 			return nil, fmt.Errorf("failed to get posts: %w", err)
 		}
 
-		posts := c.parsePostsResponse(resp)
+		posts := c.parsePostsResponse(resp, "post")
 		if len(posts) == 0 {
 			break
 		}
 
 		allPosts = append(allPosts, posts...)
+		if onItem != nil && len(allPosts)/progressEvery != (len(allPosts)-len(posts))/progressEvery {
+			onItem(len(allPosts))
+		}
 		offset += limit
 
 		if len(posts) < limit {
@@ -185,6 +232,12 @@ func (c *Client) GetPosts() ([]models.WordPressPost, error) {
 
 // GetPages retrieves all pages
 func (c *Client) GetPages() ([]models.WordPressPost, error) {
+	return c.GetPagesWithProgress(nil)
+}
+
+// GetPagesWithProgress retrieves all pages, invoking onItem with the cumulative count
+// every few items so a caller can stream progress instead of waiting for the final total.
+func (c *Client) GetPagesWithProgress(onItem func(int)) ([]models.WordPressPost, error) {
 	var allPages []models.WordPressPost
 	offset := 0
 	limit := 100
@@ -212,12 +265,15 @@ func (c *Client) GetPages() ([]models.WordPressPost, error) {
 			return nil, fmt.Errorf("failed to get pages: %w", err)
 		}
 
-		pages := c.parsePostsResponse(resp)
+		pages := c.parsePostsResponse(resp, "page")
 		if len(pages) == 0 {
 			break
 		}
 
 		allPages = append(allPages, pages...)
+		if onItem != nil && len(allPages)/progressEvery != (len(allPages)-len(pages))/progressEvery {
+			onItem(len(allPages))
+		}
 		offset += limit
 
 		if len(pages) < limit {
@@ -230,6 +286,13 @@ func (c *Client) GetPages() ([]models.WordPressPost, error) {
 
 // GetMedia retrieves all media items
 func (c *Client) GetMedia() ([]models.WordPressMedia, error) {
+	return c.GetMediaWithProgress(nil)
+}
+
+// GetMediaWithProgress retrieves all media items, invoking onItem with the cumulative
+// count every few items so a caller can stream progress instead of waiting for the final
+// total.
+func (c *Client) GetMediaWithProgress(onItem func(int)) ([]models.WordPressMedia, error) {
 	var allMedia []models.WordPressMedia
 	offset := 0
 	limit := 100
@@ -263,6 +326,9 @@ func (c *Client) GetMedia() ([]models.WordPressMedia, error) {
 		}
 
 		allMedia = append(allMedia, media...)
+		if onItem != nil && len(allMedia)/progressEvery != (len(allMedia)-len(media))/progressEvery {
+			onItem(len(allMedia))
+		}
 		offset += limit
 
 		if len(media) < limit {
@@ -332,104 +398,239 @@ func (c *Client) GetUsers() ([]models.WordPressUser, error) {
 	return c.parseUsersResponse(resp), nil
 }
 
-// makeRequest makes an XML-RPC request
+// makeRequest makes an XML-RPC request, retrying transient failures per c.config.Retry
+// (see retry.go) and transparently compressing/decompressing the body when the server
+// supports it (see sendRequest). A permanent failure (401/403, or an XML-RPC auth
+// fault) returns immediately without consuming the remaining attempts.
 func (c *Client) makeRequest(req *XMLRPCRequest) (*XMLRPCResponse, error) {
-	// Marshal request to XML
-	xmlData, err := xml.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal XML-RPC request: %w", err)
+	maxAttempts, initialBackoff, maxBackoff, jitter := retryPolicy(c.config)
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		resp, disposition, retryAfter, err := c.attemptRequest(req)
+		if c.OnAttempt != nil {
+			c.OnAttempt(attempt+1, err)
+		}
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		if disposition == retryNever || attempt == maxAttempts-1 {
+			return nil, err
+		}
+
+		wait := backoffFor(attempt, initialBackoff, maxBackoff, jitter)
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		time.Sleep(wait)
 	}
 
-	// Add XML declaration
-	xmlRequest := []byte(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + string(xmlData))
+	return nil, lastErr
+}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(xmlRequest))
+// attemptRequest makes a single XML-RPC round trip (including the existing
+// compressed-request fallback) and classifies the outcome for makeRequest's retry loop.
+// Authentication is applied fresh on every attempt: c.auth.Apply runs against a
+// throwaway *http.Request built just to collect the headers it sets (and may blank out
+// req.Params' username/password in the process), which sendRequest then transplants
+// onto the real request alongside the XML body. Signing against a throwaway request
+// lets schemes like OAuth1 mint a new nonce/timestamp per retry instead of reusing one.
+func (c *Client) attemptRequest(req *XMLRPCRequest) (resp *XMLRPCResponse, disposition retryDisposition, retryAfter time.Duration, err error) {
+	authReq, err := http.NewRequest(http.MethodPost, c.endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, retryNever, 0, fmt.Errorf("failed to prepare XML-RPC request: %w", err)
 	}
 
-	httpReq.Header.Set("Content-Type", "text/xml")
-	httpReq.Header.Set("User-Agent", c.config.UserAgent)
+	if c.auth != nil {
+		if err := c.auth.Apply(authReq, &req.Params); err != nil {
+			return nil, retryNever, 0, fmt.Errorf("failed to apply authentication: %w", err)
+		}
+	}
 
-	// Make HTTP request
-	client := &http.Client{
-		Timeout: time.Duration(c.config.Timeout) * time.Second,
+	xmlData, err := xml.Marshal(req)
+	if err != nil {
+		return nil, retryNever, 0, fmt.Errorf("failed to marshal XML-RPC request: %w", err)
 	}
+	xmlRequest := []byte(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + string(xmlData))
 
-	httpResp, err := client.Do(httpReq)
+	httpResp, body, err := c.sendRequest(xmlRequest, c.config.CompressRequests, authReq.Header)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, classifyTransportErr(err), 0, err
 	}
 	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status: %d", httpResp.StatusCode)
+	if httpResp.StatusCode == http.StatusUnsupportedMediaType || httpResp.StatusCode == http.StatusBadRequest {
+		if c.config.CompressRequests {
+			// The server (or a proxy in front of it) rejected the compressed request;
+			// retry once uncompressed for older WordPress installs.
+			httpResp, body, err = c.sendRequest(xmlRequest, false, authReq.Header)
+			if err != nil {
+				return nil, classifyTransportErr(err), 0, err
+			}
+			defer httpResp.Body.Close()
+		}
 	}
 
-	// Read response body
-	body, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if httpResp.StatusCode != http.StatusOK {
+		disposition := classifyStatus(httpResp.StatusCode)
+		if disposition != retryNever {
+			retryAfter, _ = retryAfterDelay(httpResp.Header.Get("Retry-After"))
+		}
+		return nil, disposition, retryAfter, fmt.Errorf("HTTP request failed with status: %d", httpResp.StatusCode)
 	}
 
 	// Parse XML-RPC response
-	var resp XMLRPCResponse
-	if err := xml.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse XML-RPC response: %w", err)
+	var parsed XMLRPCResponse
+	if err := xml.Unmarshal(body, &parsed); err != nil {
+		return nil, retryNever, 0, fmt.Errorf("failed to parse XML-RPC response: %w", err)
 	}
 
 	// Check for fault
-	if resp.Fault != nil {
-		return nil, fmt.Errorf("XML-RPC fault occurred")
+	if parsed.Fault != nil {
+		code, message := decodeFault(parsed.Fault)
+		return nil, classifyFault(code), 0, fmt.Errorf("XML-RPC fault %d: %s", code, message)
+	}
+
+	return &parsed, retryNever, 0, nil
+}
+
+// sendRequest issues xmlRequest as a POST, gzipping it first when compress is true, and
+// returns the raw HTTP response alongside its body already decoded per Content-Encoding.
+// authHeaders (built by attemptRequest from the Authenticator's throwaway request) are
+// applied last, after the default headers, so e.g. an Authorization header always wins.
+// Callers are responsible for closing the returned response's Body.
+func (c *Client) sendRequest(xmlRequest []byte, compress bool, authHeaders http.Header) (*http.Response, []byte, error) {
+	payload := xmlRequest
+	if compress {
+		gzipped, err := gzipPayload(xmlRequest)
+		if err != nil {
+			return nil, nil, err
+		}
+		payload = gzipped
+	}
+
+	httpReq, err := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "text/xml")
+	httpReq.Header.Set("User-Agent", c.config.UserAgent)
+	httpReq.Header.Set("Accept-Encoding", c.acceptEncodingHeader())
+	if compress {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+		httpReq.ContentLength = int64(len(payload))
+	}
+	for key, values := range authHeaders {
+		httpReq.Header[key] = values
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	body, err := decodeResponseBody(httpResp)
+	if err != nil {
+		httpResp.Body.Close()
+		return nil, nil, err
 	}
 
-	return &resp, nil
+	return httpResp, body, nil
+}
+
+// decodeFault extracts the faultCode/faultString pair from a <fault> response.
+func decodeFault(fault *Fault) (code int, message string) {
+	raw, ok := fault.Value.Decode().(map[string]interface{})
+	if !ok {
+		return 0, "unknown fault"
+	}
+	return mapInt(raw, "faultCode"), mapString(raw, "faultString")
 }
 
-// Helper functions for parsing responses
-func (c *Client) parsePostsResponse(resp *XMLRPCResponse) []models.WordPressPost {
-	// This is a simplified implementation
-	// In a real implementation, you would parse the XML-RPC struct response properly
+// parsePostsResponse decodes a wp.getPosts/wp.getPages response into WordPressPost
+// values, tagging each with postType since the XML-RPC payload itself doesn't carry it.
+func (c *Client) parsePostsResponse(resp *XMLRPCResponse, postType string) []models.WordPressPost {
 	var posts []models.WordPressPost
 
-	// For demonstration, create a sample post
-	if len(resp.Params) > 0 {
-		post := models.WordPressPost{
-			ID:    1,
-			Title: models.RenderedContent{Rendered: "Sample Post"},
-			Type:  "post",
-		}
-		posts = append(posts, post)
+	for _, raw := range decodeStructArray(resp) {
+		posts = append(posts, convertPost(raw, postType))
 	}
 
 	return posts
 }
 
+// parseMediaResponse decodes a wp.getMediaLibrary response into WordPressMedia values.
 func (c *Client) parseMediaResponse(resp *XMLRPCResponse) []models.WordPressMedia {
 	var media []models.WordPressMedia
-	// Simplified implementation
+
+	for _, raw := range decodeStructArray(resp) {
+		media = append(media, convertMedia(raw))
+	}
+
 	return media
 }
 
+// parseCategoriesResponse decodes a wp.getTerms(taxonomy="category") response into
+// WordPressCategory values.
 func (c *Client) parseCategoriesResponse(resp *XMLRPCResponse) []models.WordPressCategory {
 	var categories []models.WordPressCategory
-	// Simplified implementation
+
+	for _, raw := range decodeStructArray(resp) {
+		categories = append(categories, convertCategory(raw))
+	}
+
 	return categories
 }
 
+// parseTagsResponse decodes a wp.getTerms(taxonomy="post_tag") response into
+// WordPressTag values.
 func (c *Client) parseTagsResponse(resp *XMLRPCResponse) []models.WordPressTag {
 	var tags []models.WordPressTag
-	// Simplified implementation
+
+	for _, raw := range decodeStructArray(resp) {
+		tags = append(tags, convertTag(raw))
+	}
+
 	return tags
 }
 
+// parseUsersResponse decodes a wp.getUsers response into WordPressUser values.
 func (c *Client) parseUsersResponse(resp *XMLRPCResponse) []models.WordPressUser {
 	var users []models.WordPressUser
-	// Simplified implementation
+
+	for _, raw := range decodeStructArray(resp) {
+		users = append(users, convertUser(raw))
+	}
+
 	return users
 }
 
+// decodeStructArray decodes the first response parameter as an XML-RPC array of
+// structs, returning each member as a map. Used by every list-returning parse*
+// function above.
+func decodeStructArray(resp *XMLRPCResponse) []map[string]interface{} {
+	if len(resp.Params) == 0 {
+		return nil
+	}
+
+	items, ok := resp.Params[0].Value.Decode().([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var structs []map[string]interface{}
+	for _, item := range items {
+		if raw, ok := item.(map[string]interface{}); ok {
+			structs = append(structs, raw)
+		}
+	}
+
+	return structs
+}
+
 // Helper function to create string pointer
 func stringPtr(s string) *string {
 	return &s
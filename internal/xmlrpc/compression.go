@@ -0,0 +1,72 @@
+package xmlrpc
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// defaultAcceptEncoding is advertised when config.Config.AcceptEncoding is empty.
+var defaultAcceptEncoding = []string{"gzip", "deflate"}
+
+// acceptEncodingHeader joins cfg.AcceptEncoding (or defaultAcceptEncoding) into an
+// Accept-Encoding header value.
+func (c *Client) acceptEncodingHeader() string {
+	encodings := c.config.AcceptEncoding
+	if len(encodings) == 0 {
+		encodings = defaultAcceptEncoding
+	}
+	return strings.Join(encodings, ", ")
+}
+
+// gzipPayload compresses body for use as a gzip-encoded request body.
+func gzipPayload(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to gzip request body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize gzipped request body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeResponseBody transparently decodes resp.Body per its Content-Encoding header
+// (gzip or deflate), returning the raw body unchanged for any other (or absent) coding.
+func decodeResponseBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer reader.Close()
+
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip response: %w", err)
+		}
+		return decoded, nil
+	case "deflate":
+		reader := flate.NewReader(bytes.NewReader(body))
+		defer reader.Close()
+
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress deflate response: %w", err)
+		}
+		return decoded, nil
+	default:
+		return body, nil
+	}
+}
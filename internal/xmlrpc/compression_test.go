@@ -0,0 +1,123 @@
+package xmlrpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// gzipString is a small test helper producing a gzipped payload for canned responses.
+func gzipString(t *testing.T, s string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatalf("failed to gzip test fixture: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestMakeRequestCompressesAndDecompresses(t *testing.T) {
+	const fixture = `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`
+
+	var (
+		gotAcceptEncoding  string
+		gotContentEncoding string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAcceptEncoding = r.Header.Get("Accept-Encoding")
+		gotContentEncoding = r.Header.Get("Content-Encoding")
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+
+		if gotContentEncoding == "gzip" {
+			reader, err := gzip.NewReader(bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("request body wasn't valid gzip: %v", err)
+			}
+			defer reader.Close()
+			decoded, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to decompress request body: %v", err)
+			}
+			if !bytes.Contains(decoded, []byte("methodCall")) {
+				t.Errorf("decompressed request body = %q, want it to contain methodCall", decoded)
+			}
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(gzipString(t, fixture))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent", CompressRequests: true}, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if err := client.TestConnection(); err != nil {
+		t.Fatalf("TestConnection() error = %v", err)
+	}
+
+	if gotContentEncoding != "gzip" {
+		t.Errorf("Content-Encoding = %q, want gzip", gotContentEncoding)
+	}
+	if !strings.Contains(gotAcceptEncoding, "gzip") {
+		t.Errorf("Accept-Encoding = %q, want it to contain gzip", gotAcceptEncoding)
+	}
+}
+
+func TestMakeRequestFallsBackWhenCompressionRejected(t *testing.T) {
+	const fixture = `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`
+
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5, UserAgent: "test-agent", CompressRequests: true}, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if err := client.TestConnection(); err != nil {
+		t.Fatalf("TestConnection() error = %v, want the client to retry uncompressed", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("server received %d requests, want 2 (compressed then uncompressed fallback)", calls)
+	}
+}
+
+func TestAcceptEncodingHeaderDefaultsToGzipDeflate(t *testing.T) {
+	client := &Client{config: &config.Config{}}
+
+	got := client.acceptEncodingHeader()
+	if got != "gzip, deflate" {
+		t.Errorf("acceptEncodingHeader() = %q, want %q", got, "gzip, deflate")
+	}
+}
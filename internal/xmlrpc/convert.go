@@ -0,0 +1,206 @@
+package xmlrpc
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// mapString reads key from m as a string, coercing the numeric types XML-RPC may hand
+// back for fields WordPress documents as strings (e.g. post_author).
+func mapString(m map[string]interface{}, key string) string {
+	switch val := m[key].(type) {
+	case string:
+		return val
+	case int:
+		return strconv.Itoa(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// mapInt reads key from m as an int, coercing string-encoded numeric fields.
+func mapInt(m map[string]interface{}, key string) int {
+	switch val := m[key].(type) {
+	case int:
+		return val
+	case float64:
+		return int(val)
+	case string:
+		n, _ := strconv.Atoi(val)
+		return n
+	default:
+		return 0
+	}
+}
+
+// mapBool reads key from m as a bool, accepting XML-RPC's native boolean as well as the
+// "0"/"1" string encoding some WordPress fields (e.g. sticky) use.
+func mapBool(m map[string]interface{}, key string) bool {
+	switch val := m[key].(type) {
+	case bool:
+		return val
+	case int:
+		return val != 0
+	case string:
+		return val == "1" || strings.EqualFold(val, "true")
+	default:
+		return false
+	}
+}
+
+// mapTime reads key from m as a models.WordPressTime, decoded from a <dateTime.iso8601>
+// value.
+func mapTime(m map[string]interface{}, key string) models.WordPressTime {
+	switch val := m[key].(type) {
+	case time.Time:
+		return models.WordPressTime{Time: val}
+	case string:
+		if t, err := time.Parse(dateTimeISO8601Format, val); err == nil {
+			return models.WordPressTime{Time: t}
+		}
+	}
+	return models.WordPressTime{}
+}
+
+// mapSlice reads key from m as a decoded XML-RPC array.
+func mapSlice(m map[string]interface{}, key string) []interface{} {
+	val, _ := m[key].([]interface{})
+	return val
+}
+
+// mapStruct reads key from m as a decoded XML-RPC struct.
+func mapStruct(m map[string]interface{}, key string) map[string]interface{} {
+	val, _ := m[key].(map[string]interface{})
+	return val
+}
+
+// convertPost maps a decoded wp.getPosts/wp.getPages struct into a WordPressPost,
+// tagging it with postType since the XML-RPC payload doesn't carry one.
+func convertPost(raw map[string]interface{}, postType string) models.WordPressPost {
+	post := models.WordPressPost{
+		ID:            mapInt(raw, "post_id"),
+		Slug:          mapString(raw, "post_name"),
+		Status:        mapString(raw, "post_status"),
+		Type:          postType,
+		Link:          mapString(raw, "link"),
+		Title:         models.RenderedContent{Rendered: mapString(raw, "post_title")},
+		Content:       models.RenderedContent{Rendered: mapString(raw, "post_content")},
+		Excerpt:       models.RenderedContent{Rendered: mapString(raw, "post_excerpt")},
+		Date:          mapTime(raw, "post_date_gmt"),
+		DateGMT:       mapTime(raw, "post_date_gmt"),
+		Modified:      mapTime(raw, "post_modified_gmt"),
+		ModifiedGMT:   mapTime(raw, "post_modified_gmt"),
+		CommentStatus: mapString(raw, "comment_status"),
+		PingStatus:    mapString(raw, "ping_status"),
+		Sticky:        mapBool(raw, "sticky"),
+		Format:        mapString(raw, "post_format"),
+		Author:        mapInt(raw, "post_author"),
+		Parent:        mapInt(raw, "post_parent"),
+	}
+
+	if thumbnail := mapStruct(raw, "post_thumbnail"); thumbnail != nil {
+		post.FeaturedMedia = mapInt(thumbnail, "attachment_id")
+	}
+
+	if customFields := mapSlice(raw, "custom_fields"); customFields != nil {
+		meta := make(map[string]interface{}, len(customFields))
+		for _, cf := range customFields {
+			if field, ok := cf.(map[string]interface{}); ok {
+				meta[mapString(field, "key")] = field["value"]
+			}
+		}
+		post.Meta = meta
+	}
+
+	for _, t := range mapSlice(raw, "terms") {
+		term, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		termID := mapInt(term, "term_id")
+		switch mapString(term, "taxonomy") {
+		case "category":
+			post.Categories = append(post.Categories, termID)
+		case "post_tag":
+			post.Tags = append(post.Tags, termID)
+		}
+	}
+
+	return post
+}
+
+// convertMedia maps a decoded wp.getMediaLibrary struct into a WordPressMedia.
+func convertMedia(raw map[string]interface{}) models.WordPressMedia {
+	media := models.WordPressMedia{
+		ID:          mapInt(raw, "attachment_id"),
+		Date:        mapTime(raw, "date_created_gmt"),
+		DateGMT:     mapTime(raw, "date_created_gmt"),
+		Link:        mapString(raw, "link"),
+		Title:       models.RenderedContent{Rendered: mapString(raw, "title")},
+		Caption:     models.RenderedContent{Rendered: mapString(raw, "caption")},
+		Description: models.RenderedContent{Rendered: mapString(raw, "description")},
+		MimeType:    mapString(raw, "type"),
+		SourceURL:   mapString(raw, "link"),
+		Post:        mapInt(raw, "parent"),
+		Type:        "attachment",
+	}
+
+	if strings.HasPrefix(media.MimeType, "image/") {
+		media.MediaType = "image"
+	} else {
+		media.MediaType = "file"
+	}
+
+	if metadata := mapStruct(raw, "metadata"); metadata != nil {
+		media.MediaDetails = models.MediaDetails{
+			Width:  metadata["width"],
+			Height: metadata["height"],
+			File:   mapString(metadata, "file"),
+		}
+	}
+
+	return media
+}
+
+// convertCategory maps a decoded wp.getTerms(taxonomy="category") struct into a
+// WordPressCategory.
+func convertCategory(raw map[string]interface{}) models.WordPressCategory {
+	return models.WordPressCategory{
+		ID:          mapInt(raw, "term_id"),
+		Name:        mapString(raw, "name"),
+		Slug:        mapString(raw, "slug"),
+		Taxonomy:    mapString(raw, "taxonomy"),
+		Parent:      mapInt(raw, "parent"),
+		Count:       mapInt(raw, "count"),
+		Description: mapString(raw, "description"),
+	}
+}
+
+// convertTag maps a decoded wp.getTerms(taxonomy="post_tag") struct into a WordPressTag.
+func convertTag(raw map[string]interface{}) models.WordPressTag {
+	return models.WordPressTag{
+		ID:          mapInt(raw, "term_id"),
+		Name:        mapString(raw, "name"),
+		Slug:        mapString(raw, "slug"),
+		Taxonomy:    mapString(raw, "taxonomy"),
+		Count:       mapInt(raw, "count"),
+		Description: mapString(raw, "description"),
+	}
+}
+
+// convertUser maps a decoded wp.getUsers struct into a WordPressUser.
+func convertUser(raw map[string]interface{}) models.WordPressUser {
+	return models.WordPressUser{
+		ID:          mapInt(raw, "user_id"),
+		Name:        mapString(raw, "display_name"),
+		URL:         mapString(raw, "url"),
+		Description: mapString(raw, "bio"),
+		Slug:        mapString(raw, "username"),
+	}
+}
@@ -0,0 +1,349 @@
+package xmlrpc
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+// unmarshalResponse is a small test helper that parses a raw XML-RPC response body the
+// way makeRequest does, without needing a live HTTP server.
+func unmarshalResponse(t *testing.T, body string) *XMLRPCResponse {
+	t.Helper()
+
+	var resp XMLRPCResponse
+	if err := xml.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	return &resp
+}
+
+func TestParsePostsResponseFields(t *testing.T) {
+	resp := unmarshalResponse(t, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+	<params>
+		<param>
+			<value>
+				<array>
+					<data>
+						<value>
+							<struct>
+								<member><name>post_id</name><value><int>42</int></value></member>
+								<member><name>post_title</name><value><string>Hello World</string></value></member>
+								<member><name>post_name</name><value><string>hello-world</string></value></member>
+								<member><name>post_status</name><value><string>publish</string></value></member>
+								<member><name>post_content</name><value><string>Body text</string></value></member>
+								<member><name>post_excerpt</name><value><string>Summary</string></value></member>
+								<member><name>post_date_gmt</name><value><dateTime.iso8601>20230115T10:30:00</dateTime.iso8601></value></member>
+								<member><name>post_modified_gmt</name><value><dateTime.iso8601>20230116T11:00:00</dateTime.iso8601></value></member>
+								<member><name>comment_status</name><value><string>open</string></value></member>
+								<member><name>ping_status</name><value><string>closed</string></value></member>
+								<member><name>sticky</name><value><boolean>1</boolean></value></member>
+								<member><name>post_format</name><value><string>standard</string></value></member>
+								<member><name>post_author</name><value><string>7</string></value></member>
+								<member>
+									<name>post_thumbnail</name>
+									<value>
+										<struct>
+											<member><name>attachment_id</name><value><int>99</int></value></member>
+										</struct>
+									</value>
+								</member>
+								<member>
+									<name>custom_fields</name>
+									<value>
+										<array>
+											<data>
+												<value>
+													<struct>
+														<member><name>key</name><value><string>views</string></value></member>
+														<member><name>value</name><value><string>123</string></value></member>
+													</struct>
+												</value>
+											</data>
+										</array>
+									</value>
+								</member>
+								<member>
+									<name>terms</name>
+									<value>
+										<array>
+											<data>
+												<value>
+													<struct>
+														<member><name>term_id</name><value><int>3</int></value></member>
+														<member><name>taxonomy</name><value><string>category</string></value></member>
+													</struct>
+												</value>
+												<value>
+													<struct>
+														<member><name>term_id</name><value><int>9</int></value></member>
+														<member><name>taxonomy</name><value><string>post_tag</string></value></member>
+													</struct>
+												</value>
+											</data>
+										</array>
+									</value>
+								</member>
+							</struct>
+						</value>
+					</data>
+				</array>
+			</value>
+		</param>
+	</params>
+</methodResponse>`)
+
+	c := &Client{}
+	posts := c.parsePostsResponse(resp, "post")
+	if len(posts) != 1 {
+		t.Fatalf("parsePostsResponse() returned %d posts, want 1", len(posts))
+	}
+
+	post := posts[0]
+	if post.ID != 42 {
+		t.Errorf("ID = %d, want 42", post.ID)
+	}
+	if post.Slug != "hello-world" {
+		t.Errorf("Slug = %q, want hello-world", post.Slug)
+	}
+	if post.Status != "publish" {
+		t.Errorf("Status = %q, want publish", post.Status)
+	}
+	if post.Type != "post" {
+		t.Errorf("Type = %q, want post", post.Type)
+	}
+	if post.Title.Rendered != "Hello World" {
+		t.Errorf("Title.Rendered = %q, want Hello World", post.Title.Rendered)
+	}
+	if post.Content.Rendered != "Body text" {
+		t.Errorf("Content.Rendered = %q, want Body text", post.Content.Rendered)
+	}
+	if !post.Sticky {
+		t.Error("Sticky = false, want true")
+	}
+	if post.Author != 7 {
+		t.Errorf("Author = %d, want 7", post.Author)
+	}
+	if post.FeaturedMedia != 99 {
+		t.Errorf("FeaturedMedia = %d, want 99", post.FeaturedMedia)
+	}
+	if post.Meta["views"] != "123" {
+		t.Errorf("Meta[views] = %v, want 123", post.Meta["views"])
+	}
+	if len(post.Categories) != 1 || post.Categories[0] != 3 {
+		t.Errorf("Categories = %v, want [3]", post.Categories)
+	}
+	if len(post.Tags) != 1 || post.Tags[0] != 9 {
+		t.Errorf("Tags = %v, want [9]", post.Tags)
+	}
+	if post.DateGMT.Time.IsZero() {
+		t.Error("DateGMT should not be zero")
+	}
+}
+
+func TestParsePostsResponsePostType(t *testing.T) {
+	resp := unmarshalResponse(t, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+	<params>
+		<param>
+			<value>
+				<array>
+					<data>
+						<value>
+							<struct>
+								<member><name>post_id</name><value><int>5</int></value></member>
+							</struct>
+						</value>
+					</data>
+				</array>
+			</value>
+		</param>
+	</params>
+</methodResponse>`)
+
+	c := &Client{}
+	pages := c.parsePostsResponse(resp, "page")
+	if len(pages) != 1 {
+		t.Fatalf("parsePostsResponse() returned %d pages, want 1", len(pages))
+	}
+	if pages[0].Type != "page" {
+		t.Errorf("Type = %q, want page", pages[0].Type)
+	}
+}
+
+func TestParseMediaResponseFields(t *testing.T) {
+	resp := unmarshalResponse(t, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+	<params>
+		<param>
+			<value>
+				<array>
+					<data>
+						<value>
+							<struct>
+								<member><name>attachment_id</name><value><int>17</int></value></member>
+								<member><name>date_created_gmt</name><value><dateTime.iso8601>20230101T00:00:00</dateTime.iso8601></value></member>
+								<member><name>link</name><value><string>https://example.com/wp-content/uploads/photo.jpg</string></value></member>
+								<member><name>title</name><value><string>Photo</string></value></member>
+								<member><name>caption</name><value><string>A photo</string></value></member>
+								<member><name>description</name><value><string>Long description</string></value></member>
+								<member><name>type</name><value><string>image/jpeg</string></value></member>
+								<member><name>parent</name><value><int>42</int></value></member>
+								<member>
+									<name>metadata</name>
+									<value>
+										<struct>
+											<member><name>width</name><value><int>800</int></value></member>
+											<member><name>height</name><value><int>600</int></value></member>
+											<member><name>file</name><value><string>2023/01/photo.jpg</string></value></member>
+										</struct>
+									</value>
+								</member>
+							</struct>
+						</value>
+					</data>
+				</array>
+			</value>
+		</param>
+	</params>
+</methodResponse>`)
+
+	c := &Client{}
+	media := c.parseMediaResponse(resp)
+	if len(media) != 1 {
+		t.Fatalf("parseMediaResponse() returned %d items, want 1", len(media))
+	}
+
+	m := media[0]
+	if m.ID != 17 {
+		t.Errorf("ID = %d, want 17", m.ID)
+	}
+	if m.MimeType != "image/jpeg" {
+		t.Errorf("MimeType = %q, want image/jpeg", m.MimeType)
+	}
+	if m.MediaType != "image" {
+		t.Errorf("MediaType = %q, want image", m.MediaType)
+	}
+	if m.Post != 42 {
+		t.Errorf("Post = %d, want 42", m.Post)
+	}
+	if m.MediaDetails.File != "2023/01/photo.jpg" {
+		t.Errorf("MediaDetails.File = %q, want 2023/01/photo.jpg", m.MediaDetails.File)
+	}
+}
+
+func TestParseCategoriesAndTagsResponse(t *testing.T) {
+	resp := unmarshalResponse(t, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+	<params>
+		<param>
+			<value>
+				<array>
+					<data>
+						<value>
+							<struct>
+								<member><name>term_id</name><value><int>3</int></value></member>
+								<member><name>name</name><value><string>News</string></value></member>
+								<member><name>slug</name><value><string>news</string></value></member>
+								<member><name>taxonomy</name><value><string>category</string></value></member>
+								<member><name>parent</name><value><int>0</int></value></member>
+								<member><name>count</name><value><int>12</int></value></member>
+								<member><name>description</name><value><string>News posts</string></value></member>
+							</struct>
+						</value>
+					</data>
+				</array>
+			</value>
+		</param>
+	</params>
+</methodResponse>`)
+
+	c := &Client{}
+
+	categories := c.parseCategoriesResponse(resp)
+	if len(categories) != 1 {
+		t.Fatalf("parseCategoriesResponse() returned %d categories, want 1", len(categories))
+	}
+	if categories[0].Name != "News" || categories[0].Slug != "news" || categories[0].Count != 12 {
+		t.Errorf("category = %+v, unexpected fields", categories[0])
+	}
+
+	tags := c.parseTagsResponse(resp)
+	if len(tags) != 1 {
+		t.Fatalf("parseTagsResponse() returned %d tags, want 1", len(tags))
+	}
+	if tags[0].Name != "News" || tags[0].Slug != "news" || tags[0].Count != 12 {
+		t.Errorf("tag = %+v, unexpected fields", tags[0])
+	}
+}
+
+func TestParseUsersResponseFields(t *testing.T) {
+	resp := unmarshalResponse(t, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+	<params>
+		<param>
+			<value>
+				<array>
+					<data>
+						<value>
+							<struct>
+								<member><name>user_id</name><value><int>1</int></value></member>
+								<member><name>display_name</name><value><string>Jane Doe</string></value></member>
+								<member><name>url</name><value><string>https://example.com</string></value></member>
+								<member><name>bio</name><value><string>Writer</string></value></member>
+								<member><name>username</name><value><string>jane</string></value></member>
+							</struct>
+						</value>
+					</data>
+				</array>
+			</value>
+		</param>
+	</params>
+</methodResponse>`)
+
+	c := &Client{}
+	users := c.parseUsersResponse(resp)
+	if len(users) != 1 {
+		t.Fatalf("parseUsersResponse() returned %d users, want 1", len(users))
+	}
+
+	u := users[0]
+	if u.ID != 1 {
+		t.Errorf("ID = %d, want 1", u.ID)
+	}
+	if u.Name != "Jane Doe" {
+		t.Errorf("Name = %q, want Jane Doe", u.Name)
+	}
+	if u.Slug != "jane" {
+		t.Errorf("Slug = %q, want jane", u.Slug)
+	}
+	if u.Description != "Writer" {
+		t.Errorf("Description = %q, want Writer", u.Description)
+	}
+}
+
+func TestDecodeFault(t *testing.T) {
+	resp := unmarshalResponse(t, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+	<fault>
+		<value>
+			<struct>
+				<member><name>faultCode</name><value><int>401</int></value></member>
+				<member><name>faultString</name><value><string>Bad login/pass combination.</string></value></member>
+			</struct>
+		</value>
+	</fault>
+</methodResponse>`)
+
+	if resp.Fault == nil {
+		t.Fatal("expected a fault")
+	}
+
+	code, message := decodeFault(resp.Fault)
+	if code != 401 {
+		t.Errorf("code = %d, want 401", code)
+	}
+	if message != "Bad login/pass combination." {
+		t.Errorf("message = %q, want %q", message, "Bad login/pass combination.")
+	}
+}
@@ -0,0 +1,269 @@
+package xmlrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// PostIterator lazily pages through wp.getPosts/wp.getPages using the number/offset
+// filter fields, instead of materializing the full result set the way GetPosts/GetPages
+// do. Callers pull items with Next and must Close the iterator when done.
+type PostIterator struct {
+	client   *Client
+	method   string
+	postType string
+	pageSize int
+	ctx      context.Context
+
+	offset  int
+	buffer  []models.WordPressPost
+	bufIdx  int
+	done    bool
+	fetched int
+	total   int
+}
+
+// IteratePosts returns a PostIterator over wp.getPosts, fetching pageSize items per
+// request (100 when pageSize <= 0).
+func (c *Client) IteratePosts(ctx context.Context, pageSize int) *PostIterator {
+	return newPostIterator(c, ctx, "wp.getPosts", "post", pageSize)
+}
+
+// IteratePages returns a PostIterator over wp.getPages, fetching pageSize items per
+// request (100 when pageSize <= 0).
+func (c *Client) IteratePages(ctx context.Context, pageSize int) *PostIterator {
+	return newPostIterator(c, ctx, "wp.getPages", "page", pageSize)
+}
+
+func newPostIterator(c *Client, ctx context.Context, method, postType string, pageSize int) *PostIterator {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	it := &PostIterator{client: c, method: method, postType: postType, pageSize: pageSize, ctx: ctx}
+	it.total = c.fetchPostCount(ctx, postType)
+	return it
+}
+
+// Next returns the next post, or (nil, io.EOF) once the iterator is exhausted. It fetches
+// a new page from the server whenever the buffered page has been consumed, and stops as
+// soon as a page comes back shorter than pageSize.
+func (it *PostIterator) Next() (*models.WordPressPost, error) {
+	for {
+		if err := it.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if it.bufIdx < len(it.buffer) {
+			post := it.buffer[it.bufIdx]
+			it.bufIdx++
+			it.fetched++
+			return &post, nil
+		}
+
+		if it.done {
+			return nil, io.EOF
+		}
+
+		if err := it.fetchPage(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Close releases the iterator's buffered page. It does no network I/O of its own, but
+// follows the Next/Close cursor convention so callers can always `defer it.Close()`.
+func (it *PostIterator) Close() error {
+	it.buffer = nil
+	it.done = true
+	return nil
+}
+
+// Progress reports how many posts have been yielded via Next so far, and the total post
+// count fetched once up front via wp.getPostCount (0 if the server doesn't support it).
+func (it *PostIterator) Progress() (fetched, total int) {
+	return it.fetched, it.total
+}
+
+func (it *PostIterator) fetchPage() error {
+	limit := it.pageSize
+	offset := it.offset
+
+	filter := &Struct{
+		Members: []Member{
+			{Name: "number", Value: Value{Int: &limit}},
+			{Name: "offset", Value: Value{Int: &offset}},
+		},
+	}
+
+	req := &XMLRPCRequest{
+		Method: it.method,
+		Params: []Param{
+			{Value: Value{Int: &it.client.blogID}},
+			{Value: Value{String: &it.client.username}},
+			{Value: Value{String: &it.client.password}},
+			{Value: Value{Struct: filter}},
+		},
+	}
+
+	resp, err := it.client.makeRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s page at offset %d: %w", it.postType, it.offset, err)
+	}
+
+	page := it.client.parsePostsResponse(resp, it.postType)
+	it.buffer = page
+	it.bufIdx = 0
+	it.offset += it.pageSize
+
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+
+	return nil
+}
+
+// MediaIterator lazily pages through wp.getMediaLibrary using the number/offset filter
+// fields, instead of materializing the full result set the way GetMedia does. Callers
+// pull items with Next and must Close the iterator when done.
+type MediaIterator struct {
+	client   *Client
+	pageSize int
+	ctx      context.Context
+
+	offset  int
+	buffer  []models.WordPressMedia
+	bufIdx  int
+	done    bool
+	fetched int
+}
+
+// IterateMedia returns a MediaIterator over wp.getMediaLibrary, fetching pageSize items
+// per request (100 when pageSize <= 0). Unlike PostIterator, its Progress has no total:
+// wp.getMediaLibrary has no equivalent of wp.getPostCount to size it up front.
+func (c *Client) IterateMedia(ctx context.Context, pageSize int) *MediaIterator {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	return &MediaIterator{client: c, pageSize: pageSize, ctx: ctx}
+}
+
+// Next returns the next media item, or (nil, io.EOF) once the iterator is exhausted.
+func (it *MediaIterator) Next() (*models.WordPressMedia, error) {
+	for {
+		if err := it.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if it.bufIdx < len(it.buffer) {
+			item := it.buffer[it.bufIdx]
+			it.bufIdx++
+			it.fetched++
+			return &item, nil
+		}
+
+		if it.done {
+			return nil, io.EOF
+		}
+
+		if err := it.fetchPage(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Close releases the iterator's buffered page.
+func (it *MediaIterator) Close() error {
+	it.buffer = nil
+	it.done = true
+	return nil
+}
+
+// Progress reports how many media items have been yielded via Next so far.
+func (it *MediaIterator) Progress() (fetched int) {
+	return it.fetched
+}
+
+func (it *MediaIterator) fetchPage() error {
+	limit := it.pageSize
+	offset := it.offset
+
+	filter := &Struct{
+		Members: []Member{
+			{Name: "number", Value: Value{Int: &limit}},
+			{Name: "offset", Value: Value{Int: &offset}},
+		},
+	}
+
+	req := &XMLRPCRequest{
+		Method: "wp.getMediaLibrary",
+		Params: []Param{
+			{Value: Value{Int: &it.client.blogID}},
+			{Value: Value{String: &it.client.username}},
+			{Value: Value{String: &it.client.password}},
+			{Value: Value{Struct: filter}},
+		},
+	}
+
+	resp, err := it.client.makeRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch media page at offset %d: %w", it.offset, err)
+	}
+
+	page := it.client.parseMediaResponse(resp)
+	it.buffer = page
+	it.bufIdx = 0
+	it.offset += it.pageSize
+
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+
+	return nil
+}
+
+// fetchPostCount calls wp.getPostCount once up front for Progress's total, tolerating
+// servers that don't implement it (older WordPress installs) by returning 0.
+func (c *Client) fetchPostCount(ctx context.Context, postType string) int {
+	req := &XMLRPCRequest{
+		Method: "wp.getPostCount",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{String: &postType}},
+		},
+	}
+
+	resp, err := c.makeRequest(req)
+	if err != nil || ctx.Err() != nil || len(resp.Params) == 0 {
+		return 0
+	}
+
+	switch decoded := resp.Params[0].Value.Decode().(type) {
+	case int:
+		return decoded
+	case map[string]interface{}:
+		total := 0
+		for _, count := range decoded {
+			switch n := count.(type) {
+			case int:
+				total += n
+			case float64:
+				total += int(n)
+			case string:
+				if parsed, err := strconv.Atoi(n); err == nil {
+					total += parsed
+				}
+			}
+		}
+		return total
+	default:
+		return 0
+	}
+}
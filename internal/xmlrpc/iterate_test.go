@@ -0,0 +1,216 @@
+package xmlrpc
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// newPagedPostsTestServer serves wp.getPosts one post per page (keyed off the offset
+// member of the request's filter struct) until offsets beyond len(titles), and answers
+// wp.getPostCount with postCount.
+func newPagedPostsTestServer(t *testing.T, titles []string, postCount int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+
+		var req XMLRPCRequest
+		if err := xml.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusOK)
+
+		switch req.Method {
+		case "wp.getPostCount":
+			fmt.Fprintf(w, `<?xml version="1.0"?><methodResponse><params><param><value><int>%d</int></value></param></params></methodResponse>`, postCount)
+
+		case "wp.getPosts":
+			offset := requestOffset(req)
+			if offset >= len(titles) {
+				io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`)
+				return
+			}
+
+			fmt.Fprintf(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data>
+				<value><struct>
+					<member><name>post_id</name><value><int>%d</int></value></member>
+					<member><name>post_title</name><value><string>%s</string></value></member>
+				</struct></value>
+			</data></array></value></param></params></methodResponse>`, offset+1, titles[offset])
+
+		default:
+			t.Fatalf("unexpected method: %s", req.Method)
+		}
+	}))
+}
+
+// requestOffset extracts the "offset" filter member a GetPosts/GetPages-style request
+// sends as its fourth param.
+func requestOffset(req XMLRPCRequest) int {
+	if len(req.Params) < 4 || req.Params[3].Value.Struct == nil {
+		return 0
+	}
+
+	for _, member := range req.Params[3].Value.Struct.Members {
+		if member.Name == "offset" {
+			if n, ok := member.Value.Decode().(int); ok {
+				return n
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestPostIteratorYieldsAllPosts(t *testing.T) {
+	titles := []string{"First", "Second", "Third"}
+	server := newPagedPostsTestServer(t, titles, len(titles))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	it := client.IteratePosts(context.Background(), 1)
+	defer it.Close()
+
+	var got []string
+	for {
+		post, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got = append(got, post.Title.Rendered)
+	}
+
+	if len(got) != len(titles) {
+		t.Fatalf("got %d posts, want %d", len(got), len(titles))
+	}
+	for i, title := range titles {
+		if got[i] != title {
+			t.Errorf("post %d title = %q, want %q", i, got[i], title)
+		}
+	}
+
+	fetched, total := it.Progress()
+	if fetched != len(titles) {
+		t.Errorf("Progress() fetched = %d, want %d", fetched, len(titles))
+	}
+	if total != len(titles) {
+		t.Errorf("Progress() total = %d, want %d", total, len(titles))
+	}
+}
+
+func TestPostIteratorRespectsContextCancellation(t *testing.T) {
+	server := newPagedPostsTestServer(t, []string{"First", "Second"}, 2)
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := client.IteratePages(ctx, 1)
+	defer it.Close()
+
+	if _, err := it.Next(); err == nil {
+		t.Error("Next() error = nil, want context cancellation error")
+	}
+}
+
+func TestMediaIteratorYieldsAllItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+
+		var req XMLRPCRequest
+		if err := xml.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusOK)
+
+		if requestOffset(req) >= 1 {
+			io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`)
+			return
+		}
+
+		io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data>
+			<value><struct>
+				<member><name>attachment_id</name><value><int>1</int></value></member>
+				<member><name>link</name><value><string>https://example.com/file.jpg</string></value></member>
+			</struct></value>
+		</data></array></value></param></params></methodResponse>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	it := client.IterateMedia(context.Background(), 1)
+	defer it.Close()
+
+	count := 0
+	for {
+		_, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("got %d media items, want 1", count)
+	}
+	if fetched := it.Progress(); fetched != 1 {
+		t.Errorf("Progress() = %d, want 1", fetched)
+	}
+}
+
+func TestFetchPostCountToleratesUnsupportedMethod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `<?xml version="1.0"?><methodResponse><fault><value><struct>
+			<member><name>faultCode</name><value><int>-32601</int></value></member>
+			<member><name>faultString</name><value><string>server error. requested method wp.getPostCount does not exist.</string></value></member>
+		</struct></value></fault></methodResponse>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{URL: server.URL, Timeout: 5}, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if count := client.fetchPostCount(context.Background(), "post"); count != 0 {
+		t.Errorf("fetchPostCount() = %d, want 0 for an unsupported method", count)
+	}
+}
@@ -0,0 +1,323 @@
+package xmlrpc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+// Term represents the subset of wp.newTerm/wp.editTerm/wp.deleteTerm fields this client
+// can author. Taxonomy is "category" or "post_tag".
+type Term struct {
+	Name        string
+	Slug        string
+	Taxonomy    string
+	Parent      int
+	Description string
+}
+
+// NewPost creates a post or page via wp.newPost and returns its numeric ID.
+func (c *Client) NewPost(ctx context.Context, post models.WordPressPost) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	req := &XMLRPCRequest{
+		Method: "wp.newPost",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{Struct: postContentStruct(post)}},
+		},
+	}
+
+	resp, err := c.makeRequest(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	id, err := responseID(resp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse wp.newPost response: %w", err)
+	}
+	return id, nil
+}
+
+// EditPost updates an existing post or page via wp.editPost.
+func (c *Client) EditPost(ctx context.Context, id int, post models.WordPressPost) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	req := &XMLRPCRequest{
+		Method: "wp.editPost",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{String: stringPtr(strconv.Itoa(id))}},
+			{Value: Value{Struct: postContentStruct(post)}},
+		},
+	}
+
+	if _, err := c.makeRequest(req); err != nil {
+		return fmt.Errorf("failed to edit post %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeletePost removes a post or page via wp.deletePost.
+func (c *Client) DeletePost(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	req := &XMLRPCRequest{
+		Method: "wp.deletePost",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{String: stringPtr(strconv.Itoa(id))}},
+		},
+	}
+
+	if _, err := c.makeRequest(req); err != nil {
+		return fmt.Errorf("failed to delete post %d: %w", id, err)
+	}
+	return nil
+}
+
+// NewTerm creates a category or tag via wp.newTerm and returns its numeric ID.
+func (c *Client) NewTerm(ctx context.Context, term Term) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	req := &XMLRPCRequest{
+		Method: "wp.newTerm",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{Struct: termStruct(term)}},
+		},
+	}
+
+	resp, err := c.makeRequest(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create term: %w", err)
+	}
+
+	id, err := responseID(resp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse wp.newTerm response: %w", err)
+	}
+	return id, nil
+}
+
+// EditTerm updates a category or tag via wp.editTerm.
+func (c *Client) EditTerm(ctx context.Context, id int, term Term) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	req := &XMLRPCRequest{
+		Method: "wp.editTerm",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{String: stringPtr(strconv.Itoa(id))}},
+			{Value: Value{Struct: termStruct(term)}},
+		},
+	}
+
+	if _, err := c.makeRequest(req); err != nil {
+		return fmt.Errorf("failed to edit term %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteTerm removes a category or tag via wp.deleteTerm.
+func (c *Client) DeleteTerm(ctx context.Context, id int, taxonomy string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	req := &XMLRPCRequest{
+		Method: "wp.deleteTerm",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{String: stringPtr(taxonomy)}},
+			{Value: Value{String: stringPtr(strconv.Itoa(id))}},
+		},
+	}
+
+	if _, err := c.makeRequest(req); err != nil {
+		return fmt.Errorf("failed to delete term %d: %w", id, err)
+	}
+	return nil
+}
+
+// UploadFile uploads binary data via wp.uploadFile and returns the resulting media item.
+func (c *Client) UploadFile(ctx context.Context, name, mime string, data []byte) (*models.WordPressMedia, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	bits := base64.StdEncoding.EncodeToString(data)
+
+	req := &XMLRPCRequest{
+		Method: "wp.uploadFile",
+		Params: []Param{
+			{Value: Value{Int: &c.blogID}},
+			{Value: Value{String: &c.username}},
+			{Value: Value{String: &c.password}},
+			{Value: Value{Struct: &Struct{
+				Members: []Member{
+					{Name: "name", Value: Value{String: stringPtr(name)}},
+					{Name: "type", Value: Value{String: stringPtr(mime)}},
+					{Name: "bits", Value: Value{Base64: stringPtr(bits)}},
+				},
+			}}},
+		},
+	}
+
+	resp, err := c.makeRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file %q: %w", name, err)
+	}
+
+	if len(resp.Params) == 0 {
+		return nil, fmt.Errorf("wp.uploadFile response had no params")
+	}
+
+	raw, ok := resp.Params[0].Value.Decode().(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("wp.uploadFile response was not a struct")
+	}
+
+	media := &models.WordPressMedia{
+		ID:        mapInt(raw, "id"),
+		Link:      mapString(raw, "url"),
+		SourceURL: mapString(raw, "url"),
+		MimeType:  mapString(raw, "type"),
+		Type:      "attachment",
+	}
+	media.MediaDetails.File = mapString(raw, "file")
+
+	return media, nil
+}
+
+// postContentStruct builds the wp.newPost/wp.editPost content struct from a
+// models.WordPressPost, the inverse of convertPost.
+func postContentStruct(post models.WordPressPost) *Struct {
+	members := []Member{
+		{Name: "post_title", Value: Value{String: stringPtr(post.Title.Rendered)}},
+		{Name: "post_content", Value: Value{String: stringPtr(post.Content.Rendered)}},
+		{Name: "post_excerpt", Value: Value{String: stringPtr(post.Excerpt.Rendered)}},
+		{Name: "post_status", Value: Value{String: stringPtr(post.Status)}},
+		{Name: "post_name", Value: Value{String: stringPtr(post.Slug)}},
+		{Name: "post_type", Value: Value{String: stringPtr(post.Type)}},
+		{Name: "comment_status", Value: Value{String: stringPtr(post.CommentStatus)}},
+		{Name: "ping_status", Value: Value{String: stringPtr(post.PingStatus)}},
+		{Name: "post_format", Value: Value{String: stringPtr(post.Format)}},
+	}
+
+	if post.FeaturedMedia != 0 {
+		members = append(members, Member{
+			Name: "post_thumbnail",
+			Value: Value{Int: intPtrVal(post.FeaturedMedia)},
+		})
+	}
+
+	if post.Parent != 0 {
+		members = append(members, Member{
+			Name:  "post_parent",
+			Value: Value{Int: intPtrVal(post.Parent)},
+		})
+	}
+
+	if len(post.Meta) > 0 {
+		var fields []Value
+		for key, value := range post.Meta {
+			fields = append(fields, Value{Struct: &Struct{
+				Members: []Member{
+					{Name: "key", Value: Value{String: stringPtr(key)}},
+					{Name: "value", Value: Value{String: stringPtr(fmt.Sprint(value))}},
+				},
+			}})
+		}
+		members = append(members, Member{Name: "custom_fields", Value: Value{Array: &Array{Data: fields}}})
+	}
+
+	var termMembers []Member
+	if len(post.Categories) > 0 {
+		termMembers = append(termMembers, Member{Name: "category", Value: Value{Array: &Array{Data: idValues(post.Categories)}}})
+	}
+	if len(post.Tags) > 0 {
+		termMembers = append(termMembers, Member{Name: "post_tag", Value: Value{Array: &Array{Data: idValues(post.Tags)}}})
+	}
+	if len(termMembers) > 0 {
+		members = append(members, Member{Name: "terms", Value: Value{Struct: &Struct{Members: termMembers}}})
+	}
+
+	return &Struct{Members: members}
+}
+
+// termStruct builds the wp.newTerm/wp.editTerm content struct from a Term.
+func termStruct(term Term) *Struct {
+	return &Struct{
+		Members: []Member{
+			{Name: "name", Value: Value{String: stringPtr(term.Name)}},
+			{Name: "slug", Value: Value{String: stringPtr(term.Slug)}},
+			{Name: "taxonomy", Value: Value{String: stringPtr(term.Taxonomy)}},
+			{Name: "parent", Value: Value{Int: intPtrVal(term.Parent)}},
+			{Name: "description", Value: Value{String: stringPtr(term.Description)}},
+		},
+	}
+}
+
+// idValues converts term IDs into XML-RPC string values, as wp.newPost expects terms
+// identified by their string ID rather than a numeric type.
+func idValues(ids []int) []Value {
+	values := make([]Value, len(ids))
+	for i, id := range ids {
+		values[i] = Value{String: stringPtr(strconv.Itoa(id))}
+	}
+	return values
+}
+
+// responseID decodes the first response parameter as a post/term ID, accepting both the
+// numeric and string encodings different WordPress versions return.
+func responseID(resp *XMLRPCResponse) (int, error) {
+	if len(resp.Params) == 0 {
+		return 0, fmt.Errorf("response had no params")
+	}
+
+	switch val := resp.Params[0].Value.Decode().(type) {
+	case int:
+		return val, nil
+	case string:
+		id, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse id %q: %w", val, err)
+		}
+		return id, nil
+	default:
+		return 0, fmt.Errorf("unexpected id type %T", val)
+	}
+}
+
+// intPtrVal returns a pointer to i; it exists alongside intPtr (test-only, in
+// client_test.go) so production code has its own allocator.
+func intPtrVal(i int) *int {
+	return &i
+}
@@ -0,0 +1,156 @@
+package xmlrpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+	"github.com/tradik/wpexporter/pkg/models"
+)
+
+func newXMLRPCTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{URL: server.URL, Timeout: 10}
+	client, err := NewClient(cfg, "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func respondXML(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(body))
+}
+
+func TestNewPost(t *testing.T) {
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondXML(w, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><string>123</string></value></param></params></methodResponse>`)
+	})
+
+	id, err := client.NewPost(context.Background(), models.WordPressPost{Title: models.RenderedContent{Rendered: "Hello"}})
+	if err != nil {
+		t.Fatalf("NewPost() error = %v", err)
+	}
+	if id != 123 {
+		t.Errorf("NewPost() = %d, want 123", id)
+	}
+}
+
+func TestNewPostContextCanceled(t *testing.T) {
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not make a request when context is already canceled")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.NewPost(ctx, models.WordPressPost{}); err == nil {
+		t.Error("NewPost() with canceled context should return an error")
+	}
+}
+
+func TestEditPost(t *testing.T) {
+	var gotMethod string
+
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		_, _ = r.Body.Read(body)
+		if strings.Contains(string(body), "wp.editPost") {
+			gotMethod = "wp.editPost"
+		}
+		respondXML(w, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+	})
+
+	if err := client.EditPost(context.Background(), 1, models.WordPressPost{Status: "publish"}); err != nil {
+		t.Fatalf("EditPost() error = %v", err)
+	}
+	if gotMethod != "wp.editPost" {
+		t.Errorf("expected request to call wp.editPost, got method marker %q", gotMethod)
+	}
+}
+
+func TestDeletePost(t *testing.T) {
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondXML(w, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+	})
+
+	if err := client.DeletePost(context.Background(), 1); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+}
+
+func TestNewTerm(t *testing.T) {
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondXML(w, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><int>7</int></value></param></params></methodResponse>`)
+	})
+
+	id, err := client.NewTerm(context.Background(), Term{Name: "News", Taxonomy: "category"})
+	if err != nil {
+		t.Fatalf("NewTerm() error = %v", err)
+	}
+	if id != 7 {
+		t.Errorf("NewTerm() = %d, want 7", id)
+	}
+}
+
+func TestEditTerm(t *testing.T) {
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondXML(w, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+	})
+
+	if err := client.EditTerm(context.Background(), 7, Term{Name: "Updates", Taxonomy: "category"}); err != nil {
+		t.Fatalf("EditTerm() error = %v", err)
+	}
+}
+
+func TestDeleteTerm(t *testing.T) {
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondXML(w, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+	})
+
+	if err := client.DeleteTerm(context.Background(), 7, "category"); err != nil {
+		t.Fatalf("DeleteTerm() error = %v", err)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	client := newXMLRPCTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondXML(w, `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><struct>
+	<member><name>id</name><value><string>55</string></value></member>
+	<member><name>file</name><value><string>photo.jpg</string></value></member>
+	<member><name>url</name><value><string>https://example.com/wp-content/uploads/photo.jpg</string></value></member>
+	<member><name>type</name><value><string>image/jpeg</string></value></member>
+</struct></value></param></params></methodResponse>`)
+	})
+
+	media, err := client.UploadFile(context.Background(), "photo.jpg", "image/jpeg", []byte("fake-bytes"))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	if media.ID != 55 {
+		t.Errorf("UploadFile() ID = %d, want 55", media.ID)
+	}
+	if media.SourceURL != "https://example.com/wp-content/uploads/photo.jpg" {
+		t.Errorf("UploadFile() SourceURL = %q, want the uploaded file URL", media.SourceURL)
+	}
+	if media.MimeType != "image/jpeg" {
+		t.Errorf("UploadFile() MimeType = %q, want image/jpeg", media.MimeType)
+	}
+}
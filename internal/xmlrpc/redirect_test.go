@@ -0,0 +1,62 @@
+package xmlrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestNewClientResolvesRedirectWhenEnabled(t *testing.T) {
+	finalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer finalServer.Close()
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, finalServer.URL, http.StatusMovedPermanently)
+	}))
+	defer redirectServer.Close()
+
+	cfg := &config.Config{
+		URL:                     redirectServer.URL,
+		Timeout:                 5,
+		ResolveRedirects:        true,
+		AllowCrossHostRedirects: true,
+	}
+
+	client, err := NewClient(cfg, "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	want := finalServer.URL + "/xmlrpc.php"
+	if client.endpoint != want {
+		t.Errorf("endpoint = %q, want %q", client.endpoint, want)
+	}
+}
+
+func TestNewClientSkipsResolutionByDefault(t *testing.T) {
+	finalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer finalServer.Close()
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, finalServer.URL, http.StatusMovedPermanently)
+	}))
+	defer redirectServer.Close()
+
+	cfg := &config.Config{URL: redirectServer.URL, Timeout: 5}
+
+	client, err := NewClient(cfg, "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	want := redirectServer.URL + "/xmlrpc.php"
+	if client.endpoint != want {
+		t.Errorf("endpoint = %q, want %q (ResolveRedirects unset keeps the configured origin)", client.endpoint, want)
+	}
+}
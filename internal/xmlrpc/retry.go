@@ -0,0 +1,143 @@
+package xmlrpc
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// retryDisposition is the outcome of classifying a failed attempt: whether it's worth
+// trying again, and if so, how long to wait first.
+type retryDisposition int
+
+const (
+	retryNever retryDisposition = iota
+	retryTransient
+	retryRateLimited
+)
+
+// permanentFaultCodes are XML-RPC faultCodes that indicate an authentication or
+// permission problem no amount of retrying will fix.
+var permanentFaultCodes = map[int]bool{
+	401: true,
+	403: true,
+}
+
+// classifyTransportErr reports whether a network-level error (failed to connect, read
+// timeout, connection reset mid-response) is worth retrying.
+func classifyTransportErr(err error) retryDisposition {
+	if err == nil {
+		return retryNever
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return retryTransient
+	}
+	if errors.Is(err, net.ErrClosed) {
+		return retryTransient
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return retryTransient
+	}
+
+	return retryNever
+}
+
+// classifyStatus reports whether an HTTP response status is worth retrying: 429 is
+// rate-limited (honor Retry-After), 5xx is a transient server error, everything else
+// (including 401/403) is permanent.
+func classifyStatus(statusCode int) retryDisposition {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return retryRateLimited
+	case statusCode >= 500:
+		return retryTransient
+	default:
+		return retryNever
+	}
+}
+
+// classifyFault reports whether an XML-RPC <fault> is worth retrying: known auth
+// faultCodes (401/403) are permanent, everything else is treated as a transient
+// server-side problem (most XML-RPC faults observed in practice come from an
+// overloaded PHP-FPM worker returning a malformed response, not a client error).
+func classifyFault(faultCode int) retryDisposition {
+	if permanentFaultCodes[faultCode] {
+		return retryNever
+	}
+	return retryTransient
+}
+
+// retryPolicy resolves cfg.Retry's zero-valued fields to their documented defaults.
+func retryPolicy(cfg *config.Config) (maxAttempts int, initialBackoff, maxBackoff time.Duration, jitter bool) {
+	maxAttempts = cfg.Retry.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	initialBackoff = time.Duration(cfg.Retry.InitialBackoffMS) * time.Millisecond
+	if initialBackoff <= 0 {
+		initialBackoff = defaultInitialBackoff
+	}
+
+	maxBackoff = time.Duration(cfg.Retry.MaxBackoffMS) * time.Millisecond
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	return maxAttempts, initialBackoff, maxBackoff, !cfg.Retry.DisableJitter
+}
+
+// backoffFor computes the exponential backoff before the given attempt (0-indexed: the
+// delay before attempt 1 retrying attempt 0), capped at maxBackoff and, unless jitter is
+// false, reduced to a uniformly random delay between 0 and that value (full jitter).
+func backoffFor(attempt int, initialBackoff, maxBackoff time.Duration, jitter bool) time.Duration {
+	backoff := initialBackoff * time.Duration(1<<uint(attempt))
+	if backoff > maxBackoff || backoff < 0 {
+		backoff = maxBackoff
+	}
+
+	if !jitter {
+		return backoff
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// retryAfterDelay parses a Retry-After header expressed either as a number of seconds or as
+// an HTTP-date, returning the resulting delay from now.
+func retryAfterDelay(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,118 @@
+package xmlrpc
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestMakeRequestRetriesTransientErrorsThenSucceeds(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:     server.URL,
+		Timeout: 5,
+		Retry:   config.RetryPolicy{MaxAttempts: 3, InitialBackoffMS: 1, MaxBackoffMS: 5},
+	}
+
+	client, err := NewClient(cfg, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetMedia(); err != nil {
+		t.Fatalf("GetMedia() error = %v, want nil after retrying transient 503s", err)
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("server received %d requests, want 3 (2 failures + 1 success)", got)
+	}
+}
+
+func TestMakeRequestDoesNotRetryPermanentFault(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `<?xml version="1.0"?><methodResponse><fault><value><struct>
+			<member><name>faultCode</name><value><int>403</int></value></member>
+			<member><name>faultString</name><value><string>Incorrect username or password.</string></value></member>
+		</struct></value></fault></methodResponse>`)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:     server.URL,
+		Timeout: 5,
+		Retry:   config.RetryPolicy{MaxAttempts: 3, InitialBackoffMS: 1, MaxBackoffMS: 5},
+	}
+
+	client, err := NewClient(cfg, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetMedia(); err == nil {
+		t.Fatal("GetMedia() error = nil, want a permanent auth fault error")
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("server received %d requests, want 1 (no retry for a permanent fault)", got)
+	}
+}
+
+func TestMakeRequestReportsEachAttempt(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:     server.URL,
+		Timeout: 5,
+		Retry:   config.RetryPolicy{MaxAttempts: 3, InitialBackoffMS: 1, MaxBackoffMS: 5},
+	}
+
+	client, err := NewClient(cfg, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var attempts []int
+	client.OnAttempt = func(attempt int, err error) {
+		attempts = append(attempts, attempt)
+	}
+
+	if _, err := client.GetMedia(); err != nil {
+		t.Fatalf("GetMedia() error = %v, want nil", err)
+	}
+
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("OnAttempt calls = %v, want [1 2]", attempts)
+	}
+}
@@ -0,0 +1,60 @@
+package xmlrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+// buildHTTPClient constructs the *http.Client used for every XML-RPC request, wiring up
+// cfg.UnixSocket or cfg.ProxyURL (http(s):// or socks5://) into a custom transport. At
+// most one of UnixSocket/ProxyURL is honored; UnixSocket takes precedence since a site
+// reachable only over a Unix socket has no meaningful proxy route anyway.
+func buildHTTPClient(cfg *config.Config) (*http.Client, error) {
+	transport := &http.Transport{}
+
+	switch {
+	case cfg.UnixSocket != "":
+		socketPath := cfg.UnixSocket
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		}
+
+	case cfg.ProxyURL != "":
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https":
+			transport.Proxy = http.ProxyURL(proxyURL)
+		case "socks5":
+			dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure socks5 proxy: %w", err)
+			}
+			transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				if contextDialer, ok := dialer.(proxy.ContextDialer); ok {
+					return contextDialer.DialContext(ctx, network, addr)
+				}
+				return dialer.Dial(network, addr)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported proxy_url scheme %q (want http, https, or socks5)", proxyURL.Scheme)
+		}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+	}, nil
+}
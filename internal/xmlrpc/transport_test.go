@@ -0,0 +1,89 @@
+package xmlrpc
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/tradik/wpexporter/internal/config"
+)
+
+func TestNewClientOverUnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "wordpress.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("net.Listen(unix) error = %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`))
+	}))
+	server.Listener.Close()
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	cfg := &config.Config{
+		URL:        "http://wp.local/xmlrpc.php",
+		Timeout:    5,
+		UserAgent:  "test-agent",
+		UnixSocket: socketPath,
+	}
+
+	client, err := NewClient(cfg, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if err := client.TestConnection(); err != nil {
+		t.Fatalf("TestConnection() over unix socket error = %v", err)
+	}
+}
+
+func TestNewClientWithHTTPProxy(t *testing.T) {
+	var proxied bool
+
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = true
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><methodResponse><params><param><value><array><data></data></array></value></param></params></methodResponse>`))
+	}))
+	defer proxyServer.Close()
+
+	cfg := &config.Config{
+		URL:       "http://example.com",
+		Timeout:   5,
+		UserAgent: "test-agent",
+		ProxyURL:  proxyServer.URL,
+	}
+
+	client, err := NewClient(cfg, "user", "pass")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if err := client.TestConnection(); err != nil {
+		t.Fatalf("TestConnection() through proxy error = %v", err)
+	}
+
+	if !proxied {
+		t.Error("expected the request to be routed through the configured HTTP proxy")
+	}
+}
+
+func TestNewClientRejectsUnsupportedProxyScheme(t *testing.T) {
+	cfg := &config.Config{
+		URL:      "http://example.com",
+		Timeout:  5,
+		ProxyURL: "ftp://proxy.example.com",
+	}
+
+	if _, err := NewClient(cfg, "user", "pass"); err == nil {
+		t.Error("NewClient() error = nil, want an error for an unsupported proxy scheme")
+	}
+}
+
@@ -0,0 +1,76 @@
+package xmlrpc
+
+import (
+	"encoding/base64"
+	"time"
+)
+
+// dateTimeISO8601Format is the (timezone-less) layout XML-RPC uses for
+// <dateTime.iso8601> values, as emitted by WordPress's XML-RPC implementation.
+const dateTimeISO8601Format = "20060102T15:04:05"
+
+// Value represents an XML-RPC <value> element: exactly one of its typed fields is set
+// when decoding, and the same fields are used to build outgoing request parameters.
+// Boolean is modeled as *int because the wire format is the literal 0/1, not "true"/
+// "false". A bare <value>text</value> with no typed child is an implicit string, which
+// Decode recovers from Text.
+type Value struct {
+	String   *string  `xml:"string,omitempty"`
+	Int      *int     `xml:"int,omitempty"`
+	I4       *int     `xml:"i4,omitempty"`
+	Boolean  *int     `xml:"boolean,omitempty"`
+	Double   *float64 `xml:"double,omitempty"`
+	DateTime *string  `xml:"dateTime.iso8601,omitempty"`
+	Base64   *string  `xml:"base64,omitempty"`
+	Struct   *Struct  `xml:"struct,omitempty"`
+	Array    *Array   `xml:"array,omitempty"`
+	Text     string   `xml:",chardata"`
+}
+
+// Decode converts v into its native Go representation:
+//   - string, int, bool, or float64 for scalars
+//   - time.Time for <dateTime.iso8601>
+//   - []byte for <base64>
+//   - map[string]interface{} for <struct>
+//   - []interface{} for <array>
+//
+// An untyped value falls back to its bare text content, per the XML-RPC spec's
+// "string is the default type" rule.
+func (v Value) Decode() interface{} {
+	switch {
+	case v.String != nil:
+		return *v.String
+	case v.Int != nil:
+		return *v.Int
+	case v.I4 != nil:
+		return *v.I4
+	case v.Boolean != nil:
+		return *v.Boolean != 0
+	case v.Double != nil:
+		return *v.Double
+	case v.DateTime != nil:
+		if t, err := time.Parse(dateTimeISO8601Format, *v.DateTime); err == nil {
+			return t
+		}
+		return *v.DateTime
+	case v.Base64 != nil:
+		if data, err := base64.StdEncoding.DecodeString(*v.Base64); err == nil {
+			return data
+		}
+		return *v.Base64
+	case v.Struct != nil:
+		result := make(map[string]interface{}, len(v.Struct.Members))
+		for _, member := range v.Struct.Members {
+			result[member.Name] = member.Value.Decode()
+		}
+		return result
+	case v.Array != nil:
+		result := make([]interface{}, len(v.Array.Data))
+		for i, item := range v.Array.Data {
+			result[i] = item.Decode()
+		}
+		return result
+	default:
+		return v.Text
+	}
+}
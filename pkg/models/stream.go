@@ -0,0 +1,122 @@
+package models
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamKind identifies the record type of one NDJSON line written by StreamExport.
+type StreamKind string
+
+const (
+	StreamKindSite     StreamKind = "site"
+	StreamKindPost     StreamKind = "post"
+	StreamKindPage     StreamKind = "page"
+	StreamKindMedia    StreamKind = "media"
+	StreamKindComment  StreamKind = "comment"
+	StreamKindCategory StreamKind = "category"
+	StreamKindTag      StreamKind = "tag"
+	StreamKindUser     StreamKind = "user"
+	StreamKindStats    StreamKind = "stats"
+)
+
+// streamEnvelope is the {"kind":...,"data":...} wrapper StreamExport writes one of per
+// line and StreamImport reads back.
+type streamEnvelope struct {
+	Kind StreamKind      `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// StreamExport writes data to w as NDJSON, one streamEnvelope-wrapped record per line,
+// for sites too large for ExportData's single buffered json.Marshal (see exportJSON vs
+// export.exportJSONStream, which instead splits into one file per kind rather than a
+// single stream). The site record is always written first and the stats record always
+// last, so a reader can tell a file is complete by checking its last line is a "stats"
+// record.
+func StreamExport(w io.Writer, data *ExportData) error {
+	enc := json.NewEncoder(w)
+
+	if err := writeStreamLine(enc, StreamKindSite, data.Site); err != nil {
+		return err
+	}
+	for _, post := range data.Posts {
+		if err := writeStreamLine(enc, StreamKindPost, post); err != nil {
+			return err
+		}
+	}
+	for _, page := range data.Pages {
+		if err := writeStreamLine(enc, StreamKindPage, page); err != nil {
+			return err
+		}
+	}
+	for _, media := range data.Media {
+		if err := writeStreamLine(enc, StreamKindMedia, media); err != nil {
+			return err
+		}
+	}
+	for _, comment := range data.Comments {
+		if err := writeStreamLine(enc, StreamKindComment, comment); err != nil {
+			return err
+		}
+	}
+	for _, category := range data.Categories {
+		if err := writeStreamLine(enc, StreamKindCategory, category); err != nil {
+			return err
+		}
+	}
+	for _, tag := range data.Tags {
+		if err := writeStreamLine(enc, StreamKindTag, tag); err != nil {
+			return err
+		}
+	}
+	for _, user := range data.Users {
+		if err := writeStreamLine(enc, StreamKindUser, user); err != nil {
+			return err
+		}
+	}
+	return writeStreamLine(enc, StreamKindStats, data.Stats)
+}
+
+func writeStreamLine(enc *json.Encoder, kind StreamKind, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s record: %w", kind, err)
+	}
+	return enc.Encode(streamEnvelope{Kind: kind, Data: raw})
+}
+
+// StreamImport reads an NDJSON stream written by StreamExport, calling handler once per
+// line with that record's kind and raw JSON data so the caller can unmarshal into
+// whichever concrete type matches kind (e.g. WordPressPost for "post"). A truncated final
+// line - the file was cut off mid-write - is silently dropped rather than returned as an
+// error, since that's the one failure mode a streaming writer can leave behind.
+func StreamImport(r io.Reader, handler func(kind string, raw json.RawMessage) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	hasLine := scanner.Scan()
+	for hasLine {
+		line := append([]byte(nil), scanner.Bytes()...)
+		hasLine = scanner.Scan()
+
+		if len(line) == 0 {
+			continue
+		}
+
+		var env streamEnvelope
+		if err := json.Unmarshal(line, &env); err != nil {
+			if !hasLine {
+				break
+			}
+			return fmt.Errorf("failed to parse stream line: %w", err)
+		}
+
+		if err := handler(string(env.Kind), env.Data); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
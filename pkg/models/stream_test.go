@@ -0,0 +1,192 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStreamExportImportRoundtrip(t *testing.T) {
+	data := &ExportData{
+		Site: SiteInfo{Name: "Test Site", URL: "https://example.com"},
+		Posts: []WordPressPost{
+			{ID: 1, Slug: "hello-world", Date: WordPressTime{mustParseWordPressTime(t, "2024-01-15T10:30:00")}},
+		},
+		Pages: []WordPressPost{
+			{ID: 2, Slug: "about"},
+		},
+		Media: []WordPressMedia{
+			{ID: 3, SourceURL: "https://example.com/image.jpg"},
+		},
+		Comments: []WordPressComment{
+			{ID: 4, Post: 1, AuthorName: "Jane"},
+		},
+		Categories: []WordPressCategory{
+			{ID: 5, Name: "News"},
+		},
+		Tags: []WordPressTag{
+			{ID: 6, Name: "featured"},
+		},
+		Users: []WordPressUser{
+			{ID: 7, Name: "admin"},
+		},
+		Stats: ExportStats{TotalPosts: 1, TotalPages: 1, TotalMedia: 1, TotalComments: 1, TotalCategories: 1, TotalTags: 1, TotalUsers: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := StreamExport(&buf, data); err != nil {
+		t.Fatalf("StreamExport() error = %v", err)
+	}
+
+	var (
+		gotSite             SiteInfo
+		gotPosts            []WordPressPost
+		gotPages            []WordPressPost
+		gotMedia            []WordPressMedia
+		gotComments         []WordPressComment
+		gotCategories       []WordPressCategory
+		gotTags             []WordPressTag
+		gotUsers            []WordPressUser
+		gotStats            ExportStats
+		firstKind, lastKind string
+	)
+
+	err := StreamImport(&buf, func(kind string, raw json.RawMessage) error {
+		if firstKind == "" {
+			firstKind = kind
+		}
+		lastKind = kind
+
+		switch StreamKind(kind) {
+		case StreamKindSite:
+			return json.Unmarshal(raw, &gotSite)
+		case StreamKindPost:
+			var post WordPressPost
+			if err := json.Unmarshal(raw, &post); err != nil {
+				return err
+			}
+			gotPosts = append(gotPosts, post)
+		case StreamKindPage:
+			var page WordPressPost
+			if err := json.Unmarshal(raw, &page); err != nil {
+				return err
+			}
+			gotPages = append(gotPages, page)
+		case StreamKindMedia:
+			var media WordPressMedia
+			if err := json.Unmarshal(raw, &media); err != nil {
+				return err
+			}
+			gotMedia = append(gotMedia, media)
+		case StreamKindComment:
+			var comment WordPressComment
+			if err := json.Unmarshal(raw, &comment); err != nil {
+				return err
+			}
+			gotComments = append(gotComments, comment)
+		case StreamKindCategory:
+			var category WordPressCategory
+			if err := json.Unmarshal(raw, &category); err != nil {
+				return err
+			}
+			gotCategories = append(gotCategories, category)
+		case StreamKindTag:
+			var tag WordPressTag
+			if err := json.Unmarshal(raw, &tag); err != nil {
+				return err
+			}
+			gotTags = append(gotTags, tag)
+		case StreamKindUser:
+			var user WordPressUser
+			if err := json.Unmarshal(raw, &user); err != nil {
+				return err
+			}
+			gotUsers = append(gotUsers, user)
+		case StreamKindStats:
+			return json.Unmarshal(raw, &gotStats)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamImport() error = %v", err)
+	}
+
+	if firstKind != string(StreamKindSite) {
+		t.Errorf("first stream record kind = %q, want %q", firstKind, StreamKindSite)
+	}
+	if lastKind != string(StreamKindStats) {
+		t.Errorf("last stream record kind = %q, want %q", lastKind, StreamKindStats)
+	}
+
+	if gotSite.Name != data.Site.Name {
+		t.Errorf("Site.Name = %q, want %q", gotSite.Name, data.Site.Name)
+	}
+	if len(gotPosts) != data.Stats.TotalPosts || len(gotPages) != data.Stats.TotalPages ||
+		len(gotMedia) != data.Stats.TotalMedia || len(gotComments) != data.Stats.TotalComments ||
+		len(gotCategories) != data.Stats.TotalCategories || len(gotTags) != data.Stats.TotalTags ||
+		len(gotUsers) != data.Stats.TotalUsers {
+		t.Errorf("streamed counts = posts:%d pages:%d media:%d comments:%d categories:%d tags:%d users:%d, want to match ExportStats %+v",
+			len(gotPosts), len(gotPages), len(gotMedia), len(gotComments), len(gotCategories), len(gotTags), len(gotUsers), data.Stats)
+	}
+	// ExportStats carries a map field (CustomContentCounts), which isn't comparable with
+	// ==, so compare the scalar counters instead.
+	if gotStats.TotalPosts != data.Stats.TotalPosts ||
+		gotStats.TotalPages != data.Stats.TotalPages ||
+		gotStats.TotalMedia != data.Stats.TotalMedia ||
+		gotStats.TotalCategories != data.Stats.TotalCategories ||
+		gotStats.TotalTags != data.Stats.TotalTags ||
+		gotStats.TotalUsers != data.Stats.TotalUsers ||
+		gotStats.MediaDownloaded != data.Stats.MediaDownloaded ||
+		gotStats.MediaFailed != data.Stats.MediaFailed ||
+		gotStats.BruteForceFound != data.Stats.BruteForceFound ||
+		gotStats.TotalComments != data.Stats.TotalComments ||
+		gotStats.TotalRevisions != data.Stats.TotalRevisions ||
+		gotStats.Skipped != data.Stats.Skipped {
+		t.Errorf("streamed Stats = %+v, want %+v", gotStats, data.Stats)
+	}
+
+	if len(gotPosts) != 1 || !gotPosts[0].Date.Equal(data.Posts[0].Date.Time) {
+		t.Errorf("streamed WordPressTime = %v, want %v", gotPosts[0].Date, data.Posts[0].Date)
+	}
+}
+
+func TestStreamImportToleratesTruncatedLastLine(t *testing.T) {
+	var buf bytes.Buffer
+	data := &ExportData{
+		Site:  SiteInfo{Name: "Test Site"},
+		Posts: []WordPressPost{{ID: 1, Slug: "hello-world"}},
+		Stats: ExportStats{TotalPosts: 1},
+	}
+	if err := StreamExport(&buf, data); err != nil {
+		t.Fatalf("StreamExport() error = %v", err)
+	}
+
+	full := buf.Bytes()
+	truncated := full[:len(full)-5]
+
+	var kinds []string
+	err := StreamImport(bytes.NewReader(truncated), func(kind string, raw json.RawMessage) error {
+		kinds = append(kinds, kind)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamImport() on truncated input error = %v, want nil", err)
+	}
+
+	if len(kinds) == 0 || kinds[0] != string(StreamKindSite) {
+		t.Errorf("StreamImport() on truncated input kinds = %v, want it to still dispatch the complete leading lines", kinds)
+	}
+	if kinds[len(kinds)-1] == string(StreamKindStats) {
+		t.Errorf("StreamImport() on truncated input dispatched the truncated stats line, want it dropped")
+	}
+}
+
+func mustParseWordPressTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	var wt WordPressTime
+	if err := json.Unmarshal([]byte(`"`+s+`"`), &wt); err != nil {
+		t.Fatalf("failed to parse WordPressTime %q: %v", s, err)
+	}
+	return wt.Time
+}
@@ -16,30 +16,30 @@ func (wt *WordPressTime) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
-	
+
 	// Try different WordPress date formats
 	formats := []string{
-		"2006-01-02T15:04:05",           // WordPress format without timezone
-		"2006-01-02T15:04:05Z",          // ISO format with Z
-		"2006-01-02T15:04:05-07:00",     // ISO format with timezone offset
-		"2006-01-02T15:04:05+00:00",     // ISO format with UTC offset
-		time.RFC3339,                    // Standard RFC3339
-		time.RFC3339Nano,                // RFC3339 with nanoseconds
+		"2006-01-02T15:04:05",       // WordPress format without timezone
+		"2006-01-02T15:04:05Z",      // ISO format with Z
+		"2006-01-02T15:04:05-07:00", // ISO format with timezone offset
+		"2006-01-02T15:04:05+00:00", // ISO format with UTC offset
+		time.RFC3339,                // Standard RFC3339
+		time.RFC3339Nano,            // RFC3339 with nanoseconds
 	}
-	
+
 	for _, format := range formats {
 		if t, err := time.Parse(format, s); err == nil {
 			wt.Time = t
 			return nil
 		}
 	}
-	
+
 	// If all formats fail, try to parse as is and assume UTC
 	if t, err := time.Parse("2006-01-02T15:04:05", s); err == nil {
 		wt.Time = t.UTC()
 		return nil
 	}
-	
+
 	// Default to current time if parsing fails
 	wt.Time = time.Now()
 	return nil
@@ -47,99 +47,167 @@ func (wt *WordPressTime) UnmarshalJSON(data []byte) error {
 
 // WordPressPost represents a WordPress post or page
 type WordPressPost struct {
-	ID              int                    `json:"id"`
-	Date            WordPressTime          `json:"date"`
-	DateGMT         WordPressTime          `json:"date_gmt"`
-	GUID            GUID                   `json:"guid"`
-	Modified        WordPressTime          `json:"modified"`
-	ModifiedGMT     WordPressTime          `json:"modified_gmt"`
-	Slug            string                 `json:"slug"`
-	Status          string                 `json:"status"`
-	Type            string                 `json:"type"`
-	Link            string                 `json:"link"`
-	Title           RenderedContent        `json:"title"`
-	Content         RenderedContent        `json:"content"`
-	Excerpt         RenderedContent        `json:"excerpt"`
-	Author          int                    `json:"author"`
-	FeaturedMedia   int                    `json:"featured_media"`
-	CommentStatus   string                 `json:"comment_status"`
-	PingStatus      string                 `json:"ping_status"`
-	Sticky          bool                   `json:"sticky"`
-	Template        string                 `json:"template"`
-	Format          string                 `json:"format"`
-	Meta            map[string]interface{} `json:"meta"`
-	Categories      []int                  `json:"categories"`
-	Tags            []int                  `json:"tags"`
-	Links           Links                  `json:"_links"`
+	ID            int                    `json:"id"`
+	Date          WordPressTime          `json:"date"`
+	DateGMT       WordPressTime          `json:"date_gmt"`
+	GUID          GUID                   `json:"guid"`
+	Modified      WordPressTime          `json:"modified"`
+	ModifiedGMT   WordPressTime          `json:"modified_gmt"`
+	Slug          string                 `json:"slug"`
+	Status        string                 `json:"status"`
+	Type          string                 `json:"type"`
+	Link          string                 `json:"link"`
+	Title         RenderedContent        `json:"title"`
+	Content       RenderedContent        `json:"content"`
+	Excerpt       RenderedContent        `json:"excerpt"`
+	Author        int                    `json:"author"`
+	FeaturedMedia int                    `json:"featured_media"`
+	Parent        int                    `json:"parent"`
+	CommentStatus string                 `json:"comment_status"`
+	PingStatus    string                 `json:"ping_status"`
+	Sticky        bool                   `json:"sticky"`
+	Template      string                 `json:"template"`
+	Format        string                 `json:"format"`
+	Meta          map[string]interface{} `json:"meta"`
+	Categories    []int                  `json:"categories"`
+	Tags          []int                  `json:"tags"`
+	Links         Links                  `json:"_links"`
 }
 
 // WordPressMedia represents a WordPress media item
 type WordPressMedia struct {
-	ID              int                    `json:"id"`
-	Date            WordPressTime          `json:"date"`
-	DateGMT         WordPressTime          `json:"date_gmt"`
-	GUID            GUID                   `json:"guid"`
-	Modified        WordPressTime          `json:"modified"`
-	ModifiedGMT     WordPressTime          `json:"modified_gmt"`
-	Slug            string                 `json:"slug"`
-	Status          string                 `json:"status"`
-	Type            string                 `json:"type"`
-	Link            string                 `json:"link"`
-	Title           RenderedContent        `json:"title"`
-	Author          int                    `json:"author"`
-	CommentStatus   string                 `json:"comment_status"`
-	PingStatus      string                 `json:"ping_status"`
-	Template        string                 `json:"template"`
-	Meta            interface{}            `json:"meta"`
-	Description     RenderedContent        `json:"description"`
-	Caption         RenderedContent        `json:"caption"`
-	AltText         string                 `json:"alt_text"`
-	MediaType       string                 `json:"media_type"`
-	MimeType        string                 `json:"mime_type"`
-	MediaDetails    MediaDetails           `json:"media_details"`
-	Post            int                    `json:"post"`
-	SourceURL       string                 `json:"source_url"`
-	Links           Links                  `json:"_links"`
+	ID            int             `json:"id"`
+	Date          WordPressTime   `json:"date"`
+	DateGMT       WordPressTime   `json:"date_gmt"`
+	GUID          GUID            `json:"guid"`
+	Modified      WordPressTime   `json:"modified"`
+	ModifiedGMT   WordPressTime   `json:"modified_gmt"`
+	Slug          string          `json:"slug"`
+	Status        string          `json:"status"`
+	Type          string          `json:"type"`
+	Link          string          `json:"link"`
+	Title         RenderedContent `json:"title"`
+	Author        int             `json:"author"`
+	CommentStatus string          `json:"comment_status"`
+	PingStatus    string          `json:"ping_status"`
+	Template      string          `json:"template"`
+	Meta          interface{}     `json:"meta"`
+	Description   RenderedContent `json:"description"`
+	Caption       RenderedContent `json:"caption"`
+	AltText       string          `json:"alt_text"`
+	MediaType     string          `json:"media_type"`
+	MimeType      string          `json:"mime_type"`
+	MediaDetails  MediaDetails    `json:"media_details"`
+	Post          int             `json:"post"`
+	SourceURL     string          `json:"source_url"`
+	Links         Links           `json:"_links"`
 }
 
 // WordPressCategory represents a WordPress category
 type WordPressCategory struct {
-	ID          int    `json:"id"`
-	Count       int    `json:"count"`
-	Description string `json:"description"`
-	Link        string `json:"link"`
-	Name        string `json:"name"`
-	Slug        string `json:"slug"`
-	Taxonomy    string `json:"taxonomy"`
-	Parent      int    `json:"parent"`
+	ID          int           `json:"id"`
+	Count       int           `json:"count"`
+	Description string        `json:"description"`
+	Link        string        `json:"link"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Taxonomy    string        `json:"taxonomy"`
+	Parent      int           `json:"parent"`
 	Meta        []interface{} `json:"meta"`
-	Links       Links  `json:"_links"`
+	Links       Links         `json:"_links"`
 }
 
 // WordPressTag represents a WordPress tag
 type WordPressTag struct {
-	ID          int    `json:"id"`
-	Count       int    `json:"count"`
-	Description string `json:"description"`
-	Link        string `json:"link"`
-	Name        string `json:"name"`
-	Slug        string `json:"slug"`
-	Taxonomy    string `json:"taxonomy"`
+	ID          int           `json:"id"`
+	Count       int           `json:"count"`
+	Description string        `json:"description"`
+	Link        string        `json:"link"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Taxonomy    string        `json:"taxonomy"`
 	Meta        []interface{} `json:"meta"`
-	Links       Links  `json:"_links"`
+	Links       Links         `json:"_links"`
 }
 
-// WordPressUser represents a WordPress user
+// WordPressUser represents a WordPress user. Email, Roles, and Capabilities are only
+// populated in a context=edit response (see Client.GetUsersWithEmailContext), which requires
+// an authenticated request with edit-level permissions - the default list-context response
+// omits them entirely.
 type WordPressUser struct {
-	ID          int               `json:"id"`
-	Name        string            `json:"name"`
-	URL         string            `json:"url"`
-	Description string            `json:"description"`
-	Link        string            `json:"link"`
-	Slug        string            `json:"slug"`
-	AvatarURLs  map[string]string `json:"avatar_urls"`
-	Meta        []interface{}     `json:"meta"`
-	Links       Links             `json:"_links"`
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	URL          string            `json:"url"`
+	Description  string            `json:"description"`
+	Link         string            `json:"link"`
+	Slug         string            `json:"slug"`
+	AvatarURLs   map[string]string `json:"avatar_urls"`
+	Meta         []interface{}     `json:"meta"`
+	Email        string            `json:"email,omitempty"`
+	Roles        []string          `json:"roles,omitempty"`
+	Capabilities map[string]bool   `json:"capabilities,omitempty"`
+	Links        Links             `json:"_links"`
+}
+
+// WordPressComment represents a comment on a WordPress post
+type WordPressComment struct {
+	ID          int             `json:"id"`
+	Post        int             `json:"post"`
+	Parent      int             `json:"parent"`
+	Author      int             `json:"author"`
+	AuthorName  string          `json:"author_name"`
+	AuthorEmail string          `json:"author_email"`
+	Date        WordPressTime   `json:"date"`
+	DateGMT     WordPressTime   `json:"date_gmt"`
+	Content     RenderedContent `json:"content"`
+	Status      string          `json:"status"`
+	Type        string          `json:"type"`
+	Meta        interface{}     `json:"meta"`
+	Links       Links           `json:"_links"`
+}
+
+// WordPressRevision represents a historical revision of a WordPress post
+type WordPressRevision struct {
+	ID          int             `json:"id"`
+	Parent      int             `json:"parent"`
+	Author      int             `json:"author"`
+	Date        WordPressTime   `json:"date"`
+	DateGMT     WordPressTime   `json:"date_gmt"`
+	Modified    WordPressTime   `json:"modified"`
+	ModifiedGMT WordPressTime   `json:"modified_gmt"`
+	Slug        string          `json:"slug"`
+	GUID        GUID            `json:"guid"`
+	Title       RenderedContent `json:"title"`
+	Content     RenderedContent `json:"content"`
+	Excerpt     RenderedContent `json:"excerpt"`
+	Links       Links           `json:"_links"`
+}
+
+// WordPressPostType represents one entry of the /wp/v2/types response, describing a
+// registered post type (built-in ones like "post"/"page", or a custom post type).
+type WordPressPostType struct {
+	Slug         string   `json:"slug"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Hierarchical bool     `json:"hierarchical"`
+	RestBase     string   `json:"rest_base"`
+	RestNSPrefix string   `json:"rest_namespace"`
+	Taxonomies   []string `json:"taxonomies"`
+	Links        Links    `json:"_links"`
+}
+
+// WordPressTaxonomy represents one entry of the /wp/v2/taxonomies response, describing a
+// registered taxonomy (built-in ones like "category"/"post_tag", or a custom taxonomy) and
+// the post types it classifies.
+type WordPressTaxonomy struct {
+	Name         string   `json:"name"`
+	Slug         string   `json:"slug"`
+	Description  string   `json:"description"`
+	Hierarchical bool     `json:"hierarchical"`
+	RestBase     string   `json:"rest_base"`
+	RestNSPrefix string   `json:"rest_namespace"`
+	Types        []string `json:"types"`
+	Links        Links    `json:"_links"`
 }
 
 // RenderedContent represents rendered WordPress content
@@ -175,17 +243,17 @@ type MediaSize struct {
 
 // Links represents WordPress API links
 type Links struct {
-	Self           []Link `json:"self,omitempty"`
-	Collection     []Link `json:"collection,omitempty"`
-	About          []Link `json:"about,omitempty"`
-	Author         []Link `json:"author,omitempty"`
-	Replies        []Link `json:"replies,omitempty"`
-	VersionHistory []Link `json:"version-history,omitempty"`
+	Self               []Link `json:"self,omitempty"`
+	Collection         []Link `json:"collection,omitempty"`
+	About              []Link `json:"about,omitempty"`
+	Author             []Link `json:"author,omitempty"`
+	Replies            []Link `json:"replies,omitempty"`
+	VersionHistory     []Link `json:"version-history,omitempty"`
 	PredecessorVersion []Link `json:"predecessor-version,omitempty"`
-	WPFeaturedmedia []Link `json:"wp:featuredmedia,omitempty"`
-	WPAttachment   []Link `json:"wp:attachment,omitempty"`
-	WPTerm         []Link `json:"wp:term,omitempty"`
-	Curies         []Link `json:"curies,omitempty"`
+	WPFeaturedmedia    []Link `json:"wp:featuredmedia,omitempty"`
+	WPAttachment       []Link `json:"wp:attachment,omitempty"`
+	WPTerm             []Link `json:"wp:term,omitempty"`
+	Curies             []Link `json:"curies,omitempty"`
 }
 
 // Link represents a WordPress API link
@@ -195,15 +263,43 @@ type Link struct {
 
 // ExportData represents the complete export data structure
 type ExportData struct {
-	Site       SiteInfo             `json:"site"`
-	Posts      []WordPressPost      `json:"posts"`
-	Pages      []WordPressPost      `json:"pages"`
-	Media      []WordPressMedia     `json:"media"`
-	Categories []WordPressCategory  `json:"categories"`
-	Tags       []WordPressTag       `json:"tags"`
-	Users      []WordPressUser      `json:"users"`
-	ExportedAt time.Time            `json:"exported_at"`
-	Stats      ExportStats          `json:"stats"`
+	Site       SiteInfo            `json:"site"`
+	Posts      []WordPressPost     `json:"posts"`
+	Pages      []WordPressPost     `json:"pages"`
+	Media      []WordPressMedia    `json:"media"`
+	Categories []WordPressCategory `json:"categories"`
+	Tags       []WordPressTag      `json:"tags"`
+	Users      []WordPressUser     `json:"users"`
+	// Comments is every comment fetched across the site (see Config.IncludeComments),
+	// regardless of which post they belong to - WordPressComment.Post identifies that.
+	Comments []WordPressComment `json:"comments,omitempty"`
+	// Revisions holds each post/page's revision history (see Config.IncludeRevisions),
+	// keyed by the post/page's ID rather than nested under WordPressPost itself, mirroring
+	// how the WordPress REST API exposes revisions as their own collection endpoint.
+	Revisions map[int][]WordPressRevision `json:"revisions,omitempty"`
+	// CustomContent holds every item fetched from a non-core post type or taxonomy
+	// discovered via Config.IncludeCustomContent (see Client.GetPostTypes and
+	// Client.GetTaxonomies), keyed by that type's rest_base (e.g. "products" for a
+	// "product" post type). Left as raw JSON since a custom type's schema isn't known
+	// ahead of time - it may extend WordPressPost with arbitrary extra fields, or not
+	// resemble it at all.
+	CustomContent map[string][]json.RawMessage `json:"custom_content,omitempty"`
+	// MediaFailures records every media size variant that exhausted its retries during
+	// download (see media.Downloader), one entry per failed variant.
+	MediaFailures []MediaFailure `json:"media_failures,omitempty"`
+	ExportedAt    time.Time      `json:"exported_at"`
+	Stats         ExportStats    `json:"stats"`
+}
+
+// MediaFailure records a media size variant that permanently failed to download after
+// exhausting its retries, so an export can surface the shortfall instead of silently
+// shipping an incomplete set of sub-sizes.
+type MediaFailure struct {
+	ID        int    `json:"id"`
+	URL       string `json:"url"`
+	SizeName  string `json:"size_name"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error"`
 }
 
 // SiteInfo represents WordPress site information
@@ -229,5 +325,17 @@ type ExportStats struct {
 	TotalTags       int `json:"total_tags"`
 	TotalUsers      int `json:"total_users"`
 	MediaDownloaded int `json:"media_downloaded"`
+	// MediaFailed counts entries in ExportData.MediaFailures - media size variants that
+	// exhausted their retries and were left out of the export rather than half-written.
+	MediaFailed     int `json:"media_failed"`
 	BruteForceFound int `json:"brute_force_found"`
+	TotalComments   int `json:"total_comments"`
+	TotalRevisions  int `json:"total_revisions"`
+	// Skipped counts posts/pages whose generated content hash matched the incremental
+	// export manifest (see export.ExportManifest) and were therefore left untouched.
+	Skipped int `json:"skipped"`
+	// CustomContentCounts holds how many items were fetched into ExportData.CustomContent
+	// per rest_base, for the non-core post types/taxonomies discovered via
+	// Config.IncludeCustomContent.
+	CustomContentCounts map[string]int `json:"custom_content_counts,omitempty"`
 }
@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -366,6 +367,133 @@ func TestWordPressTagFields(t *testing.T) {
 	}
 }
 
+func TestWordPressCommentFields(t *testing.T) {
+	commentJSON := `{
+		"id": 42,
+		"post": 7,
+		"parent": 0,
+		"author": 0,
+		"author_name": "Alice",
+		"author_email": "alice@example.com",
+		"date": "2024-01-15T10:30:00",
+		"date_gmt": "2024-01-15T10:30:00",
+		"content": {"rendered": "<p>Great post!</p>"},
+		"status": "approved",
+		"type": "comment",
+		"meta": [],
+		"_links": {}
+	}`
+
+	var comment WordPressComment
+	if err := json.Unmarshal([]byte(commentJSON), &comment); err != nil {
+		t.Fatalf("Failed to unmarshal WordPressComment: %v", err)
+	}
+
+	if comment.ID != 42 {
+		t.Errorf("WordPressComment ID = %d, want %d", comment.ID, 42)
+	}
+	if comment.Post != 7 {
+		t.Errorf("WordPressComment Post = %d, want %d", comment.Post, 7)
+	}
+	if comment.Parent != 0 {
+		t.Errorf("WordPressComment Parent = %d, want %d", comment.Parent, 0)
+	}
+	if comment.AuthorName != "Alice" {
+		t.Errorf("WordPressComment AuthorName = %s, want %s", comment.AuthorName, "Alice")
+	}
+	if comment.AuthorEmail != "alice@example.com" {
+		t.Errorf("WordPressComment AuthorEmail = %s, want %s", comment.AuthorEmail, "alice@example.com")
+	}
+	if comment.Content.Rendered != "<p>Great post!</p>" {
+		t.Errorf("WordPressComment Content.Rendered = %s, want %s", comment.Content.Rendered, "<p>Great post!</p>")
+	}
+	if comment.Status != "approved" {
+		t.Errorf("WordPressComment Status = %s, want %s", comment.Status, "approved")
+	}
+
+	roundtripped, err := json.Marshal(comment)
+	if err != nil {
+		t.Fatalf("Failed to marshal WordPressComment: %v", err)
+	}
+	var reparsed WordPressComment
+	if err := json.Unmarshal(roundtripped, &reparsed); err != nil {
+		t.Fatalf("Failed to unmarshal roundtripped WordPressComment: %v", err)
+	}
+	if !reflect.DeepEqual(reparsed, comment) {
+		t.Errorf("WordPressComment roundtrip = %+v, want %+v", reparsed, comment)
+	}
+}
+
+func TestWordPressCommentThreaded(t *testing.T) {
+	repliesJSON := `[
+		{"id": 1, "post": 7, "parent": 0, "author_name": "Alice", "content": {"rendered": "First!"}},
+		{"id": 2, "post": 7, "parent": 1, "author_name": "Bob", "content": {"rendered": "Reply to Alice"}}
+	]`
+
+	var comments []WordPressComment
+	if err := json.Unmarshal([]byte(repliesJSON), &comments); err != nil {
+		t.Fatalf("Failed to unmarshal threaded comments: %v", err)
+	}
+
+	if len(comments) != 2 {
+		t.Fatalf("got %d comments, want %d", len(comments), 2)
+	}
+	if comments[0].Parent != 0 {
+		t.Errorf("top-level comment Parent = %d, want %d", comments[0].Parent, 0)
+	}
+	if comments[1].Parent != comments[0].ID {
+		t.Errorf("reply Parent = %d, want %d (the top-level comment's ID)", comments[1].Parent, comments[0].ID)
+	}
+}
+
+func TestWordPressRevisionFields(t *testing.T) {
+	revisionJSON := `{
+		"id": 101,
+		"parent": 7,
+		"author": 1,
+		"date": "2024-01-15T10:30:00",
+		"date_gmt": "2024-01-15T10:30:00",
+		"modified": "2024-01-16T11:00:00",
+		"modified_gmt": "2024-01-16T11:00:00",
+		"slug": "7-revision-v1",
+		"guid": {"rendered": "https://example.com/?p=7&revision=101"},
+		"title": {"rendered": "Original Title"},
+		"content": {"rendered": "<p>Original content</p>"},
+		"excerpt": {"rendered": "Original excerpt"},
+		"_links": {}
+	}`
+
+	var revision WordPressRevision
+	if err := json.Unmarshal([]byte(revisionJSON), &revision); err != nil {
+		t.Fatalf("Failed to unmarshal WordPressRevision: %v", err)
+	}
+
+	if revision.ID != 101 {
+		t.Errorf("WordPressRevision ID = %d, want %d", revision.ID, 101)
+	}
+	if revision.Parent != 7 {
+		t.Errorf("WordPressRevision Parent = %d, want %d", revision.Parent, 7)
+	}
+	if revision.Title.Rendered != "Original Title" {
+		t.Errorf("WordPressRevision Title.Rendered = %s, want %s", revision.Title.Rendered, "Original Title")
+	}
+	if revision.Content.Rendered != "<p>Original content</p>" {
+		t.Errorf("WordPressRevision Content.Rendered = %s, want %s", revision.Content.Rendered, "<p>Original content</p>")
+	}
+
+	roundtripped, err := json.Marshal(revision)
+	if err != nil {
+		t.Fatalf("Failed to marshal WordPressRevision: %v", err)
+	}
+	var reparsed WordPressRevision
+	if err := json.Unmarshal(roundtripped, &reparsed); err != nil {
+		t.Fatalf("Failed to unmarshal roundtripped WordPressRevision: %v", err)
+	}
+	if !reflect.DeepEqual(reparsed, revision) {
+		t.Errorf("WordPressRevision roundtrip = %+v, want %+v", reparsed, revision)
+	}
+}
+
 func TestWordPressUserFields(t *testing.T) {
 	userJSON := `{
 		"id": 1,
@@ -605,3 +733,338 @@ func TestMediaSizeStructure(t *testing.T) {
 		t.Errorf("MediaSize.MimeType = %s, want %s", mediaSize.MimeType, "image/jpeg")
 	}
 }
+
+func TestWordPressPostTypeFields(t *testing.T) {
+	typesJSON := `{
+		"post": {
+			"slug": "post",
+			"name": "Posts",
+			"description": "",
+			"hierarchical": false,
+			"rest_base": "posts",
+			"rest_namespace": "wp/v2",
+			"taxonomies": ["category", "post_tag"],
+			"_links": {}
+		},
+		"product": {
+			"slug": "product",
+			"name": "Products",
+			"description": "Store products",
+			"hierarchical": false,
+			"rest_base": "products",
+			"rest_namespace": "wp/v2",
+			"taxonomies": ["product_cat"],
+			"_links": {}
+		}
+	}`
+
+	var types map[string]WordPressPostType
+	if err := json.Unmarshal([]byte(typesJSON), &types); err != nil {
+		t.Fatalf("Failed to unmarshal types response: %v", err)
+	}
+
+	product, ok := types["product"]
+	if !ok {
+		t.Fatal("expected a \"product\" entry in the types response")
+	}
+	if product.RestBase != "products" {
+		t.Errorf("WordPressPostType RestBase = %s, want %s", product.RestBase, "products")
+	}
+	if len(product.Taxonomies) != 1 || product.Taxonomies[0] != "product_cat" {
+		t.Errorf("WordPressPostType Taxonomies = %v, want %v", product.Taxonomies, []string{"product_cat"})
+	}
+
+	roundtripped, err := json.Marshal(product)
+	if err != nil {
+		t.Fatalf("Failed to marshal WordPressPostType: %v", err)
+	}
+	var reparsed WordPressPostType
+	if err := json.Unmarshal(roundtripped, &reparsed); err != nil {
+		t.Fatalf("Failed to unmarshal roundtripped WordPressPostType: %v", err)
+	}
+	if !reflect.DeepEqual(reparsed, product) {
+		t.Errorf("WordPressPostType roundtrip = %+v, want %+v", reparsed, product)
+	}
+}
+
+func TestWordPressTaxonomyFields(t *testing.T) {
+	taxonomiesJSON := `{
+		"category": {
+			"name": "Categories",
+			"slug": "category",
+			"description": "",
+			"hierarchical": true,
+			"rest_base": "categories",
+			"rest_namespace": "wp/v2",
+			"types": ["post"],
+			"_links": {}
+		},
+		"genre": {
+			"name": "Genre",
+			"slug": "genre",
+			"description": "Portfolio item genre",
+			"hierarchical": false,
+			"rest_base": "genre",
+			"rest_namespace": "wp/v2",
+			"types": ["portfolio"],
+			"_links": {}
+		}
+	}`
+
+	var taxonomies map[string]WordPressTaxonomy
+	if err := json.Unmarshal([]byte(taxonomiesJSON), &taxonomies); err != nil {
+		t.Fatalf("Failed to unmarshal taxonomies response: %v", err)
+	}
+
+	genre, ok := taxonomies["genre"]
+	if !ok {
+		t.Fatal("expected a \"genre\" entry in the taxonomies response")
+	}
+	if genre.RestBase != "genre" {
+		t.Errorf("WordPressTaxonomy RestBase = %s, want %s", genre.RestBase, "genre")
+	}
+	if len(genre.Types) != 1 || genre.Types[0] != "portfolio" {
+		t.Errorf("WordPressTaxonomy Types = %v, want %v", genre.Types, []string{"portfolio"})
+	}
+
+	roundtripped, err := json.Marshal(genre)
+	if err != nil {
+		t.Fatalf("Failed to marshal WordPressTaxonomy: %v", err)
+	}
+	var reparsed WordPressTaxonomy
+	if err := json.Unmarshal(roundtripped, &reparsed); err != nil {
+		t.Fatalf("Failed to unmarshal roundtripped WordPressTaxonomy: %v", err)
+	}
+	if !reflect.DeepEqual(reparsed, genre) {
+		t.Errorf("WordPressTaxonomy roundtrip = %+v, want %+v", reparsed, genre)
+	}
+}
+
+func TestExportDataCustomContentRoundtrip(t *testing.T) {
+	exportJSON := `{
+		"site": {"name": "Test Site"},
+		"posts": [],
+		"pages": [],
+		"media": [],
+		"categories": [],
+		"tags": [],
+		"users": [],
+		"custom_content": {
+			"products": [
+				{"id": 1, "slug": "widget", "acf": {"price": 9.99}},
+				{"id": 2, "slug": "gadget", "acf": {"price": 19.99}}
+			],
+			"portfolio": [
+				{"id": 1, "slug": "launch-site"}
+			]
+		},
+		"exported_at": "2024-01-15T10:30:00Z",
+		"stats": {
+			"total_posts": 0,
+			"total_pages": 0,
+			"total_media": 0,
+			"total_categories": 0,
+			"total_tags": 0,
+			"total_users": 0,
+			"media_downloaded": 0,
+			"brute_force_found": 0,
+			"custom_content_counts": {"products": 2, "portfolio": 1}
+		}
+	}`
+
+	var exportData ExportData
+	if err := json.Unmarshal([]byte(exportJSON), &exportData); err != nil {
+		t.Fatalf("Failed to unmarshal ExportData: %v", err)
+	}
+
+	if len(exportData.CustomContent["products"]) != 2 {
+		t.Errorf("ExportData CustomContent[products] length = %d, want %d", len(exportData.CustomContent["products"]), 2)
+	}
+	if len(exportData.CustomContent["portfolio"]) != 1 {
+		t.Errorf("ExportData CustomContent[portfolio] length = %d, want %d", len(exportData.CustomContent["portfolio"]), 1)
+	}
+	if exportData.Stats.CustomContentCounts["products"] != 2 {
+		t.Errorf("ExportData Stats.CustomContentCounts[products] = %d, want %d", exportData.Stats.CustomContentCounts["products"], 2)
+	}
+
+	roundtripped, err := json.Marshal(exportData)
+	if err != nil {
+		t.Fatalf("Failed to marshal ExportData: %v", err)
+	}
+	var reparsed ExportData
+	if err := json.Unmarshal(roundtripped, &reparsed); err != nil {
+		t.Fatalf("Failed to unmarshal roundtripped ExportData: %v", err)
+	}
+	if len(reparsed.CustomContent["products"]) != 2 {
+		t.Errorf("roundtripped CustomContent[products] length = %d, want %d", len(reparsed.CustomContent["products"]), 2)
+	}
+	if !reflect.DeepEqual(reparsed.Stats.CustomContentCounts, exportData.Stats.CustomContentCounts) {
+		t.Errorf("roundtripped Stats.CustomContentCounts = %+v, want %+v", reparsed.Stats.CustomContentCounts, exportData.Stats.CustomContentCounts)
+	}
+}
+
+func TestWordPressUserEditContextFields(t *testing.T) {
+	userJSON := `{
+		"id": 1,
+		"name": "Admin User",
+		"url": "https://admin.example.com",
+		"description": "Site administrator",
+		"link": "https://example.com/author/admin/",
+		"slug": "admin",
+		"email": "admin@example.com",
+		"roles": ["administrator"],
+		"capabilities": {"edit_posts": true, "manage_options": true, "subscriber": false},
+		"avatar_urls": {},
+		"meta": [],
+		"_links": {}
+	}`
+
+	var user WordPressUser
+	if err := json.Unmarshal([]byte(userJSON), &user); err != nil {
+		t.Fatalf("Failed to unmarshal WordPressUser: %v", err)
+	}
+
+	if user.Email != "admin@example.com" {
+		t.Errorf("WordPressUser Email = %s, want %s", user.Email, "admin@example.com")
+	}
+
+	if len(user.Roles) != 1 || user.Roles[0] != "administrator" {
+		t.Errorf("WordPressUser Roles = %v, want [administrator]", user.Roles)
+	}
+
+	if !user.Capabilities["edit_posts"] || !user.Capabilities["manage_options"] || user.Capabilities["subscriber"] {
+		t.Errorf("WordPressUser Capabilities = %v, want edit_posts/manage_options true, subscriber false", user.Capabilities)
+	}
+
+	// The default list-context response omits these fields entirely rather than sending
+	// them empty, so a plain (non-edit-context) user must still unmarshal cleanly.
+	listJSON := `{"id": 2, "name": "Subscriber", "slug": "subscriber", "meta": [], "_links": {}}`
+	var listUser WordPressUser
+	if err := json.Unmarshal([]byte(listJSON), &listUser); err != nil {
+		t.Fatalf("Failed to unmarshal list-context WordPressUser: %v", err)
+	}
+	if listUser.Email != "" || listUser.Roles != nil || listUser.Capabilities != nil {
+		t.Errorf("list-context WordPressUser = %+v, want Email/Roles/Capabilities unset", listUser)
+	}
+}
+
+func TestWordPressPostProtectedContent(t *testing.T) {
+	postJSON := `{
+		"id": 124,
+		"date": "2024-01-15T10:30:00Z",
+		"date_gmt": "2024-01-15T10:30:00Z",
+		"guid": {"rendered": "https://example.com/?p=124"},
+		"modified": "2024-01-15T10:30:00Z",
+		"modified_gmt": "2024-01-15T10:30:00Z",
+		"slug": "secret-post",
+		"status": "publish",
+		"type": "post",
+		"link": "https://example.com/secret-post",
+		"title": {"rendered": "Secret Post"},
+		"content": {"rendered": "The actual protected body.", "protected": true},
+		"excerpt": {"rendered": ""},
+		"author": 1,
+		"featured_media": 0,
+		"comment_status": "open",
+		"ping_status": "open",
+		"sticky": false,
+		"template": "",
+		"format": "standard",
+		"meta": {},
+		"categories": [],
+		"tags": [],
+		"_links": {}
+	}`
+
+	var post WordPressPost
+	if err := json.Unmarshal([]byte(postJSON), &post); err != nil {
+		t.Fatalf("Failed to unmarshal WordPressPost: %v", err)
+	}
+
+	if !post.Content.Protected {
+		t.Errorf("WordPressPost Content.Protected = false, want true")
+	}
+
+	if post.Content.Rendered != "The actual protected body." {
+		t.Errorf("WordPressPost Content.Rendered = %q, want %q", post.Content.Rendered, "The actual protected body.")
+	}
+
+	// An anonymous (unauthenticated) fetch of the same post renders an excerpt-style
+	// placeholder instead, with protected still true and rendered empty.
+	lockedJSON := `{"id": 124, "slug": "secret-post", "content": {"rendered": "", "protected": true}, "_links": {}}`
+	var locked WordPressPost
+	if err := json.Unmarshal([]byte(lockedJSON), &locked); err != nil {
+		t.Fatalf("Failed to unmarshal anonymous WordPressPost: %v", err)
+	}
+	if !locked.Content.Protected || locked.Content.Rendered != "" {
+		t.Errorf("anonymous WordPressPost Content = %+v, want Protected=true Rendered=\"\"", locked.Content)
+	}
+}
+
+func TestMediaDetailsSizesMultiple(t *testing.T) {
+	mediaJSON := `{
+		"id": 42,
+		"media_details": {
+			"width": 1024,
+			"height": 768,
+			"file": "2024/01/test.jpg",
+			"sizes": {
+				"thumbnail": {"file": "test-150x150.jpg", "width": 150, "height": 150, "mime_type": "image/jpeg", "source_url": "https://example.com/wp-content/uploads/2024/01/test-150x150.jpg"},
+				"medium": {"file": "test-300x225.jpg", "width": 300, "height": 225, "mime_type": "image/jpeg", "source_url": "https://example.com/wp-content/uploads/2024/01/test-300x225.jpg"},
+				"large": {"file": "test-1024x768.jpg", "width": 1024, "height": 768, "mime_type": "image/jpeg", "source_url": "https://example.com/wp-content/uploads/2024/01/test-1024x768.jpg"}
+			}
+		}
+	}`
+
+	var media WordPressMedia
+	if err := json.Unmarshal([]byte(mediaJSON), &media); err != nil {
+		t.Fatalf("Failed to unmarshal WordPressMedia: %v", err)
+	}
+
+	if len(media.MediaDetails.Sizes) != 3 {
+		t.Fatalf("MediaDetails.Sizes has %d entries, want 3", len(media.MediaDetails.Sizes))
+	}
+
+	// Map iteration order is unspecified, so each size must be retrievable by name
+	// rather than by position - this is what the downloader relies on when it looks
+	// up a specific size variant to schedule a download for.
+	wantFiles := map[string]string{
+		"thumbnail": "test-150x150.jpg",
+		"medium":    "test-300x225.jpg",
+		"large":     "test-1024x768.jpg",
+	}
+	for name, wantFile := range wantFiles {
+		size, ok := media.MediaDetails.Sizes[name]
+		if !ok {
+			t.Errorf("MediaDetails.Sizes missing entry %q", name)
+			continue
+		}
+		if size.File != wantFile {
+			t.Errorf("MediaDetails.Sizes[%q].File = %s, want %s", name, size.File, wantFile)
+		}
+	}
+}
+
+func TestMediaFailureRoundtrip(t *testing.T) {
+	failure := MediaFailure{
+		ID:        42,
+		URL:       "https://example.com/wp-content/uploads/2024/01/test-1024x768.jpg",
+		SizeName:  "large",
+		Attempts:  4,
+		LastError: "server returned status 503",
+	}
+
+	data, err := json.Marshal(failure)
+	if err != nil {
+		t.Fatalf("Failed to marshal MediaFailure: %v", err)
+	}
+
+	var reparsed MediaFailure
+	if err := json.Unmarshal(data, &reparsed); err != nil {
+		t.Fatalf("Failed to unmarshal MediaFailure: %v", err)
+	}
+
+	if !reflect.DeepEqual(reparsed, failure) {
+		t.Errorf("MediaFailure roundtrip = %+v, want %+v", reparsed, failure)
+	}
+}